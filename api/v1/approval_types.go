@@ -39,8 +39,9 @@ const (
 
 // Condition keys for Approval
 const (
-	ApprovalConditionSentRequestMail = "SentRequestMail"
-	ApprovalConditionSentResultMail  = "SentResultMail"
+	ApprovalConditionSentRequestMail  = "SentRequestMail"
+	ApprovalConditionSentResultMail   = "SentResultMail"
+	ApprovalConditionSentRequestSlack = "SentRequestSlack"
 )
 
 // ApprovalSpec defines the desired state of Approval
@@ -72,6 +73,87 @@ type ApprovalSpec struct {
 
 	// Users are the list of the users who are requested to approve the Approval
 	Users []ApprovalUser `json:"users"`
+
+	// Author is an author of the commit (pull request) to be approved
+	Author string `json:"author,omitempty"`
+
+	// Authors are the authors of all the pull requests to be approved, e.g., of the pull requests batched by the blocker.
+	// Author is the first one of them
+	Authors []string `json:"authors,omitempty"`
+
+	// ExcludedUsers are the Kubernetes users mapped from the authors and the sender by the git user ConfigMap.
+	// They cannot decide the Approval if the policy's SeparationOfDuties is set
+	ExcludedUsers []string `json:"excludedUsers,omitempty"`
+
+	// GroupUsers are the members (user names) of the policy's groups, which are resolved by the approvers ConfigMap
+	GroupUsers map[string][]string `json:"groupUsers,omitempty"`
+
+	// Policy is a policy deciding when the Approval is approved or rejected.
+	// If it's not set, the first decision of any approver wins
+	Policy *ApprovalPolicy `json:"policy,omitempty"`
+
+	ApprovalTimeout `json:",inline"`
+}
+
+// ApprovalTimeoutAction is an action taken when an Approval times out
+type ApprovalTimeoutAction string
+
+// Timeout actions
+const (
+	ApprovalTimeoutActionReject   ApprovalTimeoutAction = "Reject"
+	ApprovalTimeoutActionApprove  ApprovalTimeoutAction = "Approve"
+	ApprovalTimeoutActionEscalate ApprovalTimeoutAction = "Escalate"
+)
+
+// ApprovalTimeout describes how long to wait for the decision, and how to remind the approvers
+type ApprovalTimeout struct {
+	// Timeout is a duration to wait for the decision (e.g., 24h). The Approval waits forever if it's not set
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// OnTimeout is an action taken when the Approval times out. Default is Reject.
+	// Escalate requests the approval to EscalationApprovers, and rejects it if it times out again
+	// +kubebuilder:validation:Enum=Reject;Approve;Escalate
+	OnTimeout ApprovalTimeoutAction `json:"onTimeout,omitempty"`
+
+	// EscalationApprovers are the approvers who are requested when the Approval is escalated
+	EscalationApprovers []ApprovalUser `json:"escalationApprovers,omitempty"`
+
+	// ReminderInterval is an interval of the reminders sent to the approvers (e.g., 4h).
+	// Reminders are not sent if it's not set
+	ReminderInterval *metav1.Duration `json:"reminderInterval,omitempty"`
+}
+
+// ApprovalPolicy is a policy deciding an Approval.
+// Any rejection from an approver rejects the Approval, and it's approved only if MinApprovals distinct approvers approved it
+type ApprovalPolicy struct {
+	// MinApprovals is the minimum number of approvals from distinct approvers. Default is 1.
+	// If Groups are set, only the approvals from the members of the groups are counted
+	// +kubebuilder:validation:Minimum=1
+	MinApprovals int `json:"minApprovals,omitempty"`
+
+	// Groups are approver groups, whose members can also approve the Approval.
+	// If the approvers ConfigMap has a key of the group name, the group is resolved to the users listed in the key.
+	// Otherwise, the group is regarded as a Kubernetes group
+	Groups []string `json:"groups,omitempty"`
+
+	// SeparationOfDuties prohibits the commit author and the sender (who triggered the job) from approving the Approval
+	SeparationOfDuties bool `json:"separationOfDuties,omitempty"`
+}
+
+// Approvers returns the users who can approve the Approval, including the escalation approvers if it's escalated
+func (a *Approval) Approvers() []ApprovalUser {
+	if a.Status.EscalationTime == nil {
+		return a.Spec.Users
+	}
+	return append(append([]ApprovalUser{}, a.Spec.Users...), a.Spec.EscalationApprovers...)
+}
+
+// GetMinApprovals returns the minimum number of approvals required by the policy
+func (p *ApprovalPolicy) GetMinApprovals() int {
+	if p == nil || p.MinApprovals < 1 {
+		return 1
+	}
+	return p.MinApprovals
 }
 
 // ApprovalUser is a user
@@ -94,10 +176,37 @@ type ApprovalStatus struct {
 	// Decision time of Approval
 	DecisionTime *metav1.Time `json:"decisionTime,omitempty"`
 
+	// Decisions are the individual decisions of the approvers
+	Decisions []ApprovalDecision `json:"decisions,omitempty"`
+
+	// EscalationTime is when the Approval is escalated to the escalation approvers
+	EscalationTime *metav1.Time `json:"escalationTime,omitempty"`
+
+	// LastReminderTime is when the last reminder is sent
+	LastReminderTime *metav1.Time `json:"lastReminderTime,omitempty"`
+
 	// Conditions of Approval
 	Conditions []metav1.Condition `json:"conditions"`
 }
 
+// ApprovalDecision is a decision of an individual approver
+type ApprovalDecision struct {
+	// User is an approver who made the decision
+	User string `json:"user"`
+
+	// Result is the decision of the user (Approved/Rejected)
+	Result ApprovalResult `json:"result"`
+
+	// Reason is a reason of the decision
+	Reason string `json:"reason,omitempty"`
+
+	// Groups are the policy's groups the user was a member of, when the decision is made
+	Groups []string `json:"groups,omitempty"`
+
+	// Time is when the decision is made
+	Time metav1.Time `json:"time"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 
@@ -72,3 +72,25 @@ func TestApprovalStatus_GetDecisionTimeInZone(t *testing.T) {
 		})
 	}
 }
+
+func TestApproval_Approvers(t *testing.T) {
+	now := metav1.Now()
+	approval := &Approval{
+		Spec: ApprovalSpec{
+			Users:           []ApprovalUser{{Name: "admin"}},
+			ApprovalTimeout: ApprovalTimeout{EscalationApprovers: []ApprovalUser{{Name: "manager"}}},
+		},
+	}
+	require.Equal(t, []ApprovalUser{{Name: "admin"}}, approval.Approvers())
+
+	approval.Status.EscalationTime = &now
+	require.Equal(t, []ApprovalUser{{Name: "admin"}, {Name: "manager"}}, approval.Approvers())
+	require.Len(t, approval.Spec.Users, 1)
+}
+
+func TestApprovalPolicy_GetMinApprovals(t *testing.T) {
+	var policy *ApprovalPolicy
+	require.Equal(t, 1, policy.GetMinApprovals())
+	require.Equal(t, 1, (&ApprovalPolicy{}).GetMinApprovals())
+	require.Equal(t, 3, (&ApprovalPolicy{MinApprovals: 3}).GetMinApprovals())
+}
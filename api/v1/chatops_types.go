@@ -0,0 +1,78 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package v1
+
+// ChatOpsConfig is a config struct of the chat-ops commands
+type ChatOpsConfig struct {
+	// Permissions is a permission matrix of the chat-ops commands. The key is a command without the leading '/',
+	// e.g., test, retest, hold, approve, lgtm. Commands not in the matrix follow their default rules
+	Permissions map[string]ChatOpsPermission `json:"permissions,omitempty"`
+
+	// TrustedContributors decides who are trusted contributors.
+	// Members of the repository's owner (organization or group) and users who have write permission on the repository
+	// are always trusted
+	TrustedContributors *TrustedContributors `json:"trustedContributors,omitempty"`
+
+	// RequireOkToTest blocks the preSubmit jobs of the pull requests from untrusted contributors,
+	// until a trusted contributor comments '/ok-to-test' on the pull request
+	RequireOkToTest bool `json:"requireOkToTest,omitempty"`
+
+	// AllowedLabels is a list of the labels which can be set or removed by '/label' and '/remove-label' commands
+	// The labels gating the tests and the merges (ok-to-test, approved, lgtm, hold) cannot be set or removed, even if they are listed
+	AllowedLabels []string `json:"allowedLabels,omitempty"`
+}
+
+// ChatOpsPolicy is a base policy deciding who can call a chat-ops command
+type ChatOpsPolicy string
+
+// ChatOpsPolicy types
+const (
+	// ChatOpsPolicyDefault follows the default rule of the command
+	ChatOpsPolicyDefault = ChatOpsPolicy("default")
+	// ChatOpsPolicyWriters allows users who have write permission on the repository
+	ChatOpsPolicyWriters = ChatOpsPolicy("writers")
+	// ChatOpsPolicyTrusted allows trusted contributors
+	ChatOpsPolicyTrusted = ChatOpsPolicy("trusted")
+	// ChatOpsPolicyAnyone allows anyone
+	ChatOpsPolicyAnyone = ChatOpsPolicy("anyone")
+	// ChatOpsPolicyListed allows only the users and groups in the permission
+	ChatOpsPolicyListed = ChatOpsPolicy("listed")
+)
+
+// ChatOpsPermission is a permission of a chat-ops command
+type ChatOpsPermission struct {
+	// Policy is a base policy of the command. Default is 'default', which follows the default rule of the command
+	// +kubebuilder:validation:Enum=default;writers;trusted;anyone;listed
+	Policy ChatOpsPolicy `json:"policy,omitempty"`
+
+	// Users are the names of the git users who are allowed to call the command, in addition to the policy
+	Users []string `json:"users,omitempty"`
+
+	// Groups are the groups whose members are allowed to call the command, in addition to the policy.
+	// For GitHub and Gitea, it's an organization (org) or a team (org/team). For GitLab, it's a full path of a group
+	Groups []string `json:"groups,omitempty"`
+}
+
+// TrustedContributors is a list of the users and the groups who are trusted
+type TrustedContributors struct {
+	// Users are the names of the trusted git users
+	Users []string `json:"users,omitempty"`
+
+	// Groups are the groups whose members are trusted.
+	// For GitHub and Gitea, it's an organization (org) or a team (org/team). For GitLab, it's a full path of a group
+	Groups []string `json:"groups,omitempty"`
+}
@@ -38,6 +38,18 @@ const (
 	CustomTaskApprovalParamKeySenderName        = "senderName"
 	CustomTaskApprovalParamKeySenderEmail       = "senderEmail"
 	CustomTaskApprovalParamKeyLink              = "link"
+	CustomTaskApprovalParamKeyAuthor            = "author"
+	CustomTaskApprovalParamKeyAuthorEmail       = "authorEmail"
+	CustomTaskApprovalParamKeyAuthors           = "authors"
+
+	CustomTaskApprovalParamKeyMinApprovals       = "min-approvals"
+	CustomTaskApprovalParamKeyGroups             = "approver-groups"
+	CustomTaskApprovalParamKeySeparationOfDuties = "separation-of-duties"
+
+	CustomTaskApprovalParamKeyTimeout             = "timeout"
+	CustomTaskApprovalParamKeyOnTimeout           = "on-timeout"
+	CustomTaskApprovalParamKeyEscalationApprovers = "escalation-approvers"
+	CustomTaskApprovalParamKeyReminderInterval    = "reminder-interval"
 
 	CustomTaskApprovalApproversConfigMapKey = "approvers"
 )
@@ -65,6 +65,9 @@ type IntegrationConfigSpec struct {
 	// MergeConfig specifies how to automate the PR merge
 	MergeConfig *MergeConfig `json:"mergeConfig,omitempty"`
 
+	// ChatOps specifies who can call the chat-ops commands
+	ChatOps *ChatOpsConfig `json:"chatOps,omitempty"`
+
 	// PodTemplate for the TaskRun pods. Same as tekton's pod template. Refer to https://github.com/tektoncd/pipeline/blob/master/docs/podtemplates.md
 	PodTemplate *pod.Template `json:"podTemplate,omitempty"`
 
@@ -166,6 +169,9 @@ func ConvertToTektonParams(params []ParameterValue) []tektonv1beta1.Param {
 type IntegrationJobManageSpec struct {
 	// Timeout for pending integration job gc
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// PipelineTimeout is a maximum running time of an integration job
+	PipelineTimeout *metav1.Duration `json:"pipelineTimeout,omitempty"`
 }
 
 // IntegrationConfigJobs categorizes jobs into three types (pre-submit, post-submit and periodic jobs)
@@ -180,6 +186,28 @@ type IntegrationConfigJobs struct {
 	Periodic Periodics `json:"periodic,omitempty"`
 }
 
+// HasCache returns if any of the jobs uses cache
+func (i *IntegrationConfigJobs) HasCache() bool {
+	return i.any(func(job *Job) bool { return job.Cache != nil })
+}
+
+// HasArtifacts returns if any of the jobs uploads artifacts
+func (i *IntegrationConfigJobs) HasArtifacts() bool {
+	return i.any(func(job *Job) bool { return len(job.Artifacts) > 0 })
+}
+
+func (i *IntegrationConfigJobs) any(f func(job *Job) bool) bool {
+	if i.PreSubmit.any(f) || i.PostSubmit.any(f) {
+		return true
+	}
+	for j := range i.Periodic {
+		if f(&i.Periodic[j].Job) {
+			return true
+		}
+	}
+	return false
+}
+
 // IntegrationConfigStatus defines the observed state of IntegrationConfig
 type IntegrationConfigStatus struct {
 	// Conditions of IntegrationConfig
@@ -252,6 +280,21 @@ func GetServiceAccountName(configName string) string {
 	return fmt.Sprintf("%s-sa", configName)
 }
 
+// GetCachePVCName returns the name of the PersistentVolumeClaim storing the jobs' caches
+func GetCachePVCName(configName string) string {
+	return fmt.Sprintf("%s-cache", configName)
+}
+
+// GetArtifactPVCName returns the name of the PersistentVolumeClaim storing the jobs' artifacts
+func GetArtifactPVCName(configName string) string {
+	return fmt.Sprintf("%s-artifacts", configName)
+}
+
+// GetArtifactServerName returns the name of the Deployment/Service serving the artifact PVC to the report server
+func GetArtifactServerName(configName string) string {
+	return fmt.Sprintf("%s-artifact-server", configName)
+}
+
 // GetSecretName returns the name of related secret
 func GetSecretName(configName string) string {
 	return configName
@@ -287,6 +330,7 @@ const (
 	IntegrationConfigAPIRunPre     = "runpre"
 	IntegrationConfigAPIRunPost    = "runpost"
 	IntegrationConfigAPIWebhookURL = "webhookurl"
+	IntegrationConfigAPIQueue      = "queue"
 )
 
 // IntegrationConfigAPIReqRunPreBody is a body struct for IntegrationConfig's api request
@@ -146,6 +146,60 @@ func TestGetServiceAccountName(t *testing.T) {
 	require.Equal(t, "test-cfg-sa", GetServiceAccountName("test-cfg"))
 }
 
+func TestGetCachePVCName(t *testing.T) {
+	require.Equal(t, "test-cfg-cache", GetCachePVCName("test-cfg"))
+}
+
+func TestIntegrationConfigJobs_HasCache(t *testing.T) {
+	cache := &JobCache{Key: "go-{{ hashFiles \"go.sum\" }}", Paths: []string{".cache/go-mod"}}
+	tc := map[string]struct {
+		jobs IntegrationConfigJobs
+
+		expected bool
+	}{
+		"noCache": {
+			jobs: IntegrationConfigJobs{
+				PreSubmit:  Jobs{{Container: corev1.Container{Name: "job-1"}}},
+				PostSubmit: Jobs{{Container: corev1.Container{Name: "job-2"}}},
+			},
+			expected: false,
+		},
+		"preSubmit": {
+			jobs: IntegrationConfigJobs{
+				PreSubmit: Jobs{{Container: corev1.Container{Name: "job-1"}, Cache: cache}},
+			},
+			expected: true,
+		},
+		"periodic": {
+			jobs: IntegrationConfigJobs{
+				Periodic: Periodics{{Job: Job{Container: corev1.Container{Name: "job-1"}, Cache: cache}, Cron: "@every 1h"}},
+			},
+			expected: true,
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, c.expected, c.jobs.HasCache())
+		})
+	}
+}
+
+func TestGetArtifactPVCName(t *testing.T) {
+	require.Equal(t, "test-cfg-artifacts", GetArtifactPVCName("test-cfg"))
+	require.Equal(t, "test-cfg-artifact-server", GetArtifactServerName("test-cfg"))
+}
+
+func TestIntegrationConfigJobs_HasArtifacts(t *testing.T) {
+	jobs := IntegrationConfigJobs{
+		PreSubmit: Jobs{{Container: corev1.Container{Name: "job-1"}}},
+	}
+	require.False(t, jobs.HasArtifacts())
+
+	jobs.PostSubmit = Jobs{{Container: corev1.Container{Name: "job-2"}, Artifacts: []string{"dist/*"}}}
+	require.True(t, jobs.HasArtifacts())
+}
+
 func TestGetSecretName(t *testing.T) {
 	require.Equal(t, "test-cfg", GetSecretName("test-cfg"))
 }
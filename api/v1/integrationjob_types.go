@@ -59,6 +59,9 @@ type IntegrationJobSpec struct {
 	// Timeout for pending status garbage collection
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
 
+	// PipelineTimeout is a maximum running time of the whole jobs. It is mapped to the PipelineRun's timeout
+	PipelineTimeout *metav1.Duration `json:"pipelineTimeout,omitempty"`
+
 	// ParamConfig specifies parameter
 	ParamConfig *ParameterConfig `json:"paramConfig,omitempty"`
 }
@@ -100,6 +103,15 @@ type IntegrationJobRefsBase struct {
 	Ref  GitRef `json:"ref"`
 	Link string `json:"link"`
 	Sha  string `json:"sha"`
+
+	// Author is an author of the head commit. It's set only for the push events
+	Author *IntegrationJobRefsBaseAuthor `json:"author,omitempty"`
+}
+
+// IntegrationJobRefsBaseAuthor is an author of the pushed head commit
+type IntegrationJobRefsBaseAuthor struct {
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
 }
 
 // IntegrationJobRefsPull refers to the pull request
@@ -113,7 +125,8 @@ type IntegrationJobRefsPull struct {
 
 // IntegrationJobRefsPullAuthor is an author of the pull request
 type IntegrationJobRefsPullAuthor struct {
-	Name string `json:"name"`
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
 }
 
 // IntegrationJobStatus defines the observed state of IntegrationJob
@@ -176,6 +189,11 @@ func (i *IntegrationJob) GetReportServerAddress(jobName string) string {
 	return fmt.Sprintf("http://%s/report/%s/%s/%s", configs.CurrentExternalHostName, i.Namespace, i.Name, jobName)
 }
 
+// GetArtifactServerAddress returns Server address for downloading an artifact of the job
+func (i *IntegrationJob) GetArtifactServerAddress(jobName, path string) string {
+	return fmt.Sprintf("http://%s/artifact/%s/%s/%s/%s", configs.CurrentExternalHostName, i.Namespace, i.Name, jobName, path)
+}
+
 // IsCompleted returns whether or not a job have been completed
 func (i *IntegrationJob) IsCompleted() bool {
 	return i.Status.CompletionTime != nil
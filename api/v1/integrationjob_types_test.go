@@ -59,6 +59,17 @@ func TestIntegrationJob_GetReportServerAddress(t *testing.T) {
 	require.Equal(t, "http://test.host.com/report/test-ns/test-ij/test-job", ij.GetReportServerAddress("test-job"))
 }
 
+func TestIntegrationJob_GetArtifactServerAddress(t *testing.T) {
+	configs.CurrentExternalHostName = "test.host.com"
+	ij := &IntegrationJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-ij",
+			Namespace: "test-ns",
+		},
+	}
+	require.Equal(t, "http://test.host.com/artifact/test-ns/test-ij/test-job/dist/app", ij.GetArtifactServerAddress("test-job", "dist/app"))
+}
+
 func TestIntegrationJob_IsCompleted(t *testing.T) {
 	tc := map[string]struct {
 		completionTime *metav1.Time
@@ -34,3 +34,10 @@ const (
 	RunLabelPullRequestSha = JobLabelPrefix + "pull-request-sha"
 	RunLabelSender         = JobLabelPrefix + "sender"
 )
+
+// Labels for the cache eviction/artifact cleanup pods and the artifact servers
+const (
+	CacheEvictionLabelConfig   = JobLabelPrefix + "cache-eviction"
+	ArtifactCleanupLabelConfig = JobLabelPrefix + "artifact-cleanup"
+	ArtifactServerLabelConfig  = JobLabelPrefix + "artifact-server"
+)
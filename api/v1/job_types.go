@@ -48,6 +48,14 @@ const (
 	CommitStatusStatePending = CommitStatusState("pending")
 )
 
+// JobStatusReason is a terminal reason of a job
+type JobStatusReason string
+
+// Job's terminal reasons
+const (
+	JobStatusReasonTimedOut = JobStatusReason("TimedOut")
+)
+
 // Job is a specification of the job to be executed for specific events
 // Same level of task of tekton
 type Job struct {
@@ -82,6 +90,50 @@ type Job struct {
 
 	// Results emitted by task, which also can be used as TektonWhen input value.
 	Results []tektonv1beta1.TaskResult `json:"results,omitempty"`
+
+	// Timeout is a maximum running time of the job. It is mapped to the PipelineTask's timeout
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Cache restores the paths before the job runs and saves them after the job succeeds
+	Cache *JobCache `json:"cache,omitempty"`
+
+	// Artifacts are path globs of the files to be uploaded after the job runs
+	// Globs are relative to the working directory
+	Artifacts []string `json:"artifacts,omitempty"`
+
+	// TestReports are path globs of the JUnit XML test reports to be parsed after the job runs
+	// Globs are relative to the working directory
+	TestReports []string `json:"testReports,omitempty"`
+
+	// Coverage collects the code coverage from the coverage files after the job runs
+	Coverage *JobCoverage `json:"coverage,omitempty"`
+}
+
+// CoverageFormat is a format of the coverage files
+// +kubebuilder:validation:Enum=go;cobertura;lcov
+type CoverageFormat string
+
+// Coverage formats
+const (
+	CoverageFormatGo        = CoverageFormat("go")
+	CoverageFormatCobertura = CoverageFormat("cobertura")
+	CoverageFormatLCOV      = CoverageFormat("lcov")
+)
+
+// JobCoverage specifies the coverage files of the job
+type JobCoverage struct {
+	// Format is a format of the coverage files (go cover profile, cobertura or lcov)
+	Format CoverageFormat `json:"format"`
+
+	// Paths are path globs of the coverage files. Globs are relative to the working directory
+	// +kubebuilder:validation:MinItems=1
+	Paths []string `json:"paths"`
+
+	// Threshold is a minimum total coverage (in percent). If it's set, 'coverage' commit status is set,
+	// which fails if the total coverage is lower than the threshold
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Threshold *int `json:"threshold,omitempty"`
 }
 
 // Periodic runs on a time-basis, unrelated to git changes.
@@ -129,6 +181,23 @@ type JobApproval struct {
 
 	// RequestMessage is a message to be sent to approvers by email
 	RequestMessage string `json:"requestMessage"`
+
+	// Policy is a policy deciding when the approval is approved or rejected.
+	// If it's not set, the first decision of any approver wins
+	Policy *ApprovalPolicy `json:"policy,omitempty"`
+
+	ApprovalTimeout `json:",inline"`
+}
+
+// JobCache describes which paths are cached between IntegrationJobs
+type JobCache struct {
+	// Key is a template of the cache key. hashFiles function can be used to refer to the content of the files
+	// e.g., go-{{ hashFiles "go.sum" }}
+	Key string `json:"key"`
+
+	// Paths are paths to be cached. Relative paths are relative to the working directory
+	// +kubebuilder:validation:MinItems=1
+	Paths []string `json:"paths"`
 }
 
 // JobWhen describes when the Job should be executed
@@ -141,6 +210,25 @@ type JobWhen struct {
 	SkipTag []string `json:"skipTag,omitempty"`
 }
 
+// HasCache returns if any of the jobs uses cache
+func (j *Jobs) HasCache() bool {
+	return j.any(func(job *Job) bool { return job.Cache != nil })
+}
+
+// HasArtifacts returns if any of the jobs uploads artifacts
+func (j *Jobs) HasArtifacts() bool {
+	return j.any(func(job *Job) bool { return len(job.Artifacts) > 0 })
+}
+
+func (j *Jobs) any(f func(job *Job) bool) bool {
+	for i := range *j {
+		if f(&(*j)[i]) {
+			return true
+		}
+	}
+	return false
+}
+
 // JobStatus is a current status for each job
 type JobStatus struct {
 	// Name is a job name
@@ -160,17 +248,77 @@ type JobStatus struct {
 	// It is actually tekton task run's Status.Conditions[0].Message
 	Message string `json:"message"`
 
+	// Reason is a terminal reason of this job, distinguishing a timeout from a normal failure
+	Reason JobStatusReason `json:"reason,omitempty"`
+
 	// PodName is a name of pod where the job is running
 	PodName string `json:"podName,omitempty"`
 
+	// Artifacts are paths of the uploaded artifacts
+	Artifacts []string `json:"artifacts,omitempty"`
+
+	// TestReport is a summary of the job's test reports
+	TestReport *JobTestReport `json:"testReport,omitempty"`
+
+	// Coverage is the job's code coverage
+	Coverage *JobCoverageStatus `json:"coverage,omitempty"`
+
 	// Containers is status list for each step in the job
 	Containers []tektonv1beta1.StepState `json:"containers,omitempty"`
 }
 
+// JobTestReport is a summary of the JUnit XML test reports
+type JobTestReport struct {
+	Passed  int `json:"passed"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+
+	// FailedTests are names of the failed tests. It may be truncated if there are too many failed tests
+	FailedTests []string `json:"failedTests,omitempty"`
+}
+
+// JobCoverageStatus is a total and per-file code coverage
+type JobCoverageStatus struct {
+	// Covered is the number of the covered statements (or lines)
+	Covered int `json:"covered"`
+
+	// Total is the number of the total statements (or lines)
+	Total int `json:"total"`
+
+	// Files are the per-file coverages, sorted by the number of the uncovered statements.
+	// It may be truncated if there are too many files
+	Files []FileCoverage `json:"files,omitempty"`
+}
+
+// FileCoverage is a code coverage of a file
+type FileCoverage struct {
+	Name    string `json:"name"`
+	Covered int    `json:"covered"`
+	Total   int    `json:"total"`
+}
+
+// Percent returns the total coverage in percent
+func (c *JobCoverageStatus) Percent() float64 {
+	return coveragePercent(c.Covered, c.Total)
+}
+
+// Percent returns the file's coverage in percent
+func (f *FileCoverage) Percent() float64 {
+	return coveragePercent(f.Covered, f.Total)
+}
+
+func coveragePercent(covered, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(covered) * 100 / float64(total)
+}
+
 // Equals checks if i is equal to j
 func (j *JobStatus) Equals(i *JobStatus) bool {
 	return j.State == i.State &&
 		j.Message == i.Message &&
+		j.Reason == i.Reason &&
 		j.StartTime.Equal(i.StartTime) &&
 		j.CompletionTime.Equal(i.CompletionTime)
 }
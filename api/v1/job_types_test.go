@@ -137,3 +137,26 @@ func TestJobs_GetGraph(t *testing.T) {
 		})
 	}
 }
+
+func TestJobCoverageStatus_Percent(t *testing.T) {
+	tc := map[string]struct {
+		coverage *JobCoverageStatus
+
+		expectedPercent float64
+	}{
+		"normal": {
+			coverage:        &JobCoverageStatus{Covered: 3, Total: 4},
+			expectedPercent: 75,
+		},
+		"empty": {
+			coverage:        &JobCoverageStatus{},
+			expectedPercent: 0,
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, c.expectedPercent, c.coverage.Percent())
+		})
+	}
+}
@@ -16,12 +16,15 @@
 
 package v1
 
-import "github.com/tmax-cloud/cicd-operator/pkg/git"
+import (
+	"github.com/tmax-cloud/cicd-operator/pkg/git"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 // MergeConfig is a config struct of the merge automation feature
 type MergeConfig struct {
 	// Method is a merge method
-	// +kubebuilder:validation:Enum=squash;merge
+	// +kubebuilder:validation:Enum=squash;merge;rebase;ff-only
 	Method git.MergeMethod `json:"method,omitempty"`
 
 	// CommitTemplate is a message template for a merge commit.
@@ -30,8 +33,68 @@ type MergeConfig struct {
 
 	// Query is conditions for a open PR to be merged
 	Query MergeQuery `json:"query"`
+
+	// UpdateBranch updates the branch of a PR onto the latest base branch, when the PR is not tested based on the
+	// latest base commit. The PR is tested again by the updated branch, instead of being tested in a batch.
+	// It's always enabled for ff-only method, except for GitHub, whose PRs should be rebased by the authors.
+	// If the branch cannot be updated, the PR is kept pending until its head is changed
+	UpdateBranch bool `json:"updateBranch,omitempty"`
+
+	// BatchSize is the maximum number of PRs tested together in a batch. Default is 10
+	// +kubebuilder:validation:Minimum=1
+	BatchSize int `json:"batchSize,omitempty"`
+
+	// BatchStrategy is a strategy to find the PR which breaks a batch test. Default is linear
+	// +kubebuilder:validation:Enum=linear;bisect
+	BatchStrategy BatchStrategy `json:"batchStrategy,omitempty"`
+
+	// MergeWindows are periods when PRs are allowed to be merged. If it's not set, PRs are merged anytime
+	MergeWindows []MergeWindow `json:"mergeWindows,omitempty"`
+
+	// Freeze freezes merges ad hoc. PRs are kept in the merge queue, but are not merged until the freeze ends
+	Freeze *MergeFreeze `json:"freeze,omitempty"`
+}
+
+// MergeWindow is a period when PRs are allowed to be merged
+type MergeWindow struct {
+	// Schedule is a cron expression of the window's start time, e.g., '0 9 * * 1-5'
+	Schedule string `json:"schedule"`
+
+	// Duration is how long the window lasts, e.g., '8h'. It should be formed as a golang duration string
+	Duration string `json:"duration"`
+
+	// TimeZone is a time zone of the schedule, e.g., 'Asia/Seoul'. Default is UTC
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Branches are the base branches (or regular expressions of them) the window applies to.
+	// If it's not set, the window applies to all branches
+	Branches []string `json:"branches,omitempty"`
 }
 
+// MergeFreeze is an ad hoc freeze of merges
+type MergeFreeze struct {
+	// Reason is a reason of the freeze. It is shown in the blocker's commit status
+	Reason string `json:"reason,omitempty"`
+
+	// Until is when the freeze ends. If it's not set, the freeze lasts until it's removed
+	Until *metav1.Time `json:"until,omitempty"`
+
+	// Branches are the base branches (or regular expressions of them) to be frozen. If it's not set, all branches are frozen
+	Branches []string `json:"branches,omitempty"`
+}
+
+// BatchStrategy is a strategy to find the PR which breaks a batch test
+type BatchStrategy string
+
+// Batch strategies
+const (
+	// BatchStrategyLinear tests the failed batch again, without its last PR
+	BatchStrategyLinear = BatchStrategy("linear")
+
+	// BatchStrategyBisect splits the failed batch into halves and tests each half, until the culprit PR is isolated
+	BatchStrategyBisect = BatchStrategy("bisect")
+)
+
 // MergeQuery defines conditions for a open PR to be merged
 type MergeQuery struct {
 	// Labels specify the required labels of PR to be merged
@@ -66,4 +129,12 @@ type MergeQuery struct {
 
 	// ApproveRequired specifies whether to check github/gitlab's approval
 	ApproveRequired bool `json:"approveRequired,omitempty"`
+
+	// MinApprovals is the minimum number of approving reviews (except the author's) from the users with write permission for the PR to be merged
+	// +kubebuilder:validation:Minimum=0
+	MinApprovals int `json:"minApprovals,omitempty"`
+
+	// RequireCodeOwners specifies whether approvals from the code owners of all the changed files are required.
+	// Code owners are read from the CODEOWNERS file of the base branch
+	RequireCodeOwners bool `json:"requireCodeOwners,omitempty"`
 }
@@ -0,0 +1,140 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/tmax-cloud/cicd-operator/pkg/git"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MergeQueueSpec defines the desired state of MergeQueue
+type MergeQueueSpec struct {
+	// Repository is a repository of the merge queue (in <api url host>/<org>/<repo> form)
+	Repository string `json:"repository"`
+
+	// Front is a list of the pull request ids moved to the front of the queue, in order
+	Front []int `json:"front,omitempty"`
+
+	// Removed is a list of the pull requests removed from the queue.
+	// A removed pull request is queued again when its head sha is changed
+	Removed []MergeQueueRemovedPullRequest `json:"removed,omitempty"`
+}
+
+// MergeQueueRemovedPullRequest is a pull request removed from the merge queue
+type MergeQueueRemovedPullRequest struct {
+	// ID is an id of the pull request
+	ID int `json:"id"`
+
+	// Sha is a head sha of the pull request when it's removed
+	Sha string `json:"sha"`
+}
+
+// MergeQueueStatus defines the observed state of MergeQueue
+type MergeQueueStatus struct {
+	// PullRequests are the pull requests in the merge queue, sorted by their positions
+	PullRequests []MergeQueuePullRequest `json:"pullRequests,omitempty"`
+
+	// CurrentBatch is a batch of the pull requests, being tested before they are merged
+	CurrentBatch *MergeQueueBatch `json:"currentBatch,omitempty"`
+
+	// LastUpdateTime is a timestamp when the status is updated
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// MergeQueuePullRequest is a pull request in the merge queue
+type MergeQueuePullRequest struct {
+	// ID is an id of the pull request
+	ID int `json:"id"`
+
+	// Title is a title of the pull request
+	Title string `json:"title,omitempty"`
+
+	// Author is an author of the pull request
+	Author string `json:"author,omitempty"`
+
+	// BaseBranch is a base branch of the pull request
+	BaseBranch string `json:"baseBranch,omitempty"`
+
+	// Sha is a head sha of the pull request
+	Sha string `json:"sha,omitempty"`
+
+	// Position is a position of the pull request in the queue, starting from 1
+	Position int `json:"position"`
+
+	// Priority is a priority of the pull request, set by the priority labels. (1: high, 0: normal, -1: low)
+	Priority int `json:"priority,omitempty"`
+
+	// State is a blocker status of the pull request. Only success pull requests can be merged
+	State git.CommitStatusState `json:"state"`
+
+	// Description is a blocker status description of the pull request
+	Description string `json:"description,omitempty"`
+}
+
+// MergeQueueBatch is a batch of the pull requests, being tested before they are merged
+type MergeQueueBatch struct {
+	// PullRequests are the ids of the pull requests in the batch
+	PullRequests []int `json:"pullRequests"`
+
+	// IntegrationJob is a name of the IntegrationJob testing the batch
+	IntegrationJob string `json:"integrationJob,omitempty"`
+
+	// Remaining are the groups of the pull requests to be tested after the batch, while the batch is being bisected
+	Remaining [][]int `json:"remaining,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// MergeQueue is the Schema for the mergequeues API. It persists the blocker's merge queue of an IntegrationConfig
+// +kubebuilder:resource:shortName="mq"
+// +kubebuilder:printcolumn:name="Repository",type="string",JSONPath=".spec.repository",description="Repository of the queue"
+// +kubebuilder:printcolumn:name="Batch",type="string",JSONPath=".status.currentBatch.integrationJob",description="IntegrationJob of the current batch"
+// +kubebuilder:printcolumn:name="Updated",type="date",JSONPath=".status.lastUpdateTime",description="Last update time"
+type MergeQueue struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MergeQueueSpec   `json:"spec"`
+	Status MergeQueueStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MergeQueueList contains a list of MergeQueue
+type MergeQueueList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MergeQueue `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MergeQueue{}, &MergeQueueList{})
+}
+
+// MergeQueue's API actions
+const (
+	MergeQueueActionMove   = "move"
+	MergeQueueActionRemove = "remove"
+)
+
+// MergeQueueAPIReqBody is a body struct for IntegrationConfig's queue api request
+// +kubebuilder:object:generate=false
+type MergeQueueAPIReqBody struct {
+	Action      string `json:"action"`
+	PullRequest int    `json:"pullRequest"`
+}
@@ -76,6 +76,27 @@ func (in *Approval) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApprovalDecision) DeepCopyInto(out *ApprovalDecision) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovalDecision.
+func (in *ApprovalDecision) DeepCopy() *ApprovalDecision {
+	if in == nil {
+		return nil
+	}
+	out := new(ApprovalDecision)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApprovalList) DeepCopyInto(out *ApprovalList) {
 	*out = *in
@@ -108,6 +129,26 @@ func (in *ApprovalList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApprovalPolicy) DeepCopyInto(out *ApprovalPolicy) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovalPolicy.
+func (in *ApprovalPolicy) DeepCopy() *ApprovalPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ApprovalPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApprovalSpec) DeepCopyInto(out *ApprovalSpec) {
 	*out = *in
@@ -121,6 +162,37 @@ func (in *ApprovalSpec) DeepCopyInto(out *ApprovalSpec) {
 		*out = make([]ApprovalUser, len(*in))
 		copy(*out, *in)
 	}
+	if in.Authors != nil {
+		in, out := &in.Authors, &out.Authors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludedUsers != nil {
+		in, out := &in.ExcludedUsers, &out.ExcludedUsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GroupUsers != nil {
+		in, out := &in.GroupUsers, &out.GroupUsers
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(ApprovalPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	in.ApprovalTimeout.DeepCopyInto(&out.ApprovalTimeout)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovalSpec.
@@ -140,6 +212,21 @@ func (in *ApprovalStatus) DeepCopyInto(out *ApprovalStatus) {
 		in, out := &in.DecisionTime, &out.DecisionTime
 		*out = (*in).DeepCopy()
 	}
+	if in.Decisions != nil {
+		in, out := &in.Decisions, &out.Decisions
+		*out = make([]ApprovalDecision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EscalationTime != nil {
+		in, out := &in.EscalationTime, &out.EscalationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastReminderTime != nil {
+		in, out := &in.LastReminderTime, &out.LastReminderTime
+		*out = (*in).DeepCopy()
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -159,6 +246,36 @@ func (in *ApprovalStatus) DeepCopy() *ApprovalStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApprovalTimeout) DeepCopyInto(out *ApprovalTimeout) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.EscalationApprovers != nil {
+		in, out := &in.EscalationApprovers, &out.EscalationApprovers
+		*out = make([]ApprovalUser, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReminderInterval != nil {
+		in, out := &in.ReminderInterval, &out.ReminderInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovalTimeout.
+func (in *ApprovalTimeout) DeepCopy() *ApprovalTimeout {
+	if in == nil {
+		return nil
+	}
+	out := new(ApprovalTimeout)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApprovalUser) DeepCopyInto(out *ApprovalUser) {
 	*out = *in
@@ -174,6 +291,78 @@ func (in *ApprovalUser) DeepCopy() *ApprovalUser {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChatOpsConfig) DeepCopyInto(out *ChatOpsConfig) {
+	*out = *in
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make(map[string]ChatOpsPermission, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.TrustedContributors != nil {
+		in, out := &in.TrustedContributors, &out.TrustedContributors
+		*out = new(TrustedContributors)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedLabels != nil {
+		in, out := &in.AllowedLabels, &out.AllowedLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChatOpsConfig.
+func (in *ChatOpsConfig) DeepCopy() *ChatOpsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ChatOpsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChatOpsPermission) DeepCopyInto(out *ChatOpsPermission) {
+	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChatOpsPermission.
+func (in *ChatOpsPermission) DeepCopy() *ChatOpsPermission {
+	if in == nil {
+		return nil
+	}
+	out := new(ChatOpsPermission)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileCoverage) DeepCopyInto(out *FileCoverage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileCoverage.
+func (in *FileCoverage) DeepCopy() *FileCoverage {
+	if in == nil {
+		return nil
+	}
+	out := new(FileCoverage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitConfig) DeepCopyInto(out *GitConfig) {
 	*out = *in
@@ -347,6 +536,11 @@ func (in *IntegrationConfigSpec) DeepCopyInto(out *IntegrationConfigSpec) {
 		*out = new(MergeConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ChatOps != nil {
+		in, out := &in.ChatOps, &out.ChatOps
+		*out = new(ChatOpsConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PodTemplate != nil {
 		in, out := &in.PodTemplate, &out.PodTemplate
 		*out = new(pod.Template)
@@ -489,6 +683,11 @@ func (in *IntegrationJobManageSpec) DeepCopyInto(out *IntegrationJobManageSpec)
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.PipelineTimeout != nil {
+		in, out := &in.PipelineTimeout, &out.PipelineTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationJobManageSpec.
@@ -509,7 +708,7 @@ func (in *IntegrationJobRefs) DeepCopyInto(out *IntegrationJobRefs) {
 		*out = new(IntegrationJobSender)
 		**out = **in
 	}
-	out.Base = in.Base
+	in.Base.DeepCopyInto(&out.Base)
 	if in.Pulls != nil {
 		in, out := &in.Pulls, &out.Pulls
 		*out = make([]IntegrationJobRefsPull, len(*in))
@@ -530,6 +729,11 @@ func (in *IntegrationJobRefs) DeepCopy() *IntegrationJobRefs {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IntegrationJobRefsBase) DeepCopyInto(out *IntegrationJobRefsBase) {
 	*out = *in
+	if in.Author != nil {
+		in, out := &in.Author, &out.Author
+		*out = new(IntegrationJobRefsBaseAuthor)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationJobRefsBase.
@@ -542,6 +746,21 @@ func (in *IntegrationJobRefsBase) DeepCopy() *IntegrationJobRefsBase {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationJobRefsBaseAuthor) DeepCopyInto(out *IntegrationJobRefsBaseAuthor) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationJobRefsBaseAuthor.
+func (in *IntegrationJobRefsBaseAuthor) DeepCopy() *IntegrationJobRefsBaseAuthor {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationJobRefsBaseAuthor)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IntegrationJobRefsPull) DeepCopyInto(out *IntegrationJobRefsPull) {
 	*out = *in
@@ -617,6 +836,11 @@ func (in *IntegrationJobSpec) DeepCopyInto(out *IntegrationJobSpec) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.PipelineTimeout != nil {
+		in, out := &in.PipelineTimeout, &out.PipelineTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.ParamConfig != nil {
 		in, out := &in.ParamConfig, &out.ParamConfig
 		*out = new(ParameterConfig)
@@ -706,6 +930,31 @@ func (in *Job) DeepCopyInto(out *Job) {
 		*out = make([]v1beta1.TaskResult, len(*in))
 		copy(*out, *in)
 	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(JobCache)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Artifacts != nil {
+		in, out := &in.Artifacts, &out.Artifacts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TestReports != nil {
+		in, out := &in.TestReports, &out.TestReports
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Coverage != nil {
+		in, out := &in.Coverage, &out.Coverage
+		*out = new(JobCoverage)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Job.
@@ -731,6 +980,12 @@ func (in *JobApproval) DeepCopyInto(out *JobApproval) {
 		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(ApprovalPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	in.ApprovalTimeout.DeepCopyInto(&out.ApprovalTimeout)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobApproval.
@@ -743,6 +998,71 @@ func (in *JobApproval) DeepCopy() *JobApproval {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobCache) DeepCopyInto(out *JobCache) {
+	*out = *in
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobCache.
+func (in *JobCache) DeepCopy() *JobCache {
+	if in == nil {
+		return nil
+	}
+	out := new(JobCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobCoverage) DeepCopyInto(out *JobCoverage) {
+	*out = *in
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Threshold != nil {
+		in, out := &in.Threshold, &out.Threshold
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobCoverage.
+func (in *JobCoverage) DeepCopy() *JobCoverage {
+	if in == nil {
+		return nil
+	}
+	out := new(JobCoverage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobCoverageStatus) DeepCopyInto(out *JobCoverageStatus) {
+	*out = *in
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]FileCoverage, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobCoverageStatus.
+func (in *JobCoverageStatus) DeepCopy() *JobCoverageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(JobCoverageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JobStatus) DeepCopyInto(out *JobStatus) {
 	*out = *in
@@ -754,6 +1074,21 @@ func (in *JobStatus) DeepCopyInto(out *JobStatus) {
 		in, out := &in.CompletionTime, &out.CompletionTime
 		*out = (*in).DeepCopy()
 	}
+	if in.Artifacts != nil {
+		in, out := &in.Artifacts, &out.Artifacts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TestReport != nil {
+		in, out := &in.TestReport, &out.TestReport
+		*out = new(JobTestReport)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Coverage != nil {
+		in, out := &in.Coverage, &out.Coverage
+		*out = new(JobCoverageStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Containers != nil {
 		in, out := &in.Containers, &out.Containers
 		*out = make([]v1beta1.StepState, len(*in))
@@ -793,6 +1128,26 @@ func (in *JobTaskRef) DeepCopy() *JobTaskRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobTestReport) DeepCopyInto(out *JobTestReport) {
+	*out = *in
+	if in.FailedTests != nil {
+		in, out := &in.FailedTests, &out.FailedTests
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobTestReport.
+func (in *JobTestReport) DeepCopy() *JobTestReport {
+	if in == nil {
+		return nil
+	}
+	out := new(JobTestReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JobWhen) DeepCopyInto(out *JobWhen) {
 	*out = *in
@@ -853,6 +1208,18 @@ func (in Jobs) DeepCopy() Jobs {
 func (in *MergeConfig) DeepCopyInto(out *MergeConfig) {
 	*out = *in
 	in.Query.DeepCopyInto(&out.Query)
+	if in.MergeWindows != nil {
+		in, out := &in.MergeWindows, &out.MergeWindows
+		*out = make([]MergeWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Freeze != nil {
+		in, out := &in.Freeze, &out.Freeze
+		*out = new(MergeFreeze)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MergeConfig.
@@ -865,6 +1232,30 @@ func (in *MergeConfig) DeepCopy() *MergeConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MergeFreeze) DeepCopyInto(out *MergeFreeze) {
+	*out = *in
+	if in.Until != nil {
+		in, out := &in.Until, &out.Until
+		*out = (*in).DeepCopy()
+	}
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MergeFreeze.
+func (in *MergeFreeze) DeepCopy() *MergeFreeze {
+	if in == nil {
+		return nil
+	}
+	out := new(MergeFreeze)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MergeQuery) DeepCopyInto(out *MergeQuery) {
 	*out = *in
@@ -920,6 +1311,200 @@ func (in *MergeQuery) DeepCopy() *MergeQuery {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MergeQueue) DeepCopyInto(out *MergeQueue) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MergeQueue.
+func (in *MergeQueue) DeepCopy() *MergeQueue {
+	if in == nil {
+		return nil
+	}
+	out := new(MergeQueue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MergeQueue) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MergeQueueBatch) DeepCopyInto(out *MergeQueueBatch) {
+	*out = *in
+	if in.PullRequests != nil {
+		in, out := &in.PullRequests, &out.PullRequests
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.Remaining != nil {
+		in, out := &in.Remaining, &out.Remaining
+		*out = make([][]int, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = make([]int, len(*in))
+				copy(*out, *in)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MergeQueueBatch.
+func (in *MergeQueueBatch) DeepCopy() *MergeQueueBatch {
+	if in == nil {
+		return nil
+	}
+	out := new(MergeQueueBatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MergeQueueList) DeepCopyInto(out *MergeQueueList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MergeQueue, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MergeQueueList.
+func (in *MergeQueueList) DeepCopy() *MergeQueueList {
+	if in == nil {
+		return nil
+	}
+	out := new(MergeQueueList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MergeQueueList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MergeQueuePullRequest) DeepCopyInto(out *MergeQueuePullRequest) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MergeQueuePullRequest.
+func (in *MergeQueuePullRequest) DeepCopy() *MergeQueuePullRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(MergeQueuePullRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MergeQueueRemovedPullRequest) DeepCopyInto(out *MergeQueueRemovedPullRequest) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MergeQueueRemovedPullRequest.
+func (in *MergeQueueRemovedPullRequest) DeepCopy() *MergeQueueRemovedPullRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(MergeQueueRemovedPullRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MergeQueueSpec) DeepCopyInto(out *MergeQueueSpec) {
+	*out = *in
+	if in.Front != nil {
+		in, out := &in.Front, &out.Front
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.Removed != nil {
+		in, out := &in.Removed, &out.Removed
+		*out = make([]MergeQueueRemovedPullRequest, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MergeQueueSpec.
+func (in *MergeQueueSpec) DeepCopy() *MergeQueueSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MergeQueueSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MergeQueueStatus) DeepCopyInto(out *MergeQueueStatus) {
+	*out = *in
+	if in.PullRequests != nil {
+		in, out := &in.PullRequests, &out.PullRequests
+		*out = make([]MergeQueuePullRequest, len(*in))
+		copy(*out, *in)
+	}
+	if in.CurrentBatch != nil {
+		in, out := &in.CurrentBatch, &out.CurrentBatch
+		*out = new(MergeQueueBatch)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MergeQueueStatus.
+func (in *MergeQueueStatus) DeepCopy() *MergeQueueStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MergeQueueStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MergeWindow) DeepCopyInto(out *MergeWindow) {
+	*out = *in
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MergeWindow.
+func (in *MergeWindow) DeepCopy() *MergeWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MergeWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NotiEmail) DeepCopyInto(out *NotiEmail) {
 	*out = *in
@@ -1193,3 +1778,28 @@ func (in *TektonTaskDef) DeepCopy() *TektonTaskDef {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrustedContributors) DeepCopyInto(out *TrustedContributors) {
+	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrustedContributors.
+func (in *TrustedContributors) DeepCopy() *TrustedContributors {
+	if in == nil {
+		return nil
+	}
+	out := new(TrustedContributors)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -17,10 +17,12 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"io"
 	"os"
+	_ "time/tzdata" // Time zones of the merge windows are loaded from the embedded database, as the image doesn't have one
 
 	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
 	"github.com/tmax-cloud/cicd-operator/controllers"
@@ -30,9 +32,11 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
 var (
@@ -46,14 +50,26 @@ func init() {
 	// +kubebuilder:scaffold:scheme
 }
 
+// +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,namespace=cicd-system,verbs=get;list;watch;create;update;patch;delete
+
 func main() {
 	var healthAddr string
+	var enableLeaderElection bool
+	var shards int
+	var shardIndex int
 	opts := zap.Options{
 		Development: false,
 	}
 	opts.BindFlags(flag.CommandLine)
 
 	flag.StringVar(&healthAddr, "health-addr", ":8888", "The address the health endpoint binds to.")
+	flag.BoolVar(&enableLeaderElection, "enable-leader-election", true,
+		"Enable leader election for blocker. "+
+			"Enabling this will ensure there is only one active blocker for each shard.")
+	flag.IntVar(&shards, "shards", 1, "The number of the shards, over which the PR pools are spread.")
+	flag.IntVar(&shardIndex, "shard-index", -1,
+		"The index of the shard this blocker handles. "+
+			"If it's negative, it's derived from the ordinal of the hostname (i.e., StatefulSet pod name), modulo shards.")
 	flag.Parse()
 
 	// Set log rotation
@@ -72,11 +88,26 @@ func main() {
 		os.Exit(1)
 	}
 
+	shard, err := getShard(shards, shardIndex)
+	if err != nil {
+		setupLog.Error(err, "unable to get the shard")
+		os.Exit(1)
+	}
+
+	// Each shard has its own leader
+	leaderElectionID := "blocker.cicd.tmax.io"
+	if shard.Total > 1 {
+		leaderElectionID = fmt.Sprintf("blocker-%d.cicd.tmax.io", shard.Index)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme:                 scheme,
-		MetricsBindAddress:     "0",
-		HealthProbeBindAddress: healthAddr,
-		Port:                   9443,
+		Scheme:                     scheme,
+		MetricsBindAddress:         "0",
+		HealthProbeBindAddress:     healthAddr,
+		Port:                       9443,
+		LeaderElection:             enableLeaderElection,
+		LeaderElectionID:           leaderElectionID,
+		LeaderElectionResourceLock: resourcelock.LeasesResourceLock,
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start Manager")
@@ -105,13 +136,45 @@ func main() {
 	// Wait for initial config reconcile
 	<-configs.BlockerInitCh
 
-	// Blocker
+	// Blocker runs only if it's elected as a leader
 	b := blocker.New(mgr.GetClient())
-	go b.Start()
-	go b.StartBlockerStatusServer()
+	b.SetShard(shard)
+	if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		setupLog.Info("Starting blocker", "shard", shard.Index, "shards", shard.Total)
+		b.Start()
+		go b.StartBlockerStatusServer()
+		<-ctx.Done()
+		return nil
+	})); err != nil {
+		setupLog.Error(err, "unable to add blocker to the manager")
+		os.Exit(1)
+	}
 
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
 }
+
+func getShard(shards, shardIndex int) (blocker.Shard, error) {
+	if shards < 1 {
+		return blocker.Shard{}, fmt.Errorf("shards should be positive, but it's %d", shards)
+	}
+	if shards == 1 {
+		return blocker.Shard{Index: 0, Total: 1}, nil
+	}
+	if shardIndex >= shards {
+		return blocker.Shard{}, fmt.Errorf("shard-index %d should be less than shards %d", shardIndex, shards)
+	}
+	if shardIndex < 0 {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return blocker.Shard{}, err
+		}
+		shardIndex, err = blocker.ShardIndexFromHostname(hostname, shards)
+		if err != nil {
+			return blocker.Shard{}, err
+		}
+	}
+	return blocker.Shard{Index: shardIndex, Total: shards}, nil
+}
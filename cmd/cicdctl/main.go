@@ -22,6 +22,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/tmax-cloud/cicd-operator/cmd/cicdctl/approve"
+	"github.com/tmax-cloud/cicd-operator/cmd/cicdctl/queue"
 	"github.com/tmax-cloud/cicd-operator/cmd/cicdctl/run"
 	"github.com/tmax-cloud/cicd-operator/cmd/cicdctl/webhook"
 	"github.com/tmax-cloud/cicd-operator/pkg/cli"
@@ -38,6 +39,7 @@ func main() {
 	configs.AddFlags(cmd.PersistentFlags())
 
 	approve.New(configs).AddToCommand(cmd)
+	queue.New(configs).AddToCommand(cmd)
 	run.New(configs).AddToCommand(cmd)
 	webhook.New(configs).AddToCommand(cmd)
 
@@ -0,0 +1,148 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package queue
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/pkg/cli"
+)
+
+type command struct {
+	*cobra.Command
+
+	Config *cli.Configs
+}
+
+// New is a constructor of a queue sub-command
+func New(c *cli.Configs) cli.Command {
+	cmd := &command{Config: c}
+	cmd.Command = &cobra.Command{
+		Use:   "queue",
+		Short: "Manages the merge queue of an IntegrationConfig",
+	}
+
+	cmd.Command.AddCommand(&cobra.Command{
+		Use:   "list [IntegrationConfig]",
+		Short: "Lists the pull requests in the merge queue",
+		Args:  cobra.ExactArgs(1),
+		RunE:  cmd.runList,
+	})
+	cmd.Command.AddCommand(&cobra.Command{
+		Use:   "move [IntegrationConfig] [PullRequest]",
+		Short: "Moves a pull request to the front of the merge queue",
+		Args:  cobra.ExactArgs(2),
+		RunE:  cmd.runMove,
+	})
+	cmd.Command.AddCommand(&cobra.Command{
+		Use:   "remove [IntegrationConfig] [PullRequest]",
+		Short: "Removes a pull request from the merge queue, until its head is changed",
+		Args:  cobra.ExactArgs(2),
+		RunE:  cmd.runRemove,
+	})
+
+	return cmd
+}
+
+func (command *command) AddToCommand(cmd *cobra.Command) {
+	cmd.AddCommand(command.Command)
+}
+
+func (command *command) runList(_ *cobra.Command, args []string) error {
+	ic := args[0]
+
+	client, ns, err := cli.GetClient(command.Config)
+	if err != nil {
+		return err
+	}
+
+	return cli.ExecAndHandleError(client.Get().
+		Resource(cicdv1.IntegrationConfigKind).
+		Namespace(ns).
+		Name(ic).
+		SubResource(cicdv1.IntegrationConfigAPIQueue), printQueue)
+}
+
+func (command *command) runMove(_ *cobra.Command, args []string) error {
+	return command.RunCommand(args, cicdv1.MergeQueueActionMove)
+}
+
+func (command *command) runRemove(_ *cobra.Command, args []string) error {
+	return command.RunCommand(args, cicdv1.MergeQueueActionRemove)
+}
+
+func (command *command) RunCommand(args []string, action string) error {
+	ic := args[0]
+	id, err := strconv.Atoi(args[1])
+	if err != nil {
+		return fmt.Errorf("pull request should be a number, got %s", args[1])
+	}
+
+	body, err := json.Marshal(cicdv1.MergeQueueAPIReqBody{Action: action, PullRequest: id})
+	if err != nil {
+		return err
+	}
+
+	// Run!
+	client, ns, err := cli.GetClient(command.Config)
+	if err != nil {
+		return err
+	}
+
+	return cli.ExecAndHandleError(client.Post().
+		Resource(cicdv1.IntegrationConfigKind).
+		Namespace(ns).
+		Name(ic).
+		SubResource(cicdv1.IntegrationConfigAPIQueue).
+		Body(body), func(_ []byte) error {
+		switch action {
+		case cicdv1.MergeQueueActionMove:
+			fmt.Printf("Moved pull request #%d to the front of the merge queue of IntegrationConfig %s/%s\n", id, ns, ic)
+		case cicdv1.MergeQueueActionRemove:
+			fmt.Printf("Removed pull request #%d from the merge queue of IntegrationConfig %s/%s\n", id, ns, ic)
+		}
+		return nil
+	})
+}
+
+func printQueue(raw []byte) error {
+	status := &cicdv1.MergeQueueStatus{}
+
+	if err := json.Unmarshal(raw, status); err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "POSITION\tID\tTITLE\tAUTHOR\tBRANCH\tPRIORITY\tSTATE\tDESCRIPTION")
+	for _, pr := range status.PullRequests {
+		_, _ = fmt.Fprintf(w, "%d\t#%d\t%s\t%s\t%s\t%d\t%s\t%s\n", pr.Position, pr.ID, pr.Title, pr.Author, pr.BaseBranch, pr.Priority, pr.State, pr.Description)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if status.CurrentBatch != nil {
+		fmt.Printf("\nCurrent batch\t: %v (IntegrationJob %s)\n", status.CurrentBatch.PullRequests, status.CurrentBatch.IntegrationJob)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package queue
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/pkg/cli"
+)
+
+func TestNew(t *testing.T) {
+	cfg := &cli.Configs{
+		KubeConfig: "test",
+	}
+	cmdIf := New(cfg)
+	cmd, ok := cmdIf.(*command)
+	require.True(t, ok)
+
+	require.Equal(t, cfg, cmd.Config)
+	require.Len(t, cmd.Commands(), 3)
+}
+
+func Test_command_AddToCommand(t *testing.T) {
+	cmd := &command{}
+	cmd.Command = &cobra.Command{}
+
+	cob := &cobra.Command{}
+	cmd.AddToCommand(cob)
+	require.Len(t, cob.Commands(), 1)
+}
+
+func Test_command_runList(t *testing.T) {
+	router := mux.NewRouter()
+	router.HandleFunc("/apis/cicdapi.tmax.io/v1/namespaces/default/integrationconfigs/test/queue", func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte(`{"pullRequests": [{"id": 23, "position": 1, "state": "success"}], "currentBatch": {"pullRequests": [23]}}`))
+	}).Methods(http.MethodGet)
+	srv := httptest.NewServer(router)
+
+	tc := map[string]struct {
+		arguments []string
+
+		errorOccurs  bool
+		errorMessage string
+	}{
+		"normal": {
+			arguments: []string{"test"},
+		},
+		"execErr": {
+			arguments:    []string{"test222"},
+			errorOccurs:  true,
+			errorMessage: "invalid character 'p' after top-level value",
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			cmd := &command{Config: &cli.Configs{APIServer: srv.URL, Namespace: "default", Insecure: true}}
+			err := cmd.runList(&cobra.Command{Use: "list"}, c.arguments)
+			if c.errorOccurs {
+				require.Error(t, err)
+				require.Equal(t, c.errorMessage, err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_command_RunCommand(t *testing.T) {
+	router := mux.NewRouter()
+	router.HandleFunc("/apis/cicdapi.tmax.io/v1/namespaces/default/integrationconfigs/test/queue", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}).Methods(http.MethodPost)
+	srv := httptest.NewServer(router)
+
+	tc := map[string]struct {
+		arguments []string
+		action    string
+
+		errorOccurs  bool
+		errorMessage string
+	}{
+		"move": {
+			arguments: []string{"test", "23"},
+			action:    cicdv1.MergeQueueActionMove,
+		},
+		"remove": {
+			arguments: []string{"test", "23"},
+			action:    cicdv1.MergeQueueActionRemove,
+		},
+		"notNumber": {
+			arguments:    []string{"test", "abc"},
+			action:       cicdv1.MergeQueueActionMove,
+			errorOccurs:  true,
+			errorMessage: "pull request should be a number, got abc",
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			cmd := &command{Config: &cli.Configs{APIServer: srv.URL, Namespace: "default", Insecure: true}}
+			err := cmd.RunCommand(c.arguments, c.action)
+			if c.errorOccurs {
+				require.Error(t, err)
+				require.Equal(t, c.errorMessage, err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_printQueue(t *testing.T) {
+	t.Run("normal", func(t *testing.T) {
+		require.NoError(t, printQueue([]byte(`{"pullRequests": [{"id": 23, "position": 1, "state": "success"}]}`)))
+	})
+
+	t.Run("unmarshalErr", func(t *testing.T) {
+		require.Error(t, printQueue([]byte("aaa")))
+	})
+}
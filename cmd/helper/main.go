@@ -0,0 +1,74 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/pkg/reports"
+)
+
+// Helper runs the utility steps of the jobs (e.g., parsing the test reports) in the jobs' pods.
+// Usage: helper <command> [flags] <path globs...>
+func main() {
+	if len(os.Args) < 2 {
+		exit(fmt.Errorf("usage: %s test-report|coverage [flags] <path globs...>", os.Args[0]))
+	}
+
+	flags := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	result := flags.String("result", "", "Path of the file, to which the result is written.")
+	maxLength := flags.Int("max-length", 4096, "Maximum length of the result.")
+	format := flags.String("format", string(cicdv1.CoverageFormatGo), "Format of the coverage files (go/cobertura/lcov).")
+	_ = flags.Parse(os.Args[2:])
+
+	files, err := reports.FindFiles(".", flags.Args())
+	if err != nil {
+		exit(err)
+	}
+
+	var summary string
+	switch os.Args[1] {
+	case "test-report":
+		summary, err = reports.SummarizeTestReports(files, *maxLength, os.Stderr)
+	case "coverage":
+		var wd string
+		if wd, err = os.Getwd(); err == nil {
+			summary, err = reports.SummarizeCoverage(cicdv1.CoverageFormat(*format), files, wd+"/", *maxLength, os.Stderr)
+		}
+	default:
+		err = fmt.Errorf("unknown command %s", os.Args[1])
+	}
+	if err != nil {
+		exit(err)
+	}
+
+	fmt.Println(summary)
+	if *result != "" {
+		if err := ioutil.WriteFile(*result, []byte(summary), 0644); err != nil {
+			exit(err)
+		}
+	}
+}
+
+func exit(err error) {
+	_, _ = fmt.Fprintln(os.Stderr, err.Error())
+	os.Exit(1)
+}
@@ -4,6 +4,7 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 
 	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
@@ -11,17 +12,28 @@ import (
 	"github.com/tmax-cloud/cicd-operator/controllers"
 	"github.com/tmax-cloud/cicd-operator/internal/configs"
 	"github.com/tmax-cloud/cicd-operator/internal/logrotate"
+	"github.com/tmax-cloud/cicd-operator/pkg/approvallink"
+	"github.com/tmax-cloud/cicd-operator/pkg/approvalui"
+	"github.com/tmax-cloud/cicd-operator/pkg/blocker"
 	"github.com/tmax-cloud/cicd-operator/pkg/chatops"
 	"github.com/tmax-cloud/cicd-operator/pkg/chatops/plugins/approve"
+	"github.com/tmax-cloud/cicd-operator/pkg/chatops/plugins/assign"
+	"github.com/tmax-cloud/cicd-operator/pkg/chatops/plugins/cancel"
 	"github.com/tmax-cloud/cicd-operator/pkg/chatops/plugins/hold"
+	"github.com/tmax-cloud/cicd-operator/pkg/chatops/plugins/label"
+	"github.com/tmax-cloud/cicd-operator/pkg/chatops/plugins/lgtm"
+	"github.com/tmax-cloud/cicd-operator/pkg/chatops/plugins/override"
 	"github.com/tmax-cloud/cicd-operator/pkg/chatops/plugins/trigger"
 	"github.com/tmax-cloud/cicd-operator/pkg/dispatcher"
 	"github.com/tmax-cloud/cicd-operator/pkg/git"
 	"github.com/tmax-cloud/cicd-operator/pkg/plugins/size"
 	"github.com/tmax-cloud/cicd-operator/pkg/server"
+	"github.com/tmax-cloud/cicd-operator/pkg/slackapp"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	authentication "k8s.io/client-go/kubernetes/typed/authentication/v1"
+	authorization "k8s.io/client-go/kubernetes/typed/authorization/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -109,12 +121,28 @@ func main() {
 	approveHandler := &approve.Handler{Client: mgr.GetClient()}
 	triggerHandler := &trigger.Handler{Client: mgr.GetClient()}
 	holdHandler := &hold.Handler{Client: mgr.GetClient()}
+	lgtmHandler := &lgtm.Handler{Client: mgr.GetClient()}
+	cancelHandler := &cancel.Handler{Client: mgr.GetClient()}
+	overrideHandler := &override.Handler{Client: mgr.GetClient()}
+	assignHandler := &assign.Handler{Client: mgr.GetClient()}
+	labelHandler := &label.Handler{Client: mgr.GetClient()}
 
 	co.RegisterCommandHandler(approve.CommandTypeApprove, approveHandler.HandleChatOps)
 	co.RegisterCommandHandler(approve.CommandTypeGitLabApprove, approveHandler.HandleChatOps)
 	co.RegisterCommandHandler(trigger.CommandTypeTest, triggerHandler.HandleChatOps)
 	co.RegisterCommandHandler(trigger.CommandTypeRetest, triggerHandler.HandleChatOps)
+	co.RegisterCommandHandler(trigger.CommandTypeRetestAll, triggerHandler.HandleChatOps)
+	co.RegisterCommandHandler(trigger.CommandTypeOkToTest, triggerHandler.HandleChatOps)
 	co.RegisterCommandHandler(hold.CommandTypeHold, holdHandler.HandleChatOps)
+	co.RegisterCommandHandler(lgtm.CommandTypeLGTM, lgtmHandler.HandleChatOps)
+	co.RegisterCommandHandler(cancel.CommandTypeCancel, cancelHandler.HandleChatOps)
+	co.RegisterCommandHandler(override.CommandTypeOverride, overrideHandler.HandleChatOps)
+	co.RegisterCommandHandler(assign.CommandTypeAssign, assignHandler.HandleChatOps)
+	co.RegisterCommandHandler(assign.CommandTypeUnassign, assignHandler.HandleChatOps)
+	co.RegisterCommandHandler(assign.CommandTypeCC, assignHandler.HandleChatOps)
+	co.RegisterCommandHandler(assign.CommandTypeUnCC, assignHandler.HandleChatOps)
+	co.RegisterCommandHandler(label.CommandTypeLabel, labelHandler.HandleChatOps)
+	co.RegisterCommandHandler(label.CommandTypeRemoveLabel, labelHandler.HandleChatOps)
 
 	// Create and start webhook server
 	srv := server.New(mgr.GetClient(), mgr.GetConfig())
@@ -122,7 +150,34 @@ func main() {
 	server.AddPlugin([]git.EventType{git.EventTypePullRequest, git.EventTypePush}, &dispatcher.Dispatcher{Client: mgr.GetClient()})
 	server.AddPlugin([]git.EventType{git.EventTypeIssueComment, git.EventTypePullRequestReview, git.EventTypePullRequestReviewComment, git.EventTypeCommitComment}, co)
 	server.AddPlugin([]git.EventType{git.EventTypePullRequest, git.EventTypePullRequestReview}, approveHandler)
+	server.AddPlugin([]git.EventType{git.EventTypePullRequest}, lgtmHandler)
 	server.AddPlugin([]git.EventType{git.EventTypePullRequest}, &size.Size{Client: mgr.GetClient()})
+	server.AddPlugin([]git.EventType{git.EventTypePullRequest, git.EventTypePullRequestReview, git.EventTypeStatus}, blocker.NewEventForwarder(mgr.GetClient()))
+
+	// Add slack app handlers
+	authCli, err := authorization.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to initiate authorization client")
+		os.Exit(1)
+	}
+	slackApp := slackapp.New(mgr.GetClient(), authCli)
+	srv.AddHandler(http.MethodPost, slackapp.InteractionPath, slackApp.InteractionHandler())
+	srv.AddHandler(http.MethodPost, slackapp.CommandPath, slackApp.CommandHandler())
+
+	// Add approval web UI handlers
+	authnCli, err := authentication.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to initiate authentication client")
+		os.Exit(1)
+	}
+	approvalUI := approvalui.New(mgr.GetClient(), authnCli, authCli)
+	srv.AddHandler(http.MethodGet, approvalui.ListPath, approvalUI.ListHandler())
+	srv.AddHandler(http.MethodPost, approvalui.LoginPath, approvalUI.LoginHandler())
+	srv.AddHandler(http.MethodPost, approvalui.LogoutPath, approvalUI.LogoutHandler())
+	srv.AddHandler(http.MethodGet, approvallink.Path, approvalUI.LinkHandler())
+	srv.AddHandler(http.MethodPost, approvallink.Path, approvalUI.LinkHandler())
+	srv.AddHandler(http.MethodGet, approvalui.DetailPath, approvalUI.DetailHandler())
+	srv.AddHandler(http.MethodPost, approvalui.DetailPath, approvalUI.DecisionHandler())
 	go srv.Start()
 
 	setupLog.Info("starting manager")
@@ -24,12 +24,15 @@ import (
 	"os"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
 	"github.com/tmax-cloud/cicd-operator/internal/configs"
 	"github.com/tmax-cloud/cicd-operator/internal/utils"
+	"github.com/tmax-cloud/cicd-operator/pkg/approvallink"
 	"github.com/tmax-cloud/cicd-operator/pkg/notification/mail"
+	"github.com/tmax-cloud/cicd-operator/pkg/notification/slack"
 	rbac "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -72,7 +75,7 @@ const (
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile reconciles Approval object
-func (r *ApprovalReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *ApprovalReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, retErr error) {
 	log := r.Log.WithValues("approval", req.NamespacedName)
 
 	// Get Approval
@@ -108,8 +111,18 @@ func (r *ApprovalReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	r.bumpV050(instance)
 
 	defer func() {
+		// If the result is changed by the controller (e.g., timed out), take an optimistic lock not to overwrite
+		// the decisions made meanwhile. It's requeued to be processed again on conflict
 		p := client.MergeFrom(original)
+		if instance.Status.Result != original.Status.Result {
+			p = client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})
+		}
 		if err := r.Client.Status().Patch(ctx, instance, p); err != nil {
+			if errors.IsConflict(err) {
+				log.Info("approval is modified meanwhile, requeueing")
+				result = ctrl.Result{Requeue: true}
+				return
+			}
 			log.Error(err, "")
 		}
 	}()
@@ -134,7 +147,12 @@ func (r *ApprovalReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 
 	// Process mails
 	r.processMail(instance)
-	return ctrl.Result{}, nil
+
+	// Process slack messages
+	r.processSlack(instance)
+
+	// Process timeout, escalation and reminders
+	return ctrl.Result{RequeueAfter: r.processTimeout(instance)}, nil
 }
 
 // Update to v0.5.0 - reason, message became required
@@ -157,13 +175,18 @@ func (r *ApprovalReconciler) processMail(instance *cicdv1.Approval) {
 
 	// Set SentRequestMail
 	if reqCond := meta.FindStatusCondition(instance.Status.Conditions, cicdv1.ApprovalConditionSentRequestMail); reqCond == nil || reqCond.Status == "" || reqCond.Status == metav1.ConditionUnknown {
-		title, content, err := r.generateMail(instance, configMapKeyRequestTitle, configMapKeyRequestContent)
+		mails, err := r.generateRequestMails(instance, instance.Spec.Users)
 		if err != nil {
 			reqCond.Status = metav1.ConditionFalse
 			reqCond.Reason = "EmailGenerateError"
 			reqCond.Message = err.Error()
 		} else {
-			r.sendMail(utils.ParseEmailFromUsers(instance.Spec.Users), title, content, reqCond)
+			for _, m := range mails {
+				r.sendMail(m.receivers, m.title, m.content, reqCond)
+				if reqCond.Status != metav1.ConditionTrue {
+					break
+				}
+			}
 		}
 	}
 
@@ -186,6 +209,174 @@ func (r *ApprovalReconciler) processMail(instance *cicdv1.Approval) {
 	}
 }
 
+func (r *ApprovalReconciler) processSlack(instance *cicdv1.Approval) {
+	if !configs.EnableSlackApp || configs.SlackApprovalChannel == "" {
+		return
+	}
+
+	// Post request message only once, while it's awaiting
+	if instance.Status.Result != cicdv1.ApprovalResultAwaiting {
+		return
+	}
+	if cond := meta.FindStatusCondition(instance.Status.Conditions, cicdv1.ApprovalConditionSentRequestSlack); cond != nil && cond.Status == metav1.ConditionTrue {
+		return
+	}
+
+	cond := metav1.Condition{
+		Type:    cicdv1.ApprovalConditionSentRequestSlack,
+		Status:  metav1.ConditionTrue,
+		Reason:  "SlackMessageSent",
+		Message: "Slack message is sent",
+	}
+	if err := r.sendSlackRequest(instance); err != nil {
+		r.Log.Error(err, "")
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "ErrorSendingSlackMessage"
+		cond.Message = err.Error()
+	}
+	meta.SetStatusCondition(&instance.Status.Conditions, cond)
+}
+
+func (r *ApprovalReconciler) sendSlackRequest(instance *cicdv1.Approval) error {
+	cred, err := slack.GetAppCredentials(r.Client)
+	if err != nil {
+		return err
+	}
+	return slack.PostMessage(cred.BotToken, generateSlackRequestMessage(instance, configs.SlackApprovalChannel, "Approval is requested"))
+}
+
+func generateSlackRequestMessage(instance *cicdv1.Approval, channel, title string) *slack.Message {
+	var approvers []string
+	for _, u := range instance.Approvers() {
+		approvers = append(approvers, u.Name)
+	}
+
+	content := fmt.Sprintf("*%s* : %s/%s\n*IntegrationJob* : %s\n*Job* : %s\n*Approvers* : %s",
+		title, instance.Namespace, instance.Name, instance.Spec.IntegrationJob, instance.Spec.JobName, strings.Join(approvers, ", "))
+	if instance.Spec.Sender != nil {
+		content += fmt.Sprintf("\n*Requested by* : %s", instance.Spec.Sender.Name)
+	}
+	if instance.Spec.Message != "" {
+		content += fmt.Sprintf("\n*Message* : %s", instance.Spec.Message)
+	}
+	if instance.Spec.Link != "" {
+		content += fmt.Sprintf("\n*Link* : %s", instance.Spec.Link)
+	}
+	if policy := instance.Spec.Policy; policy != nil {
+		content += fmt.Sprintf("\n*Required approvals* : %d", policy.GetMinApprovals())
+		if len(policy.Groups) > 0 {
+			content += fmt.Sprintf("\n*Approver groups* : %s", strings.Join(policy.Groups, ", "))
+		}
+	}
+
+	value := fmt.Sprintf("%s/%s", instance.Namespace, instance.Name)
+	return &slack.Message{
+		Channel: channel,
+		Text:    fmt.Sprintf("%s : %s", title, value),
+		Blocks: []slack.MessageBlock{{
+			Type: "section",
+			Text: &slack.BlockText{Type: "mrkdwn", Text: content},
+		}, {
+			Type: "actions",
+			Elements: []slack.BlockElement{
+				{Type: "button", Text: &slack.BlockText{Type: "plain_text", Text: "Approve"}, ActionID: slack.ActionIDApprove, Value: value, Style: "primary"},
+				{Type: "button", Text: &slack.BlockText{Type: "plain_text", Text: "Reject"}, ActionID: slack.ActionIDReject, Value: value, Style: "danger"},
+			},
+		}},
+	}
+}
+
+// processTimeout times out, escalates or reminds the awaiting Approval. It returns the duration after which the Approval
+// should be reconciled again (0 if it doesn't need to be)
+func (r *ApprovalReconciler) processTimeout(instance *cicdv1.Approval) time.Duration {
+	if instance.Status.Result != cicdv1.ApprovalResultAwaiting {
+		return 0
+	}
+
+	now := time.Now()
+	var requeueAfter time.Duration
+
+	// Waiting starts from the creation, or from the escalation if it's escalated
+	start := instance.CreationTimestamp.Time
+	if instance.Status.EscalationTime != nil {
+		start = instance.Status.EscalationTime.Time
+	}
+
+	if timeout := instance.Spec.Timeout; timeout != nil && timeout.Duration > 0 {
+		deadline := start.Add(timeout.Duration)
+		if !now.Before(deadline) {
+			r.timeOut(instance, now)
+			if instance.Status.Result != cicdv1.ApprovalResultAwaiting {
+				return 0
+			}
+			// Escalated - wait again
+			start = now
+			deadline = now.Add(timeout.Duration)
+		}
+		requeueAfter = deadline.Sub(now)
+	}
+
+	if interval := instance.Spec.ReminderInterval; interval != nil && interval.Duration > 0 {
+		last := start
+		if instance.Status.LastReminderTime != nil && instance.Status.LastReminderTime.Time.After(start) {
+			last = instance.Status.LastReminderTime.Time
+		}
+		next := last.Add(interval.Duration)
+		if !now.Before(next) {
+			r.notify(instance, "Reminder", "Approval is still awaiting", instance.Approvers())
+			instance.Status.LastReminderTime = &metav1.Time{Time: now}
+			next = now.Add(interval.Duration)
+		}
+		if requeueAfter == 0 || next.Sub(now) < requeueAfter {
+			requeueAfter = next.Sub(now)
+		}
+	}
+
+	return requeueAfter
+}
+
+// timeOut takes the timeout action. Approval is escalated only once, and is rejected if it times out again
+func (r *ApprovalReconciler) timeOut(instance *cicdv1.Approval, now time.Time) {
+	if instance.Spec.OnTimeout == cicdv1.ApprovalTimeoutActionEscalate && instance.Status.EscalationTime == nil && len(instance.Spec.EscalationApprovers) > 0 {
+		instance.Status.EscalationTime = &metav1.Time{Time: now}
+		r.notify(instance, "Escalated", "Approval is escalated", instance.Spec.EscalationApprovers)
+		return
+	}
+
+	instance.Status.Result = cicdv1.ApprovalResultRejected
+	if instance.Spec.OnTimeout == cicdv1.ApprovalTimeoutActionApprove {
+		instance.Status.Result = cicdv1.ApprovalResultApproved
+	}
+	instance.Status.Reason = fmt.Sprintf("Approval timed out after %s", instance.Spec.Timeout.Duration)
+	instance.Status.DecisionTime = &metav1.Time{Time: now}
+}
+
+// notify sends the request mail (with the title prefix) to the users and posts the slack request message (with the
+// slack title). Failures are only logged, as they are not critical to the Approval itself
+func (r *ApprovalReconciler) notify(instance *cicdv1.Approval, mailPrefix, slackTitle string, users []cicdv1.ApprovalUser) {
+	if !instance.Spec.SkipSendMail && configs.EnableMail {
+		mails, err := r.generateRequestMails(instance, users)
+		for _, m := range mails {
+			if err = r.MailSender.Send(m.receivers, fmt.Sprintf("[%s] %s", mailPrefix, m.title), m.content, true); err != nil {
+				break
+			}
+		}
+		if err != nil {
+			r.Log.Error(err, "")
+		}
+	}
+
+	if configs.EnableSlackApp && configs.SlackApprovalChannel != "" {
+		cred, err := slack.GetAppCredentials(r.Client)
+		if err == nil {
+			err = slack.PostMessage(cred.BotToken, generateSlackRequestMessage(instance, configs.SlackApprovalChannel, slackTitle))
+		}
+		if err != nil {
+			r.Log.Error(err, "")
+		}
+	}
+}
+
 func (r *ApprovalReconciler) roleAndBindingName(approvalName string) string {
 	return "cicd-approval-" + approvalName
 }
@@ -245,7 +436,7 @@ func (r *ApprovalReconciler) createOrUpdateRoleBinding(approval *cicdv1.Approval
 	}
 
 	// Set users in role bindings
-	for _, u := range approval.Spec.Users {
+	for _, u := range approval.Approvers() {
 		// Default is user
 		apiGroup := rbac.GroupName
 		kind := rbac.UserKind
@@ -265,20 +456,7 @@ func (r *ApprovalReconciler) createOrUpdateRoleBinding(approval *cicdv1.Approval
 			name = token[1]
 		}
 
-		// Check if exists in RoleBinding
-		subjectExist := false
-		for _, s := range binding.Subjects {
-			if s.APIGroup == apiGroup && s.Kind == kind && s.Namespace == ns && s.Name == name {
-				subjectExist = true
-				break
-			}
-		}
-
-		if subjectExist {
-			continue
-		}
-
-		binding.Subjects = append(binding.Subjects, rbac.Subject{
+		addSubject(binding, rbac.Subject{
 			APIGroup:  apiGroup,
 			Kind:      kind,
 			Name:      name,
@@ -286,9 +464,30 @@ func (r *ApprovalReconciler) createOrUpdateRoleBinding(approval *cicdv1.Approval
 		})
 	}
 
+	// Set groups in role bindings
+	if approval.Spec.Policy != nil {
+		for _, g := range approval.Spec.Policy.Groups {
+			addSubject(binding, rbac.Subject{
+				APIGroup: rbac.GroupName,
+				Kind:     rbac.GroupKind,
+				Name:     g,
+			})
+		}
+	}
+
 	return utils.CreateOrPatchObject(binding, original, approval, r.Client, r.Scheme)
 }
 
+// addSubject adds the subject to the RoleBinding, if it does not exist
+func addSubject(binding *rbac.RoleBinding, subject rbac.Subject) {
+	for _, s := range binding.Subjects {
+		if s.APIGroup == subject.APIGroup && s.Kind == subject.Kind && s.Namespace == subject.Namespace && s.Name == subject.Name {
+			return
+		}
+	}
+	binding.Subjects = append(binding.Subjects, subject)
+}
+
 func (r *ApprovalReconciler) labelsForRoleAndBinding(approval *cicdv1.Approval) map[string]string {
 	result := map[string]string{
 		cicdv1.JobLabelPrefix + "approval":       approval.Name,
@@ -306,7 +505,56 @@ func (r *ApprovalReconciler) annotationsForRoleAndBinding(approval *cicdv1.Appro
 	return result
 }
 
-func (r *ApprovalReconciler) generateMail(instance *cicdv1.Approval, titleKey, contentKey string) (string, string, error) {
+// requestMail is the data of the request mail templates. Approval's fields are promoted, so the templates can refer to
+// them directly. ApproveURL, RejectURL are set only if the approval links are enabled
+type requestMail struct {
+	*cicdv1.Approval
+	ApproveURL string
+	RejectURL  string
+}
+
+// mailMessage is a generated mail to be sent
+type mailMessage struct {
+	receivers []string
+	title     string
+	content   string
+}
+
+// generateRequestMails generates the request mails for the users. If the approval links are enabled, a mail is generated
+// for each user, with the approve/reject links signed for the user's email
+func (r *ApprovalReconciler) generateRequestMails(instance *cicdv1.Approval, users []cicdv1.ApprovalUser) ([]mailMessage, error) {
+	emails := utils.ParseEmailFromUsers(users)
+	if !approvallink.Enabled() || len(emails) == 0 {
+		title, content, err := r.generateMail(&requestMail{Approval: instance}, configMapKeyRequestTitle, configMapKeyRequestContent)
+		if err != nil {
+			return nil, err
+		}
+		return []mailMessage{{receivers: emails, title: title, content: content}}, nil
+	}
+
+	key, err := approvallink.GetSigningKey(r.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var mails []mailMessage
+	for _, email := range emails {
+		data := &requestMail{
+			Approval:   instance,
+			ApproveURL: approvallink.New(instance, email, cicdv1.ApprovalAPIApprove, now).URL(key),
+			RejectURL:  approvallink.New(instance, email, cicdv1.ApprovalAPIReject, now).URL(key),
+		}
+		title, content, err := r.generateMail(data, configMapKeyRequestTitle, configMapKeyRequestContent)
+		if err != nil {
+			return nil, err
+		}
+		mails = append(mails, mailMessage{receivers: []string{email}, title: title, content: content})
+	}
+	return mails, nil
+}
+
+func (r *ApprovalReconciler) generateMail(data interface{}, titleKey, contentKey string) (string, string, error) {
 	templatePath := os.Getenv(configMapPathEnv)
 	if templatePath == "" {
 		templatePath = configMapPathDefault
@@ -325,11 +573,11 @@ func (r *ApprovalReconciler) generateMail(instance *cicdv1.Approval, titleKey, c
 
 	// Generate mail title, content
 	title := &bytes.Buffer{}
-	if err := titleTemplate.Execute(title, instance); err != nil {
+	if err := titleTemplate.Execute(title, data); err != nil {
 		return "", "", err
 	}
 	content := &bytes.Buffer{}
-	if err := contentTemplate.Execute(content, instance); err != nil {
+	if err := contentTemplate.Execute(content, data); err != nil {
 		return "", "", err
 	}
 
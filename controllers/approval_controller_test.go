@@ -18,16 +18,24 @@ package controllers
 
 import (
 	"context"
+	"html"
 	"io/ioutil"
+	"net/url"
 	"os"
 	"path"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
 	"github.com/tmax-cloud/cicd-operator/internal/configs"
 	"github.com/tmax-cloud/cicd-operator/internal/test"
+	"github.com/tmax-cloud/cicd-operator/internal/utils"
+	"github.com/tmax-cloud/cicd-operator/pkg/approvallink"
 	"github.com/tmax-cloud/cicd-operator/pkg/notification/mail"
+	"github.com/tmax-cloud/cicd-operator/pkg/notification/slack"
+	corev1 "k8s.io/api/core/v1"
 	rbac "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -36,6 +44,7 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -288,6 +297,68 @@ func TestApprovalReconciler_Reconcile(t *testing.T) {
 	}
 }
 
+func TestApprovalReconciler_Reconcile_timeoutConflict(t *testing.T) {
+	s := runtime.NewScheme()
+	require.NoError(t, cicdv1.AddToScheme(s))
+	require.NoError(t, rbac.AddToScheme(s))
+
+	approval := &cicdv1.Approval{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-approval", Namespace: "test-ns", CreationTimestamp: metav1.Time{Time: time.Now().Add(-time.Hour)}},
+		Spec: cicdv1.ApprovalSpec{
+			SkipSendMail:    true,
+			Users:           []cicdv1.ApprovalUser{{Name: "admin", Email: "admin@tmax.co.kr"}},
+			ApprovalTimeout: cicdv1.ApprovalTimeout{Timeout: &metav1.Duration{Duration: 30 * time.Minute}},
+		},
+		Status: cicdv1.ApprovalStatus{Result: cicdv1.ApprovalResultAwaiting},
+	}
+	fakeCli := fake.NewClientBuilder().WithScheme(s).WithObjects(approval).Build()
+	reconciler := &ApprovalReconciler{Client: &decidingClient{Client: fakeCli}, Log: &test.FakeLogger{}, Scheme: s}
+
+	// The Approval is approved while the reconciler is timing it out
+	result, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "test-approval", Namespace: "test-ns"}})
+	require.NoError(t, err)
+	require.True(t, result.Requeue)
+
+	stored := &cicdv1.Approval{}
+	require.NoError(t, fakeCli.Get(context.Background(), types.NamespacedName{Name: "test-approval", Namespace: "test-ns"}, stored))
+	require.Equal(t, cicdv1.ApprovalResultApproved, stored.Status.Result)
+	require.Equal(t, "admin", stored.Status.Approver)
+
+	// Requeued one keeps the decision
+	reconciler.Client = fakeCli
+	result, err = reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "test-approval", Namespace: "test-ns"}})
+	require.NoError(t, err)
+	require.False(t, result.Requeue)
+
+	require.NoError(t, fakeCli.Get(context.Background(), types.NamespacedName{Name: "test-approval", Namespace: "test-ns"}, stored))
+	require.Equal(t, cicdv1.ApprovalResultApproved, stored.Status.Result)
+	require.Equal(t, "admin", stored.Status.Approver)
+}
+
+// decidingClient approves the Approval right after the first Get of it, as if an approver decided it concurrently
+type decidingClient struct {
+	client.Client
+	decided bool
+}
+
+func (c *decidingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	if err := c.Client.Get(ctx, key, obj); err != nil {
+		return err
+	}
+	if _, ok := obj.(*cicdv1.Approval); !ok || c.decided {
+		return nil
+	}
+	c.decided = true
+
+	approval := &cicdv1.Approval{}
+	if err := c.Client.Get(ctx, key, approval); err != nil {
+		return err
+	}
+	approval.Status.Result = cicdv1.ApprovalResultApproved
+	approval.Status.Approver = "admin"
+	return c.Client.Status().Update(ctx, approval)
+}
+
 func TestApprovalReconciler_bumpV050(t *testing.T) {
 	reconciler := &ApprovalReconciler{}
 
@@ -845,6 +916,72 @@ func TestApprovalReconciler_generateMail(t *testing.T) {
 	}
 }
 
+func TestApprovalReconciler_generateRequestMails(t *testing.T) {
+	templatePath := path.Join(os.TempDir(), "cicd-test-request-mails-template")
+	require.NoError(t, os.Setenv(configMapPathEnv, templatePath))
+	defer func() {
+		_ = os.Unsetenv(configMapPathEnv)
+	}()
+	require.NoError(t, os.MkdirAll(templatePath, os.ModePerm))
+	defer func() {
+		_ = os.RemoveAll(templatePath)
+	}()
+	require.NoError(t, ioutil.WriteFile(path.Join(templatePath, configMapKeyRequestTitle), []byte("{{.Name}}"), os.ModePerm))
+	require.NoError(t, ioutil.WriteFile(path.Join(templatePath, configMapKeyRequestContent), []byte("{{if .ApproveURL}}{{.ApproveURL}} {{.RejectURL}}{{else}}no link{{end}}"), os.ModePerm))
+
+	s := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(s))
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "approval-link", Namespace: utils.Namespace()},
+		Data:       map[string][]byte{approvallink.SecretKeySigningKey: []byte("key")},
+	}
+	reconciler := &ApprovalReconciler{Client: fake.NewClientBuilder().WithScheme(s).WithObjects(secret).Build()}
+
+	approval := &cicdv1.Approval{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-approval", Namespace: "test-ns", UID: "test-uid"},
+		Spec: cicdv1.ApprovalSpec{
+			Users: []cicdv1.ApprovalUser{{Name: "admin", Email: "admin@tmax.co.kr"}, {Name: "admin2", Email: "admin2@tmax.co.kr"}},
+		},
+	}
+
+	// Links disabled
+	configs.ApprovalLinkSecret = ""
+	mails, err := reconciler.generateRequestMails(approval, approval.Spec.Users)
+	require.NoError(t, err)
+	require.Equal(t, []mailMessage{{receivers: []string{"admin@tmax.co.kr", "admin2@tmax.co.kr"}, title: "test-approval", content: "no link"}}, mails)
+
+	// Links enabled
+	configs.ApprovalLinkSecret = "approval-link"
+	configs.ApprovalLinkTTL = 24
+	defer func() {
+		configs.ApprovalLinkSecret = ""
+	}()
+	mails, err = reconciler.generateRequestMails(approval, approval.Spec.Users)
+	require.NoError(t, err)
+	require.Len(t, mails, 2)
+	for i, email := range []string{"admin@tmax.co.kr", "admin2@tmax.co.kr"} {
+		require.Equal(t, []string{email}, mails[i].receivers)
+		require.Equal(t, "test-approval", mails[i].title)
+
+		urls := strings.Split(html.UnescapeString(mails[i].content), " ")
+		require.Len(t, urls, 2)
+		for j, decision := range []string{cicdv1.ApprovalAPIApprove, cicdv1.ApprovalAPIReject} {
+			u, err := url.Parse(urls[j])
+			require.NoError(t, err)
+			link, err := approvallink.Parse([]byte("key"), u.Query(), time.Now())
+			require.NoError(t, err)
+			require.Equal(t, email, link.Email)
+			require.Equal(t, decision, link.Decision)
+			require.Equal(t, approval.UID, link.UID)
+		}
+	}
+
+	// No secret
+	configs.ApprovalLinkSecret = "no-secret"
+	_, err = reconciler.generateRequestMails(approval, approval.Spec.Users)
+	require.Error(t, err)
+}
+
 func TestApprovalReconciler_sendMail(t *testing.T) {
 	tc := map[string]struct {
 		users    []string
@@ -897,6 +1034,215 @@ func TestApprovalReconciler_sendMail(t *testing.T) {
 	}
 }
 
+func TestApprovalReconciler_processSlack(t *testing.T) {
+	tc := map[string]struct {
+		disabled   bool
+		result     cicdv1.ApprovalResult
+		conditions []metav1.Condition
+
+		expectedCond *metav1.Condition
+	}{
+		"noSecret": {
+			result: cicdv1.ApprovalResultAwaiting,
+			expectedCond: &metav1.Condition{
+				Type:    cicdv1.ApprovalConditionSentRequestSlack,
+				Status:  metav1.ConditionFalse,
+				Reason:  "ErrorSendingSlackMessage",
+				Message: "secrets \"slack-app\" not found",
+			},
+		},
+		"alreadySent": {
+			result: cicdv1.ApprovalResultAwaiting,
+			conditions: []metav1.Condition{
+				{Type: cicdv1.ApprovalConditionSentRequestSlack, Status: metav1.ConditionTrue, Reason: "SlackMessageSent", Message: "Slack message is sent"},
+			},
+			expectedCond: &metav1.Condition{
+				Type:    cicdv1.ApprovalConditionSentRequestSlack,
+				Status:  metav1.ConditionTrue,
+				Reason:  "SlackMessageSent",
+				Message: "Slack message is sent",
+			},
+		},
+		"decided": {
+			result: cicdv1.ApprovalResultApproved,
+		},
+		"disabled": {
+			disabled: true,
+			result:   cicdv1.ApprovalResultAwaiting,
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			configs.EnableSlackApp = !c.disabled
+			configs.SlackAppSecret = "slack-app"
+			configs.SlackApprovalChannel = "C0001"
+			defer func() {
+				configs.EnableSlackApp = false
+			}()
+
+			instance := &cicdv1.Approval{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-approval", Namespace: "test-ns"},
+				Status:     cicdv1.ApprovalStatus{Result: c.result, Conditions: c.conditions},
+			}
+
+			reconciler := &ApprovalReconciler{Log: &test.FakeLogger{}, Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()}
+			reconciler.processSlack(instance)
+
+			cond := meta.FindStatusCondition(instance.Status.Conditions, cicdv1.ApprovalConditionSentRequestSlack)
+			if c.expectedCond == nil {
+				require.Nil(t, cond)
+				return
+			}
+			require.NotNil(t, cond)
+			require.Equal(t, c.expectedCond.Status, cond.Status)
+			require.Equal(t, c.expectedCond.Reason, cond.Reason)
+			require.Equal(t, c.expectedCond.Message, cond.Message)
+		})
+	}
+}
+
+func TestApprovalReconciler_processTimeout(t *testing.T) {
+	now := time.Now()
+	hourAgo := metav1.Time{Time: now.Add(-time.Hour)}
+
+	tc := map[string]struct {
+		timeout        cicdv1.ApprovalTimeout
+		escalationTime *metav1.Time
+		lastReminder   *metav1.Time
+
+		expectedResult    cicdv1.ApprovalResult
+		expectedReason    string
+		expectedEscalated bool
+		expectedReminded  bool
+		expectedRequeue   time.Duration
+		expectedMails     []string
+	}{
+		"noTimeout": {
+			expectedResult: cicdv1.ApprovalResultAwaiting,
+		},
+		"notTimedOut": {
+			timeout:         cicdv1.ApprovalTimeout{Timeout: &metav1.Duration{Duration: 2 * time.Hour}},
+			expectedResult:  cicdv1.ApprovalResultAwaiting,
+			expectedRequeue: time.Hour,
+		},
+		"reject": {
+			timeout:        cicdv1.ApprovalTimeout{Timeout: &metav1.Duration{Duration: 30 * time.Minute}},
+			expectedResult: cicdv1.ApprovalResultRejected,
+			expectedReason: "Approval timed out after 30m0s",
+		},
+		"approve": {
+			timeout:        cicdv1.ApprovalTimeout{Timeout: &metav1.Duration{Duration: 30 * time.Minute}, OnTimeout: cicdv1.ApprovalTimeoutActionApprove},
+			expectedResult: cicdv1.ApprovalResultApproved,
+			expectedReason: "Approval timed out after 30m0s",
+		},
+		"escalate": {
+			timeout: cicdv1.ApprovalTimeout{
+				Timeout:             &metav1.Duration{Duration: 30 * time.Minute},
+				OnTimeout:           cicdv1.ApprovalTimeoutActionEscalate,
+				EscalationApprovers: []cicdv1.ApprovalUser{{Name: "manager", Email: "manager@tmax.co.kr"}},
+			},
+			expectedResult:    cicdv1.ApprovalResultAwaiting,
+			expectedEscalated: true,
+			expectedRequeue:   30 * time.Minute,
+			expectedMails:     []string{"[Escalated] request"},
+		},
+		"escalatedTimedOut": {
+			timeout: cicdv1.ApprovalTimeout{
+				Timeout:             &metav1.Duration{Duration: 30 * time.Minute},
+				OnTimeout:           cicdv1.ApprovalTimeoutActionEscalate,
+				EscalationApprovers: []cicdv1.ApprovalUser{{Name: "manager", Email: "manager@tmax.co.kr"}},
+			},
+			escalationTime:    &hourAgo,
+			expectedResult:    cicdv1.ApprovalResultRejected,
+			expectedReason:    "Approval timed out after 30m0s",
+			expectedEscalated: true,
+		},
+		"remind": {
+			timeout:          cicdv1.ApprovalTimeout{ReminderInterval: &metav1.Duration{Duration: 20 * time.Minute}},
+			expectedResult:   cicdv1.ApprovalResultAwaiting,
+			expectedReminded: true,
+			expectedRequeue:  20 * time.Minute,
+			expectedMails:    []string{"[Reminder] request"},
+		},
+		"reminderNotDue": {
+			timeout:         cicdv1.ApprovalTimeout{ReminderInterval: &metav1.Duration{Duration: 40 * time.Minute}},
+			lastReminder:    &metav1.Time{Time: now.Add(-20 * time.Minute)},
+			expectedResult:  cicdv1.ApprovalResultAwaiting,
+			expectedRequeue: 20 * time.Minute,
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			configs.EnableMail = true
+			templatePath := path.Join(os.TempDir(), "cicd-test-process-timeout-template")
+			require.NoError(t, os.Setenv(configMapPathEnv, templatePath))
+			defer func() {
+				_ = os.Unsetenv(configMapPathEnv)
+			}()
+			require.NoError(t, os.MkdirAll(templatePath, os.ModePerm))
+			defer func() {
+				_ = os.RemoveAll(templatePath)
+			}()
+			require.NoError(t, ioutil.WriteFile(path.Join(templatePath, configMapKeyRequestTitle), []byte("request"), os.ModePerm))
+			require.NoError(t, ioutil.WriteFile(path.Join(templatePath, configMapKeyRequestContent), []byte("request"), os.ModePerm))
+
+			instance := &cicdv1.Approval{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-approval", Namespace: "test-ns", CreationTimestamp: hourAgo},
+				Spec: cicdv1.ApprovalSpec{
+					Users:           []cicdv1.ApprovalUser{{Name: "admin", Email: "admin@tmax.co.kr"}},
+					ApprovalTimeout: c.timeout,
+				},
+				Status: cicdv1.ApprovalStatus{
+					Result:           cicdv1.ApprovalResultAwaiting,
+					EscalationTime:   c.escalationTime,
+					LastReminderTime: c.lastReminder,
+				},
+			}
+
+			sender := mail.NewFakeSender()
+			reconciler := &ApprovalReconciler{Log: &test.FakeLogger{}, MailSender: sender}
+			requeue := reconciler.processTimeout(instance)
+
+			require.Equal(t, c.expectedResult, instance.Status.Result)
+			require.Equal(t, c.expectedReason, instance.Status.Reason)
+			require.Equal(t, c.expectedEscalated, instance.Status.EscalationTime != nil)
+			require.Equal(t, c.expectedReminded, instance.Status.LastReminderTime != nil && instance.Status.LastReminderTime != c.lastReminder)
+			require.InDelta(t, c.expectedRequeue.Seconds(), requeue.Seconds(), 5)
+
+			var titles []string
+			for _, m := range sender.Mails {
+				titles = append(titles, m.Title)
+			}
+			require.Equal(t, c.expectedMails, titles)
+		})
+	}
+}
+
+func Test_generateSlackRequestMessage(t *testing.T) {
+	instance := &cicdv1.Approval{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-approval", Namespace: "test-ns"},
+		Spec: cicdv1.ApprovalSpec{
+			IntegrationJob: "test-ij",
+			JobName:        "deploy",
+			Message:        "please approve",
+			Sender:         &cicdv1.ApprovalUser{Name: "requester"},
+			Users:          []cicdv1.ApprovalUser{{Name: "admin"}, {Name: "manager"}},
+		},
+	}
+
+	msg := generateSlackRequestMessage(instance, "C0001", "Approval is requested")
+	require.Equal(t, "C0001", msg.Channel)
+	require.Equal(t, "Approval is requested : test-ns/test-approval", msg.Text)
+	require.Len(t, msg.Blocks, 2)
+	require.Equal(t, "*Approval is requested* : test-ns/test-approval\n*IntegrationJob* : test-ij\n*Job* : deploy\n*Approvers* : admin, manager\n*Requested by* : requester\n*Message* : please approve", msg.Blocks[0].Text.Text)
+	require.Equal(t, []slack.BlockElement{
+		{Type: "button", Text: &slack.BlockText{Type: "plain_text", Text: "Approve"}, ActionID: slack.ActionIDApprove, Value: "test-ns/test-approval", Style: "primary"},
+		{Type: "button", Text: &slack.BlockText{Type: "plain_text", Text: "Reject"}, ActionID: slack.ActionIDReject, Value: "test-ns/test-approval", Style: "danger"},
+	}, msg.Blocks[1].Elements)
+}
+
 func TestApprovalReconciler_setError(t *testing.T) {
 	utilruntime.Must(cicdv1.AddToScheme(scheme.Scheme))
 	approval := &cicdv1.Approval{
@@ -19,6 +19,7 @@ package customs
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -121,7 +122,11 @@ func (a *ApprovalRunHandler) Handle(run *tektonv1alpha1.Run) (ctrl.Result, error
 			cond.Status = corev1.ConditionFalse
 		}
 		cond.Reason = reason
-		cond.Message = fmt.Sprintf("%s %s this approval, reason: %s, decisionTime: %s", approval.Status.Approver, strings.ToLower(reason), approval.Status.Reason, approval.Status.DecisionTime)
+		approver := approval.Status.Approver
+		if approver == "" {
+			approver = "timeout"
+		}
+		cond.Message = fmt.Sprintf("%s %s this approval, reason: %s, decisionTime: %s", approver, strings.ToLower(reason), approval.Status.Reason, approval.Status.DecisionTime)
 		run.Status.CompletionTime = &metav1.Time{Time: time.Now()}
 	} else {
 		cond.Status = corev1.ConditionUnknown
@@ -180,8 +185,8 @@ func (a *ApprovalRunHandler) newApproval(run *tektonv1alpha1.Run) (*cicdv1.Appro
 	if err != nil {
 		return nil, err
 	}
+	cm := &corev1.ConfigMap{}
 	if approverCm != "" {
-		cm := &corev1.ConfigMap{}
 		_ = a.Client.Get(context.Background(), types.NamespacedName{Name: approverCm, Namespace: run.Namespace}, cm)
 		if cm.Data != nil {
 			cmListStr, exist := cm.Data[cicdv1.CustomTaskApprovalApproversConfigMapKey]
@@ -194,6 +199,27 @@ func (a *ApprovalRunHandler) newApproval(run *tektonv1alpha1.Run) (*cicdv1.Appro
 		}
 	}
 
+	// Get policy (optional)
+	policy, authors := parseApprovalPolicy(run.Spec.Params)
+	var groupUsers map[string][]string
+	if policy != nil {
+		// Groups in the approvers ConfigMap are resolved to its users. The others are regarded as Kubernetes groups
+		for _, g := range policy.Groups {
+			groupListStr, exist := cm.Data[g]
+			if !exist {
+				continue
+			}
+			groupList, _ := utils.ParseApproversList(groupListStr)
+			approversStr = append(approversStr, groupList...)
+			if groupUsers == nil {
+				groupUsers = map[string][]string{}
+			}
+			for _, u := range parseApprover(groupList) {
+				groupUsers[g] = append(groupUsers[g], u.Name)
+			}
+		}
+	}
+
 	// Parse approvers in form of <Name>=<Email>
 	approvers := parseApprover(approversStr)
 
@@ -225,6 +251,24 @@ func (a *ApprovalRunHandler) newApproval(run *tektonv1alpha1.Run) (*cicdv1.Appro
 		return nil, err
 	}
 
+	// Authors and sender are git users. Record the kubernetes users mapped from them, to keep them from deciding
+	var excludedUsers []string
+	if policy != nil && policy.SeparationOfDuties {
+		excludedUsers, err = a.mapGitUsers(append(authors, cicdv1.ApprovalUser{Name: sender, Email: senderEmail})...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var author string
+	var authorNames []string
+	for _, u := range authors {
+		authorNames = append(authorNames, u.Name)
+	}
+	if len(authorNames) > 0 {
+		author = authorNames[0]
+	}
+
 	return &cicdv1.Approval{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      run.Name,
@@ -242,10 +286,97 @@ func (a *ApprovalRunHandler) newApproval(run *tektonv1alpha1.Run) (*cicdv1.Appro
 			},
 			Message: msg,
 			Link:    link,
+			Author:  author,
+			Authors: authorNames,
+			Policy:  policy,
+
+			ExcludedUsers: excludedUsers,
+			GroupUsers:    groupUsers,
+
+			ApprovalTimeout: parseApprovalTimeout(run.Spec.Params),
 		},
 	}, nil
 }
 
+// parseApprovalTimeout parses the timeout/reminder parameters. They are optional, for the Runs created by the older versions
+func parseApprovalTimeout(params []tektonv1beta1.Param) cicdv1.ApprovalTimeout {
+	timeout := cicdv1.ApprovalTimeout{}
+
+	if timeoutStr, _, err := searchParam(params, cicdv1.CustomTaskApprovalParamKeyTimeout, tektonv1beta1.ParamTypeString); err == nil {
+		if d, err := time.ParseDuration(timeoutStr); err == nil {
+			timeout.Timeout = &metav1.Duration{Duration: d}
+		}
+	}
+	onTimeout, _, _ := searchParam(params, cicdv1.CustomTaskApprovalParamKeyOnTimeout, tektonv1beta1.ParamTypeString)
+	timeout.OnTimeout = cicdv1.ApprovalTimeoutAction(onTimeout)
+	_, escalationApprovers, _ := searchParam(params, cicdv1.CustomTaskApprovalParamKeyEscalationApprovers, tektonv1beta1.ParamTypeArray)
+	timeout.EscalationApprovers = parseApprover(escalationApprovers)
+
+	if intervalStr, _, err := searchParam(params, cicdv1.CustomTaskApprovalParamKeyReminderInterval, tektonv1beta1.ParamTypeString); err == nil {
+		if d, err := time.ParseDuration(intervalStr); err == nil {
+			timeout.ReminderInterval = &metav1.Duration{Duration: d}
+		}
+	}
+
+	return timeout
+}
+
+// parseApprovalPolicy parses the policy parameters and the authors. They are optional, for the Runs created by the older versions
+func parseApprovalPolicy(params []tektonv1beta1.Param) (*cicdv1.ApprovalPolicy, []cicdv1.ApprovalUser) {
+	minApprovals, _, err := searchParam(params, cicdv1.CustomTaskApprovalParamKeyMinApprovals, tektonv1beta1.ParamTypeString)
+	if err != nil {
+		return nil, nil
+	}
+
+	policy := &cicdv1.ApprovalPolicy{}
+	policy.MinApprovals, _ = strconv.Atoi(minApprovals)
+	_, policy.Groups, _ = searchParam(params, cicdv1.CustomTaskApprovalParamKeyGroups, tektonv1beta1.ParamTypeArray)
+	separation, _, _ := searchParam(params, cicdv1.CustomTaskApprovalParamKeySeparationOfDuties, tektonv1beta1.ParamTypeString)
+	policy.SeparationOfDuties, _ = strconv.ParseBool(separation)
+	// Runs created by the older versions only have a single author
+	if _, authors, err := searchParam(params, cicdv1.CustomTaskApprovalParamKeyAuthors, tektonv1beta1.ParamTypeArray); err == nil {
+		return policy, parseApprover(authors)
+	}
+	author, _, _ := searchParam(params, cicdv1.CustomTaskApprovalParamKeyAuthor, tektonv1beta1.ParamTypeString)
+	if author == "" {
+		return policy, nil
+	}
+	authorEmail, _, _ := searchParam(params, cicdv1.CustomTaskApprovalParamKeyAuthorEmail, tektonv1beta1.ParamTypeString)
+
+	return policy, []cicdv1.ApprovalUser{{Name: author, Email: authorEmail}}
+}
+
+// mapGitUsers maps the git users to kubernetes users, using the git user ConfigMap.
+// A git user is looked up by its name first, and then by its email.
+// It fails if the ConfigMap is not configured or cannot be read, not to let the unmapped users decide the Approval
+func (a *ApprovalRunHandler) mapGitUsers(gitUsers ...cicdv1.ApprovalUser) ([]string, error) {
+	if configs.GitUserConfigMap == "" {
+		return nil, fmt.Errorf("gitUserConfigMap should be configured for the separation of duties")
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := a.Client.Get(context.Background(), types.NamespacedName{Name: configs.GitUserConfigMap, Namespace: utils.Namespace()}, cm); err != nil {
+		return nil, fmt.Errorf("cannot get git user configmap %s, err : %s", configs.GitUserConfigMap, err.Error())
+	}
+
+	var users []string
+	mapped := map[string]struct{}{}
+	for _, u := range gitUsers {
+		for _, key := range []string{u.Name, u.Email} {
+			user, exist := cm.Data[key]
+			if key == "" || !exist || user == "" {
+				continue
+			}
+			if _, ok := mapped[user]; !ok {
+				mapped[user] = struct{}{}
+				users = append(users, user)
+			}
+			break
+		}
+	}
+	return users, nil
+}
+
 func parseApprover(approversStr []string) []cicdv1.ApprovalUser {
 	var approvers []cicdv1.ApprovalUser
 	for _, approverStr := range approversStr {
@@ -28,6 +28,7 @@ import (
 	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
 	"github.com/tmax-cloud/cicd-operator/internal/configs"
+	"github.com/tmax-cloud/cicd-operator/internal/utils"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -149,6 +150,80 @@ func TestApprovalRunHandler_Handle(t *testing.T) {
 				require.Equal(t, cicdv1.ApprovalUser{Name: "admin3@tmax.co.kr"}, approval.Spec.Users[2])
 			},
 		},
+		"creationPolicy": {
+			preFunc: func(t *testing.T, run *tektonv1alpha1.Run, handler *ApprovalRunHandler) {
+				configMapName := "approver-cm-2"
+				cm := &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: configMapName, Namespace: testApprovalRunNamespace},
+					Data:       map[string]string{"release-managers": "manager1@tmax.co.kr,manager2@tmax.co.kr=manager2@tmax.co.kr"},
+				}
+				require.NoError(t, handler.Client.Create(context.Background(), cm))
+				run.Spec.Params[1].Value.StringVal = configMapName
+
+				configs.GitUserConfigMap = "git-users"
+				gitUsers := &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: configs.GitUserConfigMap, Namespace: utils.Namespace()},
+					Data:       map[string]string{"author1@tmax.co.kr": "author1@tmax.co.kr", "author2": "author2@tmax.co.kr", "developer1": "developer1@tmax.co.kr"},
+				}
+				require.NoError(t, handler.Client.Create(context.Background(), gitUsers))
+
+				run.Spec.Params = append(run.Spec.Params, []tektonv1beta1.Param{
+					{Name: cicdv1.CustomTaskApprovalParamKeyAuthor, Value: tektonv1beta1.ArrayOrString{Type: tektonv1beta1.ParamTypeString, StringVal: "author1"}},
+					{Name: cicdv1.CustomTaskApprovalParamKeyAuthorEmail, Value: tektonv1beta1.ArrayOrString{Type: tektonv1beta1.ParamTypeString, StringVal: "author1@tmax.co.kr"}},
+					{Name: cicdv1.CustomTaskApprovalParamKeyAuthors, Value: tektonv1beta1.ArrayOrString{Type: tektonv1beta1.ParamTypeArray, ArrayVal: []string{"author1=author1@tmax.co.kr", "author2"}}},
+					{Name: cicdv1.CustomTaskApprovalParamKeyMinApprovals, Value: tektonv1beta1.ArrayOrString{Type: tektonv1beta1.ParamTypeString, StringVal: "2"}},
+					{Name: cicdv1.CustomTaskApprovalParamKeyGroups, Value: tektonv1beta1.ArrayOrString{Type: tektonv1beta1.ParamTypeArray, ArrayVal: []string{"release-managers", "k8s-group"}}},
+					{Name: cicdv1.CustomTaskApprovalParamKeySeparationOfDuties, Value: tektonv1beta1.ArrayOrString{Type: tektonv1beta1.ParamTypeString, StringVal: "true"}},
+				}...)
+			},
+			verifyFunc: func(t *testing.T, run *tektonv1alpha1.Run, approval *cicdv1.Approval) {
+				require.Equal(t, "author1", approval.Spec.Author)
+				require.Equal(t, []string{"author1", "author2"}, approval.Spec.Authors)
+				require.Equal(t, &cicdv1.ApprovalPolicy{MinApprovals: 2, Groups: []string{"release-managers", "k8s-group"}, SeparationOfDuties: true}, approval.Spec.Policy)
+				require.Equal(t, map[string][]string{"release-managers": {"manager1@tmax.co.kr", "manager2@tmax.co.kr"}}, approval.Spec.GroupUsers)
+				require.Equal(t, []string{"author1@tmax.co.kr", "author2@tmax.co.kr", "developer1@tmax.co.kr"}, approval.Spec.ExcludedUsers)
+				require.Equal(t, []cicdv1.ApprovalUser{
+					{Name: "admin@tmax.co.kr", Email: "admin@tmax.co.kr"},
+					{Name: "manager1@tmax.co.kr"},
+					{Name: "manager2@tmax.co.kr", Email: "manager2@tmax.co.kr"},
+				}, approval.Spec.Users)
+			},
+		},
+		"separationOfDutiesNoGitUserCM": {
+			preFunc: func(t *testing.T, run *tektonv1alpha1.Run, handler *ApprovalRunHandler) {
+				run.Spec.Params = append(run.Spec.Params, []tektonv1beta1.Param{
+					{Name: cicdv1.CustomTaskApprovalParamKeyAuthor, Value: tektonv1beta1.ArrayOrString{Type: tektonv1beta1.ParamTypeString, StringVal: "author1"}},
+					{Name: cicdv1.CustomTaskApprovalParamKeyMinApprovals, Value: tektonv1beta1.ArrayOrString{Type: tektonv1beta1.ParamTypeString, StringVal: "1"}},
+					{Name: cicdv1.CustomTaskApprovalParamKeySeparationOfDuties, Value: tektonv1beta1.ArrayOrString{Type: tektonv1beta1.ParamTypeString, StringVal: "true"}},
+				}...)
+			},
+			verifyFunc: func(t *testing.T, run *tektonv1alpha1.Run, approval *cicdv1.Approval) {
+				cond := run.Status.GetCondition(apis.ConditionSucceeded)
+				require.NotNil(t, cond)
+				require.True(t, cond.IsFalse())
+				require.Equal(t, "CannotCreateApproval", cond.Reason)
+				require.Equal(t, "gitUserConfigMap should be configured for the separation of duties", cond.Message)
+				require.Empty(t, approval.Name)
+			},
+		},
+		"separationOfDutiesGitUserCMNotFound": {
+			preFunc: func(t *testing.T, run *tektonv1alpha1.Run, handler *ApprovalRunHandler) {
+				configs.GitUserConfigMap = "git-users-not-found"
+				run.Spec.Params = append(run.Spec.Params, []tektonv1beta1.Param{
+					{Name: cicdv1.CustomTaskApprovalParamKeyAuthor, Value: tektonv1beta1.ArrayOrString{Type: tektonv1beta1.ParamTypeString, StringVal: "author1"}},
+					{Name: cicdv1.CustomTaskApprovalParamKeyMinApprovals, Value: tektonv1beta1.ArrayOrString{Type: tektonv1beta1.ParamTypeString, StringVal: "1"}},
+					{Name: cicdv1.CustomTaskApprovalParamKeySeparationOfDuties, Value: tektonv1beta1.ArrayOrString{Type: tektonv1beta1.ParamTypeString, StringVal: "true"}},
+				}...)
+			},
+			verifyFunc: func(t *testing.T, run *tektonv1alpha1.Run, approval *cicdv1.Approval) {
+				cond := run.Status.GetCondition(apis.ConditionSucceeded)
+				require.NotNil(t, cond)
+				require.True(t, cond.IsFalse())
+				require.Equal(t, "CannotCreateApproval", cond.Reason)
+				require.Contains(t, cond.Message, "cannot get git user configmap git-users-not-found")
+				require.Empty(t, approval.Name)
+			},
+		},
 		"createError": {
 			preFunc: func(t *testing.T, run *tektonv1alpha1.Run, handler *ApprovalRunHandler) {
 				s := runtime.NewScheme()
@@ -276,6 +351,7 @@ func TestApprovalRunHandler_Handle(t *testing.T) {
 	for name, c := range tc {
 		t.Run(name, func(t *testing.T) {
 			configs.EnableMail = true
+			configs.GitUserConfigMap = ""
 
 			s := runtime.NewScheme()
 			utilruntime.Must(corev1.AddToScheme(s))
@@ -671,3 +747,20 @@ func createApprovalForRun(cli client.Client, status cicdv1.ApprovalStatus) error
 	approval.Status = status
 	return cli.Status().Update(context.Background(), approval)
 }
+
+func Test_parseApprovalTimeout(t *testing.T) {
+	require.Equal(t, cicdv1.ApprovalTimeout{}, parseApprovalTimeout(nil))
+
+	timeout := parseApprovalTimeout([]tektonv1beta1.Param{
+		{Name: cicdv1.CustomTaskApprovalParamKeyTimeout, Value: tektonv1beta1.ArrayOrString{Type: tektonv1beta1.ParamTypeString, StringVal: "24h0m0s"}},
+		{Name: cicdv1.CustomTaskApprovalParamKeyOnTimeout, Value: tektonv1beta1.ArrayOrString{Type: tektonv1beta1.ParamTypeString, StringVal: "Escalate"}},
+		{Name: cicdv1.CustomTaskApprovalParamKeyEscalationApprovers, Value: tektonv1beta1.ArrayOrString{Type: tektonv1beta1.ParamTypeArray, ArrayVal: []string{"manager=manager@tmax.co.kr"}}},
+		{Name: cicdv1.CustomTaskApprovalParamKeyReminderInterval, Value: tektonv1beta1.ArrayOrString{Type: tektonv1beta1.ParamTypeString, StringVal: "4h"}},
+	})
+	require.Equal(t, cicdv1.ApprovalTimeout{
+		Timeout:             &metav1.Duration{Duration: 24 * time.Hour},
+		OnTimeout:           cicdv1.ApprovalTimeoutActionEscalate,
+		EscalationApprovers: []cicdv1.ApprovalUser{{Name: "manager", Email: "manager@tmax.co.kr"}},
+		ReminderInterval:    &metav1.Duration{Duration: 4 * time.Hour},
+	}, timeout)
+}
@@ -79,7 +79,7 @@ func TestSlackRunHandler_Handle(t *testing.T) {
 			expectedMessage: slack.Message{
 				Text: "IntegrationJobNotification",
 				Blocks: []slack.MessageBlock{
-					{Type: "section", Text: slack.BlockText{Type: "mrkdwn", Text: "test-ij-1/test-job-1"}},
+					{Type: "section", Text: &slack.BlockText{Type: "mrkdwn", Text: "test-ij-1/test-job-1"}},
 				},
 			},
 		},
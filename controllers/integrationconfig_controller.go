@@ -18,19 +18,26 @@ package controllers
 
 import (
 	"context"
+	"strconv"
+
 	"github.com/go-logr/logr"
+	"github.com/tmax-cloud/cicd-operator/internal/configs"
 	"github.com/tmax-cloud/cicd-operator/internal/utils"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/pkg/artifacts"
 	"github.com/tmax-cloud/cicd-operator/pkg/periodictrigger"
 )
 
@@ -52,6 +59,8 @@ var periodicTriggers = map[string]*periodictrigger.PeriodicTrigger{}
 // +kubebuilder:rbac:groups=cicd.tmax.io,resources=integrationconfigs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=cicd.tmax.io,resources=integrationconfigs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets;serviceaccounts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile reconciles IntegrationConfig
 func (r *IntegrationConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -131,6 +140,26 @@ func (r *IntegrationConfigReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return ctrl.Result{}, nil
 	}
 
+	// Cache PVC - mounted to the jobs using cache
+	if err := r.createCachePVC(instance); err != nil {
+		log.Error(err, "")
+		cond := meta.FindStatusCondition(instance.Status.Conditions, cicdv1.IntegrationConfigConditionReady)
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "CannotCreateCachePVC"
+		cond.Message = err.Error()
+		return ctrl.Result{}, nil
+	}
+
+	// Artifact storage - PVC and its file server used by the jobs uploading artifacts
+	if err := r.createArtifactStorage(instance); err != nil {
+		log.Error(err, "")
+		cond := meta.FindStatusCondition(instance.Status.Conditions, cicdv1.IntegrationConfigConditionReady)
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "CannotCreateArtifactStorage"
+		cond.Message = err.Error()
+		return ctrl.Result{}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -440,6 +469,119 @@ func (r *IntegrationConfigReconciler) createServiceAccount(instance *cicdv1.Inte
 	return r.Client.Create(context.Background(), sa)
 }
 
+// Create cache PVC, if any of the jobs uses cache
+// The caches are evicted by the garbage collector
+func (r *IntegrationConfigReconciler) createCachePVC(instance *cicdv1.IntegrationConfig) error {
+	if !instance.Spec.Jobs.HasCache() {
+		return nil
+	}
+	return r.createPVC(instance, cicdv1.GetCachePVCName(instance.Name), configs.CacheStorageSize, configs.CacheStorageClass, corev1.PersistentVolumeAccessMode(configs.CacheAccessMode))
+}
+
+// Create artifact PVC and its file server, if any of the jobs uploads artifacts to the PVC
+// The artifacts are cleaned up by the garbage collector
+func (r *IntegrationConfigReconciler) createArtifactStorage(instance *cicdv1.IntegrationConfig) error {
+	if !instance.Spec.Jobs.HasArtifacts() || configs.ArtifactStorage != configs.ArtifactStoragePVC {
+		return nil
+	}
+	// ReadWriteMany, as the PVC is mounted by the jobs and the file server at the same time
+	if err := r.createPVC(instance, cicdv1.GetArtifactPVCName(instance.Name), configs.ArtifactStorageSize, configs.ArtifactStorageClass, corev1.ReadWriteMany); err != nil {
+		return err
+	}
+	return r.createArtifactServer(instance)
+}
+
+// createArtifactServer creates a file server Deployment/Service owned by the IntegrationConfig, if they do not exist.
+// The server mounts the artifact PVC read-only, and the report server reaches it through the API server's service proxy
+func (r *IntegrationConfigReconciler) createArtifactServer(instance *cicdv1.IntegrationConfig) error {
+	name := cicdv1.GetArtifactServerName(instance.Name)
+	labels := map[string]string{cicdv1.ArtifactServerLabelConfig: instance.Name}
+
+	deploy := &appsv1.Deployment{}
+	if err := r.Client.Get(context.Background(), types.NamespacedName{Name: name, Namespace: instance.Namespace}, deploy); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		replicas := int32(1)
+		deploy = &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: instance.Namespace, Labels: labels},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Selector: &metav1.LabelSelector{MatchLabels: labels},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: labels},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{
+							Name:         "httpd",
+							Image:        configs.ArtifactServerImage,
+							Command:      []string{"httpd", "-f", "-p", strconv.Itoa(artifacts.ServerPort), "-h", artifacts.ServerMountPath},
+							Ports:        []corev1.ContainerPort{{Name: "http", ContainerPort: artifacts.ServerPort}},
+							VolumeMounts: []corev1.VolumeMount{{Name: "artifacts", MountPath: artifacts.ServerMountPath, ReadOnly: true}},
+						}},
+						Volumes: []corev1.Volume{{
+							Name: "artifacts",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: cicdv1.GetArtifactPVCName(instance.Name), ReadOnly: true},
+							},
+						}},
+					},
+				},
+			},
+		}
+		if err := utils.CreateOrPatchObject(deploy, nil, instance, r.Client, r.Scheme); err != nil {
+			return err
+		}
+	}
+
+	svc := &corev1.Service{}
+	if err := r.Client.Get(context.Background(), types.NamespacedName{Name: name, Namespace: instance.Namespace}, svc); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		svc = &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: instance.Namespace, Labels: labels},
+			Spec: corev1.ServiceSpec{
+				Selector: labels,
+				Ports:    []corev1.ServicePort{{Name: "http", Port: artifacts.ServerPort, TargetPort: intstr.FromInt(artifacts.ServerPort)}},
+			},
+		}
+		return utils.CreateOrPatchObject(svc, nil, instance, r.Client, r.Scheme)
+	}
+	return nil
+}
+
+// createPVC creates a PVC owned by the IntegrationConfig, if it does not exist
+func (r *IntegrationConfigReconciler) createPVC(instance *cicdv1.IntegrationConfig, name, storageSize, storageClass string, accessMode corev1.PersistentVolumeAccessMode) error {
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := r.Client.Get(context.Background(), types.NamespacedName{Name: name, Namespace: instance.Namespace}, pvc); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+	} else {
+		// PVC's spec is immutable
+		return nil
+	}
+
+	size, err := resource.ParseQuantity(storageSize)
+	if err != nil {
+		return err
+	}
+
+	pvc.Name = name
+	pvc.Namespace = instance.Namespace
+	pvc.Spec = corev1.PersistentVolumeClaimSpec{
+		AccessModes: []corev1.PersistentVolumeAccessMode{accessMode},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceStorage: size},
+		},
+	}
+	if storageClass != "" {
+		pvc.Spec.StorageClassName = &storageClass
+	}
+
+	return utils.CreateOrPatchObject(pvc, nil, instance, r.Client, r.Scheme)
+}
+
 func upgradeV050Condition(cond *metav1.Condition, trueMsg, falseMsg string) {
 	var msg string
 	switch cond.Status {
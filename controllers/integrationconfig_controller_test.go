@@ -26,8 +26,11 @@ import (
 	"github.com/tmax-cloud/cicd-operator/internal/test"
 	"github.com/tmax-cloud/cicd-operator/pkg/git"
 	gitfake "github.com/tmax-cloud/cicd-operator/pkg/git/fake"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -1192,6 +1195,187 @@ func TestIntegrationConfigReconciler_createServiceAccount(t *testing.T) {
 	}
 }
 
+func TestIntegrationConfigReconciler_createCachePVC(t *testing.T) {
+	s := runtime.NewScheme()
+	utilruntime.Must(corev1.AddToScheme(s))
+	utilruntime.Must(cicdv1.AddToScheme(s))
+
+	cacheJobs := cicdv1.IntegrationConfigJobs{
+		PreSubmit: cicdv1.Jobs{
+			{Container: corev1.Container{Name: "test"}, Cache: &cicdv1.JobCache{Key: "go-mod", Paths: []string{".cache"}}},
+		},
+	}
+
+	tc := map[string]struct {
+		ic           *cicdv1.IntegrationConfig
+		pvc          *corev1.PersistentVolumeClaim
+		storageSize  string
+		storageClass string
+
+		errorOccurs  bool
+		errorMessage string
+		verifyFunc   func(t *testing.T, reconciler *IntegrationConfigReconciler)
+	}{
+		"noCache": {
+			ic: &cicdv1.IntegrationConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-ic", Namespace: "test-ns"},
+			},
+			storageSize: "10Gi",
+			verifyFunc: func(t *testing.T, reconciler *IntegrationConfigReconciler) {
+				pvc := &corev1.PersistentVolumeClaim{}
+				err := reconciler.Client.Get(context.Background(), types.NamespacedName{Name: "test-ic-cache", Namespace: "test-ns"}, pvc)
+				require.Error(t, err)
+				require.True(t, errors.IsNotFound(err))
+			},
+		},
+		"create": {
+			ic: &cicdv1.IntegrationConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-ic", Namespace: "test-ns"},
+				Spec:       cicdv1.IntegrationConfigSpec{Jobs: cacheJobs},
+			},
+			storageSize:  "10Gi",
+			storageClass: "nfs",
+			verifyFunc: func(t *testing.T, reconciler *IntegrationConfigReconciler) {
+				pvc := &corev1.PersistentVolumeClaim{}
+				require.NoError(t, reconciler.Client.Get(context.Background(), types.NamespacedName{Name: "test-ic-cache", Namespace: "test-ns"}, pvc))
+				require.Equal(t, resource.MustParse("10Gi"), pvc.Spec.Resources.Requests[corev1.ResourceStorage])
+				require.Equal(t, "nfs", *pvc.Spec.StorageClassName)
+				require.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}, pvc.Spec.AccessModes)
+				require.Len(t, pvc.OwnerReferences, 1)
+			},
+		},
+		"exist": {
+			ic: &cicdv1.IntegrationConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-ic", Namespace: "test-ns"},
+				Spec:       cicdv1.IntegrationConfigSpec{Jobs: cacheJobs},
+			},
+			pvc: &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-ic-cache", Namespace: "test-ns"},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")}},
+				},
+			},
+			storageSize: "10Gi",
+			verifyFunc: func(t *testing.T, reconciler *IntegrationConfigReconciler) {
+				pvc := &corev1.PersistentVolumeClaim{}
+				require.NoError(t, reconciler.Client.Get(context.Background(), types.NamespacedName{Name: "test-ic-cache", Namespace: "test-ns"}, pvc))
+				require.Equal(t, resource.MustParse("1Gi"), pvc.Spec.Resources.Requests[corev1.ResourceStorage])
+			},
+		},
+		"sizeErr": {
+			ic: &cicdv1.IntegrationConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-ic", Namespace: "test-ns"},
+				Spec:       cicdv1.IntegrationConfigSpec{Jobs: cacheJobs},
+			},
+			storageSize:  "10GGi",
+			errorOccurs:  true,
+			errorMessage: "unable to parse quantity's suffix",
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			configs.CacheStorageSize = c.storageSize
+			configs.CacheStorageClass = c.storageClass
+			configs.CacheAccessMode = string(corev1.ReadWriteMany)
+			reconciler := &IntegrationConfigReconciler{Scheme: s, Client: fake.NewClientBuilder().WithScheme(s).WithObjects(c.ic).Build()}
+			if c.pvc != nil {
+				require.NoError(t, reconciler.Client.Create(context.Background(), c.pvc))
+			}
+
+			err := reconciler.createCachePVC(c.ic)
+			if c.errorOccurs {
+				require.Error(t, err)
+				require.Equal(t, c.errorMessage, err.Error())
+			} else {
+				require.NoError(t, err)
+				c.verifyFunc(t, reconciler)
+			}
+		})
+	}
+}
+
+func TestIntegrationConfigReconciler_createArtifactStorage(t *testing.T) {
+	s := runtime.NewScheme()
+	utilruntime.Must(corev1.AddToScheme(s))
+	utilruntime.Must(appsv1.AddToScheme(s))
+	utilruntime.Must(cicdv1.AddToScheme(s))
+
+	configs.ArtifactStorageSize = "1Gi"
+	configs.ArtifactServerImage = "busybox"
+
+	ic := &cicdv1.IntegrationConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-ic", Namespace: "test-ns"},
+		Spec: cicdv1.IntegrationConfigSpec{Jobs: cicdv1.IntegrationConfigJobs{
+			PostSubmit: cicdv1.Jobs{
+				{Container: corev1.Container{Name: "test"}, Artifacts: []string{"bin/*"}},
+			},
+		}},
+	}
+
+	tc := map[string]struct {
+		ic      *cicdv1.IntegrationConfig
+		storage string
+
+		expectedCreated bool
+	}{
+		"noArtifacts": {
+			ic:      &cicdv1.IntegrationConfig{ObjectMeta: metav1.ObjectMeta{Name: "test-ic", Namespace: "test-ns"}},
+			storage: configs.ArtifactStoragePVC,
+		},
+		"s3": {
+			ic:      ic,
+			storage: configs.ArtifactStorageS3,
+		},
+		"pvc": {
+			ic:              ic,
+			storage:         configs.ArtifactStoragePVC,
+			expectedCreated: true,
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			configs.ArtifactStorage = c.storage
+			defer func() { configs.ArtifactStorage = "" }()
+
+			reconciler := &IntegrationConfigReconciler{Scheme: s, Client: fake.NewClientBuilder().WithScheme(s).WithObjects(c.ic.DeepCopy()).Build()}
+			require.NoError(t, reconciler.createArtifactStorage(c.ic))
+			// Idempotent
+			require.NoError(t, reconciler.createArtifactStorage(c.ic))
+
+			pvc := &corev1.PersistentVolumeClaim{}
+			deploy := &appsv1.Deployment{}
+			svc := &corev1.Service{}
+			pvcErr := reconciler.Client.Get(context.Background(), types.NamespacedName{Name: "test-ic-artifacts", Namespace: "test-ns"}, pvc)
+			deployErr := reconciler.Client.Get(context.Background(), types.NamespacedName{Name: "test-ic-artifact-server", Namespace: "test-ns"}, deploy)
+			svcErr := reconciler.Client.Get(context.Background(), types.NamespacedName{Name: "test-ic-artifact-server", Namespace: "test-ns"}, svc)
+			if !c.expectedCreated {
+				require.True(t, errors.IsNotFound(pvcErr))
+				require.True(t, errors.IsNotFound(deployErr))
+				require.True(t, errors.IsNotFound(svcErr))
+				return
+			}
+
+			require.NoError(t, pvcErr)
+			require.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}, pvc.Spec.AccessModes)
+
+			require.NoError(t, deployErr)
+			require.Len(t, deploy.OwnerReferences, 1)
+			podSpec := deploy.Spec.Template.Spec
+			require.Equal(t, "busybox", podSpec.Containers[0].Image)
+			require.Equal(t, []string{"httpd", "-f", "-p", "8080", "-h", "/artifacts"}, podSpec.Containers[0].Command)
+			require.True(t, podSpec.Containers[0].VolumeMounts[0].ReadOnly)
+			require.Equal(t, "test-ic-artifacts", podSpec.Volumes[0].PersistentVolumeClaim.ClaimName)
+			require.True(t, podSpec.Volumes[0].PersistentVolumeClaim.ReadOnly)
+
+			require.NoError(t, svcErr)
+			require.Equal(t, map[string]string{cicdv1.ArtifactServerLabelConfig: "test-ic"}, svc.Spec.Selector)
+			require.Equal(t, int32(8080), svc.Spec.Ports[0].Port)
+		})
+	}
+}
+
 func Test_upgradeV050Condition(t *testing.T) {
 	t.Run("bumpReady", func(t *testing.T) {
 		cond := &metav1.Condition{
@@ -40,6 +40,23 @@ const (
 // Common functions should be defined, if needed
 type APIHandler interface{}
 
+// RequestError is an error with a http status code, which is returned by the logics shared by the api handlers and
+// the other entrypoints (e.g., slack app)
+type RequestError struct {
+	Code    int
+	Message string
+}
+
+// NewRequestError is a constructor of RequestError
+func NewRequestError(code int, format string, a ...interface{}) *RequestError {
+	return &RequestError{Code: code, Message: fmt.Sprintf(format, a...)}
+}
+
+// Error returns the error message
+func (e *RequestError) Error() string {
+	return e.Message
+}
+
 // GetUserName extracts user name from the header
 func GetUserName(header http.Header) (string, error) {
 	for k, v := range header {
@@ -99,24 +99,30 @@ func (a *authorizer) reviewAccess(req *http.Request) error {
 	resourceName := subPaths[7]
 	subResource := subPaths[8]
 
+	return ReviewAccess(a.AuthCli, userName, userGroups, userExtras, &authorizationv1.ResourceAttributes{
+		Name:        resourceName,
+		Namespace:   ns,
+		Group:       a.APIGroup,
+		Version:     a.APIVersion,
+		Resource:    resourceType,
+		Subresource: subResource,
+		Verb:        a.Verb,
+	})
+}
+
+// ReviewAccess checks if the user is allowed to access the resource, using SubjectAccessReview.
+// It's exported for the callers which are not behind the aggregated api server (e.g., slack app)
+func ReviewAccess(cli authorization.AuthorizationV1Interface, user string, groups []string, extras map[string]authorizationv1.ExtraValue, attr *authorizationv1.ResourceAttributes) error {
 	r := &authorizationv1.SubjectAccessReview{
 		Spec: authorizationv1.SubjectAccessReviewSpec{
-			User:   userName,
-			Groups: userGroups,
-			Extra:  userExtras,
-			ResourceAttributes: &authorizationv1.ResourceAttributes{
-				Name:        resourceName,
-				Namespace:   ns,
-				Group:       a.APIGroup,
-				Version:     a.APIVersion,
-				Resource:    resourceType,
-				Subresource: subResource,
-				Verb:        a.Verb,
-			},
+			User:               user,
+			Groups:             groups,
+			Extra:              extras,
+			ResourceAttributes: attr,
 		},
 	}
 
-	result, err := a.AuthCli.SubjectAccessReviews().Create(context.Background(), r, metav1.CreateOptions{})
+	result, err := cli.SubjectAccessReviews().Create(context.Background(), r, metav1.CreateOptions{})
 	if err != nil {
 		return err
 	}
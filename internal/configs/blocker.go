@@ -28,10 +28,16 @@ const (
 // ApplyBlockerConfigChange is a configmap handler for blocker-config configmap
 func ApplyBlockerConfigChange(cm *corev1.ConfigMap) error {
 	getVars(cm.Data, map[string]operatorConfig{
-		"mergeSyncPeriod":      {Type: cfgTypeInt, IntVal: &MergeSyncPeriod, IntDefault: 1},                               // Merge automation sync period
-		"mergeBlockLabel":      {Type: cfgTypeString, StringVal: &MergeBlockLabel, StringDefault: "ci/hold"},              // Merge automation block label
-		"mergeKindSquashLabel": {Type: cfgTypeString, StringVal: &MergeKindSquashLabel, StringDefault: "ci/merge-squash"}, // Merge kind squash label
-		"mergeKindMergeLabel":  {Type: cfgTypeString, StringVal: &MergeKindMergeLabel, StringDefault: "ci/merge-merge"},   // Merge kind squash label
+		"mergeSyncPeriod":        {Type: cfgTypeInt, IntVal: &MergeSyncPeriod, IntDefault: 1},                                     // Merge automation sync period
+		"mergeResyncPeriod":      {Type: cfgTypeInt, IntVal: &MergeResyncPeriod, IntDefault: 30},                                  // Merge automation full resync period
+		"mergeBlockLabel":        {Type: cfgTypeString, StringVal: &MergeBlockLabel, StringDefault: "ci/hold"},                    // Merge automation block label
+		"mergeKindSquashLabel":   {Type: cfgTypeString, StringVal: &MergeKindSquashLabel, StringDefault: "ci/merge-squash"},       // Merge kind squash label
+		"mergeKindMergeLabel":    {Type: cfgTypeString, StringVal: &MergeKindMergeLabel, StringDefault: "ci/merge-merge"},         // Merge kind squash label
+		"mergePriorityHighLabel": {Type: cfgTypeString, StringVal: &MergePriorityHighLabel, StringDefault: "merge/priority-high"}, // Merge priority high label
+		"mergePriorityLowLabel":  {Type: cfgTypeString, StringVal: &MergePriorityLowLabel, StringDefault: "merge/priority-low"},   // Merge priority low label
+		"mergeFreeze":            {Type: cfgTypeBool, BoolVal: &MergeFreeze, BoolDefault: false},                                  // Cluster-wide merge freeze
+		"mergeFreezeReason":      {Type: cfgTypeString, StringVal: &MergeFreezeReason, StringDefault: ""},                         // Reason of the cluster-wide merge freeze
+		"mergeFreezeUntil":       {Type: cfgTypeString, StringVal: &MergeFreezeUntil, StringDefault: ""},                          // End of the cluster-wide merge freeze
 	})
 
 	// Init
@@ -47,9 +53,12 @@ func ApplyBlockerConfigChange(cm *corev1.ConfigMap) error {
 
 // Merge Automation Configs
 var (
-	// MergeSyncPeriod is a PR sync period in minute
+	// MergeSyncPeriod is a period for checking batches and merging PRs in minute
 	MergeSyncPeriod int
 
+	// MergeResyncPeriod is a period of the full PR sync in minute. PRs are synced by git events in between
+	MergeResyncPeriod int
+
 	// MergeBlockLabel is a label name which blocks a PR to be merged
 	MergeBlockLabel string
 
@@ -58,4 +67,19 @@ var (
 
 	// MergeKindMergeLabel is a label to make a PR to be merged by 'merge'
 	MergeKindMergeLabel string
+
+	// MergePriorityHighLabel is a label to make a PR to be merged before the others
+	MergePriorityHighLabel string
+
+	// MergePriorityLowLabel is a label to make a PR to be merged after the others
+	MergePriorityLowLabel string
+
+	// MergeFreeze freezes merges of all IntegrationConfigs
+	MergeFreeze bool
+
+	// MergeFreezeReason is a reason of the cluster-wide merge freeze
+	MergeFreezeReason string
+
+	// MergeFreezeUntil is when the cluster-wide merge freeze ends, in RFC3339 format. The freeze lasts until it's disabled if it's empty
+	MergeFreezeUntil string
 )
@@ -31,32 +31,56 @@ func TestApplyBlockerConfigChange(t *testing.T) {
 			require.NoError(t, err)
 
 			require.Equal(t, 1, MergeSyncPeriod)
+			require.Equal(t, 30, MergeResyncPeriod)
 			require.Equal(t, "ci/hold", MergeBlockLabel)
 			require.Equal(t, "ci/merge-squash", MergeKindSquashLabel)
 			require.Equal(t, "ci/merge-merge", MergeKindMergeLabel)
+			require.Equal(t, "merge/priority-high", MergePriorityHighLabel)
+			require.Equal(t, "merge/priority-low", MergePriorityLowLabel)
+			require.False(t, MergeFreeze)
+			require.Equal(t, "", MergeFreezeReason)
+			require.Equal(t, "", MergeFreezeUntil)
 		}},
 		"normal": {ConfigMap: &corev1.ConfigMap{
 			Data: map[string]string{
-				"mergeSyncPeriod":      "1",
-				"mergeBlockLabel":      "test-block",
-				"mergeKindSquashLabel": "test-squash",
-				"mergeKindMergeLabel":  "test-merge",
+				"mergeSyncPeriod":        "1",
+				"mergeResyncPeriod":      "10",
+				"mergeBlockLabel":        "test-block",
+				"mergeKindSquashLabel":   "test-squash",
+				"mergeKindMergeLabel":    "test-merge",
+				"mergePriorityHighLabel": "test-high",
+				"mergePriorityLowLabel":  "test-low",
+				"mergeFreeze":            "true",
+				"mergeFreezeReason":      "incident",
+				"mergeFreezeUntil":       "2021-12-24T18:00:00+09:00",
 			},
 		}, AssertFunc: func(t *testing.T, err error) {
 			require.NoError(t, err)
 
 			require.Equal(t, 1, MergeSyncPeriod)
+			require.Equal(t, 10, MergeResyncPeriod)
 			require.Equal(t, "test-block", MergeBlockLabel)
 			require.Equal(t, "test-squash", MergeKindSquashLabel)
 			require.Equal(t, "test-merge", MergeKindMergeLabel)
+			require.Equal(t, "test-high", MergePriorityHighLabel)
+			require.Equal(t, "test-low", MergePriorityLowLabel)
+			require.True(t, MergeFreeze)
+			require.Equal(t, "incident", MergeFreezeReason)
+			require.Equal(t, "2021-12-24T18:00:00+09:00", MergeFreezeUntil)
 		}},
 	}
 
 	for name, c := range tc {
 		MergeSyncPeriod = 0
+		MergeResyncPeriod = 0
 		MergeBlockLabel = ""
 		MergeKindSquashLabel = ""
 		MergeKindMergeLabel = ""
+		MergePriorityHighLabel = ""
+		MergePriorityLowLabel = ""
+		MergeFreeze = false
+		MergeFreezeReason = ""
+		MergeFreezeUntil = ""
 		t.Run(name, func(t *testing.T) {
 			err := ApplyBlockerConfigChange(c.ConfigMap)
 			c.AssertFunc(t, err)
@@ -53,13 +53,68 @@ func ApplyControllerConfigChange(cm *corev1.ConfigMap) error {
 		"gitImage":                  {Type: cfgTypeString, StringVal: &GitImage, StringDefault: "docker.io/alpine/git:1.0.30"}, // Git image
 		"gitCheckoutStepCPURequest": {Type: cfgTypeString, StringVal: &GitCheckoutStepCPURequest, StringDefault: "30m"},        // Git checkout step CPU request
 		"gitCheckoutStepMemRequest": {Type: cfgTypeString, StringVal: &GitCheckoutStepMemRequest, StringDefault: "100Mi"},      // Git checkout step Memory request
+		"helperImage":               {Type: cfgTypeString, StringVal: &HelperImage, StringDefault: defaultHelperImage},         // Helper image
+		"cacheImage":                {Type: cfgTypeString, StringVal: &CacheImage, StringDefault: "docker.io/alpine:3.14"},     // Cache image
+		"cacheStorageClass":         {Type: cfgTypeString, StringVal: &CacheStorageClass},                                      // Cache PVC's storage class
+		"cacheStorageSize":          {Type: cfgTypeString, StringVal: &CacheStorageSize, StringDefault: "10Gi"},                // Cache PVC's size
+		"cacheAccessMode":           {Type: cfgTypeString, StringVal: &CacheAccessMode, StringDefault: "ReadWriteMany"},        // Cache PVC's access mode
+		"cacheTTL":                  {Type: cfgTypeInt, IntVal: &CacheTTL, IntDefault: 168},                                    // Cache eviction threshold
+		"cacheMaxSize":              {Type: cfgTypeInt, IntVal: &CacheMaxSize, IntDefault: 8192},                               // Cache eviction size threshold
+		"artifactStorage":           {Type: cfgTypeString, StringVal: &ArtifactStorage},                                        // Artifact storage type (pvc/s3)
+		"artifactImage":             {Type: cfgTypeString, StringVal: &ArtifactImage, StringDefault: "curlimages/curl:7.80.0"}, // Artifact upload image
+		"artifactStorageClass":      {Type: cfgTypeString, StringVal: &ArtifactStorageClass},                                   // Artifact PVC's storage class
+		"artifactStorageSize":       {Type: cfgTypeString, StringVal: &ArtifactStorageSize, StringDefault: "10Gi"},             // Artifact PVC's size
+		"artifactServerImage":       {Type: cfgTypeString, StringVal: &ArtifactServerImage, StringDefault: "busybox:1.34"},     // Artifact PVC's file server image
+		"artifactS3Endpoint":        {Type: cfgTypeString, StringVal: &ArtifactS3Endpoint},                                     // Artifact S3 endpoint
+		"artifactS3Region":          {Type: cfgTypeString, StringVal: &ArtifactS3Region, StringDefault: "us-east-1"},           // Artifact S3 region
+		"artifactS3Bucket":          {Type: cfgTypeString, StringVal: &ArtifactS3Bucket},                                       // Artifact S3 bucket
+		"artifactS3Secret":          {Type: cfgTypeString, StringVal: &ArtifactS3Secret},                                       // Artifact S3 credential
+		"enableSlackApp":            {Type: cfgTypeBool, BoolVal: &EnableSlackApp, BoolDefault: false},                         // Enable Slack app
+		"slackAppSecret":            {Type: cfgTypeString, StringVal: &SlackAppSecret},                                         // Slack app credential
+		"slackApprovalChannel":      {Type: cfgTypeString, StringVal: &SlackApprovalChannel},                                   // Slack channel for approval requests
+		"slackUserConfigMap":        {Type: cfgTypeString, StringVal: &SlackUserConfigMap},                                     // Slack user - k8s user mapping
+		"enableApprovalUI":          {Type: cfgTypeBool, BoolVal: &EnableApprovalUI, BoolDefault: false},                       // Enable approval web UI
+		"approvalUIUserHeader":      {Type: cfgTypeString, StringVal: &ApprovalUIUserHeader},                                   // Approval UI's user header from auth. proxy
+		"approvalUIGroupHeader":     {Type: cfgTypeString, StringVal: &ApprovalUIGroupHeader},                                  // Approval UI's group header from auth. proxy
+		"approvalUIProxySecret":     {Type: cfgTypeString, StringVal: &ApprovalUIProxySecret},                                  // Approval UI's auth. proxy token
+		"approvalLinkSecret":        {Type: cfgTypeString, StringVal: &ApprovalLinkSecret},                                     // Approval link signing key
+		"approvalLinkTTL":           {Type: cfgTypeInt, IntVal: &ApprovalLinkTTL, IntDefault: 24},                              // Approval link expiration
+		"gitUserConfigMap":          {Type: cfgTypeString, StringVal: &GitUserConfigMap},                                       // Git user - k8s user mapping
 	})
 
+	// Check artifact storage config.s
+	if ArtifactStorage != "" && ArtifactStorage != ArtifactStoragePVC && ArtifactStorage != ArtifactStorageS3 {
+		return fmt.Errorf("artifact storage should be empty, %s or %s", ArtifactStoragePVC, ArtifactStorageS3)
+	}
+	if ArtifactStorage == ArtifactStorageS3 && (ArtifactS3Endpoint == "" || ArtifactS3Bucket == "" || ArtifactS3Secret == "") {
+		return fmt.Errorf("s3 artifact storage is enabled but s3 endpoint/bucket/secret is not given")
+	}
+
+	// Check cache config.s
+	if CacheAccessMode != string(corev1.ReadWriteMany) && CacheAccessMode != string(corev1.ReadWriteOnce) {
+		return fmt.Errorf("cache access mode should be one of %s, %s", corev1.ReadWriteMany, corev1.ReadWriteOnce)
+	}
+
 	// Check SMTP config.s
 	if EnableMail && (SMTPHost == "" || SMTPUserSecret == "") {
 		return fmt.Errorf("email is enaled but smtp access info. is not given")
 	}
 
+	// Check Slack app config.s
+	if EnableSlackApp && (SlackAppSecret == "" || SlackUserConfigMap == "") {
+		return fmt.Errorf("slack app is enabled but slack app secret/user configmap is not given")
+	}
+
+	// Check approval UI config.s
+	if ApprovalUIUserHeader != "" && ApprovalUIProxySecret == "" {
+		return fmt.Errorf("approval ui user header is given but approval ui proxy secret is not given")
+	}
+
+	// Check approval link config.s
+	if ApprovalLinkSecret != "" && ApprovalLinkTTL < 1 {
+		return fmt.Errorf("approval link is enabled but approval link ttl is not positive")
+	}
+
 	// Init
 	if !ControllerInitiated {
 		ControllerInitiated = true
@@ -126,4 +181,100 @@ var (
 
 	// GitCheckoutStepMemRequest is a memory request of a git checkout step
 	GitCheckoutStepMemRequest string
+
+	// HelperImage is an image url for the helper steps (e.g., parsing the test reports)
+	HelperImage string
+
+	// CacheImage is an image url for the cache restore/save steps and the cache eviction pods
+	CacheImage string
+
+	// CacheStorageClass is a storage class of the cache PVC (default storage class is used if it's empty)
+	CacheStorageClass string
+
+	// CacheStorageSize is a requested size of the cache PVC
+	CacheStorageSize string
+
+	// CacheAccessMode is an access mode of the cache PVC (ReadWriteMany/ReadWriteOnce)
+	CacheAccessMode string
+
+	// CacheTTL is a cache eviction threshold (in hour).
+	// If a cache is not used for TTL, it's evicted by the garbage collector
+	CacheTTL int
+
+	// CacheMaxSize is a cache eviction size threshold (in MiB).
+	// If the total size of the caches exceeds it, the least recently used caches are evicted
+	CacheMaxSize int
+
+	// ArtifactStorage is a type of the artifact storage (pvc/s3). Artifacts are not uploaded if it's empty
+	ArtifactStorage string
+
+	// ArtifactImage is an image url for the artifact upload steps
+	ArtifactImage string
+
+	// ArtifactStorageClass is a storage class of the artifact PVC (default storage class is used if it's empty)
+	ArtifactStorageClass string
+
+	// ArtifactStorageSize is a requested size of the artifact PVC
+	ArtifactStorageSize string
+
+	// ArtifactServerImage is an image url for the file servers serving the artifact PVCs to the report server.
+	// It should contain busybox httpd
+	ArtifactServerImage string
+
+	// ArtifactS3Endpoint is an endpoint url of the S3-compatible artifact storage
+	ArtifactS3Endpoint string
+
+	// ArtifactS3Region is a region of the S3 artifact storage
+	ArtifactS3Region string
+
+	// ArtifactS3Bucket is a bucket of the S3 artifact storage
+	ArtifactS3Bucket string
+
+	// ArtifactS3Secret is a secret name containing accessKey, secretKey for the S3 artifact storage
+	ArtifactS3Secret string
+
+	// EnableSlackApp is whether to enable the interactive slack app (approval buttons, slash commands) or not
+	EnableSlackApp bool
+
+	// SlackAppSecret is a secret name containing botToken, signingSecret of the slack app
+	SlackAppSecret string
+
+	// SlackApprovalChannel is a slack channel ID where the approval requests are posted. Not posted if it's empty
+	SlackApprovalChannel string
+
+	// SlackUserConfigMap is a configmap name mapping slack user IDs (keys) to kubernetes user names (values)
+	SlackUserConfigMap string
+
+	// EnableApprovalUI is whether to enable the approval web UI or not
+	EnableApprovalUI bool
+
+	// ApprovalUIUserHeader is a header containing the user name, set by an authenticating (e.g., OIDC) proxy in front of
+	// the approval web UI. Users are authenticated only with kubernetes tokens if it's empty
+	ApprovalUIUserHeader string
+
+	// ApprovalUIGroupHeader is a header containing the user's groups, set by the authenticating proxy
+	ApprovalUIGroupHeader string
+
+	// ApprovalUIProxySecret is a secret name containing token, which the authenticating proxy sends in the
+	// X-Approval-UI-Proxy-Token header. The user/group headers are trusted only for the requests with the token
+	ApprovalUIProxySecret string
+
+	// ApprovalLinkSecret is a secret name containing signingKey, used for signing the approve/reject links in the
+	// approval request mails. Links are not embedded if it's empty
+	ApprovalLinkSecret string
+
+	// ApprovalLinkTTL is a valid duration (in hour) of the approve/reject links
+	ApprovalLinkTTL int
+
+	// GitUserConfigMap is a configmap name mapping git user names or emails (keys) to kubernetes user names (values)
+	GitUserConfigMap string
+)
+
+// defaultHelperImage is built from build/helper/Dockerfile
+const defaultHelperImage = "docker.io/tmaxcloudck/cicd-helper:latest"
+
+// Artifact storage types
+const (
+	ArtifactStoragePVC = "pvc"
+	ArtifactStorageS3  = "s3"
 )
@@ -89,6 +89,32 @@ func TestApplyControllerConfigChange(t *testing.T) {
 			require.Equal(t, "", SMTPHost)
 			require.Equal(t, "", SMTPUserSecret)
 		}},
+		"artifactS3Error": {ConfigMap: &corev1.ConfigMap{
+			Data: map[string]string{
+				"artifactStorage":    "s3",
+				"artifactS3Endpoint": "https://minio.test",
+			},
+		}, AssertFunc: func(t *testing.T, err error) {
+			require.Error(t, err)
+			require.Equal(t, "s3 artifact storage is enabled but s3 endpoint/bucket/secret is not given", err.Error())
+			require.Equal(t, "us-east-1", ArtifactS3Region)
+		}},
+		"artifactStorageError": {ConfigMap: &corev1.ConfigMap{
+			Data: map[string]string{
+				"artifactStorage": "nfs",
+			},
+		}, AssertFunc: func(t *testing.T, err error) {
+			require.Error(t, err)
+			require.Equal(t, "artifact storage should be empty, pvc or s3", err.Error())
+		}},
+		"cacheAccessModeError": {ConfigMap: &corev1.ConfigMap{
+			Data: map[string]string{
+				"cacheAccessMode": "ReadOnlyMany",
+			},
+		}, AssertFunc: func(t *testing.T, err error) {
+			require.Error(t, err)
+			require.Equal(t, "cache access mode should be one of ReadWriteMany, ReadWriteOnce", err.Error())
+		}},
 	}
 
 	for name, c := range tc {
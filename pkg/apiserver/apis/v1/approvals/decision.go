@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
@@ -77,58 +78,148 @@ func (h *handler) updateDecision(w http.ResponseWriter, req *http.Request, decis
 		return
 	}
 
-	// Get corresponding Approval object
-	approval := &cicdv1.Approval{}
-	if err := h.k8sClient.Get(context.Background(), types.NamespacedName{Name: approvalName, Namespace: ns}, approval); err != nil {
+	// Groups are optional, only used for the group approvers
+	groups, _ := apiserver.GetUserGroups(req.Header)
+
+	if _, err := Decide(h.k8sClient, ns, approvalName, user, groups, decision, userReq.Reason); err != nil {
 		log.Info(err.Error())
-		_ = utils.RespondError(w, http.StatusBadRequest, fmt.Sprintf("req: %s, no Approval %s/%s is found", reqID, ns, approvalName))
+		code := http.StatusInternalServerError
+		if reqErr, ok := err.(*apiserver.RequestError); ok {
+			code = reqErr.Code
+		}
+		_ = utils.RespondError(w, code, fmt.Sprintf("req: %s, %s", reqID, err.Error()))
 		return
 	}
+
+	_ = utils.RespondJSON(w, struct{}{})
+}
+
+// Decide records the decision of the user (in the groups) on the Approval ns/name, and decides the Approval following its policy.
+// The caller should have checked if the user has the permission to update the approvals/approve (or reject) resource
+func Decide(cli client.Client, ns, name, user string, groups []string, decision cicdv1.ApprovalResult, reason string) (*cicdv1.Approval, error) {
+	// Get corresponding Approval object
+	approval := &cicdv1.Approval{}
+	if err := cli.Get(context.Background(), types.NamespacedName{Name: name, Namespace: ns}, approval); err != nil {
+		return nil, apiserver.NewRequestError(http.StatusBadRequest, "no Approval %s/%s is found", ns, name)
+	}
 	original := approval.DeepCopy()
 
 	// If Approval is already in approved/rejected status, respond with error
 	if approval.Status.Result == cicdv1.ApprovalResultApproved || approval.Status.Result == cicdv1.ApprovalResultRejected {
-		log.Info("approval is already decided")
-		_ = utils.RespondError(w, http.StatusBadRequest, fmt.Sprintf("req: %s, approval %s/%s is already in %s status", reqID, ns, approvalName, approval.Status.Result))
-		return
+		return nil, apiserver.NewRequestError(http.StatusBadRequest, "approval %s/%s is already in %s status", ns, name, approval.Status.Result)
 	}
 
 	// Check if the user is in the approver list
-	approvers := approval.Spec.Users
+	memberOf := approverGroups(approval, user, groups)
+	if !isApprover(approval, user) && len(memberOf) == 0 {
+		// Emit event
+		_ = events.Emit(cli, approval, corev1.EventTypeWarning, "ApproveNotAllowed", fmt.Sprintf("User: %s", user))
+		return nil, apiserver.NewRequestError(http.StatusForbidden, "approval %s/%s is not requested to you", ns, name)
+	}
 
-	isApprover := false
-	for _, a := range approvers {
-		if a.Name == user {
-			isApprover = true
-			break
-		}
+	// Check separation of duties
+	policy := approval.Spec.Policy
+	if policy != nil && policy.SeparationOfDuties && isAuthorOrSender(approval, user) {
+		_ = events.Emit(cli, approval, corev1.EventTypeWarning, "ApproveNotAllowed", fmt.Sprintf("User: %s, author or sender cannot decide", user))
+		return nil, apiserver.NewRequestError(http.StatusForbidden, "approval %s/%s cannot be decided by its author or sender", ns, name)
 	}
 
-	if !isApprover {
-		log.Info(fmt.Sprintf("requested user (%s) is not an approver", user))
+	// Each approver can decide only once
+	for _, d := range approval.Status.Decisions {
+		if d.User == user {
+			return nil, apiserver.NewRequestError(http.StatusBadRequest, "approval %s/%s is already %s by you", ns, name, strings.ToLower(string(d.Result)))
+		}
+	}
 
-		// Emit event
-		_ = events.Emit(h.k8sClient, approval, corev1.EventTypeWarning, "ApproveNotAllowed", fmt.Sprintf("User: %s", user))
+	// Record the decision
+	now := metav1.Time{Time: time.Now()}
+	approval.Status.Decisions = append(approval.Status.Decisions, cicdv1.ApprovalDecision{
+		User:   user,
+		Result: decision,
+		Reason: reason,
+		Groups: memberOf,
+		Time:   now,
+	})
+
+	// Any rejection rejects the Approval, and it's approved only if enough approvers approved it.
+	// If the policy has groups, only the approvals from the members of the groups are counted
+	var approvers []string
+	for _, d := range approval.Status.Decisions {
+		if d.Result != cicdv1.ApprovalResultApproved {
+			continue
+		}
+		if policy != nil && len(policy.Groups) > 0 && len(d.Groups) == 0 {
+			continue
+		}
+		approvers = append(approvers, d.User)
+	}
+	if decision == cicdv1.ApprovalResultRejected || len(approvers) >= policy.GetMinApprovals() {
+		approval.Status.Result = decision
+		approval.Status.Reason = reason
+		approval.Status.DecisionTime = &now
+		approval.Status.Approver = user
+		if decision == cicdv1.ApprovalResultApproved {
+			approval.Status.Approver = strings.Join(approvers, ",")
+		}
+	}
 
-		_ = utils.RespondError(w, http.StatusForbidden, fmt.Sprintf("req: %s, approval %s/%s is not requested to you", reqID, ns, approvalName))
-		return
+	if err := cli.Status().Patch(context.Background(), approval, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})); err != nil {
+		return nil, err
 	}
 
-	defer func() {
-		p := client.MergeFrom(original)
-		if err := h.k8sClient.Status().Patch(context.Background(), approval, p); err != nil {
-			log.Error(err, "")
+	// Emit event
+	_ = events.Emit(cli, approval, corev1.EventTypeNormal, string(decision), fmt.Sprintf("User: %s, Reason: %s", user, reason))
+
+	return approval, nil
+}
+
+// isApprover checks if the user is one of the approvers (including the escalation approvers)
+func isApprover(approval *cicdv1.Approval, user string) bool {
+	for _, a := range approval.Approvers() {
+		if a.Name == user {
+			return true
 		}
-	}()
+	}
+	return false
+}
+
+// approverGroups returns the policy's groups the user is a member of.
+// Groups resolved by the approvers ConfigMap are checked with their users, and the others with the user's kubernetes groups
+func approverGroups(approval *cicdv1.Approval, user string, groups []string) []string {
+	if approval.Spec.Policy == nil {
+		return nil
+	}
 
-	// Update status
-	approval.Status.Result = decision
-	approval.Status.Reason = userReq.Reason
-	approval.Status.Approver = user
-	approval.Status.DecisionTime = &metav1.Time{Time: time.Now()}
+	var memberOf []string
+	for _, g := range approval.Spec.Policy.Groups {
+		if members, resolved := approval.Spec.GroupUsers[g]; resolved {
+			if containsString(members, user) {
+				memberOf = append(memberOf, g)
+			}
+		} else if containsString(groups, g) {
+			memberOf = append(memberOf, g)
+		}
+	}
+	return memberOf
+}
 
-	// Emit event
-	_ = events.Emit(h.k8sClient, approval, corev1.EventTypeNormal, string(decision), fmt.Sprintf("User: %s, Reason: %s", user, userReq.Reason))
+// isAuthorOrSender checks if the user is one of the authors or the sender of the Approval.
+// The user is compared with the kubernetes users mapped from them, and also with their git user names
+func isAuthorOrSender(approval *cicdv1.Approval, user string) bool {
+	if containsString(approval.Spec.ExcludedUsers, user) {
+		return true
+	}
+	if containsString(approval.Spec.Authors, user) {
+		return true
+	}
+	return user == approval.Spec.Author || (approval.Spec.Sender != nil && user == approval.Spec.Sender.Name)
+}
 
-	_ = utils.RespondJSON(w, struct{}{})
+func containsString(arr []string, val string) bool {
+	for _, a := range arr {
+		if a == val {
+			return true
+		}
+	}
+	return false
 }
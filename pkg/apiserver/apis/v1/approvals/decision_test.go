@@ -306,3 +306,181 @@ func Test_handler_updateDecision(t *testing.T) {
 		})
 	}
 }
+
+func TestDecide(t *testing.T) {
+	s := runtime.NewScheme()
+	require.NoError(t, cicdv1.AddToScheme(s))
+
+	type decision struct {
+		user   string
+		groups []string
+		result cicdv1.ApprovalResult
+	}
+
+	tc := map[string]struct {
+		policy        *cicdv1.ApprovalPolicy
+		excludedUsers []string
+		groupUsers    map[string][]string
+		decisions     []decision
+
+		errorOccurs      bool
+		errorMessage     string
+		expectedResult   cicdv1.ApprovalResult
+		expectedApprover string
+		expectedCount    int
+	}{
+		"noPolicy": {
+			decisions:        []decision{{user: "user-1", result: cicdv1.ApprovalResultApproved}},
+			expectedResult:   cicdv1.ApprovalResultApproved,
+			expectedApprover: "user-1",
+			expectedCount:    1,
+		},
+		"quorumNotMet": {
+			policy:         &cicdv1.ApprovalPolicy{MinApprovals: 2},
+			decisions:      []decision{{user: "user-1", result: cicdv1.ApprovalResultApproved}},
+			expectedResult: cicdv1.ApprovalResultAwaiting,
+			expectedCount:  1,
+		},
+		"quorumMet": {
+			policy: &cicdv1.ApprovalPolicy{MinApprovals: 2},
+			decisions: []decision{
+				{user: "user-1", result: cicdv1.ApprovalResultApproved},
+				{user: "user-2", result: cicdv1.ApprovalResultApproved},
+			},
+			expectedResult:   cicdv1.ApprovalResultApproved,
+			expectedApprover: "user-1,user-2",
+			expectedCount:    2,
+		},
+		"anyRejection": {
+			policy: &cicdv1.ApprovalPolicy{MinApprovals: 2},
+			decisions: []decision{
+				{user: "user-1", result: cicdv1.ApprovalResultApproved},
+				{user: "user-2", result: cicdv1.ApprovalResultRejected},
+			},
+			expectedResult:   cicdv1.ApprovalResultRejected,
+			expectedApprover: "user-2",
+			expectedCount:    2,
+		},
+		"sameUserTwice": {
+			policy: &cicdv1.ApprovalPolicy{MinApprovals: 2},
+			decisions: []decision{
+				{user: "user-1", result: cicdv1.ApprovalResultApproved},
+				{user: "user-1", result: cicdv1.ApprovalResultApproved},
+			},
+			errorOccurs:    true,
+			errorMessage:   "approval test-ns/test-approval is already approved by you",
+			expectedResult: cicdv1.ApprovalResultAwaiting,
+			expectedCount:  1,
+		},
+		"groupMember": {
+			policy:           &cicdv1.ApprovalPolicy{Groups: []string{"release-managers"}},
+			decisions:        []decision{{user: "manager", groups: []string{"system:authenticated", "release-managers"}, result: cicdv1.ApprovalResultApproved}},
+			expectedResult:   cicdv1.ApprovalResultApproved,
+			expectedApprover: "manager",
+			expectedCount:    1,
+		},
+		"notGroupMember": {
+			policy:         &cicdv1.ApprovalPolicy{Groups: []string{"release-managers"}},
+			decisions:      []decision{{user: "developer", groups: []string{"developers"}, result: cicdv1.ApprovalResultApproved}},
+			errorOccurs:    true,
+			errorMessage:   "approval test-ns/test-approval is not requested to you",
+			expectedResult: cicdv1.ApprovalResultAwaiting,
+		},
+		"groupQuorumNotMet": {
+			policy: &cicdv1.ApprovalPolicy{MinApprovals: 2, Groups: []string{"release-managers"}},
+			decisions: []decision{
+				{user: "user-1", result: cicdv1.ApprovalResultApproved},
+				{user: "manager", groups: []string{"release-managers"}, result: cicdv1.ApprovalResultApproved},
+			},
+			expectedResult: cicdv1.ApprovalResultAwaiting,
+			expectedCount:  2,
+		},
+		"groupQuorumMet": {
+			policy:     &cicdv1.ApprovalPolicy{MinApprovals: 2, Groups: []string{"release-managers", "qa"}},
+			groupUsers: map[string][]string{"qa": {"tester"}},
+			decisions: []decision{
+				{user: "user-1", result: cicdv1.ApprovalResultApproved},
+				{user: "manager", groups: []string{"release-managers"}, result: cicdv1.ApprovalResultApproved},
+				{user: "tester", groups: []string{"release-managers-not"}, result: cicdv1.ApprovalResultApproved},
+			},
+			expectedResult:   cicdv1.ApprovalResultApproved,
+			expectedApprover: "manager,tester",
+			expectedCount:    3,
+		},
+		"resolvedGroupNotMember": {
+			policy:         &cicdv1.ApprovalPolicy{Groups: []string{"qa"}},
+			groupUsers:     map[string][]string{"qa": {"tester"}},
+			decisions:      []decision{{user: "developer", groups: []string{"qa"}, result: cicdv1.ApprovalResultApproved}},
+			errorOccurs:    true,
+			errorMessage:   "approval test-ns/test-approval is not requested to you",
+			expectedResult: cicdv1.ApprovalResultAwaiting,
+		},
+		"mappedAuthor": {
+			policy:         &cicdv1.ApprovalPolicy{SeparationOfDuties: true},
+			excludedUsers:  []string{"user-1"},
+			decisions:      []decision{{user: "user-1", result: cicdv1.ApprovalResultApproved}},
+			errorOccurs:    true,
+			errorMessage:   "approval test-ns/test-approval cannot be decided by its author or sender",
+			expectedResult: cicdv1.ApprovalResultAwaiting,
+		},
+		"author": {
+			policy:         &cicdv1.ApprovalPolicy{SeparationOfDuties: true},
+			decisions:      []decision{{user: "author", result: cicdv1.ApprovalResultApproved}},
+			errorOccurs:    true,
+			errorMessage:   "approval test-ns/test-approval cannot be decided by its author or sender",
+			expectedResult: cicdv1.ApprovalResultAwaiting,
+		},
+		"batchAuthor": {
+			policy:         &cicdv1.ApprovalPolicy{SeparationOfDuties: true},
+			decisions:      []decision{{user: "user-2", result: cicdv1.ApprovalResultApproved}},
+			errorOccurs:    true,
+			errorMessage:   "approval test-ns/test-approval cannot be decided by its author or sender",
+			expectedResult: cicdv1.ApprovalResultAwaiting,
+		},
+		"sender": {
+			policy:         &cicdv1.ApprovalPolicy{SeparationOfDuties: true},
+			decisions:      []decision{{user: "sender", result: cicdv1.ApprovalResultApproved}},
+			errorOccurs:    true,
+			errorMessage:   "approval test-ns/test-approval cannot be decided by its author or sender",
+			expectedResult: cicdv1.ApprovalResultAwaiting,
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			approval := &cicdv1.Approval{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-approval", Namespace: "test-ns"},
+				Spec: cicdv1.ApprovalSpec{
+					Users: []cicdv1.ApprovalUser{
+						{Name: "user-1"}, {Name: "user-2"}, {Name: "author"}, {Name: "sender"},
+					},
+					Author:        "author",
+					Authors:       []string{"author", "user-2"},
+					Sender:        &cicdv1.ApprovalUser{Name: "sender"},
+					Policy:        c.policy,
+					ExcludedUsers: c.excludedUsers,
+					GroupUsers:    c.groupUsers,
+				},
+				Status: cicdv1.ApprovalStatus{Result: cicdv1.ApprovalResultAwaiting},
+			}
+			fakeCli := fake.NewClientBuilder().WithScheme(s).WithObjects(approval).Build()
+
+			var err error
+			for _, d := range c.decisions {
+				_, err = Decide(fakeCli, "test-ns", "test-approval", d.user, d.groups, d.result, "test-reason")
+			}
+			if c.errorOccurs {
+				require.Error(t, err)
+				require.Equal(t, c.errorMessage, err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+
+			result := &cicdv1.Approval{}
+			require.NoError(t, fakeCli.Get(context.Background(), types.NamespacedName{Name: "test-approval", Namespace: "test-ns"}, result))
+			require.Equal(t, c.expectedResult, result.Status.Result)
+			require.Equal(t, c.expectedApprover, result.Status.Approver)
+			require.Len(t, result.Status.Decisions, c.expectedCount)
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
 	"github.com/tmax-cloud/cicd-operator/internal/apiserver"
 	"github.com/tmax-cloud/cicd-operator/internal/wrapper"
+	"github.com/tmax-cloud/cicd-operator/pkg/blocker"
 	authorization "k8s.io/client-go/kubernetes/typed/authorization/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -40,11 +41,12 @@ type handler struct {
 	log       logr.Logger
 
 	authorizer apiserver.Authorizer
+	forwarder  eventForwarder
 }
 
 // NewHandler instantiates a new integration configs api handler
 func NewHandler(parent wrapper.RouterWrapper, cli client.Client, authCli authorization.AuthorizationV1Interface, logger logr.Logger) (apiserver.APIHandler, error) {
-	handler := &handler{k8sClient: cli, log: logger}
+	handler := &handler{k8sClient: cli, log: logger, forwarder: blocker.NewEventForwarder(cli)}
 
 	// Authorizer
 	handler.authorizer = apiserver.NewAuthorizer(authCli, apiserver.APIGroup, APIVersion, "create")
@@ -74,5 +76,11 @@ func NewHandler(parent wrapper.RouterWrapper, cli client.Client, authCli authori
 		return nil, err
 	}
 
+	// /integrationconfigs/<integrationconfig>/queue
+	queueWrapper := wrapper.New("/"+cicdv1.IntegrationConfigAPIQueue, []string{http.MethodGet, http.MethodPost}, handler.queueHandler)
+	if err := icWrapper.Add(queueWrapper); err != nil {
+		return nil, err
+	}
+
 	return handler, nil
 }
@@ -0,0 +1,170 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package integrationconfigs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/apiserver"
+	"github.com/tmax-cloud/cicd-operator/internal/utils"
+	"github.com/tmax-cloud/cicd-operator/pkg/blocker"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// +kubebuilder:rbac:groups=cicd.tmax.io,resources=mergequeues,verbs=get;list;watch;update;patch
+
+// eventForwarder forwards the queue changes to the blockers
+type eventForwarder interface {
+	Forward(ev blocker.Event) error
+}
+
+func (h *handler) queueHandler(w http.ResponseWriter, req *http.Request) {
+	if req.Method == http.MethodPost {
+		h.queueUpdateHandler(w, req)
+		return
+	}
+	h.queueListHandler(w, req)
+}
+
+func (h *handler) queueListHandler(w http.ResponseWriter, req *http.Request) {
+	reqID := utils.RandomString(10)
+	log := h.log.WithValues("request", reqID)
+
+	ns, resName, err := getQueueParams(req)
+	if err != nil {
+		log.Info(err.Error())
+		_ = utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Get MergeQueue
+	mq := &cicdv1.MergeQueue{}
+	if err := h.k8sClient.Get(context.Background(), types.NamespacedName{Name: resName, Namespace: ns}, mq); err != nil {
+		if errors.IsNotFound(err) {
+			_ = utils.RespondJSON(w, cicdv1.MergeQueueStatus{})
+			return
+		}
+		log.Info(err.Error())
+		_ = utils.RespondError(w, http.StatusInternalServerError, fmt.Sprintf("req: %s, cannot get MergeQueue %s/%s", reqID, ns, resName))
+		return
+	}
+
+	_ = utils.RespondJSON(w, mq.Status)
+}
+
+func (h *handler) queueUpdateHandler(w http.ResponseWriter, req *http.Request) {
+	reqID := utils.RandomString(10)
+	log := h.log.WithValues("request", reqID)
+
+	ns, resName, err := getQueueParams(req)
+	if err != nil {
+		log.Info(err.Error())
+		_ = utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userReq := &cicdv1.MergeQueueAPIReqBody{}
+	if err := json.NewDecoder(req.Body).Decode(userReq); err != nil {
+		log.Info(err.Error())
+		_ = utils.RespondError(w, http.StatusBadRequest, fmt.Sprintf("req: %s, body is not in json form or is malformed, err : %s", reqID, err.Error()))
+		return
+	}
+
+	// Get MergeQueue
+	mq := &cicdv1.MergeQueue{}
+	if err := h.k8sClient.Get(context.Background(), types.NamespacedName{Name: resName, Namespace: ns}, mq); err != nil {
+		log.Info(err.Error())
+		code := http.StatusInternalServerError
+		if errors.IsNotFound(err) {
+			code = http.StatusNotFound
+		}
+		_ = utils.RespondError(w, code, fmt.Sprintf("req: %s, cannot get MergeQueue %s/%s", reqID, ns, resName))
+		return
+	}
+
+	if err := updateQueueSpec(mq, userReq); err != nil {
+		log.Info(err.Error())
+		_ = utils.RespondError(w, http.StatusBadRequest, fmt.Sprintf("req: %s, %s", reqID, err.Error()))
+		return
+	}
+
+	if err := h.k8sClient.Update(context.Background(), mq); err != nil {
+		log.Info(err.Error())
+		_ = utils.RespondError(w, http.StatusInternalServerError, fmt.Sprintf("req: %s, cannot update MergeQueue %s/%s", reqID, ns, resName))
+		return
+	}
+
+	// Let the blocker reflect the change right away. Otherwise, it's reflected on the next sync
+	if err := h.forwarder.Forward(blocker.Event{Namespace: ns, Name: resName, PullRequest: userReq.PullRequest}); err != nil {
+		log.Info(err.Error())
+	}
+}
+
+func getQueueParams(req *http.Request) (string, string, error) {
+	vars := mux.Vars(req)
+
+	ns, nsExist := vars[apiserver.NamespaceParamKey]
+	resName, nameExist := vars[icParamKey]
+	if !nsExist || !nameExist {
+		return "", "", fmt.Errorf("url is malformed")
+	}
+	return ns, resName, nil
+}
+
+// updateQueueSpec applies the requested action to the MergeQueue's spec
+func updateQueueSpec(mq *cicdv1.MergeQueue, userReq *cicdv1.MergeQueueAPIReqBody) error {
+	var queued *cicdv1.MergeQueuePullRequest
+	for i := range mq.Status.PullRequests {
+		if mq.Status.PullRequests[i].ID == userReq.PullRequest {
+			queued = &mq.Status.PullRequests[i]
+		}
+	}
+	if queued == nil {
+		return fmt.Errorf("pull request #%d is not in the merge queue", userReq.PullRequest)
+	}
+
+	// Exclude the PR from the front list
+	var front []int
+	for _, id := range mq.Spec.Front {
+		if id != queued.ID {
+			front = append(front, id)
+		}
+	}
+
+	switch userReq.Action {
+	case cicdv1.MergeQueueActionMove:
+		mq.Spec.Front = append([]int{queued.ID}, front...)
+	case cicdv1.MergeQueueActionRemove:
+		mq.Spec.Front = front
+		var removed []cicdv1.MergeQueueRemovedPullRequest
+		for _, pr := range mq.Spec.Removed {
+			if pr.ID != queued.ID {
+				removed = append(removed, pr)
+			}
+		}
+		mq.Spec.Removed = append(removed, cicdv1.MergeQueueRemovedPullRequest{ID: queued.ID, Sha: queued.Sha})
+	default:
+		return fmt.Errorf("action %s is not supported", userReq.Action)
+	}
+	return nil
+}
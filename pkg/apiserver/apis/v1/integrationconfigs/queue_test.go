@@ -0,0 +1,181 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package integrationconfigs
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/test"
+	"github.com/tmax-cloud/cicd-operator/pkg/blocker"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type fakeForwarder struct {
+	events []blocker.Event
+}
+
+func (f *fakeForwarder) Forward(ev blocker.Event) error {
+	f.events = append(f.events, ev)
+	return nil
+}
+
+func Test_handler_queueHandler(t *testing.T) {
+	testMQ := func() *cicdv1.MergeQueue {
+		return &cicdv1.MergeQueue{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-ic", Namespace: "test-ns"},
+			Spec: cicdv1.MergeQueueSpec{
+				Front:   []int{23},
+				Removed: []cicdv1.MergeQueueRemovedPullRequest{{ID: 37, Sha: "old-sha"}},
+			},
+			Status: cicdv1.MergeQueueStatus{
+				PullRequests: []cicdv1.MergeQueuePullRequest{
+					{ID: 23, Sha: "sha-23", Position: 1},
+					{ID: 12, Sha: "sha-12", Position: 2},
+				},
+			},
+		}
+	}
+	vars := map[string]string{"namespace": "test-ns", "icName": "test-ic"}
+
+	tc := map[string]struct {
+		mq     *cicdv1.MergeQueue
+		vars   map[string]string
+		method string
+		body   string
+
+		expectedCode    int
+		expectedMessage string
+		expectedSpec    *cicdv1.MergeQueueSpec
+		expectedEvents  int
+	}{
+		"list": {
+			mq:              testMQ(),
+			vars:            vars,
+			method:          http.MethodGet,
+			expectedCode:    200,
+			expectedMessage: "\"id\":23,\"sha\":\"sha-23\",\"position\":1",
+		},
+		"listNoQueue": {
+			vars:            vars,
+			method:          http.MethodGet,
+			expectedCode:    200,
+			expectedMessage: "{}",
+		},
+		"noVars": {
+			method:          http.MethodGet,
+			expectedCode:    400,
+			expectedMessage: "url is malformed",
+		},
+		"move": {
+			mq:           testMQ(),
+			vars:         vars,
+			method:       http.MethodPost,
+			body:         `{"action":"move","pullRequest":12}`,
+			expectedCode: 200,
+			expectedSpec: &cicdv1.MergeQueueSpec{
+				Front:   []int{12, 23},
+				Removed: []cicdv1.MergeQueueRemovedPullRequest{{ID: 37, Sha: "old-sha"}},
+			},
+			expectedEvents: 1,
+		},
+		"remove": {
+			mq:           testMQ(),
+			vars:         vars,
+			method:       http.MethodPost,
+			body:         `{"action":"remove","pullRequest":23}`,
+			expectedCode: 200,
+			expectedSpec: &cicdv1.MergeQueueSpec{
+				Removed: []cicdv1.MergeQueueRemovedPullRequest{{ID: 37, Sha: "old-sha"}, {ID: 23, Sha: "sha-23"}},
+			},
+			expectedEvents: 1,
+		},
+		"notInQueue": {
+			mq:              testMQ(),
+			vars:            vars,
+			method:          http.MethodPost,
+			body:            `{"action":"move","pullRequest":37}`,
+			expectedCode:    400,
+			expectedMessage: "pull request #37 is not in the merge queue",
+		},
+		"unknownAction": {
+			mq:              testMQ(),
+			vars:            vars,
+			method:          http.MethodPost,
+			body:            `{"action":"merge","pullRequest":12}`,
+			expectedCode:    400,
+			expectedMessage: "action merge is not supported",
+		},
+		"malformedBody": {
+			mq:              testMQ(),
+			vars:            vars,
+			method:          http.MethodPost,
+			body:            `{"action":`,
+			expectedCode:    400,
+			expectedMessage: "body is not in json form or is malformed",
+		},
+		"updateNoQueue": {
+			vars:            vars,
+			method:          http.MethodPost,
+			body:            `{"action":"move","pullRequest":12}`,
+			expectedCode:    404,
+			expectedMessage: "cannot get MergeQueue test-ns/test-ic",
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			s := runtime.NewScheme()
+			require.NoError(t, cicdv1.AddToScheme(s))
+
+			fakeCli := fake.NewClientBuilder().WithScheme(s).Build()
+			if c.mq != nil {
+				require.NoError(t, fakeCli.Create(context.Background(), c.mq))
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(c.method, "/", bytes.NewBufferString(c.body))
+			req = mux.SetURLVars(req, c.vars)
+
+			forwarder := &fakeForwarder{}
+			handler := &handler{log: &test.FakeLogger{}, k8sClient: fakeCli, forwarder: forwarder}
+			handler.queueHandler(w, req)
+
+			require.Equal(t, c.expectedCode, w.Result().StatusCode)
+			b, err := ioutil.ReadAll(w.Result().Body)
+			require.NoError(t, err)
+			require.Contains(t, string(b), c.expectedMessage)
+			require.Len(t, forwarder.events, c.expectedEvents)
+
+			if c.expectedSpec != nil {
+				mq := &cicdv1.MergeQueue{}
+				require.NoError(t, fakeCli.Get(context.Background(), types.NamespacedName{Name: "test-ic", Namespace: "test-ns"}, mq))
+				require.Equal(t, *c.expectedSpec, mq.Spec)
+			}
+		})
+	}
+}
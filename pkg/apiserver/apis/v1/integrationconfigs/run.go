@@ -31,6 +31,7 @@ import (
 	"github.com/tmax-cloud/cicd-operator/pkg/git"
 	"github.com/tmax-cloud/cicd-operator/pkg/server"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
@@ -67,21 +68,64 @@ func (h *handler) runHandler(w http.ResponseWriter, req *http.Request, et git.Ev
 		_ = utils.RespondError(w, http.StatusUnauthorized, fmt.Sprintf("req: %s, forbidden user, err : %s", reqID, err.Error()))
 		return
 	}
+
+	switch et {
+	case git.EventTypePullRequest:
+		userReqPre := &cicdv1.IntegrationConfigAPIReqRunPreBody{}
+		decoder := json.NewDecoder(req.Body)
+		if err := decoder.Decode(userReqPre); err != nil {
+			log.Info(err.Error())
+			_ = utils.RespondError(w, http.StatusBadRequest, fmt.Sprintf("req: %s, cannot build pull_request webhook", reqID))
+			return
+		}
+		err = RunPre(h.k8sClient, ns, resName, user, userReqPre)
+	case git.EventTypePush:
+		userReqPost := &cicdv1.IntegrationConfigAPIReqRunPostBody{}
+		decoder := json.NewDecoder(req.Body)
+		if err := decoder.Decode(userReqPost); err != nil {
+			log.Info(err.Error())
+			_ = utils.RespondError(w, http.StatusBadRequest, fmt.Sprintf("req: %s, cannot build pull_request webhook", reqID))
+			return
+		}
+		err = RunPost(h.k8sClient, ns, resName, user, userReqPost)
+	}
+	if err != nil {
+		log.Info(err.Error())
+		code := http.StatusInternalServerError
+		if reqErr, ok := err.(*apiserver.RequestError); ok {
+			code = reqErr.Code
+		}
+		_ = utils.RespondError(w, code, fmt.Sprintf("req: %s, %s", reqID, err.Error()))
+		return
+	}
+
+	_ = utils.RespondJSON(w, struct{}{})
+}
+
+// RunPre triggers pre-submit jobs of the IntegrationConfig ns/name, as the user.
+// The caller should have checked if the user has the permission to create the integrationconfigs/runpre resource
+func RunPre(cli client.Client, ns, name, user string, userReq *cicdv1.IntegrationConfigAPIReqRunPreBody) error {
+	return run(cli, ns, name, user, git.EventTypePullRequest, userReq, nil)
+}
+
+// RunPost triggers post-submit jobs of the IntegrationConfig ns/name, as the user.
+// The caller should have checked if the user has the permission to create the integrationconfigs/runpost resource
+func RunPost(cli client.Client, ns, name, user string, userReq *cicdv1.IntegrationConfigAPIReqRunPostBody) error {
+	return run(cli, ns, name, user, git.EventTypePush, nil, userReq)
+}
+
+func run(cli client.Client, ns, name, user string, et git.EventType, userReqPre *cicdv1.IntegrationConfigAPIReqRunPreBody, userReqPost *cicdv1.IntegrationConfigAPIReqRunPostBody) error {
 	userEscaped := regexp.MustCompile("[^-A-Za-z0-9_.]").ReplaceAllString(user, "_")
 
 	// Get IntegrationConfig
 	ic := &cicdv1.IntegrationConfig{}
-	if err := h.k8sClient.Get(context.Background(), types.NamespacedName{Name: resName, Namespace: ns}, ic); err != nil {
-		log.Info(err.Error())
-		_ = utils.RespondError(w, http.StatusInternalServerError, fmt.Sprintf("req: %s, cannot get IntegrationConfig %s/%s", reqID, ns, resName))
-		return
+	if err := cli.Get(context.Background(), types.NamespacedName{Name: name, Namespace: ns}, ic); err != nil {
+		return apiserver.NewRequestError(http.StatusInternalServerError, "cannot get IntegrationConfig %s/%s", ns, name)
 	}
 
 	gitHost, err := ic.Spec.Git.GetGitHost()
 	if err != nil {
-		log.Info(err.Error())
-		_ = utils.RespondError(w, http.StatusInternalServerError, fmt.Sprintf("req: %s, cannot get IntegrationConfig %s/%s's git host", reqID, ns, resName))
-		return
+		return apiserver.NewRequestError(http.StatusInternalServerError, "cannot get IntegrationConfig %s/%s's git host", ns, name)
 	}
 
 	// Build webhook
@@ -91,73 +135,45 @@ func (h *handler) runHandler(w http.ResponseWriter, req *http.Request, et git.Ev
 			Name: ic.Spec.Git.Repository,
 			URL:  fmt.Sprintf("%s/%s", gitHost, ic.Spec.Git.Repository),
 		},
+		Sender: git.User{
+			Name: fmt.Sprintf("trigger-%s-end", userEscaped),
+		},
 	}
 
-	userReqPre := &cicdv1.IntegrationConfigAPIReqRunPreBody{}
-	userReqPost := &cicdv1.IntegrationConfigAPIReqRunPostBody{}
-
+	// Build webhook and update IntegrationConfig based on the request
 	switch et {
 	case git.EventTypePullRequest:
-		decoder := json.NewDecoder(req.Body)
-		if err := decoder.Decode(userReqPre); err != nil {
-			log.Info(err.Error())
-			_ = utils.RespondError(w, http.StatusBadRequest, fmt.Sprintf("req: %s, cannot build pull_request webhook", reqID))
-			return
-		}
 		pr, err := buildPullRequestWebhook(userReqPre, userEscaped)
 		if err != nil {
-			log.Info(err.Error())
-			_ = utils.RespondError(w, http.StatusBadRequest, fmt.Sprintf("req: %s, cannot build pull_request webhook", reqID))
-			return
+			return apiserver.NewRequestError(http.StatusBadRequest, "cannot build pull_request webhook")
 		}
 		wh.PullRequest = pr
-	case git.EventTypePush:
-		decoder := json.NewDecoder(req.Body)
-		if err := decoder.Decode(userReqPost); err != nil {
-			log.Info(err.Error())
-			_ = utils.RespondError(w, http.StatusBadRequest, fmt.Sprintf("req: %s, cannot build pull_request webhook", reqID))
-			return
-		}
-		push, err := buildPushWebhook(userReqPost)
-		if err != nil {
-			log.Info(err.Error())
-			_ = utils.RespondError(w, http.StatusBadRequest, fmt.Sprintf("req: %s, cannot build push webhook", reqID))
-			return
-		}
-		wh.Push = push
-	}
-	wh.Sender = git.User{
-		Name: fmt.Sprintf("trigger-%s-end", userEscaped),
-	}
 
-	// Update IntegrationConfig based on the request
-	switch et {
-	case git.EventTypePullRequest:
 		updatedIC, err := updateIntegrationConfigPre(ic, userReqPre, et)
 		if err != nil {
-			log.Info(err.Error())
-			_ = utils.RespondError(w, http.StatusBadRequest, "cannot update pull_request integrationconfig. please check jobName is valid")
-			return
+			return apiserver.NewRequestError(http.StatusBadRequest, "cannot update pull_request integrationconfig. please check jobName is valid")
 		}
 		ic = updatedIC
 	case git.EventTypePush:
+		push, err := buildPushWebhook(userReqPost)
+		if err != nil {
+			return apiserver.NewRequestError(http.StatusBadRequest, "cannot build push webhook")
+		}
+		wh.Push = push
+
 		updatedIC, err := updateIntegrationConfigPost(ic, userReqPost, et)
 		if err != nil {
-			log.Info(err.Error())
-			_ = utils.RespondError(w, http.StatusBadRequest, "cannot update push integrationconfig. please check jobName is valid")
-			return
+			return apiserver.NewRequestError(http.StatusBadRequest, "cannot update push integrationconfig. please check jobName is valid")
 		}
 		ic = updatedIC
 	}
 
 	// Trigger Run!
 	if err := server.HandleEvent(wh, ic, "dispatcher"); err != nil {
-		log.Info(err.Error())
-		_ = utils.RespondError(w, http.StatusInternalServerError, fmt.Sprintf("req: %s, cannot handle event, err : %s", reqID, err.Error()))
-		return
+		return apiserver.NewRequestError(http.StatusInternalServerError, "cannot handle event, err : %s", err.Error())
 	}
 
-	_ = utils.RespondJSON(w, struct{}{})
+	return nil
 }
 
 func updateIntegrationConfigPost(ic *cicdv1.IntegrationConfig, userReqBody *cicdv1.IntegrationConfigAPIReqRunPostBody, et git.EventType) (*cicdv1.IntegrationConfig, error) {
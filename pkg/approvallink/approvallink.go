@@ -0,0 +1,155 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+// Package approvallink generates and verifies the approve/reject links embedded in the approval request mails.
+// A link is bound to the Approval's UID and the recipient's email, expires after approvalLinkTTL hours, and is signed
+// with HMAC-SHA256 using the signing key in the approvalLinkSecret secret.
+package approvallink
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/configs"
+	"github.com/tmax-cloud/cicd-operator/internal/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Path is a path of the approval links
+const Path = "/approvals/link"
+
+// SecretKeySigningKey is a key of the signing key in the approval link secret
+const SecretKeySigningKey = "signingKey"
+
+const (
+	queryKeyNamespace = "namespace"
+	queryKeyName      = "name"
+	queryKeyUID       = "uid"
+	queryKeyEmail     = "email"
+	queryKeyDecision  = "decision"
+	queryKeyExpires   = "expires"
+	queryKeySignature = "signature"
+)
+
+// Link is an approve/reject link for an Approval, sent to a recipient
+type Link struct {
+	Namespace string
+	Name      string
+	UID       types.UID
+	Email     string
+	Decision  string
+	Expires   time.Time
+}
+
+// Enabled returns whether the approval links are enabled or not
+func Enabled() bool {
+	return configs.ApprovalLinkSecret != ""
+}
+
+// GetSigningKey gets the signing key from the approval link secret
+func GetSigningKey(cli client.Client) ([]byte, error) {
+	secret := &corev1.Secret{}
+	if err := cli.Get(context.Background(), types.NamespacedName{Name: configs.ApprovalLinkSecret, Namespace: utils.Namespace()}, secret); err != nil {
+		return nil, err
+	}
+
+	key, exist := secret.Data[SecretKeySigningKey]
+	if !exist || len(key) == 0 {
+		return nil, fmt.Errorf("secret %s should have key %s", configs.ApprovalLinkSecret, SecretKeySigningKey)
+	}
+	return key, nil
+}
+
+// New is a constructor of a Link. decision should be one of approve, reject
+func New(approval *cicdv1.Approval, email, decision string, now time.Time) *Link {
+	return &Link{
+		Namespace: approval.Namespace,
+		Name:      approval.Name,
+		UID:       approval.UID,
+		Email:     email,
+		Decision:  decision,
+		Expires:   now.Add(time.Duration(configs.ApprovalLinkTTL) * time.Hour),
+	}
+}
+
+// URL returns a signed url of the link
+func (l *Link) URL(key []byte) string {
+	query := url.Values{}
+	query.Set(queryKeyNamespace, l.Namespace)
+	query.Set(queryKeyName, l.Name)
+	query.Set(queryKeyUID, string(l.UID))
+	query.Set(queryKeyEmail, l.Email)
+	query.Set(queryKeyDecision, l.Decision)
+	query.Set(queryKeyExpires, strconv.FormatInt(l.Expires.Unix(), 10))
+	query.Set(queryKeySignature, l.sign(key))
+
+	return fmt.Sprintf("http://%s%s?%s", configs.CurrentExternalHostName, Path, query.Encode())
+}
+
+// Parse parses the query of the link url, and verifies its signature and expiration
+func Parse(key []byte, query url.Values, now time.Time) (*Link, error) {
+	sec, err := strconv.ParseInt(query.Get(queryKeyExpires), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("link is malformed")
+	}
+
+	l := &Link{
+		Namespace: query.Get(queryKeyNamespace),
+		Name:      query.Get(queryKeyName),
+		UID:       types.UID(query.Get(queryKeyUID)),
+		Email:     query.Get(queryKeyEmail),
+		Decision:  query.Get(queryKeyDecision),
+		Expires:   time.Unix(sec, 0),
+	}
+
+	if !hmac.Equal([]byte(query.Get(queryKeySignature)), []byte(l.sign(key))) {
+		return nil, fmt.Errorf("link signature is not valid")
+	}
+
+	if now.After(l.Expires) {
+		return nil, fmt.Errorf("link is expired")
+	}
+
+	if l.Decision != cicdv1.ApprovalAPIApprove && l.Decision != cicdv1.ApprovalAPIReject {
+		return nil, fmt.Errorf("decision %s is not valid", l.Decision)
+	}
+
+	return l, nil
+}
+
+// sign returns a hex-encoded HMAC-SHA256 signature of the link
+func (l *Link) sign(key []byte) string {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(strings.Join([]string{
+		l.Namespace,
+		l.Name,
+		string(l.UID),
+		l.Email,
+		l.Decision,
+		strconv.FormatInt(l.Expires.Unix(), 10),
+	}, "\n")))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,170 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package approvallink
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/configs"
+	"github.com/tmax-cloud/cicd-operator/internal/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetSigningKey(t *testing.T) {
+	configs.ApprovalLinkSecret = "approval-link"
+	defer func() {
+		configs.ApprovalLinkSecret = ""
+	}()
+
+	s := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(s))
+
+	cli := fake.NewClientBuilder().WithScheme(s).Build()
+	_, err := GetSigningKey(cli)
+	require.Error(t, err)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "approval-link", Namespace: utils.Namespace()},
+		Data:       map[string][]byte{"wrongKey": []byte("key")},
+	}
+	require.NoError(t, cli.Create(context.Background(), secret))
+	_, err = GetSigningKey(cli)
+	require.Error(t, err)
+	require.Equal(t, "secret approval-link should have key signingKey", err.Error())
+
+	secret.Data = map[string][]byte{SecretKeySigningKey: []byte("key")}
+	require.NoError(t, cli.Update(context.Background(), secret))
+	key, err := GetSigningKey(cli)
+	require.NoError(t, err)
+	require.Equal(t, []byte("key"), key)
+}
+
+func TestLink_URL(t *testing.T) {
+	configs.CurrentExternalHostName = "cicd-webhook.example.com"
+	configs.ApprovalLinkTTL = 24
+
+	now := time.Unix(1600000000, 0)
+	approval := &cicdv1.Approval{ObjectMeta: metav1.ObjectMeta{Name: "test-approval", Namespace: "test-ns", UID: "test-uid"}}
+	link := New(approval, "admin@tmax.co.kr", cicdv1.ApprovalAPIApprove, now)
+	require.Equal(t, now.Add(24*time.Hour), link.Expires)
+
+	u, err := url.Parse(link.URL([]byte("key")))
+	require.NoError(t, err)
+	require.Equal(t, "cicd-webhook.example.com", u.Host)
+	require.Equal(t, Path, u.Path)
+
+	parsed, err := Parse([]byte("key"), u.Query(), now)
+	require.NoError(t, err)
+	require.Equal(t, link, parsed)
+}
+
+func TestParse(t *testing.T) {
+	configs.ApprovalLinkTTL = 24
+
+	now := time.Unix(1600000000, 0)
+	approval := &cicdv1.Approval{ObjectMeta: metav1.ObjectMeta{Name: "test-approval", Namespace: "test-ns", UID: "test-uid"}}
+
+	tc := map[string]struct {
+		decision string
+		key      string
+		modify   func(query url.Values)
+		now      time.Time
+
+		expectedErrMsg string
+	}{
+		"normal": {
+			decision: cicdv1.ApprovalAPIReject,
+			key:      "key",
+			now:      now,
+		},
+		"wrongKey": {
+			decision:       cicdv1.ApprovalAPIApprove,
+			key:            "wrong-key",
+			now:            now,
+			expectedErrMsg: "link signature is not valid",
+		},
+		"modifiedEmail": {
+			decision: cicdv1.ApprovalAPIApprove,
+			key:      "key",
+			modify: func(query url.Values) {
+				query.Set(queryKeyEmail, "other@tmax.co.kr")
+			},
+			now:            now,
+			expectedErrMsg: "link signature is not valid",
+		},
+		"modifiedExpires": {
+			decision: cicdv1.ApprovalAPIApprove,
+			key:      "key",
+			modify: func(query url.Values) {
+				query.Set(queryKeyExpires, "1700000000")
+			},
+			now:            now,
+			expectedErrMsg: "link signature is not valid",
+		},
+		"malformedExpires": {
+			decision: cicdv1.ApprovalAPIApprove,
+			key:      "key",
+			modify: func(query url.Values) {
+				query.Del(queryKeyExpires)
+			},
+			now:            now,
+			expectedErrMsg: "link is malformed",
+		},
+		"expired": {
+			decision:       cicdv1.ApprovalAPIApprove,
+			key:            "key",
+			now:            now.Add(25 * time.Hour),
+			expectedErrMsg: "link is expired",
+		},
+		"wrongDecision": {
+			decision:       "hold",
+			key:            "key",
+			now:            now,
+			expectedErrMsg: "decision hold is not valid",
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			u, err := url.Parse(New(approval, "admin@tmax.co.kr", c.decision, now).URL([]byte("key")))
+			require.NoError(t, err)
+			query := u.Query()
+			if c.modify != nil {
+				c.modify(query)
+			}
+
+			link, err := Parse([]byte(c.key), query, c.now)
+			if c.expectedErrMsg != "" {
+				require.Error(t, err)
+				require.Equal(t, c.expectedErrMsg, err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, "test-uid", string(link.UID))
+				require.Equal(t, "admin@tmax.co.kr", link.Email)
+				require.Equal(t, c.decision, link.Decision)
+			}
+		})
+	}
+}
@@ -0,0 +1,275 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+// Package approvalui implements a web UI for the approvers, served next to the report server.
+// It lists the pending approvals the user can decide, shows their IntegrationJobs and lets the user approve/reject them.
+// Users are authenticated by an authenticating (e.g., OIDC) proxy's headers or kubernetes tokens, and the same
+// SubjectAccessReview as the api server is done for them.
+package approvalui
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-logr/logr"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/apiserver"
+	"github.com/tmax-cloud/cicd-operator/internal/configs"
+	"github.com/tmax-cloud/cicd-operator/internal/utils"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	authentication "k8s.io/client-go/kubernetes/typed/authentication/v1"
+	authorization "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Paths of the approval web UI
+const (
+	ListPath   = "/approvals"
+	DetailPath = "/approvals/{namespace}/{name}"
+	LoginPath  = "/approvals/login"
+	LogoutPath = "/approvals/logout"
+)
+
+const (
+	apiVersion = "v1"
+
+	paramKeyNamespace = "namespace"
+	paramKeyName      = "name"
+
+	formKeyToken    = "token"
+	formKeyDecision = "decision"
+	formKeyReason   = "reason"
+
+	tokenCookieName   = "cicd-approval-token"
+	tokenCookieMaxAge = 8 * 60 * 60
+
+	// ProxyTokenHeader is a header containing the authenticating proxy's token, proving the request is from the proxy
+	ProxyTokenHeader = "X-Approval-UI-Proxy-Token"
+	// SecretKeyProxyToken is a key of the proxy's token in the approval UI proxy secret
+	SecretKeyProxyToken = "token"
+)
+
+// UI is an approval web UI
+type UI struct {
+	k8sClient client.Client
+	authnCli  authentication.AuthenticationV1Interface
+	authCli   authorization.AuthorizationV1Interface
+	log       logr.Logger
+}
+
+// New is a constructor of UI
+func New(cli client.Client, authnCli authentication.AuthenticationV1Interface, authCli authorization.AuthorizationV1Interface) *UI {
+	return &UI{
+		k8sClient: cli,
+		authnCli:  authnCli,
+		authCli:   authCli,
+		log:       logf.Log.WithName("approval-ui"),
+	}
+}
+
+// ListHandler returns a handler listing the pending approvals
+func (u *UI) ListHandler() http.Handler {
+	return u.authenticated(u.handleList)
+}
+
+// DetailHandler returns a handler showing an approval
+func (u *UI) DetailHandler() http.Handler {
+	return u.authenticated(u.handleDetail)
+}
+
+// DecisionHandler returns a handler deciding an approval
+func (u *UI) DecisionHandler() http.Handler {
+	return u.authenticated(u.handleDecision)
+}
+
+// LoginHandler returns a handler logging in using a kubernetes token
+func (u *UI) LoginHandler() http.Handler {
+	return http.HandlerFunc(u.handleLogin)
+}
+
+// LogoutHandler returns a handler logging out
+func (u *UI) LogoutHandler() http.Handler {
+	return http.HandlerFunc(u.handleLogout)
+}
+
+// userInfo is an authenticated user
+type userInfo struct {
+	Name   string
+	Groups []string
+}
+
+type authenticatedHandlerFunc func(w http.ResponseWriter, req *http.Request, user *userInfo, log logr.Logger)
+
+// authenticated wraps the handler, so it's called only for the authenticated users.
+// Login page is shown for the unauthenticated users
+func (u *UI) authenticated(handler authenticatedHandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		log := u.log.WithValues("path", req.URL.Path)
+
+		if !configs.EnableApprovalUI {
+			http.NotFound(w, req)
+			return
+		}
+
+		if req.Method != http.MethodGet && !isSameOrigin(req) {
+			u.renderError(w, log, http.StatusForbidden, "cross-origin request is not allowed")
+			return
+		}
+
+		user, err := u.authenticate(req)
+		if err != nil {
+			log.Info(err.Error())
+			u.render(w, log, http.StatusUnauthorized, "login", &loginPage{Message: "Log in to decide approvals"})
+			return
+		}
+
+		handler(w, req, user, log.WithValues("user", user.Name))
+	})
+}
+
+// authenticate authenticates the user using the authenticating proxy's headers or the kubernetes token.
+// Proxy's headers are trusted only if the request has the proxy's token
+func (u *UI) authenticate(req *http.Request) (*userInfo, error) {
+	if configs.ApprovalUIUserHeader != "" {
+		if name := req.Header.Get(configs.ApprovalUIUserHeader); name != "" {
+			if err := u.verifyProxyToken(req); err != nil {
+				return nil, err
+			}
+			user := &userInfo{Name: name}
+			if configs.ApprovalUIGroupHeader != "" {
+				for _, v := range req.Header.Values(configs.ApprovalUIGroupHeader) {
+					for _, g := range strings.Split(v, ",") {
+						if g = strings.TrimSpace(g); g != "" {
+							user.Groups = append(user.Groups, g)
+						}
+					}
+				}
+			}
+			return user, nil
+		}
+	}
+
+	token := getToken(req)
+	if token == "" {
+		return nil, fmt.Errorf("no credential is given")
+	}
+	return u.reviewToken(token)
+}
+
+// verifyProxyToken checks if the request has the authenticating proxy's token, stored in the approval UI proxy secret
+func (u *UI) verifyProxyToken(req *http.Request) error {
+	if configs.ApprovalUIProxySecret == "" {
+		return fmt.Errorf("approval ui proxy secret is not configured")
+	}
+
+	secret := &corev1.Secret{}
+	if err := u.k8sClient.Get(context.Background(), types.NamespacedName{Name: configs.ApprovalUIProxySecret, Namespace: utils.Namespace()}, secret); err != nil {
+		return err
+	}
+	token, exist := secret.Data[SecretKeyProxyToken]
+	if !exist || len(token) == 0 {
+		return fmt.Errorf("secret %s should have key %s", configs.ApprovalUIProxySecret, SecretKeyProxyToken)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(req.Header.Get(ProxyTokenHeader)), token) != 1 {
+		return fmt.Errorf("request is not from the authenticating proxy")
+	}
+	return nil
+}
+
+// +kubebuilder:rbac:groups="authentication.k8s.io",resources=tokenreviews,verbs=create
+
+// reviewToken authenticates the kubernetes token using TokenReview
+func (u *UI) reviewToken(token string) (*userInfo, error) {
+	review, err := u.authnCli.TokenReviews().Create(context.Background(), &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if !review.Status.Authenticated {
+		return nil, fmt.Errorf("token is not authenticated, err : %s", review.Status.Error)
+	}
+	return &userInfo{Name: review.Status.User.Username, Groups: review.Status.User.Groups}, nil
+}
+
+// getToken extracts the kubernetes token from the Authorization header or the cookie
+func getToken(req *http.Request) string {
+	if auth := req.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+	}
+	if cookie, err := req.Cookie(tokenCookieName); err == nil {
+		return cookie.Value
+	}
+	return ""
+}
+
+// isSameOrigin checks if the request is sent from the UI itself, not to be forged by other sites
+func isSameOrigin(req *http.Request) bool {
+	origin := req.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && u.Host == req.Host
+}
+
+// authorize checks if the user can access the subresource of the approval
+func (u *UI) authorize(user *userInfo, ns, name, subResource string) error {
+	return apiserver.ReviewAccess(u.authCli, user.Name, user.Groups, nil, &authorizationv1.ResourceAttributes{
+		Name:        name,
+		Namespace:   ns,
+		Group:       apiserver.APIGroup,
+		Version:     apiVersion,
+		Resource:    cicdv1.ApprovalKind,
+		Subresource: subResource,
+		Verb:        "update",
+	})
+}
+
+// render executes the page template and writes it
+func (u *UI) render(w http.ResponseWriter, log logr.Logger, code int, page string, data interface{}) {
+	// Execute into a buffer first, not to write a partial page with the header
+	var buf bytes.Buffer
+	if err := pages.ExecuteTemplate(&buf, page, data); err != nil {
+		log.Error(err, "cannot execute template")
+		http.Error(w, "cannot render page", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(code)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		log.Error(err, "cannot write page")
+	}
+}
+
+func (u *UI) renderError(w http.ResponseWriter, log logr.Logger, code int, msg string) {
+	log.Info(msg)
+	u.render(w, log, code, "message", &messagePage{Title: http.StatusText(code), Message: msg})
+}
+
+var pages = template.Must(template.New("").Parse(pageTemplates))
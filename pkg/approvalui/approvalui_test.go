@@ -0,0 +1,258 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package approvalui
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/configs"
+	"github.com/tmax-cloud/cicd-operator/internal/test"
+	"github.com/tmax-cloud/cicd-operator/internal/utils"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	testing2 "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testToken      = "admin-token"
+	testProxyToken = "proxy-token"
+)
+
+func TestUI_authenticate(t *testing.T) {
+	tc := map[string]struct {
+		userHeader  string
+		groupHeader string
+		proxySecret string
+		header      map[string][]string
+		cookie      string
+
+		expectedErrOccur bool
+		expectedErrMsg   string
+		expectedUser     *userInfo
+	}{
+		"proxyHeader": {
+			userHeader:   "X-Forwarded-User",
+			groupHeader:  "X-Forwarded-Groups",
+			proxySecret:  "approval-ui-proxy",
+			header:       map[string][]string{"X-Forwarded-User": {"admin"}, "X-Forwarded-Groups": {"dev, ops", "qa"}, ProxyTokenHeader: {testProxyToken}},
+			expectedUser: &userInfo{Name: "admin", Groups: []string{"dev", "ops", "qa"}},
+		},
+		"proxyHeaderWithoutProxyToken": {
+			userHeader:       "X-Forwarded-User",
+			groupHeader:      "X-Forwarded-Groups",
+			proxySecret:      "approval-ui-proxy",
+			header:           map[string][]string{"X-Forwarded-User": {"admin"}, "X-Forwarded-Groups": {"dev"}},
+			expectedErrOccur: true,
+			expectedErrMsg:   "request is not from the authenticating proxy",
+		},
+		"proxyHeaderWrongProxyToken": {
+			userHeader:       "X-Forwarded-User",
+			proxySecret:      "approval-ui-proxy",
+			header:           map[string][]string{"X-Forwarded-User": {"admin"}, ProxyTokenHeader: {"wrong-token"}},
+			expectedErrOccur: true,
+			expectedErrMsg:   "request is not from the authenticating proxy",
+		},
+		"proxySecretNotConfigured": {
+			userHeader:       "X-Forwarded-User",
+			header:           map[string][]string{"X-Forwarded-User": {"admin"}, ProxyTokenHeader: {testProxyToken}},
+			expectedErrOccur: true,
+			expectedErrMsg:   "approval ui proxy secret is not configured",
+		},
+		"proxyHeaderNotConfigured": {
+			header:           map[string][]string{"X-Forwarded-User": {"admin"}},
+			expectedErrOccur: true,
+			expectedErrMsg:   "no credential is given",
+		},
+		"bearerToken": {
+			header:       map[string][]string{"Authorization": {"Bearer " + testToken}},
+			expectedUser: &userInfo{Name: "admin", Groups: []string{"system:authenticated"}},
+		},
+		"cookie": {
+			cookie:       testToken,
+			expectedUser: &userInfo{Name: "admin", Groups: []string{"system:authenticated"}},
+		},
+		"invalidToken": {
+			header:           map[string][]string{"Authorization": {"Bearer wrong-token"}},
+			expectedErrOccur: true,
+			expectedErrMsg:   "token is not authenticated, err : invalid token",
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			ui := newTestUI(t, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "approval-ui-proxy", Namespace: utils.Namespace()},
+				Data:       map[string][]byte{SecretKeyProxyToken: []byte(testProxyToken)},
+			})
+			configs.ApprovalUIUserHeader = c.userHeader
+			configs.ApprovalUIGroupHeader = c.groupHeader
+			configs.ApprovalUIProxySecret = c.proxySecret
+
+			req := httptest.NewRequest(http.MethodGet, ListPath, nil)
+			for k, v := range c.header {
+				for _, vv := range v {
+					req.Header.Add(k, vv)
+				}
+			}
+			if c.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: tokenCookieName, Value: c.cookie})
+			}
+
+			user, err := ui.authenticate(req)
+			if c.expectedErrOccur {
+				require.Error(t, err)
+				require.Equal(t, c.expectedErrMsg, err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, c.expectedUser, user)
+			}
+		})
+	}
+}
+
+func TestUI_authenticated(t *testing.T) {
+	ui := newTestUI(t)
+	handler := ui.authenticated(func(w http.ResponseWriter, _ *http.Request, user *userInfo, _ logr.Logger) {
+		_, _ = w.Write([]byte(user.Name))
+	})
+
+	// Not authenticated
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, ListPath, nil))
+	require.Equal(t, http.StatusUnauthorized, w.Result().StatusCode)
+	require.Contains(t, w.Body.String(), `action="/approvals/login"`)
+
+	// Authenticated
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, ListPath, nil)
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+	require.Equal(t, "admin", w.Body.String())
+
+	// Cross-origin
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, ListPath, nil)
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	req.Header.Set("Origin", "http://evil.com")
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusForbidden, w.Result().StatusCode)
+
+	// Disabled
+	configs.EnableApprovalUI = false
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, ListPath, nil))
+	require.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+}
+
+func TestUI_handleLogin(t *testing.T) {
+	ui := newTestUI(t)
+
+	// Invalid token
+	w := httptest.NewRecorder()
+	ui.handleLogin(w, newFormRequest(LoginPath, url.Values{formKeyToken: {"wrong-token"}}))
+	require.Equal(t, http.StatusUnauthorized, w.Result().StatusCode)
+	require.Empty(t, w.Result().Cookies())
+
+	// Valid token
+	w = httptest.NewRecorder()
+	ui.handleLogin(w, newFormRequest(LoginPath, url.Values{formKeyToken: {testToken}}))
+	require.Equal(t, http.StatusSeeOther, w.Result().StatusCode)
+	require.Equal(t, ListPath, w.Result().Header.Get("Location"))
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	require.Equal(t, tokenCookieName, cookies[0].Name)
+	require.Equal(t, testToken, cookies[0].Value)
+	require.True(t, cookies[0].HttpOnly)
+
+	// Logout
+	w = httptest.NewRecorder()
+	ui.handleLogout(w, newFormRequest(LogoutPath, url.Values{}))
+	require.Equal(t, http.StatusSeeOther, w.Result().StatusCode)
+	cookies = w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	require.Equal(t, "", cookies[0].Value)
+	require.True(t, cookies[0].MaxAge < 0)
+}
+
+func Test_isSameOrigin(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "http://cicd-webhook.example.com/approvals/login", nil)
+	require.True(t, isSameOrigin(req))
+
+	req.Header.Set("Origin", "http://cicd-webhook.example.com")
+	require.True(t, isSameOrigin(req))
+
+	req.Header.Set("Origin", "http://evil.com")
+	require.False(t, isSameOrigin(req))
+}
+
+func newFormRequest(path string, form url.Values) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+func newTestUI(t *testing.T, objs ...client.Object) *UI {
+	configs.EnableApprovalUI = true
+	configs.ApprovalUIUserHeader = ""
+	configs.ApprovalUIGroupHeader = ""
+	configs.ApprovalUIProxySecret = ""
+
+	s := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(s))
+	require.NoError(t, cicdv1.AddToScheme(s))
+
+	fakeSet := fake.NewSimpleClientset()
+	fakeSet.PrependReactor("create", "tokenreviews", func(action testing2.Action) (bool, runtime.Object, error) {
+		review := action.(testing2.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		review.Status.Authenticated = review.Spec.Token == testToken
+		if review.Status.Authenticated {
+			review.Status.User = authenticationv1.UserInfo{Username: "admin", Groups: []string{"system:authenticated"}}
+		} else {
+			review.Status.Error = "invalid token"
+		}
+		return true, review, nil
+	})
+	fakeSet.PrependReactor("create", "subjectaccessreviews", func(action testing2.Action) (bool, runtime.Object, error) {
+		sar := action.(testing2.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		sar.Status.Allowed = sar.Spec.User == "admin"
+		if !sar.Status.Allowed {
+			sar.Status.Reason = "not allowed"
+		}
+		return true, sar, nil
+	})
+
+	return &UI{
+		k8sClient: fakeclient.NewClientBuilder().WithScheme(s).WithObjects(objs...).Build(),
+		authnCli:  fakeSet.AuthenticationV1(),
+		authCli:   fakeSet.AuthorizationV1(),
+		log:       &test.FakeLogger{},
+	}
+}
@@ -0,0 +1,246 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package approvalui
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/apiserver"
+	"github.com/tmax-cloud/cicd-operator/internal/configs"
+	"github.com/tmax-cloud/cicd-operator/pkg/apiserver/apis/v1/approvals"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type loginPage struct {
+	Message string
+}
+
+type messagePage struct {
+	Title   string
+	Message string
+}
+
+type listPage struct {
+	User      string
+	Approvals []cicdv1.Approval
+}
+
+type detailPage struct {
+	User         string
+	Approval     *cicdv1.Approval
+	MinApprovals int
+	Refs         *cicdv1.IntegrationJobRefs
+	Jobs         []detailJob
+	CanDecide    bool
+}
+
+type detailJob struct {
+	Name      string
+	State     string
+	ReportURL string
+}
+
+// handleList lists the pending approvals the user can decide
+func (u *UI) handleList(w http.ResponseWriter, _ *http.Request, user *userInfo, log logr.Logger) {
+	approvalList := &cicdv1.ApprovalList{}
+	if err := u.k8sClient.List(context.Background(), approvalList); err != nil {
+		log.Error(err, "")
+		u.renderError(w, log, http.StatusInternalServerError, "cannot list approvals")
+		return
+	}
+
+	page := &listPage{User: user.Name}
+	for _, approval := range approvalList.Items {
+		if approval.Status.Result != cicdv1.ApprovalResultAwaiting || isDecidedBy(&approval, user.Name) {
+			continue
+		}
+		if err := u.authorize(user, approval.Namespace, approval.Name, cicdv1.ApprovalAPIApprove); err != nil {
+			continue
+		}
+		page.Approvals = append(page.Approvals, approval)
+	}
+
+	// Oldest first
+	sort.Slice(page.Approvals, func(i, j int) bool {
+		return page.Approvals[i].CreationTimestamp.Before(&page.Approvals[j].CreationTimestamp)
+	})
+
+	u.render(w, log, http.StatusOK, "list", page)
+}
+
+// handleDetail shows the approval and its IntegrationJob
+func (u *UI) handleDetail(w http.ResponseWriter, req *http.Request, user *userInfo, log logr.Logger) {
+	approval, ok := u.getAuthorizedApproval(w, req, user, log)
+	if !ok {
+		return
+	}
+
+	page := &detailPage{
+		User:         user.Name,
+		Approval:     approval,
+		MinApprovals: approval.Spec.Policy.GetMinApprovals(),
+		CanDecide:    approval.Status.Result == cicdv1.ApprovalResultAwaiting && !isDecidedBy(approval, user.Name),
+	}
+
+	// IntegrationJob may have been garbage-collected
+	ij := &cicdv1.IntegrationJob{}
+	if err := u.k8sClient.Get(context.Background(), types.NamespacedName{Name: approval.Spec.IntegrationJob, Namespace: approval.Namespace}, ij); err == nil {
+		page.Refs = &ij.Spec.Refs
+		for _, j := range ij.Status.Jobs {
+			page.Jobs = append(page.Jobs, detailJob{
+				Name:      j.Name,
+				State:     string(j.State),
+				ReportURL: fmt.Sprintf("/report/%s/%s/%s", ij.Namespace, ij.Name, j.Name),
+			})
+		}
+	} else if approval.Spec.IntegrationJob != "" {
+		log.Info(fmt.Sprintf("cannot get IntegrationJob %s, err : %s", approval.Spec.IntegrationJob, err.Error()))
+	}
+
+	u.render(w, log, http.StatusOK, "detail", page)
+}
+
+// handleDecision approves or rejects the approval
+func (u *UI) handleDecision(w http.ResponseWriter, req *http.Request, user *userInfo, log logr.Logger) {
+	vars := mux.Vars(req)
+	ns, name := vars[paramKeyNamespace], vars[paramKeyName]
+
+	if err := req.ParseForm(); err != nil {
+		u.renderError(w, log, http.StatusBadRequest, "form is malformed")
+		return
+	}
+
+	var decision cicdv1.ApprovalResult
+	var subResource string
+	switch req.PostForm.Get(formKeyDecision) {
+	case cicdv1.ApprovalAPIApprove:
+		decision, subResource = cicdv1.ApprovalResultApproved, cicdv1.ApprovalAPIApprove
+	case cicdv1.ApprovalAPIReject:
+		decision, subResource = cicdv1.ApprovalResultRejected, cicdv1.ApprovalAPIReject
+	default:
+		u.renderError(w, log, http.StatusBadRequest, fmt.Sprintf("decision should be one of %s, %s", cicdv1.ApprovalAPIApprove, cicdv1.ApprovalAPIReject))
+		return
+	}
+
+	if err := u.authorize(user, ns, name, subResource); err != nil {
+		u.renderError(w, log, http.StatusForbidden, fmt.Sprintf("you cannot %s approval %s/%s", req.PostForm.Get(formKeyDecision), ns, name))
+		return
+	}
+
+	if _, err := approvals.Decide(u.k8sClient, ns, name, user.Name, user.Groups, decision, req.PostForm.Get(formKeyReason)); err != nil {
+		code := http.StatusInternalServerError
+		if reqErr, ok := err.(*apiserver.RequestError); ok {
+			code = reqErr.Code
+		}
+		u.renderError(w, log, code, err.Error())
+		return
+	}
+
+	http.Redirect(w, req, fmt.Sprintf("%s/%s/%s", ListPath, ns, name), http.StatusSeeOther)
+}
+
+// getAuthorizedApproval gets the approval in the path, only if the user can approve it
+func (u *UI) getAuthorizedApproval(w http.ResponseWriter, req *http.Request, user *userInfo, log logr.Logger) (*cicdv1.Approval, bool) {
+	vars := mux.Vars(req)
+	ns, name := vars[paramKeyNamespace], vars[paramKeyName]
+
+	// Do not let the user know whether the approval exists or not, if the user cannot access it
+	if err := u.authorize(user, ns, name, cicdv1.ApprovalAPIApprove); err != nil {
+		u.renderError(w, log, http.StatusForbidden, fmt.Sprintf("you cannot access approval %s/%s", ns, name))
+		return nil, false
+	}
+
+	approval := &cicdv1.Approval{}
+	if err := u.k8sClient.Get(context.Background(), types.NamespacedName{Name: name, Namespace: ns}, approval); err != nil {
+		u.renderError(w, log, http.StatusNotFound, fmt.Sprintf("no approval %s/%s is found", ns, name))
+		return nil, false
+	}
+
+	return approval, true
+}
+
+// isDecidedBy checks if the user already decided the approval
+func isDecidedBy(approval *cicdv1.Approval, user string) bool {
+	for _, d := range approval.Status.Decisions {
+		if d.User == user {
+			return true
+		}
+	}
+	return false
+}
+
+// handleLogin authenticates the kubernetes token and stores it in the cookie
+func (u *UI) handleLogin(w http.ResponseWriter, req *http.Request) {
+	log := u.log.WithValues("path", req.URL.Path)
+
+	if !configs.EnableApprovalUI {
+		http.NotFound(w, req)
+		return
+	}
+
+	if !isSameOrigin(req) {
+		u.renderError(w, log, http.StatusForbidden, "cross-origin request is not allowed")
+		return
+	}
+
+	if err := req.ParseForm(); err != nil {
+		u.renderError(w, log, http.StatusBadRequest, "form is malformed")
+		return
+	}
+
+	token := req.PostForm.Get(formKeyToken)
+	if _, err := u.reviewToken(token); err != nil {
+		log.Info(err.Error())
+		u.render(w, log, http.StatusUnauthorized, "login", &loginPage{Message: "Token is not valid"})
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     tokenCookieName,
+		Value:    token,
+		Path:     ListPath,
+		MaxAge:   tokenCookieMaxAge,
+		HttpOnly: true,
+		Secure:   isSecure(req),
+		SameSite: http.SameSiteStrictMode,
+	})
+	http.Redirect(w, req, ListPath, http.StatusSeeOther)
+}
+
+// handleLogout removes the token cookie
+func (u *UI) handleLogout(w http.ResponseWriter, req *http.Request) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     tokenCookieName,
+		Path:     ListPath,
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   isSecure(req),
+		SameSite: http.SameSiteStrictMode,
+	})
+	http.Redirect(w, req, ListPath, http.StatusSeeOther)
+}
+
+// isSecure checks if the request is sent over https, directly or via the ingress
+func isSecure(req *http.Request) bool {
+	return req.TLS != nil || req.Header.Get("X-Forwarded-Proto") == "https"
+}
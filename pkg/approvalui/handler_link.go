@@ -0,0 +1,133 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package approvalui
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/apiserver"
+	"github.com/tmax-cloud/cicd-operator/pkg/apiserver/apis/v1/approvals"
+	"github.com/tmax-cloud/cicd-operator/pkg/approvallink"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type linkPage struct {
+	Approval *cicdv1.Approval
+	Email    string
+	Decision string
+	Action   string
+}
+
+// LinkHandler returns a handler for the approve/reject links in the approval request mails.
+// GET shows a confirmation page and POST decides the approval, so the links are not used by mail scanners prefetching them
+func (u *UI) LinkHandler() http.Handler {
+	return http.HandlerFunc(u.handleLink)
+}
+
+func (u *UI) handleLink(w http.ResponseWriter, req *http.Request) {
+	log := u.log.WithValues("path", req.URL.Path)
+
+	if !approvallink.Enabled() {
+		http.NotFound(w, req)
+		return
+	}
+
+	if req.Method != http.MethodGet && !isSameOrigin(req) {
+		u.renderError(w, log, http.StatusForbidden, "cross-origin request is not allowed")
+		return
+	}
+
+	key, err := approvallink.GetSigningKey(u.k8sClient)
+	if err != nil {
+		log.Error(err, "")
+		u.renderError(w, log, http.StatusInternalServerError, "cannot get approval link signing key")
+		return
+	}
+
+	link, err := approvallink.Parse(key, req.URL.Query(), time.Now())
+	if err != nil {
+		u.renderError(w, log, http.StatusForbidden, err.Error())
+		return
+	}
+	log = log.WithValues("email", link.Email)
+
+	// Link is bound to the Approval's UID, not to be used for a re-created Approval with the same name
+	approval := &cicdv1.Approval{}
+	if err := u.k8sClient.Get(context.Background(), types.NamespacedName{Name: link.Name, Namespace: link.Namespace}, approval); err != nil || approval.UID != link.UID {
+		u.renderError(w, log, http.StatusNotFound, fmt.Sprintf("no approval %s/%s is found", link.Namespace, link.Name))
+		return
+	}
+
+	user := approverByEmail(approval, link.Email)
+	if user == "" {
+		u.renderError(w, log, http.StatusForbidden, fmt.Sprintf("approval %s/%s is not requested to %s", link.Namespace, link.Name, link.Email))
+		return
+	}
+
+	if req.Method == http.MethodGet {
+		if approval.Status.Result != cicdv1.ApprovalResultAwaiting || isDecidedBy(approval, user) {
+			u.renderError(w, log, http.StatusBadRequest, fmt.Sprintf("approval %s/%s is already decided", link.Namespace, link.Name))
+			return
+		}
+		u.render(w, log, http.StatusOK, "link", &linkPage{Approval: approval, Email: link.Email, Decision: link.Decision, Action: req.URL.RequestURI()})
+		return
+	}
+
+	if err := req.ParseForm(); err != nil {
+		u.renderError(w, log, http.StatusBadRequest, "form is malformed")
+		return
+	}
+
+	decision := cicdv1.ApprovalResultApproved
+	if link.Decision == cicdv1.ApprovalAPIReject {
+		decision = cicdv1.ApprovalResultRejected
+	}
+	reason := req.PostForm.Get(formKeyReason)
+	if reason == "" {
+		reason = fmt.Sprintf("%s via email link", decision)
+	}
+
+	// Each recipient can decide only once, and the decided approvals cannot be decided again
+	if _, err := approvals.Decide(u.k8sClient, link.Namespace, link.Name, user, nil, decision, reason); err != nil {
+		code := http.StatusInternalServerError
+		if reqErr, ok := err.(*apiserver.RequestError); ok {
+			code = reqErr.Code
+		}
+		u.renderError(w, log, code, err.Error())
+		return
+	}
+
+	u.render(w, log, http.StatusOK, "message", &messagePage{
+		Title:   "Decided",
+		Message: fmt.Sprintf("Approval %s/%s is %s by %s", link.Namespace, link.Name, strings.ToLower(string(decision)), user),
+	})
+}
+
+// approverByEmail returns the name of the approver with the email
+func approverByEmail(approval *cicdv1.Approval, email string) string {
+	for _, a := range approval.Approvers() {
+		if a.Email != "" && a.Email == email {
+			return a.Name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,224 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package approvalui
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/configs"
+	"github.com/tmax-cloud/cicd-operator/internal/utils"
+	"github.com/tmax-cloud/cicd-operator/pkg/approvallink"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	testSigningKey = "signing-key"
+)
+
+func TestUI_handleLink(t *testing.T) {
+	tc := map[string]struct {
+		method   string
+		email    string
+		decision string
+		key      string
+		uid      string
+		result   cicdv1.ApprovalResult
+
+		expectedCode     int
+		expectedContents []string
+		expectedResult   cicdv1.ApprovalResult
+	}{
+		"confirm": {
+			method:           http.MethodGet,
+			email:            "admin@tmax.co.kr",
+			decision:         cicdv1.ApprovalAPIApprove,
+			key:              testSigningKey,
+			uid:              "test-uid",
+			result:           cicdv1.ApprovalResultAwaiting,
+			expectedCode:     http.StatusOK,
+			expectedContents: []string{"Approve as admin@tmax.co.kr"},
+			expectedResult:   cicdv1.ApprovalResultAwaiting,
+		},
+		"approve": {
+			method:           http.MethodPost,
+			email:            "admin@tmax.co.kr",
+			decision:         cicdv1.ApprovalAPIApprove,
+			key:              testSigningKey,
+			uid:              "test-uid",
+			result:           cicdv1.ApprovalResultAwaiting,
+			expectedCode:     http.StatusOK,
+			expectedContents: []string{"Approval test-ns/test-approval is approved by admin"},
+			expectedResult:   cicdv1.ApprovalResultApproved,
+		},
+		"reject": {
+			method:           http.MethodPost,
+			email:            "admin@tmax.co.kr",
+			decision:         cicdv1.ApprovalAPIReject,
+			key:              testSigningKey,
+			uid:              "test-uid",
+			result:           cicdv1.ApprovalResultAwaiting,
+			expectedCode:     http.StatusOK,
+			expectedContents: []string{"Approval test-ns/test-approval is rejected by admin"},
+			expectedResult:   cicdv1.ApprovalResultRejected,
+		},
+		"invalidSignature": {
+			method:           http.MethodPost,
+			email:            "admin@tmax.co.kr",
+			decision:         cicdv1.ApprovalAPIApprove,
+			key:              "wrong-key",
+			uid:              "test-uid",
+			result:           cicdv1.ApprovalResultAwaiting,
+			expectedCode:     http.StatusForbidden,
+			expectedContents: []string{"link signature is not valid"},
+			expectedResult:   cicdv1.ApprovalResultAwaiting,
+		},
+		"recreatedApproval": {
+			method:           http.MethodPost,
+			email:            "admin@tmax.co.kr",
+			decision:         cicdv1.ApprovalAPIApprove,
+			key:              testSigningKey,
+			uid:              "old-uid",
+			result:           cicdv1.ApprovalResultAwaiting,
+			expectedCode:     http.StatusNotFound,
+			expectedContents: []string{"no approval test-ns/test-approval is found"},
+			expectedResult:   cicdv1.ApprovalResultAwaiting,
+		},
+		"notApprover": {
+			method:           http.MethodPost,
+			email:            "developer@tmax.co.kr",
+			decision:         cicdv1.ApprovalAPIApprove,
+			key:              testSigningKey,
+			uid:              "test-uid",
+			result:           cicdv1.ApprovalResultAwaiting,
+			expectedCode:     http.StatusForbidden,
+			expectedContents: []string{"approval test-ns/test-approval is not requested to developer@tmax.co.kr"},
+			expectedResult:   cicdv1.ApprovalResultAwaiting,
+		},
+		"alreadyDecidedConfirm": {
+			method:           http.MethodGet,
+			email:            "admin@tmax.co.kr",
+			decision:         cicdv1.ApprovalAPIApprove,
+			key:              testSigningKey,
+			uid:              "test-uid",
+			result:           cicdv1.ApprovalResultRejected,
+			expectedCode:     http.StatusBadRequest,
+			expectedContents: []string{"approval test-ns/test-approval is already decided"},
+			expectedResult:   cicdv1.ApprovalResultRejected,
+		},
+		"alreadyDecided": {
+			method:           http.MethodPost,
+			email:            "admin@tmax.co.kr",
+			decision:         cicdv1.ApprovalAPIApprove,
+			key:              testSigningKey,
+			uid:              "test-uid",
+			result:           cicdv1.ApprovalResultRejected,
+			expectedCode:     http.StatusBadRequest,
+			expectedContents: []string{"approval test-ns/test-approval is already in Rejected status"},
+			expectedResult:   cicdv1.ApprovalResultRejected,
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			approval := testApproval("test-approval", c.result)
+			approval.UID = "test-uid"
+			approval.Spec.Users[0].Email = "admin@tmax.co.kr"
+			ui := newTestLinkUI(t, approval)
+
+			linkApproval := approval.DeepCopy()
+			linkApproval.UID = "old-uid"
+			if c.uid == "test-uid" {
+				linkApproval.UID = "test-uid"
+			}
+			link, err := url.Parse(approvallink.New(linkApproval, c.email, c.decision, time.Now()).URL([]byte(c.key)))
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(c.method, link.RequestURI(), bytes.NewBufferString(url.Values{formKeyReason: {}}.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			w := httptest.NewRecorder()
+			ui.handleLink(w, req)
+			require.Equal(t, c.expectedCode, w.Result().StatusCode)
+			for _, content := range c.expectedContents {
+				require.Contains(t, w.Body.String(), content)
+			}
+
+			result := &cicdv1.Approval{}
+			require.NoError(t, ui.k8sClient.Get(context.Background(), client.ObjectKeyFromObject(approval), result))
+			require.Equal(t, c.expectedResult, result.Status.Result)
+			if c.method == http.MethodPost && c.expectedCode == http.StatusOK {
+				require.Equal(t, "admin", result.Status.Approver)
+			}
+		})
+	}
+}
+
+func TestUI_handleLinkReuse(t *testing.T) {
+	approval := testApproval("test-approval", cicdv1.ApprovalResultAwaiting)
+	approval.UID = "test-uid"
+	approval.Spec.Users = []cicdv1.ApprovalUser{{Name: "admin", Email: "admin@tmax.co.kr"}, {Name: "admin2", Email: "admin2@tmax.co.kr"}}
+	approval.Spec.Policy = &cicdv1.ApprovalPolicy{MinApprovals: 2}
+	ui := newTestLinkUI(t, approval)
+
+	link, err := url.Parse(approvallink.New(approval, "admin@tmax.co.kr", cicdv1.ApprovalAPIApprove, time.Now()).URL([]byte(testSigningKey)))
+	require.NoError(t, err)
+
+	// First use
+	w := httptest.NewRecorder()
+	ui.handleLink(w, httptest.NewRequest(http.MethodPost, link.RequestURI(), nil))
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	// Reuse
+	w = httptest.NewRecorder()
+	ui.handleLink(w, httptest.NewRequest(http.MethodPost, link.RequestURI(), nil))
+	require.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	require.Contains(t, w.Body.String(), "approval test-ns/test-approval is already approved by you")
+
+	result := &cicdv1.Approval{}
+	require.NoError(t, ui.k8sClient.Get(context.Background(), client.ObjectKeyFromObject(approval), result))
+	require.Equal(t, cicdv1.ApprovalResultAwaiting, result.Status.Result)
+	require.Len(t, result.Status.Decisions, 1)
+}
+
+func TestUI_handleLinkDisabled(t *testing.T) {
+	ui := newTestUI(t)
+	configs.ApprovalLinkSecret = ""
+
+	w := httptest.NewRecorder()
+	ui.handleLink(w, httptest.NewRequest(http.MethodGet, approvallink.Path, nil))
+	require.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+}
+
+func newTestLinkUI(t *testing.T, objs ...client.Object) *UI {
+	configs.ApprovalLinkSecret = "approval-link"
+	configs.ApprovalLinkTTL = 24
+
+	return newTestUI(t, append(objs, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "approval-link", Namespace: utils.Namespace()},
+		Data:       map[string][]byte{approvallink.SecretKeySigningKey: []byte(testSigningKey)},
+	})...)
+}
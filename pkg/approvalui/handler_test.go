@@ -0,0 +1,217 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package approvalui
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/test"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestUI_handleList(t *testing.T) {
+	ui := newTestUI(t,
+		testApproval("awaiting", cicdv1.ApprovalResultAwaiting),
+		testApproval("approved", cicdv1.ApprovalResultApproved),
+		&cicdv1.Approval{
+			ObjectMeta: metav1.ObjectMeta{Name: "decided", Namespace: "test-ns"},
+			Status: cicdv1.ApprovalStatus{
+				Result:    cicdv1.ApprovalResultAwaiting,
+				Decisions: []cicdv1.ApprovalDecision{{User: "admin", Result: cicdv1.ApprovalResultApproved}},
+			},
+		},
+	)
+
+	// Allowed user
+	w := httptest.NewRecorder()
+	ui.handleList(w, httptest.NewRequest(http.MethodGet, ListPath, nil), &userInfo{Name: "admin"}, &test.FakeLogger{})
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+	body := w.Body.String()
+	require.Contains(t, body, "/approvals/test-ns/awaiting")
+	require.NotContains(t, body, "/approvals/test-ns/approved")
+	require.NotContains(t, body, "/approvals/test-ns/decided")
+
+	// Not allowed user
+	w = httptest.NewRecorder()
+	ui.handleList(w, httptest.NewRequest(http.MethodGet, ListPath, nil), &userInfo{Name: "developer"}, &test.FakeLogger{})
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+	require.Contains(t, w.Body.String(), "No approval is waiting for your decision")
+}
+
+func TestUI_handleDetail(t *testing.T) {
+	approval := testApproval("test-approval", cicdv1.ApprovalResultAwaiting)
+	approval.Spec.IntegrationJob = "test-ij"
+	ij := &cicdv1.IntegrationJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-ij", Namespace: "test-ns"},
+		Spec: cicdv1.IntegrationJobSpec{
+			Refs: cicdv1.IntegrationJobRefs{
+				Repository: "tmax-cloud/cicd-test",
+				Link:       "https://github.com/tmax-cloud/cicd-test",
+				Base:       cicdv1.IntegrationJobRefsBase{Ref: "refs/heads/master", Sha: "a1b2c3"},
+				Pulls: []cicdv1.IntegrationJobRefsPull{{
+					ID:   12,
+					Link: "https://github.com/tmax-cloud/cicd-test/pull/12",
+				}},
+			},
+		},
+		Status: cicdv1.IntegrationJobStatus{
+			Jobs: []cicdv1.JobStatus{{Name: "test-job", State: cicdv1.CommitStatusStatePending}},
+		},
+	}
+	ui := newTestUI(t, approval, ij)
+
+	tc := map[string]struct {
+		user string
+		name string
+
+		expectedCode     int
+		expectedContents []string
+	}{
+		"normal": {
+			user:         "admin",
+			name:         "test-approval",
+			expectedCode: http.StatusOK,
+			expectedContents: []string{
+				"tmax-cloud/cicd-test",
+				"https://github.com/tmax-cloud/cicd-test/pull/12",
+				"/report/test-ns/test-ij/test-job",
+				`value="approve"`,
+			},
+		},
+		"notAllowed": {
+			user:             "developer",
+			name:             "test-approval",
+			expectedCode:     http.StatusForbidden,
+			expectedContents: []string{"you cannot access approval test-ns/test-approval"},
+		},
+		"notFound": {
+			user:             "admin",
+			name:             "no-approval",
+			expectedCode:     http.StatusNotFound,
+			expectedContents: []string{"no approval test-ns/no-approval is found"},
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/approvals/test-ns/"+c.name, nil)
+			req = mux.SetURLVars(req, map[string]string{paramKeyNamespace: "test-ns", paramKeyName: c.name})
+
+			w := httptest.NewRecorder()
+			ui.handleDetail(w, req, &userInfo{Name: c.user}, &test.FakeLogger{})
+			require.Equal(t, c.expectedCode, w.Result().StatusCode)
+			for _, content := range c.expectedContents {
+				require.Contains(t, w.Body.String(), content)
+			}
+		})
+	}
+}
+
+func TestUI_handleDecision(t *testing.T) {
+	tc := map[string]struct {
+		user     string
+		decision string
+		result   cicdv1.ApprovalResult
+
+		expectedCode   int
+		expectedResult cicdv1.ApprovalResult
+		expectedReason string
+	}{
+		"approve": {
+			user:           "admin",
+			decision:       "approve",
+			result:         cicdv1.ApprovalResultAwaiting,
+			expectedCode:   http.StatusSeeOther,
+			expectedResult: cicdv1.ApprovalResultApproved,
+			expectedReason: "looks good",
+		},
+		"reject": {
+			user:           "admin",
+			decision:       "reject",
+			result:         cicdv1.ApprovalResultAwaiting,
+			expectedCode:   http.StatusSeeOther,
+			expectedResult: cicdv1.ApprovalResultRejected,
+			expectedReason: "looks good",
+		},
+		"wrongDecision": {
+			user:           "admin",
+			decision:       "hold",
+			result:         cicdv1.ApprovalResultAwaiting,
+			expectedCode:   http.StatusBadRequest,
+			expectedResult: cicdv1.ApprovalResultAwaiting,
+		},
+		"notAllowed": {
+			user:           "developer",
+			decision:       "approve",
+			result:         cicdv1.ApprovalResultAwaiting,
+			expectedCode:   http.StatusForbidden,
+			expectedResult: cicdv1.ApprovalResultAwaiting,
+		},
+		"alreadyDecided": {
+			user:           "admin",
+			decision:       "approve",
+			result:         cicdv1.ApprovalResultRejected,
+			expectedCode:   http.StatusBadRequest,
+			expectedResult: cicdv1.ApprovalResultRejected,
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			approval := testApproval("test-approval", c.result)
+			ui := newTestUI(t, approval)
+
+			form := url.Values{}
+			form.Set(formKeyDecision, c.decision)
+			form.Set(formKeyReason, "looks good")
+			req := httptest.NewRequest(http.MethodPost, "/approvals/test-ns/test-approval", bytes.NewBufferString(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req = mux.SetURLVars(req, map[string]string{paramKeyNamespace: "test-ns", paramKeyName: "test-approval"})
+
+			w := httptest.NewRecorder()
+			ui.handleDecision(w, req, &userInfo{Name: c.user}, &test.FakeLogger{})
+			require.Equal(t, c.expectedCode, w.Result().StatusCode)
+
+			result := &cicdv1.Approval{}
+			require.NoError(t, ui.k8sClient.Get(context.Background(), client.ObjectKeyFromObject(approval), result))
+			require.Equal(t, c.expectedResult, result.Status.Result)
+			if c.expectedReason != "" {
+				require.Equal(t, c.user, result.Status.Approver)
+				require.Equal(t, c.expectedReason, result.Status.Reason)
+			}
+		})
+	}
+}
+
+func testApproval(name string, result cicdv1.ApprovalResult) *cicdv1.Approval {
+	return &cicdv1.Approval{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns"},
+		Spec: cicdv1.ApprovalSpec{
+			Users: []cicdv1.ApprovalUser{{Name: "admin"}},
+		},
+		Status: cicdv1.ApprovalStatus{Result: result},
+	}
+}
@@ -0,0 +1,175 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package approvalui
+
+// pageTemplates are the html templates of the approval web UI pages (login, message, link, list, detail)
+const pageTemplates = `
+{{define "header"}}<!doctype html>
+<html lang="en">
+  <head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1, shrink-to-fit=no">
+    <link rel="stylesheet" href="https://maxcdn.bootstrapcdn.com/bootstrap/4.0.0/css/bootstrap.min.css" integrity="sha384-Gn5384xqQ1aoWXA+058RXPxPg6fy4IWvTNh0E263XmFcJlSAwiGgFAW/dAiS6JXm" crossorigin="anonymous">
+    <title>Approvals</title>
+  </head>
+  <body>
+    <div class="container">
+      <h2><a href="/approvals">Approvals</a></h2>
+{{end}}
+
+{{define "footer"}}    </div>
+  </body>
+</html>
+{{end}}
+
+{{define "user"}}      <form method="post" action="/approvals/logout" class="text-right">
+        <small>{{.}}</small>
+        <button type="submit" class="btn btn-link btn-sm">Log out</button>
+      </form>
+      <hr/>
+{{end}}
+
+{{define "login"}}{{template "header"}}      <hr/>
+      <p>{{.Message}}</p>
+      <form method="post" action="/approvals/login">
+        <div class="form-group">
+          <label for="token">Kubernetes token</label>
+          <input type="password" class="form-control" id="token" name="token" required>
+        </div>
+        <button type="submit" class="btn btn-primary">Log in</button>
+      </form>
+{{template "footer"}}{{end}}
+
+{{define "message"}}{{template "header"}}      <hr/>
+      <h3>{{.Title}}</h3>
+      <p>{{.Message}}</p>
+      <a href="/approvals">Back to the list</a>
+{{template "footer"}}{{end}}
+
+{{define "link"}}{{template "header"}}      <hr/>
+      <h3>{{.Approval.Namespace}}/{{.Approval.Name}}</h3>
+      <table class="table">
+        <tbody>
+          <tr><td>Message</td><td>{{.Approval.Spec.Message}}</td></tr>
+          <tr><td>IntegrationJob</td><td>{{.Approval.Spec.IntegrationJob}}</td></tr>
+          <tr><td>Job</td><td>{{.Approval.Spec.JobName}}</td></tr>
+          <tr><td>Sender</td><td>{{with .Approval.Spec.Sender}}{{.Name}}{{end}}</td></tr>
+          <tr><td>Link</td><td>{{with .Approval.Spec.Link}}<a href="{{.}}">{{.}}</a>{{end}}</td></tr>
+        </tbody>
+      </table>
+      <form method="post" action="{{.Action}}">
+        <div class="form-group">
+          <label for="reason">Reason</label>
+          <textarea class="form-control" id="reason" name="reason"></textarea>
+        </div>
+        {{if eq .Decision "approve"}}
+        <button type="submit" class="btn btn-success">Approve as {{.Email}}</button>
+        {{else}}
+        <button type="submit" class="btn btn-danger">Reject as {{.Email}}</button>
+        {{end}}
+      </form>
+{{template "footer"}}{{end}}
+
+{{define "list"}}{{template "header"}}{{template "user" .User}}      <h3>Pending Approvals</h3>
+      <table class="table">
+        <thead>
+          <tr>
+            <th>Namespace</th>
+            <th>Name</th>
+            <th>IntegrationJob</th>
+            <th>Job</th>
+            <th>Sender</th>
+            <th>Created</th>
+          </tr>
+        </thead>
+        <tbody>
+          {{range .Approvals}}
+          <tr>
+            <td>{{.Namespace}}</td>
+            <td><a href="/approvals/{{.Namespace}}/{{.Name}}">{{.Name}}</a></td>
+            <td>{{.Spec.IntegrationJob}}</td>
+            <td>{{.Spec.JobName}}</td>
+            <td>{{with .Spec.Sender}}{{.Name}}{{end}}</td>
+            <td>{{.CreationTimestamp}}</td>
+          </tr>
+          {{else}}
+          <tr>
+            <td colspan="6">No approval is waiting for your decision</td>
+          </tr>
+          {{end}}
+        </tbody>
+      </table>
+{{template "footer"}}{{end}}
+
+{{define "detail"}}{{template "header"}}{{template "user" .User}}      <h3>{{.Approval.Namespace}}/{{.Approval.Name}}</h3>
+      <table class="table">
+        <tbody>
+          <tr><td>Status</td><td>{{.Approval.Status.Result}}</td></tr>
+          <tr><td>Message</td><td>{{.Approval.Spec.Message}}</td></tr>
+          <tr><td>Sender</td><td>{{with .Approval.Spec.Sender}}{{.Name}}{{end}}</td></tr>
+          <tr><td>Author</td><td>{{.Approval.Spec.Author}}</td></tr>
+          <tr><td>Link</td><td>{{with .Approval.Spec.Link}}<a href="{{.}}">{{.}}</a>{{end}}</td></tr>
+          <tr><td>Approvers</td><td>{{range .Approval.Approvers}}{{.Name}} {{end}}</td></tr>
+          <tr><td>Required approvals</td><td>{{.MinApprovals}}</td></tr>
+          <tr><td>Reason</td><td>{{.Approval.Status.Reason}}</td></tr>
+        </tbody>
+      </table>
+      {{with .Approval.Status.Decisions}}
+      <h4>Decisions</h4>
+      <table class="table">
+        <tbody>
+          {{range .}}
+          <tr><td>{{.User}}</td><td>{{.Result}}</td><td>{{.Reason}}</td><td>{{.Time}}</td></tr>
+          {{end}}
+        </tbody>
+      </table>
+      {{end}}
+      {{with .Refs}}
+      <h4>IntegrationJob {{$.Approval.Spec.IntegrationJob}}</h4>
+      <table class="table">
+        <tbody>
+          <tr><td>Repository</td><td><a href="{{.Link}}">{{.Repository}}</a></td></tr>
+          <tr><td>Base</td><td>{{.Base.Ref}} (<a href="{{.Base.Link}}">{{.Base.Sha}}</a>)</td></tr>
+          {{range .Pulls}}
+          <tr><td>Pull request</td><td>#{{.ID}} {{.Ref}} by {{.Author.Name}} (<a href="{{.Link}}">diff</a>)</td></tr>
+          {{end}}
+        </tbody>
+      </table>
+      {{end}}
+      {{with .Jobs}}
+      <h4>Jobs</h4>
+      <table class="table">
+        <tbody>
+          {{range .}}
+          <tr><td>{{.Name}}</td><td>{{.State}}</td><td><a href="{{.ReportURL}}">logs</a></td></tr>
+          {{end}}
+        </tbody>
+      </table>
+      {{end}}
+      {{if .CanDecide}}
+      <h4>Decision</h4>
+      <form method="post" action="/approvals/{{.Approval.Namespace}}/{{.Approval.Name}}">
+        <div class="form-group">
+          <label for="reason">Reason</label>
+          <textarea class="form-control" id="reason" name="reason"></textarea>
+        </div>
+        <button type="submit" class="btn btn-success" name="decision" value="approve">Approve</button>
+        <button type="submit" class="btn btn-danger" name="decision" value="reject">Reject</button>
+      </form>
+      {{end}}
+{{template "footer"}}{{end}}
+`
@@ -0,0 +1,41 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+// Package artifacts implements utilities for the jobs' artifacts, uploaded to PVCs or S3-compatible storages
+package artifacts
+
+import (
+	"path"
+
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+)
+
+// Artifact PVCs are served by a file server (busybox httpd) per IntegrationConfig, which mounts the PVC read-only.
+// The report server reaches it through the API server's service proxy
+const (
+	ServerPort      = 8080
+	ServerMountPath = "/artifacts"
+)
+
+// Prefix returns a prefix (i.e., directory) of the job's artifacts
+func Prefix(ij *cicdv1.IntegrationJob, jobName string) string {
+	return path.Join(ij.Namespace, ij.Name, jobName)
+}
+
+// ObjectKey returns a key of the artifact in the storage
+func ObjectKey(ij *cicdv1.IntegrationJob, jobName, artifact string) string {
+	return path.Join(Prefix(ij, jobName), artifact)
+}
@@ -0,0 +1,370 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package artifacts
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tmax-cloud/cicd-operator/internal/configs"
+	"github.com/tmax-cloud/cicd-operator/internal/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Keys of the S3 credential secret
+const (
+	S3AccessKeyKey = "accessKey"
+	S3SecretKeyKey = "secretKey"
+)
+
+const (
+	s3Algorithm       = "AWS4-HMAC-SHA256"
+	s3Service         = "s3"
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+	s3TimeFormat      = "20060102T150405Z"
+
+	s3PolicyExpirationFormat = "2006-01-02T15:04:05.000Z"
+)
+
+// Form fields of the presigned POST policy
+const (
+	PostFieldAlgorithm  = "X-Amz-Algorithm"
+	PostFieldCredential = "X-Amz-Credential"
+	PostFieldDate       = "X-Amz-Date"
+	PostFieldPolicy     = "Policy"
+	PostFieldSignature  = "X-Amz-Signature"
+)
+
+// S3 is a minimal client for S3-compatible storages, which presigns, lists and deletes artifact objects
+type S3 struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+
+	HTTPClient *http.Client
+
+	now func() time.Time
+}
+
+// PostPolicy is a presigned POST policy, which allows uploading objects only under a key prefix
+// without handing out the storage credential
+type PostPolicy struct {
+	URL    string
+	Fields map[string]string
+}
+
+// NewS3 initiates a S3 client using the operator's configurations
+func NewS3(c client.Client) (*S3, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: configs.ArtifactS3Secret, Namespace: utils.Namespace()}, secret); err != nil {
+		return nil, err
+	}
+	accessKey, accessKeyExist := secret.Data[S3AccessKeyKey]
+	secretKey, secretKeyExist := secret.Data[S3SecretKeyKey]
+	if !accessKeyExist || !secretKeyExist {
+		return nil, fmt.Errorf("secret %s should contain %s and %s", configs.ArtifactS3Secret, S3AccessKeyKey, S3SecretKeyKey)
+	}
+
+	return &S3{
+		Endpoint:   configs.ArtifactS3Endpoint,
+		Region:     configs.ArtifactS3Region,
+		Bucket:     configs.ArtifactS3Bucket,
+		AccessKey:  string(accessKey),
+		SecretKey:  string(secretKey),
+		HTTPClient: http.DefaultClient,
+	}, nil
+}
+
+// PresignGet generates a presigned url for downloading the object
+func (s *S3) PresignGet(key string, expires time.Duration) (string, error) {
+	u, err := s.objectURL(key)
+	if err != nil {
+		return "", err
+	}
+
+	t := s.currentTime()
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", s3Algorithm)
+	query.Set("X-Amz-Credential", s.AccessKey+"/"+s.scope(t))
+	query.Set("X-Amz-Date", t.Format(s3TimeFormat))
+	query.Set("X-Amz-Expires", fmt.Sprintf("%d", int(expires.Seconds())))
+	query.Set("X-Amz-SignedHeaders", "host")
+	canonicalQuery := canonicalQueryString(query)
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		u.EscapedPath(),
+		canonicalQuery,
+		"host:" + u.Host + "\n",
+		"host",
+		s3UnsignedPayload,
+	}, "\n")
+
+	return fmt.Sprintf("%s://%s%s?%s&X-Amz-Signature=%s", u.Scheme, u.Host, u.EscapedPath(), canonicalQuery, s.sign(t, canonicalRequest)), nil
+}
+
+// PresignPost generates a presigned POST policy for uploading objects, whose keys start with the prefix
+func (s *S3) PresignPost(prefix string, expires time.Duration) (*PostPolicy, error) {
+	u, err := s.bucketURL()
+	if err != nil {
+		return nil, err
+	}
+
+	t := s.currentTime()
+	credential := s.AccessKey + "/" + s.scope(t)
+	var conditions []interface{}
+	if s.Bucket != "" {
+		conditions = append(conditions, map[string]string{"bucket": s.Bucket})
+	}
+	conditions = append(conditions,
+		[]string{"starts-with", "$key", prefix},
+		map[string]string{"x-amz-algorithm": s3Algorithm},
+		map[string]string{"x-amz-credential": credential},
+		map[string]string{"x-amz-date": t.Format(s3TimeFormat)},
+	)
+	policy, err := json.Marshal(map[string]interface{}{
+		"expiration": t.Add(expires).Format(s3PolicyExpirationFormat),
+		"conditions": conditions,
+	})
+	if err != nil {
+		return nil, err
+	}
+	encodedPolicy := base64.StdEncoding.EncodeToString(policy)
+
+	return &PostPolicy{
+		URL: u.String(),
+		Fields: map[string]string{
+			PostFieldAlgorithm:  s3Algorithm,
+			PostFieldCredential: credential,
+			PostFieldDate:       t.Format(s3TimeFormat),
+			PostFieldPolicy:     encodedPolicy,
+			PostFieldSignature:  hex.EncodeToString(hmacSHA256(s.signingKey(t), encodedPolicy)),
+		},
+	}, nil
+}
+
+// List lists the keys of the objects, whose keys start with the prefix
+func (s *S3) List(prefix string) ([]string, error) {
+	u, err := s.bucketURL()
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	continuationToken := ""
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		query.Set("prefix", prefix)
+		if continuationToken != "" {
+			query.Set("continuation-token", continuationToken)
+		}
+		u.RawQuery = canonicalQueryString(query)
+
+		resp, err := s.do(http.MethodGet, u)
+		if err != nil {
+			return nil, err
+		}
+		result := &listBucketResult{}
+		err = xml.NewDecoder(resp.Body).Decode(result)
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("cannot list objects %s, status code: %d", prefix, resp.StatusCode)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range result.Contents {
+			keys = append(keys, c.Key)
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return keys, nil
+		}
+		continuationToken = result.NextContinuationToken
+	}
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// Delete deletes the object
+func (s *S3) Delete(key string) error {
+	u, err := s.objectURL(key)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.do(http.MethodDelete, u)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("cannot delete object %s, status code: %d", key, resp.StatusCode)
+	}
+	return nil
+}
+
+// do sends a request without a body, signed in the authorization header. The query should be canonical
+func (s *S3) do(method string, u *url.URL) (*http.Response, error) {
+	t := s.currentTime()
+	payloadHash := hashHex("")
+	canonicalRequest := strings.Join([]string{
+		method,
+		u.EscapedPath(),
+		u.RawQuery,
+		"host:" + u.Host + "\nx-amz-content-sha256:" + payloadHash + "\nx-amz-date:" + t.Format(s3TimeFormat) + "\n",
+		"host;x-amz-content-sha256;x-amz-date",
+		payloadHash,
+	}, "\n")
+
+	req, err := http.NewRequest(method, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+	req.Header.Set("x-amz-date", t.Format(s3TimeFormat))
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=%s",
+		s3Algorithm, s.AccessKey, s.scope(t), s.sign(t, canonicalRequest)))
+
+	httpClient := s.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return httpClient.Do(req)
+}
+
+// bucketURL returns the url of the bucket
+func (s *S3) bucketURL() (*url.URL, error) {
+	u, err := s.objectURL("")
+	if err != nil {
+		return nil, err
+	}
+	if u.Path != "/" {
+		u.Path = strings.TrimSuffix(u.Path, "/")
+		u.RawPath = ""
+	}
+	return u, nil
+}
+
+// objectURL returns the url of the object. If bucket is empty, endpoint is regarded as a virtual-hosted style url
+func (s *S3) objectURL(key string) (*url.URL, error) {
+	u, err := url.Parse(s.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	p := strings.TrimSuffix(u.Path, "/")
+	if s.Bucket != "" {
+		p += "/" + s.Bucket
+	}
+	u.Path = p + "/" + strings.TrimPrefix(key, "/")
+	u.RawPath = p + "/" + escapePath(strings.TrimPrefix(key, "/"))
+	return u, nil
+}
+
+func (s *S3) currentTime() time.Time {
+	if s.now != nil {
+		return s.now().UTC()
+	}
+	return time.Now().UTC()
+}
+
+func (s *S3) scope(t time.Time) string {
+	return strings.Join([]string{t.Format("20060102"), s.Region, s3Service, "aws4_request"}, "/")
+}
+
+func (s *S3) sign(t time.Time, canonicalRequest string) string {
+	stringToSign := strings.Join([]string{s3Algorithm, t.Format(s3TimeFormat), s.scope(t), hashHex(canonicalRequest)}, "\n")
+	return hex.EncodeToString(hmacSHA256(s.signingKey(t), stringToSign))
+}
+
+func (s *S3) signingKey(t time.Time) []byte {
+	key := hmacSHA256([]byte("AWS4"+s.SecretKey), t.Format("20060102"))
+	key = hmacSHA256(key, s.Region)
+	key = hmacSHA256(key, s3Service)
+	return hmacSHA256(key, "aws4_request")
+}
+
+func canonicalQueryString(query url.Values) string {
+	var keys []string
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var pairs []string
+	for _, k := range keys {
+		pairs = append(pairs, escape(k)+"="+escape(query.Get(k)))
+	}
+	return strings.Join(pairs, "&")
+}
+
+// escapePath escapes each segment of the path as specified in the AWS signature version 4
+func escapePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, s := range segments {
+		segments[i] = escape(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	for _, c := range []byte(s) {
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			b.WriteString(fmt.Sprintf("%%%02X", c))
+		}
+	}
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	_, _ = h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func hashHex(data string) string {
+	h := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(h[:])
+}
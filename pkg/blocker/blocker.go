@@ -33,6 +33,7 @@ import (
 const (
 	blockerContext        = "blocker"
 	defaultBlockerMessage = "Not mergeable."
+	mergePoolDescription  = "In merge pool."
 )
 
 // blocker blocks PRs to be merged. TODO - Need a cool name
@@ -42,6 +43,8 @@ const (
 //      (We say the PRs are in 'MergePool')
 //   3. (Merger) Merge PRs in the merge pool with successful commit statuses and no merge conflicts.
 // These three roles run in their own goroutine, periodically.
+// Pool Syncer also receives git events from the webhook server (see event.go) and updates the pools incrementally,
+// so the periodic full sync is only a safety net for the lost events.
 type blocker struct {
 	client client.Client
 	log    logr.Logger
@@ -57,6 +60,15 @@ type blocker struct {
 
 	// statusSynced is a channel from StatusSyncer to Merger, which indicates the completion of status sync
 	statusSynced chan struct{}
+
+	// events is a queue of the events forwarded from the webhook server, consumed by PoolSyncer
+	events chan Event
+
+	// eventToken is a token for authenticating the events. It's loaded when the status server starts
+	eventToken []byte
+
+	// shard specifies which pools are handled by this blocker
+	shard Shard
 }
 
 // New creates a new blocker
@@ -67,10 +79,16 @@ func New(c client.Client) *blocker {
 		lastPoolSync: time.Now(),
 		poolSynced:   make(chan struct{}, 1),
 		statusSynced: make(chan struct{}, 1),
+		events:       make(chan Event, eventQueueSize),
 		Pools:        map[poolKey]*PRPool{},
 	}
 }
 
+// SetShard sets the shard of the blocker. It should be called before the blocker starts
+func (b *blocker) SetShard(shard Shard) {
+	b.shard = shard
+}
+
 // Start executes three main components of the blocker
 func (b *blocker) Start() {
 	go b.loopSyncPRs()
@@ -104,6 +122,68 @@ type PRPool struct {
 	// CurrentBatch is a batch of PRs, waiting for a block-merge.
 	// If it's non-nil, maybe merger is retesting the PRs.
 	CurrentBatch *Batch
+
+	// Front and Removed are the manual changes of the queue, loaded from the MergeQueue's spec.
+	// Front is a list of PR ids moved to the front of the queue, and Removed maps the removed PR ids to their head SHAs
+	Front   []int
+	Removed map[int]string
+
+	// Events are the recent events of the pool (e.g., batch tests, merges, failures), kept only in memory.
+	// Only the latest maxPoolEvents events are kept
+	Events []PoolEvent
+
+	// status is a snapshot of the pool's status for the dashboard, updated under lock by the syncers and the merger.
+	// It's guarded by statusLock, which is never held across git API calls
+	statusLock sync.RWMutex
+	status     poolStatus
+}
+
+// PoolEventType is a type of the pool's event
+type PoolEventType string
+
+// Pool event types
+const (
+	PoolEventBatchStarted   = PoolEventType("BatchStarted")
+	PoolEventBatchSucceeded = PoolEventType("BatchSucceeded")
+	PoolEventBatchFailed    = PoolEventType("BatchFailed")
+	PoolEventBatchDropped   = PoolEventType("BatchDropped")
+	PoolEventMerged         = PoolEventType("Merged")
+	PoolEventMergeFailed    = PoolEventType("MergeFailed")
+	PoolEventKickedOut      = PoolEventType("KickedOut")
+)
+
+const maxPoolEvents = 100
+
+// PoolEvent is an event of the pool, recorded by the merger
+type PoolEvent struct {
+	Type         PoolEventType `json:"type"`
+	Time         time.Time     `json:"time"`
+	PullRequests []int         `json:"pull_requests"`
+	Job          string        `json:"job,omitempty"`
+	Message      string        `json:"message,omitempty"`
+}
+
+// IsBatchEvent checks if the event is about a batch test
+func (e PoolEvent) IsBatchEvent() bool {
+	switch e.Type {
+	case PoolEventBatchStarted, PoolEventBatchSucceeded, PoolEventBatchFailed, PoolEventBatchDropped:
+		return true
+	}
+	return false
+}
+
+// recordEvent records an event of the pool. pool.lock should be held by the caller
+func (p *PRPool) recordEvent(eventType PoolEventType, prs []*PullRequest, job, message string) {
+	p.Events = append(p.Events, PoolEvent{
+		Type:         eventType,
+		Time:         time.Now(),
+		PullRequests: getPRIDs(prs),
+		Job:          job,
+		Message:      message,
+	})
+	if len(p.Events) > maxPoolEvents {
+		p.Events = p.Events[len(p.Events)-maxPoolEvents:]
+	}
 }
 
 // Batch is a batch of PRs, waiting for a block-merge.
@@ -116,15 +196,25 @@ type Batch struct {
 
 	// Processing is an indicator that the batch is under process
 	Processing bool
+
+	// Remaining are the groups of PRs to be tested after PRs, while the batch is being bisected
+	Remaining [][]*PullRequest
 }
 
-// Contains checks if a PR is in the batch
+// Contains checks if a PR is in the batch, including the remaining groups
 func (b *Batch) Contains(id int) bool {
 	for _, pr := range b.PRs {
 		if pr.ID == id {
 			return true
 		}
 	}
+	for _, prs := range b.Remaining {
+		for _, pr := range prs {
+			if pr.ID == id {
+				return true
+			}
+		}
+	}
 	return false
 }
 
@@ -139,11 +229,18 @@ func NewPRPool(ns, name string) *PRPool {
 		PullRequests:   map[int]*PullRequest{},
 		MergePool:      NewMergePool(),
 		NamespacedName: types.NamespacedName{Name: name, Namespace: ns},
+		status: poolStatus{
+			Namespace:    ns,
+			Name:         name,
+			PullRequests: []pullRequestStatus{},
+			BatchHistory: []PoolEvent{},
+			Events:       []PoolEvent{},
+		},
 	}
 }
 
 // MergePool is a pool for PRs.
-// Keys are git.CommitStatusState (same as PullRequest.BlockerStatus) - pr.ID
+// Keys are git.CommitStatusState (same as PullRequest.BlockerStatus, except for the PRs frozen by the merge freeze) - pr.ID
 type MergePool map[git.CommitStatusState]map[int]*PullRequest
 
 // NewMergePool creates a new MergePool
@@ -194,10 +291,31 @@ type PullRequest struct {
 	// blockerCacheDirty specifies if the commit status should be updated
 	blockerCacheDirty bool
 
+	// heldSha and heldDescription keep the PR in the pending pool while its head is heldSha,
+	// e.g., when its branch should be updated manually
+	heldSha         string
+	heldDescription string
+
 	// Statuses stores whole commit statuses of the PR
 	Statuses map[string]git.CommitStatus
 
 	// Commits are the list of commits in the PR
 	// Only set right before merging it, only if mergeConfig's commitTemplate is not empty
 	Commits []git.Commit
+
+	// Reviews are the reviews of the PR
+	// Only set if mergeConfig's query requires approvals (minApprovals or requireCodeOwners)
+	Reviews []git.PullRequestReview
+
+	// ChangedFiles and CodeOwners are the files changed by the PR and the code owners of the base branch
+	// Only set if mergeConfig's query requires approvals of code owners. CodeOwners is nil if there is no CODEOWNERS file
+	ChangedFiles []string
+	CodeOwners   *git.CodeOwners
+
+	// ApprovedTeams are the team owners (org/team) in CodeOwners, which have at least one approver as a member
+	ApprovedTeams map[string]struct{}
+
+	// NonWriters are the approvers without write permission on the repository.
+	// Their approvals are not counted for minApprovals. Only set if mergeConfig's query requires minApprovals
+	NonWriters map[string]struct{}
 }
@@ -27,6 +27,12 @@ import (
 
 // checkConditionsSimple checks labels, approved, author, branch conditions for a PR to be in a merge pool
 func checkConditionsSimple(q cicdv1.MergeQuery, pr *git.PullRequest) (bool, string) {
+	pass, messages := listUnmetConditionsSimple(q, pr)
+	return pass, strings.Join(messages, " ")
+}
+
+// listUnmetConditionsSimple is a checkConditionsSimple, which returns the unmet conditions as a list
+func listUnmetConditionsSimple(q cicdv1.MergeQuery, pr *git.PullRequest) (bool, []string) {
 	var messages []string
 
 	// Check labels
@@ -60,21 +66,22 @@ func checkConditionsSimple(q cicdv1.MergeQuery, pr *git.PullRequest) (bool, stri
 		messages = append(messages, branchCheckMsg)
 	}
 
-	return passLabelChecks && passAuthorCheck && passBranchCheck, strings.Join(messages, " ")
+	return passLabelChecks && passAuthorCheck && passBranchCheck, messages
 }
 
 // checkConditionsFull is a checkConditionsSimple + commit status check + merge conflict check
 // Return: status / removeFromMergePool / description
 func checkConditionsFull(q cicdv1.MergeQuery, pr *PullRequest) (bool, bool, string) {
-	var messages []string
+	pass, remove, messages := listUnmetConditionsFull(q, pr)
+	return pass, remove, strings.Join(messages, " ")
+}
 
+// listUnmetConditionsFull is a checkConditionsFull, which returns the unmet conditions as a list
+func listUnmetConditionsFull(q cicdv1.MergeQuery, pr *PullRequest) (bool, bool, []string) {
 	// Check labels (, approved), branch, author
-	simpleResult, simpleMessage := checkConditionsSimple(q, &pr.PullRequest)
-	if simpleMessage != "" {
-		messages = append(messages, simpleMessage)
-	}
+	simpleResult, messages := listUnmetConditionsSimple(q, &pr.PullRequest)
 	if !simpleResult {
-		return false, true, strings.Join(messages, " ")
+		return false, true, messages
 	}
 
 	// Check merge conflict
@@ -83,13 +90,19 @@ func checkConditionsFull(q cicdv1.MergeQuery, pr *PullRequest) (bool, bool, stri
 		messages = append(messages, "Merge conflicts exist.")
 	}
 
+	// Check approvals
+	passApprovals, approvalsMsg := checkApprovals(q, pr)
+	if approvalsMsg != "" {
+		messages = append(messages, approvalsMsg)
+	}
+
 	// Check commit statuses
 	passCommitStatus, commitStatusMsg := checkChecks(pr.Statuses, q)
 	if commitStatusMsg != "" {
 		messages = append(messages, commitStatusMsg)
 	}
 
-	return simpleResult && passMergeConflict && passCommitStatus, false, strings.Join(messages, " ")
+	return simpleResult && passMergeConflict && passApprovals && passCommitStatus, false, messages
 }
 
 func checkBranch(b string, q cicdv1.MergeQuery) (bool, string) {
@@ -162,6 +175,96 @@ func checkLabels(labels map[string]struct{}, q cicdv1.MergeQuery) (bool, string)
 	return isProperLabels, msg
 }
 
+// checkApprovals checks the number of approvals and the approvals of the code owners
+func checkApprovals(q cicdv1.MergeQuery, pr *PullRequest) (bool, string) {
+	if q.MinApprovals <= 0 && !q.RequireCodeOwners {
+		return true, ""
+	}
+
+	var messages []string
+	approvers := getApprovers(pr)
+
+	// Code owners are user names, emails or teams. Teams are resolved when the PR status is synced
+	approverKeys := map[string]struct{}{}
+	for _, a := range approvers {
+		approverKeys[strings.ToLower(a.Name)] = struct{}{}
+		if a.Email != "" {
+			approverKeys[strings.ToLower(a.Email)] = struct{}{}
+		}
+	}
+	for t := range pr.ApprovedTeams {
+		approverKeys[t] = struct{}{}
+	}
+
+	// Approvals of the users without write permission are not counted
+	numApprovals := 0
+	for _, a := range approvers {
+		if _, nonWriter := pr.NonWriters[a.Name]; !nonWriter {
+			numApprovals++
+		}
+	}
+	passMinApprovals := numApprovals >= q.MinApprovals
+	if !passMinApprovals {
+		messages = append(messages, fmt.Sprintf("Approvals [%d/%d] are not enough.", numApprovals, q.MinApprovals))
+	}
+
+	passCodeOwners := true
+	if q.RequireCodeOwners && pr.CodeOwners != nil {
+		missing := map[string]struct{}{}
+		for _, f := range pr.ChangedFiles {
+			owners := pr.CodeOwners.Owners(f)
+			approved := len(owners) == 0 // Files without owners don't need any approval
+			for _, o := range owners {
+				if _, exist := approverKeys[strings.ToLower(o)]; exist {
+					approved = true
+					break
+				}
+			}
+			if !approved {
+				passCodeOwners = false
+				for _, o := range owners {
+					missing[o] = struct{}{}
+				}
+			}
+		}
+		if !passCodeOwners {
+			var owners []string
+			for o := range missing {
+				owners = append(owners, o)
+			}
+			sort.Strings(owners)
+			messages = append(messages, fmt.Sprintf("Approval of code owners [%s] is required.", strings.Join(owners, ",")))
+		}
+	}
+
+	return passMinApprovals && passCodeOwners, strings.Join(messages, " ")
+}
+
+// getApprovers returns the reviewers whose latest review is an approval, except for the author
+func getApprovers(pr *PullRequest) []git.User {
+	latest := map[string]git.PullRequestReview{}
+	var names []string
+	for _, r := range pr.Reviews {
+		// Comments don't change the approval state
+		if r.State == git.PullRequestReviewStateCommented {
+			continue
+		}
+		if _, exist := latest[r.Author.Name]; !exist {
+			names = append(names, r.Author.Name)
+		}
+		latest[r.Author.Name] = r
+	}
+
+	var approvers []git.User
+	for _, name := range names {
+		if latest[name].State != git.PullRequestReviewStateApproved || name == pr.Author.Name {
+			continue
+		}
+		approvers = append(approvers, latest[name].Author)
+	}
+	return approvers
+}
+
 func checkChecks(statuses map[string]git.CommitStatus, q cicdv1.MergeQuery) (bool, string) {
 	var unmetChecks []string
 	passAllRequiredChecks := true
@@ -202,6 +305,27 @@ func checkChecks(statuses map[string]git.CommitStatus, q cicdv1.MergeQuery) (boo
 	return passAllRequiredChecks, msg
 }
 
+// listPendingChecks lists the checks which are not completed yet. Required checks which are not reported yet are also pending
+func listPendingChecks(statuses map[string]git.CommitStatus, q cicdv1.MergeQuery) []string {
+	var pending []string
+	if len(q.Checks) > 0 {
+		for _, c := range q.Checks {
+			s, exist := statuses[c]
+			if !exist || s.State == git.CommitStatusStatePending {
+				pending = append(pending, c)
+			}
+		}
+	} else {
+		for context, s := range statuses {
+			if context != blockerContext && s.State == git.CommitStatusStatePending {
+				pending = append(pending, context)
+			}
+		}
+	}
+	sort.Strings(pending)
+	return pending
+}
+
 func containsString(needle string, arr []string) bool {
 	for _, e := range arr {
 		if e == needle {
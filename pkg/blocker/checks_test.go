@@ -540,6 +540,122 @@ func TestCheckChecks(t *testing.T) {
 	}
 }
 
+func TestCheckApprovals(t *testing.T) {
+	codeOwners, err := git.ParseCodeOwners("* @default-owner\n/docs/ @doc-owner doc@tmax.co.kr\n/vendor/\n/api/ @tmax-cloud/API-Owners\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tc := map[string]struct {
+		query        cicdv1.MergeQuery
+		reviews      []git.PullRequestReview
+		changedFiles []string
+		codeOwners   *git.CodeOwners
+		teams        map[string]struct{}
+		nonWriters   map[string]struct{}
+
+		expectedResult  bool
+		expectedMessage string
+	}{
+		"notRequired": {
+			query:          cicdv1.MergeQuery{},
+			expectedResult: true,
+		},
+		"minApprovals": {
+			query: cicdv1.MergeQuery{MinApprovals: 2},
+			reviews: []git.PullRequestReview{
+				{Author: git.User{Name: "reviewer1"}, State: git.PullRequestReviewStateApproved},
+				{Author: git.User{Name: "reviewer2"}, State: git.PullRequestReviewStateApproved},
+				{Author: git.User{Name: "reviewer2"}, State: git.PullRequestReviewStateCommented},
+			},
+			expectedResult: true,
+		},
+		"minApprovalsFail": {
+			query: cicdv1.MergeQuery{MinApprovals: 2},
+			reviews: []git.PullRequestReview{
+				{Author: git.User{Name: "reviewer1"}, State: git.PullRequestReviewStateApproved},
+				{Author: git.User{Name: "reviewer2"}, State: git.PullRequestReviewStateApproved},
+				{Author: git.User{Name: "reviewer2"}, State: git.PullRequestReviewStateUnapproved},
+				{Author: git.User{Name: "author"}, State: git.PullRequestReviewStateApproved},
+			},
+			expectedResult:  false,
+			expectedMessage: "Approvals [1/2] are not enough.",
+		},
+		"minApprovalsNonWriter": {
+			query: cicdv1.MergeQuery{MinApprovals: 2},
+			reviews: []git.PullRequestReview{
+				{Author: git.User{Name: "reviewer1"}, State: git.PullRequestReviewStateApproved},
+				{Author: git.User{Name: "outsider"}, State: git.PullRequestReviewStateApproved},
+			},
+			nonWriters:      map[string]struct{}{"outsider": {}},
+			expectedResult:  false,
+			expectedMessage: "Approvals [1/2] are not enough.",
+		},
+		"codeOwners": {
+			query: cicdv1.MergeQuery{RequireCodeOwners: true},
+			reviews: []git.PullRequestReview{
+				{Author: git.User{Name: "Default-Owner"}, State: git.PullRequestReviewStateApproved},
+				{Author: git.User{Name: "reviewer", Email: "doc@tmax.co.kr"}, State: git.PullRequestReviewStateApproved},
+			},
+			changedFiles:   []string{"main.go", "docs/README.md", "vendor/a.go"},
+			codeOwners:     codeOwners,
+			expectedResult: true,
+		},
+		"codeOwnersFail": {
+			query: cicdv1.MergeQuery{RequireCodeOwners: true, MinApprovals: 2},
+			reviews: []git.PullRequestReview{
+				{Author: git.User{Name: "default-owner"}, State: git.PullRequestReviewStateApproved},
+			},
+			changedFiles:    []string{"main.go", "docs/README.md"},
+			codeOwners:      codeOwners,
+			expectedResult:  false,
+			expectedMessage: "Approvals [1/2] are not enough. Approval of code owners [doc-owner,doc@tmax.co.kr] is required.",
+		},
+		"codeOwnersTeam": {
+			query: cicdv1.MergeQuery{RequireCodeOwners: true},
+			reviews: []git.PullRequestReview{
+				{Author: git.User{Name: "team-member"}, State: git.PullRequestReviewStateApproved},
+			},
+			changedFiles:   []string{"api/types.go"},
+			codeOwners:     codeOwners,
+			teams:          map[string]struct{}{"tmax-cloud/api-owners": {}},
+			expectedResult: true,
+		},
+		"codeOwnersTeamFail": {
+			query: cicdv1.MergeQuery{RequireCodeOwners: true},
+			reviews: []git.PullRequestReview{
+				{Author: git.User{Name: "reviewer"}, State: git.PullRequestReviewStateApproved},
+			},
+			changedFiles:    []string{"api/types.go"},
+			codeOwners:      codeOwners,
+			expectedResult:  false,
+			expectedMessage: "Approval of code owners [tmax-cloud/API-Owners] is required.",
+		},
+		"noCodeOwnersFile": {
+			query:          cicdv1.MergeQuery{RequireCodeOwners: true},
+			changedFiles:   []string{"main.go"},
+			expectedResult: true,
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			pr := &PullRequest{
+				PullRequest:   git.PullRequest{Author: git.User{Name: "author"}},
+				Reviews:       c.reviews,
+				ChangedFiles:  c.changedFiles,
+				CodeOwners:    c.codeOwners,
+				ApprovedTeams: c.teams,
+				NonWriters:    c.nonWriters,
+			}
+			result, msg := checkApprovals(c.query, pr)
+
+			assert.Equal(t, c.expectedResult, result, "Result")
+			assert.Equal(t, c.expectedMessage, msg, "Message")
+		})
+	}
+}
+
 func checkTestConfig() (*cicdv1.IntegrationConfig, *PullRequest) {
 	ic := &cicdv1.IntegrationConfig{
 		ObjectMeta: metav1.ObjectMeta{
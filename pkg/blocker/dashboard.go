@@ -0,0 +1,295 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package blocker
+
+import (
+	"html/template"
+	"net/http"
+	"sort"
+
+	"github.com/gorilla/mux"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/utils"
+	"github.com/tmax-cloud/cicd-operator/pkg/git"
+)
+
+// dashboard.go contains the blocker's dashboard, which shows the detailed status of the PR pools.
+// The dashboard is served in HTML (/dashboard) and in JSON (/pools), and can be filtered by namespace and config (name)
+// of the IntegrationConfig, e.g., /dashboard?namespace=default&config=my-config
+
+const (
+	dashboardParamNamespace = "namespace"
+	dashboardParamConfig    = "config"
+)
+
+// poolStatus is a detailed status of a PR pool
+type poolStatus struct {
+	Key       string `json:"key"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+
+	PullRequests []pullRequestStatus     `json:"pull_requests"`
+	CurrentBatch *cicdv1.MergeQueueBatch `json:"current_batch,omitempty"`
+
+	// BatchHistory is the recent events of the batch tests, and Events is the recent merge/failure events.
+	// Both are sorted from the newest one
+	BatchHistory []PoolEvent `json:"batch_history"`
+	Events       []PoolEvent `json:"events"`
+}
+
+// pullRequestStatus is a status of a PR in a pool
+type pullRequestStatus struct {
+	ID         int    `json:"id"`
+	Title      string `json:"title"`
+	URL        string `json:"url"`
+	Author     string `json:"author"`
+	BaseBranch string `json:"base_branch"`
+	Sha        string `json:"sha"`
+
+	// Position is the position in the merge queue. It's 0 if the PR is not in the merge pool
+	Position    int                   `json:"position,omitempty"`
+	Status      git.CommitStatusState `json:"status"`
+	Description string                `json:"description"`
+
+	// UnmetConditions are the conditions of the MergeQuery, which the PR does not meet
+	UnmetConditions []string `json:"unmet_conditions,omitempty"`
+	PendingChecks   []string `json:"pending_checks,omitempty"`
+}
+
+func (b *blocker) handlePoolList(w http.ResponseWriter, req *http.Request) {
+	_ = utils.RespondJSON(w, b.generatePoolStatuses(req.URL.Query().Get(dashboardParamNamespace), req.URL.Query().Get(dashboardParamConfig)))
+}
+
+func (b *blocker) handlePool(w http.ResponseWriter, req *http.Request) {
+	vars := mux.Vars(req)
+	statuses := b.generatePoolStatuses(vars[dashboardParamNamespace], vars[dashboardParamConfig])
+	if len(statuses) == 0 {
+		_ = utils.RespondError(w, http.StatusNotFound, "there is no pr pool for "+vars[dashboardParamNamespace]+"/"+vars[dashboardParamConfig])
+		return
+	}
+	_ = utils.RespondJSON(w, statuses[0])
+}
+
+func (b *blocker) handleDashboard(w http.ResponseWriter, req *http.Request) {
+	namespace := req.URL.Query().Get(dashboardParamNamespace)
+	config := req.URL.Query().Get(dashboardParamConfig)
+	b.renderDashboard(w, dashboardPage{Namespace: namespace, Config: config, Pools: b.generatePoolStatuses(namespace, config)})
+}
+
+func (b *blocker) handleDashboardPool(w http.ResponseWriter, req *http.Request) {
+	vars := mux.Vars(req)
+	statuses := b.generatePoolStatuses(vars[dashboardParamNamespace], vars[dashboardParamConfig])
+	if len(statuses) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+	}
+	b.renderDashboard(w, dashboardPage{Namespace: vars[dashboardParamNamespace], Config: vars[dashboardParamConfig], Pools: statuses})
+}
+
+type dashboardPage struct {
+	Namespace string
+	Config    string
+	Pools     []poolStatus
+}
+
+func (b *blocker) renderDashboard(w http.ResponseWriter, page dashboardPage) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := dashboardTemplate.Execute(w, page); err != nil {
+		b.log.Error(err, "")
+	}
+}
+
+// generatePoolStatuses generates the statuses of the pools, filtered by the namespace and the name of the IntegrationConfig.
+// Empty filter matches all the pools
+func (b *blocker) generatePoolStatuses(namespace, name string) []poolStatus {
+	statuses := []poolStatus{}
+	for key, pool := range b.Pools {
+		if (namespace != "" && pool.Namespace != namespace) || (name != "" && pool.Name != name) {
+			continue
+		}
+		statuses = append(statuses, pool.getStatus(key))
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Namespace != statuses[j].Namespace {
+			return statuses[i].Namespace < statuses[j].Namespace
+		}
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
+
+// generatePoolStatus generates the detailed status of the pool. Key is not set, as the pool does not know its key.
+// pool.lock should be held by the caller
+func generatePoolStatus(pool *PRPool, ic *cicdv1.IntegrationConfig) poolStatus {
+	// Query is needed to list the unmet conditions
+	var query *cicdv1.MergeQuery
+	if ic.Spec.MergeConfig != nil {
+		query = &ic.Spec.MergeConfig.Query
+	}
+
+	queue := generateMergeQueueStatus(pool)
+	positions := map[int]int{}
+	for _, pr := range queue.PullRequests {
+		positions[pr.ID] = pr.Position
+	}
+
+	status := poolStatus{
+		Namespace:    pool.Namespace,
+		Name:         pool.Name,
+		PullRequests: []pullRequestStatus{},
+		CurrentBatch: queue.CurrentBatch,
+		BatchHistory: []PoolEvent{},
+		Events:       []PoolEvent{},
+	}
+
+	for _, pr := range sortPullRequestByID(pool.PullRequests) {
+		prStatus := pullRequestStatus{
+			ID:          pr.ID,
+			Title:       pr.Title,
+			URL:         pr.URL,
+			Author:      pr.Author.Name,
+			BaseBranch:  cicdv1.GitRef(pr.Base.Ref).GetBranch(),
+			Sha:         pr.Head.Sha,
+			Position:    positions[pr.ID],
+			Status:      pr.BlockerStatus,
+			Description: pr.BlockerDescription,
+		}
+		if query != nil {
+			// Commit statuses and reviews are only synced for the PRs in the merge pool
+			if prStatus.Position > 0 {
+				_, _, prStatus.UnmetConditions = listUnmetConditionsFull(*query, pr)
+				prStatus.PendingChecks = listPendingChecks(pr.Statuses, *query)
+			} else {
+				_, prStatus.UnmetConditions = listUnmetConditionsSimple(*query, &pr.PullRequest)
+			}
+		}
+		status.PullRequests = append(status.PullRequests, prStatus)
+	}
+
+	for i := len(pool.Events) - 1; i >= 0; i-- {
+		if pool.Events[i].IsBatchEvent() {
+			status.BatchHistory = append(status.BatchHistory, pool.Events[i])
+		} else {
+			status.Events = append(status.Events, pool.Events[i])
+		}
+	}
+
+	return status
+}
+
+// updateStatus stores a snapshot of the pool's status for the dashboard, so that the dashboard does not wait for
+// pool.lock, which is held across the merger's retests and sleeps. pool.lock should be held by the caller
+func (p *PRPool) updateStatus(ic *cicdv1.IntegrationConfig) {
+	status := generatePoolStatus(p, ic)
+
+	p.statusLock.Lock()
+	defer p.statusLock.Unlock()
+	p.status = status
+}
+
+// getStatus returns the latest snapshot of the pool's status
+func (p *PRPool) getStatus(key poolKey) poolStatus {
+	p.statusLock.RLock()
+	defer p.statusLock.RUnlock()
+
+	status := p.status
+	status.Key = string(key)
+	return status
+}
+
+var dashboardTemplate = template.Must(template.New("dashboard").Parse(`<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <title>Blocker Dashboard</title>
+  <style>
+    body { font-family: sans-serif; margin: 2em; color: #24292e; }
+    table { border-collapse: collapse; width: 100%; margin-bottom: 1.5em; }
+    th, td { border: 1px solid #d1d5da; padding: 4px 8px; text-align: left; vertical-align: top; font-size: 14px; }
+    th { background: #f6f8fa; }
+    .success { color: #22863a; }
+    .pending { color: #b08800; }
+    .empty { color: #6a737d; }
+    ul { margin: 0; padding-left: 1.2em; }
+  </style>
+</head>
+<body>
+<h1><a href="/dashboard">Blocker Dashboard</a></h1>
+<form method="get" action="/dashboard">
+  Namespace <input type="text" name="namespace" value="{{ .Namespace }}">
+  Config <input type="text" name="config" value="{{ .Config }}">
+  <input type="submit" value="Filter">
+</form>
+{{- range .Pools }}
+<h2><a href="/dashboard/{{ .Namespace }}/{{ .Name }}">{{ .Namespace }}/{{ .Name }}</a> <small>({{ .Key }}, <a href="/pools/{{ .Namespace }}/{{ .Name }}">json</a>)</small></h2>
+
+<h3>Pull Requests</h3>
+{{- if .PullRequests }}
+<table>
+  <tr><th>#</th><th>Title</th><th>Author</th><th>Base</th><th>Queue</th><th>Status</th><th>Unmet Conditions</th><th>Pending Checks</th></tr>
+  {{- range .PullRequests }}
+  <tr>
+    <td><a href="{{ .URL }}">{{ .ID }}</a></td>
+    <td>{{ .Title }}</td>
+    <td>{{ .Author }}</td>
+    <td>{{ .BaseBranch }}</td>
+    <td>{{ if .Position }}{{ .Position }}{{ else }}-{{ end }}</td>
+    <td class="{{ .Status }}">{{ .Status }}<br>{{ .Description }}</td>
+    <td><ul>{{ range .UnmetConditions }}<li>{{ . }}</li>{{ end }}</ul></td>
+    <td><ul>{{ range .PendingChecks }}<li>{{ . }}</li>{{ end }}</ul></td>
+  </tr>
+  {{- end }}
+</table>
+{{- else }}
+<p class="empty">No open pull requests.</p>
+{{- end }}
+
+<h3>Current Batch</h3>
+{{- with .CurrentBatch }}
+<p>Pull requests {{ .PullRequests }} are being tested by IntegrationJob <code>{{ .IntegrationJob }}</code>.{{ if .Remaining }} Remaining groups: {{ .Remaining }}{{ end }}</p>
+{{- else }}
+<p class="empty">No batch is being tested.</p>
+{{- end }}
+
+<h3>Batch History</h3>
+{{- if .BatchHistory }}
+<table>
+  <tr><th>Time</th><th>Event</th><th>Pull Requests</th><th>IntegrationJob</th><th>Message</th></tr>
+  {{- range .BatchHistory }}
+  <tr><td>{{ .Time.Format "2006-01-02 15:04:05" }}</td><td>{{ .Type }}</td><td>{{ .PullRequests }}</td><td>{{ .Job }}</td><td>{{ .Message }}</td></tr>
+  {{- end }}
+</table>
+{{- else }}
+<p class="empty">No batch tests yet.</p>
+{{- end }}
+
+<h3>Recent Events</h3>
+{{- if .Events }}
+<table>
+  <tr><th>Time</th><th>Event</th><th>Pull Requests</th><th>IntegrationJob</th><th>Message</th></tr>
+  {{- range .Events }}
+  <tr><td>{{ .Time.Format "2006-01-02 15:04:05" }}</td><td>{{ .Type }}</td><td>{{ .PullRequests }}</td><td>{{ .Job }}</td><td>{{ .Message }}</td></tr>
+  {{- end }}
+</table>
+{{- else }}
+<p class="empty">No merges or failures yet.</p>
+{{- end }}
+{{- else }}
+<p class="empty">No pools found.</p>
+{{- end }}
+</body>
+</html>
+`))
@@ -0,0 +1,188 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package blocker
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmax-cloud/cicd-operator/pkg/git"
+)
+
+func TestBlocker_dashboard(t *testing.T) {
+	ic, cli := mergeTestConfig()
+	b := New(cli)
+
+	pool := NewPRPool(testICNamespace, testICName)
+	b.Pools["api.github.com/tmax-cloud/cicd-operator"] = pool
+	b.Pools["api.github.com/tmax-cloud/other"] = NewPRPool("other-ns", "other")
+
+	inPool := &PullRequest{
+		PullRequest: git.PullRequest{
+			ID:        1,
+			Title:     "in-pool",
+			Base:      git.Base{Ref: "refs/heads/master"},
+			Labels:    []git.IssueLabel{{Name: "approved"}},
+			Mergeable: true,
+		},
+		BlockerStatus:      git.CommitStatusStatePending,
+		BlockerDescription: "Checks [test-1] are not successful.",
+		Statuses:           map[string]git.CommitStatus{"test-1": {Context: "test-1", State: git.CommitStatusStatePending}},
+	}
+	notInPool := &PullRequest{
+		PullRequest: git.PullRequest{
+			ID:    2,
+			Title: "not-in-pool",
+			Base:  git.Base{Ref: "master"},
+		},
+		BlockerStatus:      git.CommitStatusStatePending,
+		BlockerDescription: "Not mergeable. Label [approved] is required.",
+	}
+	pool.PullRequests[1] = inPool
+	pool.PullRequests[2] = notInPool
+	pool.MergePool.Add(inPool)
+
+	pool.recordEvent(PoolEventBatchStarted, []*PullRequest{inPool}, "batch-1", "")
+	pool.recordEvent(PoolEventBatchFailed, []*PullRequest{inPool}, "batch-1", "")
+	pool.recordEvent(PoolEventKickedOut, []*PullRequest{inPool}, "batch-1", "Failed the batch test.")
+	pool.updateStatus(ic)
+
+	srv := httptest.NewServer(b.newRouter())
+	defer srv.Close()
+
+	t.Run("jsonList", func(t *testing.T) {
+		var result []poolStatus
+		code := getJSON(t, fmt.Sprintf("%s/pools", srv.URL), &result)
+		require.Equal(t, http.StatusOK, code)
+		require.Len(t, result, 2)
+		require.Equal(t, testICNamespace, result[0].Namespace)
+		require.Equal(t, "other-ns", result[1].Namespace)
+	})
+
+	t.Run("jsonListFiltered", func(t *testing.T) {
+		var result []poolStatus
+		code := getJSON(t, fmt.Sprintf("%s/pools?namespace=%s&config=%s", srv.URL, testICNamespace, testICName), &result)
+		require.Equal(t, http.StatusOK, code)
+		require.Len(t, result, 1)
+		require.Equal(t, testICName, result[0].Name)
+
+		code = getJSON(t, fmt.Sprintf("%s/pools?config=unknown", srv.URL), &result)
+		require.Equal(t, http.StatusOK, code)
+		require.Empty(t, result)
+	})
+
+	t.Run("jsonPool", func(t *testing.T) {
+		result := poolStatus{}
+		code := getJSON(t, fmt.Sprintf("%s/pools/%s/%s", srv.URL, testICNamespace, testICName), &result)
+		require.Equal(t, http.StatusOK, code)
+
+		require.Len(t, result.PullRequests, 2)
+		require.Equal(t, 1, result.PullRequests[0].Position)
+		require.Equal(t, "master", result.PullRequests[0].BaseBranch)
+		require.Equal(t, []string{"Checks [test-1] are not successful."}, result.PullRequests[0].UnmetConditions)
+		require.Equal(t, []string{"test-1"}, result.PullRequests[0].PendingChecks)
+		require.Equal(t, 0, result.PullRequests[1].Position)
+		require.Equal(t, []string{"Label [approved] is required."}, result.PullRequests[1].UnmetConditions)
+		require.Empty(t, result.PullRequests[1].PendingChecks)
+
+		require.Len(t, result.BatchHistory, 2)
+		require.Equal(t, PoolEventBatchFailed, result.BatchHistory[0].Type)
+		require.Equal(t, PoolEventBatchStarted, result.BatchHistory[1].Type)
+		require.Len(t, result.Events, 1)
+		require.Equal(t, PoolEventKickedOut, result.Events[0].Type)
+		require.Equal(t, []int{1}, result.Events[0].PullRequests)
+	})
+
+	t.Run("jsonPoolNotFound", func(t *testing.T) {
+		result := poolStatus{}
+		code := getJSON(t, fmt.Sprintf("%s/pools/%s/unknown", srv.URL, testICNamespace), &result)
+		require.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("html", func(t *testing.T) {
+		resp, err := http.Get(fmt.Sprintf("%s/dashboard?namespace=%s", srv.URL, testICNamespace))
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		body, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Contains(t, resp.Header.Get("Content-Type"), "text/html")
+		require.Contains(t, string(body), fmt.Sprintf("%s/%s", testICNamespace, testICName))
+		require.NotContains(t, string(body), "other-ns/other")
+		require.Contains(t, string(body), "Label [approved] is required.")
+		require.Contains(t, string(body), "KickedOut")
+	})
+
+	t.Run("snapshotWhileLocked", func(t *testing.T) {
+		// The dashboard should be served while the merger holds the pool's lock
+		pool.lock.Lock()
+		defer pool.lock.Unlock()
+
+		result := poolStatus{}
+		code := getJSON(t, fmt.Sprintf("%s/pools/%s/%s", srv.URL, testICNamespace, testICName), &result)
+		require.Equal(t, http.StatusOK, code)
+		require.Len(t, result.PullRequests, 2)
+		require.Equal(t, "api.github.com/tmax-cloud/cicd-operator", result.Key)
+
+		// Snapshot is not changed until it's updated
+		pool.PullRequests[3] = &PullRequest{PullRequest: git.PullRequest{ID: 3, Title: "new"}}
+		code = getJSON(t, fmt.Sprintf("%s/pools/%s/%s", srv.URL, testICNamespace, testICName), &result)
+		require.Equal(t, http.StatusOK, code)
+		require.Len(t, result.PullRequests, 2)
+
+		pool.updateStatus(ic)
+		code = getJSON(t, fmt.Sprintf("%s/pools/%s/%s", srv.URL, testICNamespace, testICName), &result)
+		require.Equal(t, http.StatusOK, code)
+		require.Len(t, result.PullRequests, 3)
+		delete(pool.PullRequests, 3)
+		pool.updateStatus(ic)
+	})
+
+	t.Run("htmlPoolNotFound", func(t *testing.T) {
+		resp, err := http.Get(fmt.Sprintf("%s/dashboard/%s/unknown", srv.URL, testICNamespace))
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestPRPool_recordEvent(t *testing.T) {
+	pool := NewPRPool(testICNamespace, testICName)
+	for i := 0; i < maxPoolEvents+10; i++ {
+		pool.recordEvent(PoolEventMerged, []*PullRequest{{PullRequest: git.PullRequest{ID: i}}}, "", "")
+	}
+	require.Len(t, pool.Events, maxPoolEvents)
+	require.Equal(t, []int{10}, pool.Events[0].PullRequests)
+	require.Equal(t, []int{maxPoolEvents + 9}, pool.Events[maxPoolEvents-1].PullRequests)
+}
+
+func getJSON(t *testing.T, url string, result interface{}) int {
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusOK {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(result))
+	}
+	return resp.StatusCode
+}
@@ -0,0 +1,373 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package blocker
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/utils"
+	"github.com/tmax-cloud/cicd-operator/pkg/git"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// event.go contains an event endpoint of the blocker and a webhook plugin which forwards git events to it.
+// For each event, the blocker syncs only the PRs related to the event, instead of listing all the open PRs.
+// Events are authenticated by a token in the eventSecretName secret, which is shared by the blocker and the forwarders.
+
+const (
+	// EventPath is a path of the blocker's event endpoint
+	EventPath = "/events"
+
+	// eventServiceName is a name of the headless service for the blockers' event endpoints
+	eventServiceName = "blocker-events"
+
+	// eventSecretName is a name of the secret containing the token of the event endpoint
+	eventSecretName = "blocker-event-token"
+	eventSecretKey  = "token"
+
+	eventQueueSize = 100
+)
+
+// Event is an event forwarded from the webhook server to the blocker
+type Event struct {
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	PullRequest int    `json:"pullRequest,omitempty"`
+	Sha         string `json:"sha,omitempty"`
+}
+
+func (b *blocker) handleEvent(w http.ResponseWriter, req *http.Request) {
+	if !isEventAuthorized(req, b.eventToken) {
+		_ = utils.RespondError(w, http.StatusUnauthorized, "invalid event token")
+		return
+	}
+
+	ev := Event{}
+	if err := json.NewDecoder(req.Body).Decode(&ev); err != nil {
+		_ = utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	select {
+	case b.events <- ev:
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		// The event is dropped, but it'll be covered by the next full sync
+		_ = utils.RespondError(w, http.StatusServiceUnavailable, "event queue is full")
+	}
+}
+
+// isEventAuthorized checks if the request has the bearer token of the event endpoint
+func isEventAuthorized(req *http.Request, token []byte) bool {
+	if len(token) == 0 {
+		return false
+	}
+	expected := []byte("Bearer " + string(token))
+	return subtle.ConstantTimeCompare([]byte(req.Header.Get("Authorization")), expected) == 1
+}
+
+// getEventToken gets the token of the event endpoint from the eventSecretName secret
+func getEventToken(cli client.Client) ([]byte, error) {
+	secret := &corev1.Secret{}
+	if err := cli.Get(context.Background(), types.NamespacedName{Name: eventSecretName, Namespace: utils.Namespace()}, secret); err != nil {
+		return nil, err
+	}
+
+	token, exist := secret.Data[eventSecretKey]
+	if !exist || len(token) == 0 {
+		return nil, fmt.Errorf("secret %s should have key %s", eventSecretName, eventSecretKey)
+	}
+	return token, nil
+}
+
+// ensureEventToken gets the token of the event endpoint, creating the secret with a random token if it doesn't exist
+func ensureEventToken(cli client.Client) ([]byte, error) {
+	token, err := getEventToken(cli)
+	if err == nil || !errors.IsNotFound(err) {
+		return token, err
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: eventSecretName, Namespace: utils.Namespace()},
+		Data:       map[string][]byte{eventSecretKey: []byte(hex.EncodeToString(raw))},
+	}
+	if err := cli.Create(context.Background(), secret); err != nil {
+		// Other blocker may have created it
+		if errors.IsAlreadyExists(err) {
+			return getEventToken(cli)
+		}
+		return nil, err
+	}
+	return secret.Data[eventSecretKey], nil
+}
+
+// syncEvent syncs the PRs related to the event, and reports their commit statuses
+func (b *blocker) syncEvent(ev Event) {
+	log := b.log.WithName("event").WithValues("ic", types.NamespacedName{Namespace: ev.Namespace, Name: ev.Name})
+
+	ic := &cicdv1.IntegrationConfig{}
+	if err := b.client.Get(context.Background(), types.NamespacedName{Namespace: ev.Namespace, Name: ev.Name}, ic); err != nil {
+		log.Error(err, "")
+		return
+	}
+	// Skip if token is nil or merge automation is not activated
+	if ic.Spec.Git.Token == nil || ic.Spec.MergeConfig == nil {
+		return
+	}
+	// Skip if the pool belongs to another shard
+	key := genPoolKey(ic)
+	if !b.shard.owns(key) {
+		return
+	}
+
+	gitCli, err := utils.GetGitCli(ic, b.client)
+	if err != nil {
+		log.Error(err, "")
+		return
+	}
+
+	pool, exist := b.Pools[key]
+	if exist {
+		b.syncEventPRs(pool, ic, ev, gitCli)
+	} else {
+		// The pool is not initialized yet (e.g., a new IntegrationConfig). Sync the whole pool
+		b.syncOnePool(ic)
+		pool, exist = b.Pools[key]
+		if !exist {
+			return
+		}
+		b.syncOneMergePoolStatus(pool, ic, gitCli)
+	}
+
+	b.reportCommitStatus(pool, ic, gitCli)
+
+	// Notify that a sync is done
+	if len(b.statusSynced) < cap(b.statusSynced) {
+		b.statusSynced <- struct{}{}
+	}
+}
+
+func (b *blocker) syncEventPRs(pool *PRPool, ic *cicdv1.IntegrationConfig, ev Event, gitCli git.Client) {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+	defer pool.updateStatus(ic)
+
+	log := b.log.WithName("event").WithValues("repo", genPoolKey(ic))
+
+	if err := b.loadMergeQueueSpec(pool); err != nil {
+		log.Error(err, "")
+	}
+
+	// Find PRs related to the event
+	ids := map[int]struct{}{}
+	if ev.PullRequest != 0 {
+		ids[ev.PullRequest] = struct{}{}
+	}
+	if ev.Sha != "" {
+		for id, pr := range pool.PullRequests {
+			if pr.Head.Sha == ev.Sha {
+				ids[id] = struct{}{}
+			}
+		}
+	}
+
+	permissions := writePermissions{}
+	for id := range ids {
+		rawPR, err := gitCli.GetPullRequest(id)
+		if err != nil {
+			log.Error(err, "")
+			continue
+		}
+
+		// Delete closed/merged PR
+		if rawPR.State != git.PullRequestStateOpen {
+			pool.MergePool.Delete(id)
+			delete(pool.PullRequests, id)
+			continue
+		}
+
+		pr := b.syncOnePR(pool, ic, *rawPR)
+
+		// Sync status if it's in the merge pool
+		for status, prs := range pool.MergePool {
+			if _, inPool := prs[id]; inPool {
+				b.syncOnePRStatus(pool, ic, pr, status, gitCli, permissions)
+				break
+			}
+		}
+	}
+	linkBatch(pool)
+
+	if err := b.saveMergeQueue(pool, ic); err != nil {
+		log.Error(err, "")
+	}
+}
+
+// EventForwarder is a webhook plugin, which forwards git events to the blockers.
+// Events are queued and forwarded in background, not to block the webhook server
+type EventForwarder struct {
+	client client.Client
+	log    logr.Logger
+
+	// lookupHost resolves addresses of the blockers. It's net.LookupHost by default
+	lookupHost func(host string) ([]string, error)
+	httpClient *http.Client
+	port       int
+
+	queue chan Event
+}
+
+// NewEventForwarder creates a new EventForwarder and starts forwarding the queued events
+func NewEventForwarder(cli client.Client) *EventForwarder {
+	f := newEventForwarder(cli)
+	go f.run()
+	return f
+}
+
+func newEventForwarder(cli client.Client) *EventForwarder {
+	return &EventForwarder{
+		client:     cli,
+		log:        logf.Log.WithName("blocker-event-forwarder"),
+		lookupHost: net.LookupHost,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		port:       StatusPort,
+		queue:      make(chan Event, eventQueueSize),
+	}
+}
+
+func (f *EventForwarder) run() {
+	for ev := range f.queue {
+		if err := f.Forward(ev); err != nil {
+			// The event is lost, but it'll be covered by the next full sync
+			f.log.Error(err, "", "ic", types.NamespacedName{Namespace: ev.Namespace, Name: ev.Name})
+		}
+	}
+}
+
+// Name returns a name of the plugin
+func (f *EventForwarder) Name() string {
+	return "blocker"
+}
+
+// Handle queues PR, review and commit status events to be forwarded to the blockers
+func (f *EventForwarder) Handle(wh *git.Webhook, ic *cicdv1.IntegrationConfig) error {
+	if ic.Spec.MergeConfig == nil {
+		return nil
+	}
+
+	ev := Event{Namespace: ic.Namespace, Name: ic.Name}
+	switch wh.EventType {
+	case git.EventTypePullRequest:
+		if wh.PullRequest == nil {
+			return nil
+		}
+		ev.PullRequest = wh.PullRequest.ID
+	case git.EventTypePullRequestReview:
+		if wh.IssueComment == nil || wh.IssueComment.Issue.PullRequest == nil {
+			return nil
+		}
+		ev.PullRequest = wh.IssueComment.Issue.PullRequest.ID
+	case git.EventTypeStatus:
+		// Skip the blocker's own commit status
+		if wh.Status == nil || wh.Status.CommitStatus.Context == blockerContext {
+			return nil
+		}
+		ev.Sha = wh.Status.Sha
+	default:
+		return nil
+	}
+
+	select {
+	case f.queue <- ev:
+		return nil
+	default:
+		return fmt.Errorf("event queue is full")
+	}
+}
+
+// Forward sends the event to all the blockers, as only the leader of the shard handles the event.
+// It returns an error only if no blocker accepted the event
+func (f *EventForwarder) Forward(ev Event) error {
+	body, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+
+	token, err := getEventToken(f.client)
+	if err != nil {
+		return err
+	}
+
+	host := fmt.Sprintf("%s.%s.svc", eventServiceName, utils.Namespace())
+	addrs, err := f.lookupHost(host)
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	accepted := false
+	for _, addr := range addrs {
+		req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("http://%s%s", net.JoinHostPort(addr, strconv.Itoa(f.port)), EventPath), bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+string(token))
+
+		resp, err := f.httpClient.Do(req)
+		if err != nil {
+			// Non-leader blockers don't serve the endpoint
+			lastErr = err
+			continue
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusAccepted {
+			lastErr = fmt.Errorf("blocker %s responded %d", addr, resp.StatusCode)
+			continue
+		}
+		accepted = true
+	}
+
+	if !accepted {
+		if lastErr == nil {
+			lastErr = fmt.Errorf("no blocker found for %s", host)
+		}
+		return lastErr
+	}
+	return nil
+}
@@ -0,0 +1,308 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package blocker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/utils"
+	"github.com/tmax-cloud/cicd-operator/pkg/git"
+	gitfake "github.com/tmax-cloud/cicd-operator/pkg/git/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testEventToken = "test-token"
+
+func TestBlocker_handleEvent(t *testing.T) {
+	tc := map[string]struct {
+		body       string
+		token      string
+		queueFull  bool
+		statusCode int
+		event      *Event
+	}{
+		"normal": {
+			body:       `{"namespace":"default","name":"test","pullRequest":23}`,
+			token:      testEventToken,
+			statusCode: http.StatusAccepted,
+			event:      &Event{Namespace: "default", Name: "test", PullRequest: 23},
+		},
+		"noToken": {
+			body:       `{"namespace":"default","name":"test","pullRequest":23}`,
+			statusCode: http.StatusUnauthorized,
+		},
+		"wrongToken": {
+			body:       `{"namespace":"default","name":"test","pullRequest":23}`,
+			token:      "wrong-token",
+			statusCode: http.StatusUnauthorized,
+		},
+		"malformed": {
+			body:       `{"namespace":`,
+			token:      testEventToken,
+			statusCode: http.StatusBadRequest,
+		},
+		"queueFull": {
+			body:       `{"namespace":"default","name":"test","sha":"abc"}`,
+			token:      testEventToken,
+			queueFull:  true,
+			statusCode: http.StatusServiceUnavailable,
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			b := New(statusServerTestConfig())
+			b.eventToken = []byte(testEventToken)
+			if c.queueFull {
+				for i := 0; i < cap(b.events); i++ {
+					b.events <- Event{}
+				}
+			}
+			srv := httptest.NewServer(b.newRouter())
+			defer srv.Close()
+
+			req, err := http.NewRequest(http.MethodPost, srv.URL+EventPath, bytes.NewBufferString(c.body))
+			require.NoError(t, err)
+			if c.token != "" {
+				req.Header.Set("Authorization", "Bearer "+c.token)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.Equal(t, c.statusCode, resp.StatusCode)
+			if c.event != nil {
+				require.Len(t, b.events, 1)
+				require.Equal(t, *c.event, <-b.events)
+			} else if !c.queueFull {
+				require.Len(t, b.events, 0)
+			}
+		})
+	}
+}
+
+func TestBlocker_syncEvent(t *testing.T) {
+	fakeCli, ic := syncPoolTestEnv()
+	b := New(fakeCli)
+	key := genPoolKey(ic)
+	gitfake.Repos[testRepo].PullRequests[testPRID].Head.Sha = testSHA
+
+	// New pool
+	b.syncEvent(Event{Namespace: ic.Namespace, Name: ic.Name, PullRequest: testPRID})
+	require.Len(t, b.Pools[key].PullRequests, 1)
+	require.Len(t, b.Pools[key].MergePool[git.CommitStatusStatePending], 0)
+	require.Len(t, b.statusSynced, 1)
+	<-b.statusSynced
+
+	// LGTM
+	gitfake.Repos[testRepo].PullRequests[testPRID].Labels = append(gitfake.Repos[testRepo].PullRequests[testPRID].Labels, git.IssueLabel{Name: "lgtm"})
+	b.syncEvent(Event{Namespace: ic.Namespace, Name: ic.Name, PullRequest: testPRID})
+	require.NotNil(t, b.Pools[key].MergePool.Search(testPRID))
+	require.Equal(t, testSHA, b.Pools[key].PullRequests[testPRID].Head.Sha)
+
+	// Status event
+	gitfake.Repos[testRepo].CommitStatuses[testSHA] = []git.CommitStatus{{Context: "test-1", State: git.CommitStatusStateSuccess}}
+	b.syncEvent(Event{Namespace: ic.Namespace, Name: ic.Name, Sha: testSHA})
+	require.Contains(t, b.Pools[key].PullRequests[testPRID].Statuses, "test-1")
+
+	// Closed
+	gitfake.Repos[testRepo].PullRequests[testPRID].State = git.PullRequestStateClosed
+	b.syncEvent(Event{Namespace: ic.Namespace, Name: ic.Name, PullRequest: testPRID})
+	require.Len(t, b.Pools[key].PullRequests, 0)
+	require.Nil(t, b.Pools[key].MergePool.Search(testPRID))
+
+	// Another shard
+	other := New(fakeCli)
+	other.SetShard(Shard{Index: 1, Total: 2})
+	if other.shard.owns(key) {
+		other.SetShard(Shard{Index: 0, Total: 2})
+	}
+	other.syncEvent(Event{Namespace: ic.Namespace, Name: ic.Name, PullRequest: testPRID})
+	require.Len(t, other.Pools, 0)
+}
+
+func TestEnsureEventToken(t *testing.T) {
+	cli := eventTestClient()
+
+	_, err := getEventToken(cli)
+	require.Error(t, err)
+
+	token, err := ensureEventToken(cli)
+	require.NoError(t, err)
+	require.Len(t, token, 64)
+
+	// Should reuse the existing token
+	token2, err := ensureEventToken(cli)
+	require.NoError(t, err)
+	require.Equal(t, token, token2)
+}
+
+func TestEventForwarder_Handle(t *testing.T) {
+	tc := map[string]struct {
+		webhook     *git.Webhook
+		mergeConfig *cicdv1.MergeConfig
+		queueFull   bool
+
+		expectedEvent  *Event
+		expectedErrMsg string
+	}{
+		"pullRequest": {
+			webhook:       &git.Webhook{EventType: git.EventTypePullRequest, PullRequest: &git.PullRequest{ID: 23}},
+			mergeConfig:   &cicdv1.MergeConfig{},
+			expectedEvent: &Event{Namespace: "default", Name: "test", PullRequest: 23},
+		},
+		"review": {
+			webhook:       &git.Webhook{EventType: git.EventTypePullRequestReview, IssueComment: &git.IssueComment{Issue: git.Issue{PullRequest: &git.PullRequest{ID: 24}}}},
+			mergeConfig:   &cicdv1.MergeConfig{},
+			expectedEvent: &Event{Namespace: "default", Name: "test", PullRequest: 24},
+		},
+		"status": {
+			webhook:       &git.Webhook{EventType: git.EventTypeStatus, Status: &git.Status{Sha: testSHA, CommitStatus: git.CommitStatus{Context: "test-1"}}},
+			mergeConfig:   &cicdv1.MergeConfig{},
+			expectedEvent: &Event{Namespace: "default", Name: "test", Sha: testSHA},
+		},
+		"blockerStatus": {
+			webhook:     &git.Webhook{EventType: git.EventTypeStatus, Status: &git.Status{Sha: testSHA, CommitStatus: git.CommitStatus{Context: blockerContext}}},
+			mergeConfig: &cicdv1.MergeConfig{},
+		},
+		"noMergeConfig": {
+			webhook: &git.Webhook{EventType: git.EventTypePullRequest, PullRequest: &git.PullRequest{ID: 23}},
+		},
+		"queueFull": {
+			webhook:        &git.Webhook{EventType: git.EventTypePullRequest, PullRequest: &git.PullRequest{ID: 23}},
+			mergeConfig:    &cicdv1.MergeConfig{},
+			queueFull:      true,
+			expectedErrMsg: "event queue is full",
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			f := newEventForwarder(eventTestClient())
+			if c.queueFull {
+				for i := 0; i < cap(f.queue); i++ {
+					f.queue <- Event{}
+				}
+			}
+
+			ic := &cicdv1.IntegrationConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec:       cicdv1.IntegrationConfigSpec{MergeConfig: c.mergeConfig},
+			}
+			err := f.Handle(c.webhook, ic)
+			if c.expectedErrMsg != "" {
+				require.Error(t, err)
+				require.Equal(t, c.expectedErrMsg, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			if c.expectedEvent != nil {
+				require.Len(t, f.queue, 1)
+				require.Equal(t, *c.expectedEvent, <-f.queue)
+			} else {
+				require.Len(t, f.queue, 0)
+			}
+		})
+	}
+}
+
+func TestEventForwarder_Forward(t *testing.T) {
+	tc := map[string]struct {
+		noSecret   bool
+		statusCode int
+
+		expectedErrMsg string
+	}{
+		"accepted": {
+			statusCode: http.StatusAccepted,
+		},
+		"notAccepted": {
+			statusCode:     http.StatusServiceUnavailable,
+			expectedErrMsg: "blocker 127.0.0.1 responded 503",
+		},
+		"noSecret": {
+			noSecret:       true,
+			expectedErrMsg: "secrets \"blocker-event-token\" not found",
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			ev := Event{Namespace: "default", Name: "test", PullRequest: 23}
+
+			var received *Event
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				require.Equal(t, EventPath, req.URL.Path)
+				require.Equal(t, "Bearer "+testEventToken, req.Header.Get("Authorization"))
+				received = &Event{}
+				require.NoError(t, json.NewDecoder(req.Body).Decode(received))
+				w.WriteHeader(c.statusCode)
+			}))
+			defer srv.Close()
+
+			u, err := url.Parse(srv.URL)
+			require.NoError(t, err)
+			port, err := strconv.Atoi(u.Port())
+			require.NoError(t, err)
+
+			cli := eventTestClient()
+			if !c.noSecret {
+				require.NoError(t, cli.Create(context.Background(), &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: eventSecretName, Namespace: utils.Namespace()},
+					Data:       map[string][]byte{eventSecretKey: []byte(testEventToken)},
+				}))
+			}
+
+			f := newEventForwarder(cli)
+			f.port = port
+			f.lookupHost = func(host string) ([]string, error) {
+				require.Equal(t, fmt.Sprintf("%s.%s.svc", eventServiceName, utils.Namespace()), host)
+				return []string{"127.0.0.1"}, nil
+			}
+
+			err = f.Forward(ev)
+			if c.expectedErrMsg != "" {
+				require.Error(t, err)
+				require.Equal(t, c.expectedErrMsg, err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+			if !c.noSecret {
+				require.Equal(t, &ev, received)
+			}
+		})
+	}
+}
+
+func eventTestClient() client.Client {
+	s := runtime.NewScheme()
+	utilruntime.Must(corev1.AddToScheme(s))
+	return fake.NewClientBuilder().WithScheme(s).Build()
+}
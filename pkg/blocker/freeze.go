@@ -0,0 +1,161 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package blocker
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/configs"
+	"github.com/tmax-cloud/cicd-operator/pkg/git"
+	"gopkg.in/robfig/cron.v2"
+)
+
+// freeze.go includes methods for checking the merge freeze, which is set cluster-wide, ad hoc, or by the merge windows
+
+// mergeFreeze describes why merges are frozen and when the freeze ends
+type mergeFreeze struct {
+	Reason string
+
+	// Until is when the freeze ends. It's zero if the freeze lasts until it's removed
+	Until time.Time
+}
+
+// description generates a blocker status description for the frozen PRs
+func (f *mergeFreeze) description() string {
+	if f.Until.IsZero() {
+		return fmt.Sprintf("Merge is frozen (%s).", f.Reason)
+	}
+	return fmt.Sprintf("Merge is frozen (%s) until %s.", f.Reason, f.Until.Format("2006-01-02 15:04 MST"))
+}
+
+// getMergeFreeze returns the merge freeze of the base branch at the time.
+// It returns nil if the PRs to the branch can be merged
+func getMergeFreeze(cfg *cicdv1.MergeConfig, baseRef string, now time.Time) *mergeFreeze {
+	branch := cicdv1.GitRef(baseRef).GetBranch()
+
+	// Cluster-wide freeze
+	if configs.MergeFreeze {
+		freeze := &mergeFreeze{Reason: configs.MergeFreezeReason}
+		if freeze.Reason == "" {
+			freeze.Reason = "cluster-wide freeze"
+		}
+		until, err := time.Parse(time.RFC3339, configs.MergeFreezeUntil)
+		if err == nil {
+			freeze.Until = until
+		}
+		if freeze.Until.IsZero() || now.Before(freeze.Until) {
+			return freeze
+		}
+	}
+
+	if cfg == nil {
+		return nil
+	}
+
+	// Ad hoc freeze
+	if cfg.Freeze != nil && matchBranches(branch, cfg.Freeze.Branches) && (cfg.Freeze.Until == nil || now.Before(cfg.Freeze.Until.Time)) {
+		freeze := &mergeFreeze{Reason: cfg.Freeze.Reason}
+		if freeze.Reason == "" {
+			freeze.Reason = "ad hoc freeze"
+		}
+		if cfg.Freeze.Until != nil {
+			freeze.Until = cfg.Freeze.Until.Time
+		}
+		return freeze
+	}
+
+	// Merge windows
+	var nextStart time.Time
+	windowExists := false
+	for _, w := range cfg.MergeWindows {
+		if !matchBranches(branch, w.Branches) {
+			continue
+		}
+		windowExists = true
+
+		open, next, err := checkMergeWindow(w, now)
+		if err != nil {
+			return &mergeFreeze{Reason: fmt.Sprintf("invalid merge window: %s", err.Error())}
+		}
+		if open {
+			return nil
+		}
+		if !next.IsZero() && (nextStart.IsZero() || next.Before(nextStart)) {
+			nextStart = next
+		}
+	}
+	if windowExists {
+		return &mergeFreeze{Reason: "out of merge windows", Until: nextStart}
+	}
+
+	return nil
+}
+
+// syncMergeFreeze re-evaluates the merge freeze for the PRs in the success pool, as the freeze may start or end between status syncs.
+// pool.lock should be held by the caller
+func syncMergeFreeze(pool *PRPool, ic *cicdv1.IntegrationConfig) {
+	for _, pr := range pool.MergePool[git.CommitStatusStateSuccess] {
+		setBlockerStatus(pr, ic.Spec.MergeConfig, git.CommitStatusStateSuccess, mergePoolDescription)
+	}
+}
+
+// checkMergeWindow checks if the window is open at the time. If it's closed, it also returns the next start time of the window
+func checkMergeWindow(w cicdv1.MergeWindow, now time.Time) (bool, time.Time, error) {
+	duration, err := time.ParseDuration(w.Duration)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+
+	tz := w.TimeZone
+	if tz == "" {
+		tz = "UTC"
+	}
+	schedule, err := cron.Parse(fmt.Sprintf("TZ=%s %s", tz, w.Schedule))
+	if err != nil {
+		return false, time.Time{}, err
+	}
+
+	// The window is open if it started within the duration
+	start := schedule.Next(now.Add(-duration))
+	if !start.IsZero() && !start.After(now) {
+		return true, time.Time{}, nil
+	}
+	return false, schedule.Next(now), nil
+}
+
+// matchBranches checks if the branch matches any of the branches. Each branch can be a regular expression.
+// It returns true if branches are not specified
+func matchBranches(branch string, branches []string) bool {
+	if len(branches) == 0 {
+		return true
+	}
+	for _, b := range branches {
+		if strings.ContainsAny(b, "*^?$") {
+			re, err := regexp.Compile(b)
+			if err == nil && re.MatchString(branch) {
+				return true
+			}
+		} else if b == branch {
+			return true
+		}
+	}
+	return false
+}
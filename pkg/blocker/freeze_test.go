@@ -0,0 +1,168 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package blocker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/configs"
+	"github.com/tmax-cloud/cicd-operator/pkg/git"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetMergeFreeze(t *testing.T) {
+	kst, err := time.LoadLocation("Asia/Seoul")
+	require.NoError(t, err)
+
+	fridayEvening := time.Date(2021, 12, 24, 19, 0, 0, 0, kst)
+	fridayMorning := time.Date(2021, 12, 24, 10, 0, 0, 0, kst)
+	window := cicdv1.MergeWindow{Schedule: "0 9 * * 1-5", Duration: "9h", TimeZone: "Asia/Seoul", Branches: []string{"release-.*"}}
+
+	tc := map[string]struct {
+		clusterFreeze      bool
+		clusterFreezeUntil string
+		cfg                *cicdv1.MergeConfig
+		baseRef            string
+		now                time.Time
+
+		expectedFreeze      *mergeFreeze
+		expectedDescription string
+	}{
+		"notFrozen": {
+			cfg:     &cicdv1.MergeConfig{},
+			baseRef: "master",
+			now:     fridayEvening,
+		},
+		"cluster": {
+			clusterFreeze:       true,
+			cfg:                 &cicdv1.MergeConfig{},
+			baseRef:             "master",
+			now:                 fridayEvening,
+			expectedFreeze:      &mergeFreeze{Reason: "incident"},
+			expectedDescription: "Merge is frozen (incident).",
+		},
+		"clusterUntil": {
+			clusterFreeze:       true,
+			clusterFreezeUntil:  "2021-12-24T20:00:00+09:00",
+			cfg:                 &cicdv1.MergeConfig{},
+			baseRef:             "master",
+			now:                 fridayEvening,
+			expectedFreeze:      &mergeFreeze{Reason: "incident", Until: time.Date(2021, 12, 24, 20, 0, 0, 0, time.FixedZone("", 9*60*60))},
+			expectedDescription: "Merge is frozen (incident) until 2021-12-24 20:00 +0900.",
+		},
+		"clusterExpired": {
+			clusterFreeze:      true,
+			clusterFreezeUntil: "2021-12-24T18:00:00+09:00",
+			cfg:                &cicdv1.MergeConfig{},
+			baseRef:            "master",
+			now:                fridayEvening,
+		},
+		"adHoc": {
+			cfg:                 &cicdv1.MergeConfig{Freeze: &cicdv1.MergeFreeze{Branches: []string{"master"}}},
+			baseRef:             "refs/heads/master",
+			now:                 fridayEvening,
+			expectedFreeze:      &mergeFreeze{Reason: "ad hoc freeze"},
+			expectedDescription: "Merge is frozen (ad hoc freeze).",
+		},
+		"adHocOtherBranch": {
+			cfg:     &cicdv1.MergeConfig{Freeze: &cicdv1.MergeFreeze{Branches: []string{"master"}}},
+			baseRef: "refs/heads/dev",
+			now:     fridayEvening,
+		},
+		"adHocExpired": {
+			cfg:     &cicdv1.MergeConfig{Freeze: &cicdv1.MergeFreeze{Reason: "release", Until: &metav1.Time{Time: fridayMorning}}},
+			baseRef: "master",
+			now:     fridayEvening,
+		},
+		"windowOpen": {
+			cfg:     &cicdv1.MergeConfig{MergeWindows: []cicdv1.MergeWindow{window}},
+			baseRef: "release-1.0",
+			now:     fridayMorning,
+		},
+		"windowClosed": {
+			cfg:                 &cicdv1.MergeConfig{MergeWindows: []cicdv1.MergeWindow{window}},
+			baseRef:             "release-1.0",
+			now:                 fridayEvening,
+			expectedFreeze:      &mergeFreeze{Reason: "out of merge windows", Until: time.Date(2021, 12, 27, 9, 0, 0, 0, kst)},
+			expectedDescription: "Merge is frozen (out of merge windows) until 2021-12-27 09:00 KST.",
+		},
+		"windowOtherBranch": {
+			cfg:     &cicdv1.MergeConfig{MergeWindows: []cicdv1.MergeWindow{window}},
+			baseRef: "master",
+			now:     fridayEvening,
+		},
+		"windowInvalid": {
+			cfg:                 &cicdv1.MergeConfig{MergeWindows: []cicdv1.MergeWindow{{Schedule: "0 9 * * 1-5", Duration: "9"}}},
+			baseRef:             "master",
+			now:                 fridayEvening,
+			expectedFreeze:      &mergeFreeze{Reason: "invalid merge window: time: missing unit in duration \"9\""},
+			expectedDescription: "Merge is frozen (invalid merge window: time: missing unit in duration \"9\").",
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			configs.MergeFreeze = c.clusterFreeze
+			configs.MergeFreezeReason = "incident"
+			configs.MergeFreezeUntil = c.clusterFreezeUntil
+			defer func() {
+				configs.MergeFreeze = false
+			}()
+
+			freeze := getMergeFreeze(c.cfg, c.baseRef, c.now)
+			if c.expectedFreeze == nil {
+				require.Nil(t, freeze)
+				return
+			}
+			require.NotNil(t, freeze)
+			require.Equal(t, c.expectedFreeze.Reason, freeze.Reason)
+			require.True(t, c.expectedFreeze.Until.Equal(freeze.Until))
+			require.Equal(t, c.expectedDescription, freeze.description())
+		})
+	}
+}
+
+func TestSyncMergeFreeze(t *testing.T) {
+	ic := &cicdv1.IntegrationConfig{Spec: cicdv1.IntegrationConfigSpec{MergeConfig: &cicdv1.MergeConfig{
+		Freeze: &cicdv1.MergeFreeze{Reason: "release"},
+	}}}
+	pool := NewPRPool("default", "test")
+	pr := &PullRequest{
+		PullRequest:        git.PullRequest{ID: 1, Base: git.Base{Ref: "master"}},
+		BlockerStatus:      git.CommitStatusStateSuccess,
+		BlockerDescription: mergePoolDescription,
+	}
+	pool.MergePool.Add(pr)
+
+	// Frozen
+	syncMergeFreeze(pool, ic)
+	require.Equal(t, git.CommitStatusStatePending, pr.BlockerStatus)
+	require.Equal(t, "Merge is frozen (release).", pr.BlockerDescription)
+	require.True(t, pr.blockerCacheDirty)
+	require.NotNil(t, pool.MergePool[git.CommitStatusStateSuccess][1])
+
+	// Freeze ended
+	pr.blockerCacheDirty = false
+	ic.Spec.MergeConfig.Freeze = nil
+	syncMergeFreeze(pool, ic)
+	require.Equal(t, git.CommitStatusStateSuccess, pr.BlockerStatus)
+	require.Equal(t, mergePoolDescription, pr.BlockerDescription)
+	require.True(t, pr.blockerCacheDirty)
+}
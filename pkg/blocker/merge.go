@@ -21,6 +21,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 	"text/template"
 	"time"
 
@@ -30,6 +31,7 @@ import (
 	"github.com/tmax-cloud/cicd-operator/pkg/dispatcher"
 	"github.com/tmax-cloud/cicd-operator/pkg/git"
 	"github.com/tmax-cloud/cicd-operator/pkg/pipelinemanager"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -37,12 +39,19 @@ import (
 var log = logf.Log.WithName("blocker")
 
 const (
-	maxBatchSize = 10
+	defaultBatchSize = 10
 )
 
 func (b *blocker) loopMerge() {
-	// Call retestAndMerge method whenever a status sync is done
-	for range b.statusSynced {
+	// Call retestAndMerge method whenever a status sync is done.
+	// Also call it periodically, so that batches are checked between full pool syncs
+	ticker := time.NewTicker(time.Duration(configs.MergeSyncPeriod) * time.Minute)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-b.statusSynced:
+		case <-ticker.C:
+		}
 		b.retestAndMerge()
 	}
 }
@@ -65,14 +74,26 @@ func (b *blocker) retestAndMergeOnePool(pool *PRPool) {
 	if ic.Spec.MergeConfig == nil {
 		return
 	}
+	defer pool.updateStatus(ic)
 	log := b.log.WithName("merger").WithValues("repo", genPoolKey(ic))
 
+	// Persist the merge queue, as the current batch may be changed
+	defer func() {
+		if err := b.saveMergeQueue(pool, ic); err != nil {
+			log.Error(err, "")
+		}
+	}()
+
 	gitCli, err := utils.GetGitCli(ic, b.client)
 	if err != nil {
 		log.Error(err, "")
 		return
 	}
 
+	// Reflect the merge freeze, which may be started or ended after the last status sync
+	syncMergeFreeze(pool, ic)
+	defer b.setDirtyCommitStatuses(pool, ic, gitCli)
+
 	// Exit if we're waiting for batch re-test
 	if pool.CurrentBatch != nil {
 		// Do nothing if it's still processing
@@ -92,8 +113,21 @@ func (b *blocker) retestAndMergeOnePool(pool *PRPool) {
 		return
 	}
 
-	// Sort PRs - older (low id) one is prioritized
-	candidates := sortPullRequestByID(pool.MergePool[git.CommitStatusStateSuccess])
+	// Sort PRs - PRs moved to the front and PRs with higher priority come first, then older (low id) ones
+	if err := b.loadMergeQueueSpec(pool); err != nil {
+		log.Error(err, "")
+	}
+	var candidates []*PullRequest
+	for _, pr := range sortPullRequestByQueue(pool.MergePool[git.CommitStatusStateSuccess], pool.Front) {
+		// PRs to the frozen branches are kept in the merge pool, but not tested or merged
+		if getMergeFreeze(ic.Spec.MergeConfig, pr.Base.Ref, time.Now()) != nil {
+			continue
+		}
+		candidates = append(candidates, pr)
+	}
+	if len(candidates) == 0 {
+		return
+	}
 
 	// PR with the highest priority (the oldest one)
 	pr := candidates[0]
@@ -109,6 +143,22 @@ func (b *blocker) retestAndMergeOnePool(pool *PRPool) {
 	// Merge it if the tests are done based on the latest commit
 	if isBaseLatest {
 		if err := b.mergePullRequest(pr, ic, gitCli); err != nil {
+			log.Error(err, "")
+			pool.recordEvent(PoolEventMergeFailed, []*PullRequest{pr}, "", err.Error())
+			// Fast-forward merge fails if the branch is behind the base, even if it's tested based on the latest commit
+			if getMergeMethod(pr, ic) == git.MergeMethodFastForward {
+				if err := updatePullRequestBranch(pool, pr, ic, gitCli); err != nil {
+					log.Error(err, "")
+				}
+			}
+			return
+		}
+		pool.recordEvent(PoolEventMerged, []*PullRequest{pr}, "", "")
+		b.retargetChildren(pool, ic, pr, gitCli)
+	} else if shouldUpdateBranch(pr, ic) {
+		// Update the branch, so that it's tested again based on the latest commit
+		log.Info(fmt.Sprintf("PR #%d is not tested based on the latest commit of %s. Updating the branch", pr.ID, branch))
+		if err := updatePullRequestBranch(pool, pr, ic, gitCli); err != nil {
 			log.Error(err, "")
 			return
 		}
@@ -125,7 +175,7 @@ func (b *blocker) retestAndMergeOnePool(pool *PRPool) {
 			}
 			pool.CurrentBatch.PRs = append(pool.CurrentBatch.PRs, p)
 			prIDs = append(prIDs, p.ID)
-			if len(pool.CurrentBatch.PRs) == maxBatchSize {
+			if len(pool.CurrentBatch.PRs) == getBatchSize(ic) {
 				break
 			}
 		}
@@ -137,6 +187,7 @@ func (b *blocker) retestAndMergeOnePool(pool *PRPool) {
 			log.Error(err, "Fail to create integrationJob for batch.")
 			return
 		}
+		pool.recordEvent(PoolEventBatchStarted, pool.CurrentBatch.PRs, pool.CurrentBatch.Job.Name, "")
 	}
 }
 
@@ -151,17 +202,37 @@ func (b *blocker) handleBatch(pool *PRPool, ic *cicdv1.IntegrationConfig, gitCli
 	// Check if the tests for batch is successful
 	ij := &cicdv1.IntegrationJob{}
 	if err := b.client.Get(context.Background(), pool.CurrentBatch.Job, ij); err != nil {
+		// Drop the batch if its IntegrationJob is gone, so that the PRs are batched again
+		if errors.IsNotFound(err) {
+			log.Info(fmt.Sprintf("IntegrationJob %s for the batch is not found. Dropping the batch", pool.CurrentBatch.Job.String()))
+			pool.recordEvent(PoolEventBatchDropped, pool.CurrentBatch.PRs, pool.CurrentBatch.Job.Name, "IntegrationJob is not found.")
+			pool.CurrentBatch = nil
+			return nil
+		}
 		return err
 	}
 
 	switch ij.Status.State {
 	case cicdv1.IntegrationJobStateCompleted:
+		// Wait for the merge freeze to end
+		if len(pool.CurrentBatch.PRs) == 0 {
+			return b.testNextGroup(pool, ic)
+		}
+		if freeze := getMergeFreeze(ic.Spec.MergeConfig, pool.CurrentBatch.PRs[0].Base.Ref, time.Now()); freeze != nil {
+			log.Info(fmt.Sprintf("Batch %v is not merged. %s", getPRIDs(pool.CurrentBatch.PRs), freeze.description()))
+			return nil
+		}
+		pool.recordEvent(PoolEventBatchSucceeded, pool.CurrentBatch.PRs, pool.CurrentBatch.Job.Name, "")
+
 		// If batch test is successful, merge them all, sequentially
 		// TODO - what if the target branch is updated during the test...? (manually by a user)
 		for len(pool.CurrentBatch.PRs) > 0 {
 			if err := b.tryMerge(pool.CurrentBatch.PRs[0], ic, gitCli); err != nil {
+				pool.recordEvent(PoolEventMergeFailed, pool.CurrentBatch.PRs[:1], "", err.Error())
 				return err
 			}
+			pool.recordEvent(PoolEventMerged, pool.CurrentBatch.PRs[:1], "", "")
+			b.retargetChildren(pool, ic, pool.CurrentBatch.PRs[0], gitCli)
 			pool.CurrentBatch.PRs = pool.CurrentBatch.PRs[1:]
 
 			// Wait 5 sec and give github/gitlab time to recalculate the mergeability
@@ -169,8 +240,12 @@ func (b *blocker) handleBatch(pool *PRPool, ic *cicdv1.IntegrationConfig, gitCli
 				time.Sleep(5 * time.Second)
 			}
 		}
-		pool.CurrentBatch = nil
+		return b.testNextGroup(pool, ic)
 	case cicdv1.IntegrationJobStateFailed:
+		pool.recordEvent(PoolEventBatchFailed, pool.CurrentBatch.PRs, pool.CurrentBatch.Job.Name, ij.Status.Message)
+		if ic.Spec.MergeConfig.BatchStrategy == cicdv1.BatchStrategyBisect {
+			return b.bisectBatch(pool, ic, ij, gitCli)
+		}
 		// If batch test fails, test again with one less PR in the batch
 		// But if the length is 1 and fails...? Kick it out from the merge pool
 		if pool.CurrentBatch.Len() <= 1 {
@@ -182,6 +257,7 @@ func (b *blocker) handleBatch(pool *PRPool, ic *cicdv1.IntegrationConfig, gitCli
 				log.Error(err, "Fail to create integrationJob for batch.")
 				return err
 			}
+			pool.recordEvent(PoolEventBatchStarted, pool.CurrentBatch.PRs, pool.CurrentBatch.Job.Name, "")
 		}
 	default:
 		// Do nothing if it's still running
@@ -189,6 +265,107 @@ func (b *blocker) handleBatch(pool *PRPool, ic *cicdv1.IntegrationConfig, gitCli
 	return nil
 }
 
+// bisectBatch splits the failed batch into halves and tests the first half, leaving the other half to be tested next.
+// If the failed batch has only one PR, the PR is the culprit. It's kicked out from the merge pool with a comment
+// and the next group of the batch is tested
+func (b *blocker) bisectBatch(pool *PRPool, ic *cicdv1.IntegrationConfig, ij *cicdv1.IntegrationJob, gitCli git.Client) error {
+	batch := pool.CurrentBatch
+	if batch.Len() > 1 {
+		half := (batch.Len() + 1) / 2
+		batch.Remaining = append([][]*PullRequest{batch.PRs[half:]}, batch.Remaining...)
+		batch.PRs = batch.PRs[:half]
+		log.Info(fmt.Sprintf("Batch test failed. Bisecting - %+v", getPRIDs(batch.PRs)))
+		if err := b.createIntegrationJobForBatch(getGitPRsFromPRs(batch.PRs), ic, &batch.Job); err != nil {
+			log.Error(err, "Fail to create integrationJob for batch.")
+			return err
+		}
+		pool.recordEvent(PoolEventBatchStarted, batch.PRs, batch.Job.Name, "")
+		return nil
+	}
+
+	culprit := batch.PRs[0]
+	log.Info(fmt.Sprintf("PR #%d failed the batch test. Kicking it out from the merge pool", culprit.ID))
+	rejectPullRequest(pool, culprit)
+	pool.recordEvent(PoolEventKickedOut, batch.PRs, ij.Name, "Failed the batch test.")
+	if err := gitCli.RegisterComment(git.IssueTypePullRequest, culprit.ID, "", generateCulpritComment(ij)); err != nil {
+		log.Error(err, "")
+	}
+	return b.testNextGroup(pool, ic)
+}
+
+// testNextGroup tests the next group of PRs remaining in the batch. The batch is dropped if there is no group left
+func (b *blocker) testNextGroup(pool *PRPool, ic *cicdv1.IntegrationConfig) error {
+	batch := pool.CurrentBatch
+	if len(batch.Remaining) == 0 {
+		pool.CurrentBatch = nil
+		return nil
+	}
+	batch.PRs, batch.Remaining = batch.Remaining[0], batch.Remaining[1:]
+	log.Info(fmt.Sprintf("Batched tests - %+v", getPRIDs(batch.PRs)))
+	if err := b.createIntegrationJobForBatch(getGitPRsFromPRs(batch.PRs), ic, &batch.Job); err != nil {
+		log.Error(err, "Fail to create integrationJob for batch.")
+		return err
+	}
+	pool.recordEvent(PoolEventBatchStarted, batch.PRs, batch.Job.Name, "")
+	return nil
+}
+
+// rejectPullRequest moves the PR to the pending merge pool, so that it's not batched again
+// until its commit statuses are synced
+func rejectPullRequest(pool *PRPool, pr *PullRequest) {
+	setPullRequestPending(pool, pr, "Failed the batch test.")
+}
+
+// updatePullRequestBranch updates the PR's branch onto the latest base and moves it to the pending pool.
+// If the branch cannot be updated automatically, the PR is held in the pending pool until its head is changed,
+// so that it's not retried every time
+func updatePullRequestBranch(pool *PRPool, pr *PullRequest, ic *cicdv1.IntegrationConfig, gitCli git.Client) error {
+	// GitHub updates the branch by merging the base branch into it, which breaks the linear history of ff-only
+	if ic.Spec.Git.Type == cicdv1.GitTypeGitHub && getMergeMethod(pr, ic) == git.MergeMethodFastForward {
+		holdPullRequest(pool, pr, "Branch is behind the base. Rebase it onto the latest base to be fast-forwarded.")
+		return nil
+	}
+
+	if err := gitCli.UpdatePullRequestBranch(pr.ID, pr.Head.Sha); err != nil {
+		holdPullRequest(pool, pr, "Failed to update the branch onto the latest base. Please update it manually.")
+		return err
+	}
+	setPullRequestPending(pool, pr, "Branch is updated onto the latest base. Waiting for the tests.")
+	return nil
+}
+
+// holdPullRequest moves the PR to the pending pool, and keeps it there until its head is changed
+func holdPullRequest(pool *PRPool, pr *PullRequest, description string) {
+	pr.heldSha = pr.Head.Sha
+	pr.heldDescription = description
+	setPullRequestPending(pool, pr, description)
+}
+
+func setPullRequestPending(pool *PRPool, pr *PullRequest, description string) {
+	pool.MergePool.Delete(pr.ID)
+	pr.BlockerStatus = git.CommitStatusStatePending
+	pr.BlockerDescription = description
+	pr.blockerCacheDirty = true
+	pool.MergePool.Add(pr)
+}
+
+// shouldUpdateBranch checks if the PR's branch should be updated, instead of being tested in a batch
+func shouldUpdateBranch(pr *PullRequest, ic *cicdv1.IntegrationConfig) bool {
+	return ic.Spec.MergeConfig.UpdateBranch || getMergeMethod(pr, ic) == git.MergeMethodFastForward
+}
+
+// generateCulpritComment generates a comment for the PR which broke the batch test
+func generateCulpritComment(ij *cicdv1.IntegrationJob) string {
+	var jobs []string
+	for _, j := range ij.Status.Jobs {
+		if j.State == cicdv1.CommitStatusStateFailure || j.State == cicdv1.CommitStatusStateError {
+			jobs = append(jobs, fmt.Sprintf("- [%s](%s)", j.Name, ij.GetReportServerAddress(j.Name)))
+		}
+	}
+	return fmt.Sprintf("This pull request is kicked out from the merge pool, as it failed the batch test `%s`.\n\nFailed jobs:\n%s",
+		ij.Name, strings.Join(jobs, "\n"))
+}
+
 func (b *blocker) tryMerge(pr *PullRequest, ic *cicdv1.IntegrationConfig, gitCli git.Client) error {
 	var err error
 	const maxRetry = 3
@@ -220,6 +397,14 @@ func (b *blocker) tryMerge(pr *PullRequest, ic *cicdv1.IntegrationConfig, gitCli
 	return err
 }
 
+func getPRIDs(prs []*PullRequest) []int {
+	var ids []int
+	for _, p := range prs {
+		ids = append(ids, p.ID)
+	}
+	return ids
+}
+
 func getGitPRsFromPRs(prs []*PullRequest) []git.PullRequest {
 	gitPRs := []git.PullRequest{}
 	for _, p := range prs {
@@ -271,6 +456,13 @@ func (b *blocker) mergePullRequest(pr *PullRequest, ic *cicdv1.IntegrationConfig
 	return nil
 }
 
+func getBatchSize(ic *cicdv1.IntegrationConfig) int {
+	if ic.Spec.MergeConfig.BatchSize > 0 {
+		return ic.Spec.MergeConfig.BatchSize
+	}
+	return defaultBatchSize
+}
+
 func getMergeMethod(pr *PullRequest, ic *cicdv1.IntegrationConfig) git.MergeMethod {
 	method := ic.Spec.MergeConfig.Method
 	if method == "" {
@@ -322,6 +514,41 @@ func sortPullRequestByID(prs map[int]*PullRequest) PullRequestByID {
 	return candidates
 }
 
+// sortPullRequestByQueue sorts the PRs by their order in the merge queue.
+// PRs in front come first in order, then PRs with higher priority. Older (low id) ones come first for the same priority
+func sortPullRequestByQueue(prs map[int]*PullRequest, front []int) []*PullRequest {
+	frontIdx := map[int]int{}
+	for i, id := range front {
+		if _, exist := frontIdx[id]; !exist {
+			frontIdx[id] = i
+		}
+	}
+
+	candidates := sortPullRequestByID(prs)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		fi, iFront := frontIdx[candidates[i].ID]
+		fj, jFront := frontIdx[candidates[j].ID]
+		if iFront || jFront {
+			return iFront && (!jFront || fi < fj)
+		}
+		return getPriority(candidates[i]) > getPriority(candidates[j])
+	})
+	return candidates
+}
+
+// getPriority returns the merge priority of the PR, set by the priority labels. (1: high, 0: normal, -1: low)
+func getPriority(pr *PullRequest) int {
+	for _, l := range pr.Labels {
+		if configs.MergePriorityHighLabel != "" && l.Name == configs.MergePriorityHighLabel {
+			return 1
+		}
+		if configs.MergePriorityLowLabel != "" && l.Name == configs.MergePriorityLowLabel {
+			return -1
+		}
+	}
+	return 0
+}
+
 // PullRequestByID is a PR id, sorted by ID
 type PullRequestByID []*PullRequest
 
@@ -0,0 +1,197 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package blocker
+
+import (
+	"context"
+	"reflect"
+
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/pkg/git"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// merge_queue.go persists the PR pools into MergeQueue objects, so that the blocker can rebuild the pools
+// (especially the current batch) after a restart. A MergeQueue is named after the pool's IntegrationConfig.
+
+// +kubebuilder:rbac:groups=cicd.tmax.io,resources=mergequeues,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=cicd.tmax.io,resources=mergequeues/status,verbs=get;update;patch
+
+// saveMergeQueue stores the pool's state into the MergeQueue. pool.lock should be held by the caller
+func (b *blocker) saveMergeQueue(pool *PRPool, ic *cicdv1.IntegrationConfig) error {
+	mq := &cicdv1.MergeQueue{}
+	if err := b.client.Get(context.Background(), pool.NamespacedName, mq); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		mq = &cicdv1.MergeQueue{
+			ObjectMeta: metav1.ObjectMeta{Name: pool.Name, Namespace: pool.Namespace},
+			Spec:       cicdv1.MergeQueueSpec{Repository: string(genPoolKey(ic))},
+		}
+		if err := controllerutil.SetControllerReference(ic, mq, b.client.Scheme()); err != nil {
+			return err
+		}
+		if err := b.client.Create(context.Background(), mq); err != nil {
+			return err
+		}
+	}
+
+	status := generateMergeQueueStatus(pool)
+	if reflect.DeepEqual(status.PullRequests, mq.Status.PullRequests) && reflect.DeepEqual(status.CurrentBatch, mq.Status.CurrentBatch) {
+		return nil
+	}
+
+	now := metav1.Now()
+	status.LastUpdateTime = &now
+	mq.Status = status
+	return b.client.Status().Update(context.Background(), mq)
+}
+
+// loadMergeQueueSpec loads the manual changes of the queue (front, removed PRs) from the MergeQueue's spec.
+// pool.lock should be held by the caller
+func (b *blocker) loadMergeQueueSpec(pool *PRPool) error {
+	mq := &cicdv1.MergeQueue{}
+	if err := b.client.Get(context.Background(), pool.NamespacedName, mq); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	pool.Front = mq.Spec.Front
+	pool.Removed = map[int]string{}
+	for _, pr := range mq.Spec.Removed {
+		pool.Removed[pr.ID] = pr.Sha
+	}
+	return nil
+}
+
+// restoreMergeQueue restores the current batch from the MergeQueue, for a newly created pool.
+// The batch's PRs are placeholders (only with IDs), until they are linked to the pool's PRs by linkBatch
+func (b *blocker) restoreMergeQueue(pool *PRPool) error {
+	mq := &cicdv1.MergeQueue{}
+	if err := b.client.Get(context.Background(), pool.NamespacedName, mq); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	batch := mq.Status.CurrentBatch
+	if batch == nil || batch.IntegrationJob == "" {
+		return nil
+	}
+	pool.CurrentBatch = &Batch{Job: types.NamespacedName{Name: batch.IntegrationJob, Namespace: pool.Namespace}}
+	pool.CurrentBatch.PRs = placeholderPRs(batch.PullRequests)
+	for _, ids := range batch.Remaining {
+		pool.CurrentBatch.Remaining = append(pool.CurrentBatch.Remaining, placeholderPRs(ids))
+	}
+	b.log.Info("Restored the current batch", "pool", pool.NamespacedName, "job", batch.IntegrationJob, "prs", batch.PullRequests)
+	return nil
+}
+
+func placeholderPRs(ids []int) []*PullRequest {
+	var prs []*PullRequest
+	for _, id := range ids {
+		prs = append(prs, &PullRequest{PullRequest: git.PullRequest{ID: id}})
+	}
+	return prs
+}
+
+// deleteMergeQueue deletes the pool's MergeQueue
+func (b *blocker) deleteMergeQueue(pool *PRPool) error {
+	mq := &cicdv1.MergeQueue{ObjectMeta: metav1.ObjectMeta{Name: pool.Name, Namespace: pool.Namespace}}
+	if err := b.client.Delete(context.Background(), mq); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// linkBatch replaces the current batch's PRs with the pool's PRs. Closed PRs are removed from the batch,
+// and the batch is dropped if there is no PR left
+func linkBatch(pool *PRPool) {
+	if pool.CurrentBatch == nil {
+		return
+	}
+	var remaining [][]*PullRequest
+	for _, group := range pool.CurrentBatch.Remaining {
+		if prs := linkPRs(pool, group); len(prs) > 0 {
+			remaining = append(remaining, prs)
+		}
+	}
+	pool.CurrentBatch.Remaining = remaining
+
+	prs := linkPRs(pool, pool.CurrentBatch.PRs)
+	if len(prs) == 0 {
+		pool.CurrentBatch = nil
+		return
+	}
+	pool.CurrentBatch.PRs = prs
+}
+
+func linkPRs(pool *PRPool, prs []*PullRequest) []*PullRequest {
+	var linked []*PullRequest
+	for _, pr := range prs {
+		if poolPR, exist := pool.PullRequests[pr.ID]; exist {
+			linked = append(linked, poolPR)
+		}
+	}
+	return linked
+}
+
+// generateMergeQueueStatus generates a MergeQueue status from the pool
+func generateMergeQueueStatus(pool *PRPool) cicdv1.MergeQueueStatus {
+	status := cicdv1.MergeQueueStatus{}
+
+	queued := map[int]*PullRequest{}
+	for _, prs := range pool.MergePool {
+		for id, pr := range prs {
+			queued[id] = pr
+		}
+	}
+	for i, pr := range sortPullRequestByQueue(queued, pool.Front) {
+		status.PullRequests = append(status.PullRequests, cicdv1.MergeQueuePullRequest{
+			ID:          pr.ID,
+			Title:       pr.Title,
+			Author:      pr.Author.Name,
+			BaseBranch:  cicdv1.GitRef(pr.Base.Ref).GetBranch(),
+			Sha:         pr.Head.Sha,
+			Position:    i + 1,
+			Priority:    getPriority(pr),
+			State:       pr.BlockerStatus,
+			Description: pr.BlockerDescription,
+		})
+	}
+
+	if pool.CurrentBatch != nil {
+		status.CurrentBatch = &cicdv1.MergeQueueBatch{IntegrationJob: pool.CurrentBatch.Job.Name}
+		for _, pr := range pool.CurrentBatch.PRs {
+			status.CurrentBatch.PullRequests = append(status.CurrentBatch.PullRequests, pr.ID)
+		}
+		for _, prs := range pool.CurrentBatch.Remaining {
+			var ids []int
+			for _, pr := range prs {
+				ids = append(ids, pr.ID)
+			}
+			status.CurrentBatch.Remaining = append(status.CurrentBatch.Remaining, ids)
+		}
+	}
+	return status
+}
@@ -0,0 +1,162 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package blocker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/pkg/git"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestBlocker_saveMergeQueue(t *testing.T) {
+	ic, cli := mergeTestConfig()
+	b := New(cli)
+
+	pool := NewPRPool(testICNamespace, testICName)
+	pr1 := &PullRequest{PullRequest: git.PullRequest{ID: 37, Title: "second", Base: git.Base{Ref: "refs/heads/master"}, Head: git.Head{Sha: "sha-37"}}, BlockerStatus: git.CommitStatusStatePending, BlockerDescription: "Not mergeable."}
+	pr2 := &PullRequest{PullRequest: git.PullRequest{ID: 12, Title: "first", Author: git.User{Name: "author"}, Base: git.Base{Ref: "master"}}, BlockerStatus: git.CommitStatusStateSuccess, BlockerDescription: "In merge pool."}
+	pool.PullRequests[pr1.ID] = pr1
+	pool.PullRequests[pr2.ID] = pr2
+	pool.MergePool.Add(pr1)
+	pool.MergePool.Add(pr2)
+	pool.CurrentBatch = &Batch{PRs: []*PullRequest{pr2}, Job: types.NamespacedName{Name: "batch-ij", Namespace: testICNamespace}}
+
+	require.NoError(t, b.saveMergeQueue(pool, ic))
+
+	mq := &cicdv1.MergeQueue{}
+	require.NoError(t, cli.Get(context.Background(), pool.NamespacedName, mq))
+	require.Equal(t, string(genPoolKey(ic)), mq.Spec.Repository)
+	require.Equal(t, ic.Name, mq.OwnerReferences[0].Name)
+	require.Equal(t, []cicdv1.MergeQueuePullRequest{
+		{ID: 12, Title: "first", Author: "author", BaseBranch: "master", Position: 1, State: git.CommitStatusStateSuccess, Description: "In merge pool."},
+		{ID: 37, Title: "second", BaseBranch: "master", Sha: "sha-37", Position: 2, State: git.CommitStatusStatePending, Description: "Not mergeable."},
+	}, mq.Status.PullRequests)
+	require.Equal(t, &cicdv1.MergeQueueBatch{PullRequests: []int{12}, IntegrationJob: "batch-ij"}, mq.Status.CurrentBatch)
+	require.NotNil(t, mq.Status.LastUpdateTime)
+
+	// Restore into a new pool (i.e., after a restart)
+	restored := NewPRPool(testICNamespace, testICName)
+	require.NoError(t, b.restoreMergeQueue(restored))
+	require.Equal(t, pool.CurrentBatch.Job, restored.CurrentBatch.Job)
+	require.Equal(t, []int{12}, []int{restored.CurrentBatch.PRs[0].ID})
+
+	// Link the batch to the synced PRs
+	restored.PullRequests[pr2.ID] = pr2
+	linkBatch(restored)
+	require.Equal(t, pr2, restored.CurrentBatch.PRs[0])
+
+	// Delete
+	require.NoError(t, b.deleteMergeQueue(pool))
+	require.True(t, errors.IsNotFound(cli.Get(context.Background(), pool.NamespacedName, mq)))
+	require.NoError(t, b.deleteMergeQueue(pool))
+}
+
+func TestBlocker_restoreMergeQueue(t *testing.T) {
+	tc := map[string]struct {
+		mq *cicdv1.MergeQueue
+
+		expectedBatch *Batch
+	}{
+		"noMergeQueue": {},
+		"noBatch": {
+			mq: &cicdv1.MergeQueue{Status: cicdv1.MergeQueueStatus{PullRequests: []cicdv1.MergeQueuePullRequest{{ID: 12}}}},
+		},
+		"batchWithoutJob": {
+			mq: &cicdv1.MergeQueue{Status: cicdv1.MergeQueueStatus{CurrentBatch: &cicdv1.MergeQueueBatch{PullRequests: []int{12}}}},
+		},
+		"batch": {
+			mq: &cicdv1.MergeQueue{Status: cicdv1.MergeQueueStatus{CurrentBatch: &cicdv1.MergeQueueBatch{PullRequests: []int{12, 23}, IntegrationJob: "batch-ij"}}},
+			expectedBatch: &Batch{
+				PRs: []*PullRequest{{PullRequest: git.PullRequest{ID: 12}}, {PullRequest: git.PullRequest{ID: 23}}},
+				Job: types.NamespacedName{Name: "batch-ij", Namespace: testICNamespace},
+			},
+		},
+		"bisectedBatch": {
+			mq: &cicdv1.MergeQueue{Status: cicdv1.MergeQueueStatus{CurrentBatch: &cicdv1.MergeQueueBatch{PullRequests: []int{12}, IntegrationJob: "batch-ij", Remaining: [][]int{{23}, {37, 41}}}}},
+			expectedBatch: &Batch{
+				PRs: []*PullRequest{{PullRequest: git.PullRequest{ID: 12}}},
+				Job: types.NamespacedName{Name: "batch-ij", Namespace: testICNamespace},
+				Remaining: [][]*PullRequest{
+					{{PullRequest: git.PullRequest{ID: 23}}},
+					{{PullRequest: git.PullRequest{ID: 37}}, {PullRequest: git.PullRequest{ID: 41}}},
+				},
+			},
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			_, cli := mergeTestConfig()
+			if c.mq != nil {
+				c.mq.Name = testICName
+				c.mq.Namespace = testICNamespace
+				require.NoError(t, cli.Create(context.Background(), c.mq))
+			}
+
+			pool := NewPRPool(testICNamespace, testICName)
+			require.NoError(t, New(cli).restoreMergeQueue(pool))
+			require.Equal(t, c.expectedBatch, pool.CurrentBatch)
+		})
+	}
+}
+
+func TestBlocker_loadMergeQueueSpec(t *testing.T) {
+	_, cli := mergeTestConfig()
+	b := New(cli)
+	pool := NewPRPool(testICNamespace, testICName)
+
+	// No MergeQueue
+	require.NoError(t, b.loadMergeQueueSpec(pool))
+	require.Nil(t, pool.Front)
+
+	require.NoError(t, cli.Create(context.Background(), &cicdv1.MergeQueue{
+		ObjectMeta: metav1.ObjectMeta{Name: testICName, Namespace: testICNamespace},
+		Spec: cicdv1.MergeQueueSpec{
+			Front:   []int{23, 12},
+			Removed: []cicdv1.MergeQueueRemovedPullRequest{{ID: 37, Sha: testSHA}},
+		},
+	}))
+	require.NoError(t, b.loadMergeQueueSpec(pool))
+	require.Equal(t, []int{23, 12}, pool.Front)
+	require.Equal(t, map[int]string{37: testSHA}, pool.Removed)
+}
+
+func TestLinkBatch(t *testing.T) {
+	pool := NewPRPool(testICNamespace, testICName)
+	pr := &PullRequest{PullRequest: git.PullRequest{ID: 12, Title: "synced"}}
+	pool.PullRequests[pr.ID] = pr
+
+	pool.CurrentBatch = &Batch{PRs: []*PullRequest{{PullRequest: git.PullRequest{ID: 12}}, {PullRequest: git.PullRequest{ID: 23}}}}
+	linkBatch(pool)
+	require.Equal(t, []*PullRequest{pr}, pool.CurrentBatch.PRs)
+
+	// Remaining groups of a bisected batch
+	pool.CurrentBatch.Remaining = [][]*PullRequest{{{PullRequest: git.PullRequest{ID: 12}}}, {{PullRequest: git.PullRequest{ID: 23}}}}
+	linkBatch(pool)
+	require.Equal(t, [][]*PullRequest{{pr}}, pool.CurrentBatch.Remaining)
+
+	// Every PR is closed
+	delete(pool.PullRequests, pr.ID)
+	linkBatch(pool)
+	require.Nil(t, pool.CurrentBatch)
+}
@@ -59,16 +59,52 @@ func TestSortPullRequestByID(t *testing.T) {
 	assert.Equal(t, 72, sorted[2].ID, "3rd PR")
 }
 
+func TestSortPullRequestByQueue(t *testing.T) {
+	configs.MergePriorityHighLabel = "merge/priority-high"
+	configs.MergePriorityLowLabel = "merge/priority-low"
+
+	prs := map[int]*PullRequest{
+		13: {PullRequest: git.PullRequest{ID: 13}},
+		6:  {PullRequest: git.PullRequest{ID: 6, Labels: []git.IssueLabel{{Name: "merge/priority-low"}}}},
+		72: {PullRequest: git.PullRequest{ID: 72, Labels: []git.IssueLabel{{Name: "merge/priority-high"}}}},
+		44: {PullRequest: git.PullRequest{ID: 44}},
+		51: {PullRequest: git.PullRequest{ID: 51}},
+	}
+
+	tc := map[string]struct {
+		front       []int
+		expectedIDs []int
+	}{
+		"priority": {
+			expectedIDs: []int{72, 13, 44, 51, 6},
+		},
+		"front": {
+			front:       []int{51, 6, 99},
+			expectedIDs: []int{51, 6, 72, 13, 44},
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, c.expectedIDs, getPRIDs(sortPullRequestByQueue(prs, c.front)))
+		})
+	}
+}
+
 func TestBlocker_retestAndMergeOnePool(t *testing.T) {
 	tc := map[string]struct {
 		prs           []*PullRequest
 		baseSHA       string
 		existingBatch *Batch
 		existingJob   *cicdv1.IntegrationJob
-
-		expectedIJRefPulls   []cicdv1.IntegrationJobRefsPull
-		expectedBatchCreated bool
-		expectedPRMerged     bool
+		freeze        *cicdv1.MergeFreeze
+		method        git.MergeMethod
+		updateBranch  bool
+
+		expectedIJRefPulls    []cicdv1.IntegrationJobRefsPull
+		expectedBatchCreated  bool
+		expectedPRMerged      bool
+		expectedBranchUpdated bool
 	}{
 		"successful": {
 			baseSHA: "22ccae53032027186ba739dfaa473ee61a82b298",
@@ -89,6 +125,26 @@ func TestBlocker_retestAndMergeOnePool(t *testing.T) {
 			},
 			expectedPRMerged: true,
 		},
+		"frozen": {
+			baseSHA: "22ccae53032027186ba739dfaa473ee61a82b298",
+			prs: []*PullRequest{
+				{
+					PullRequest: git.PullRequest{
+						ID:        12,
+						Base:      git.Base{Ref: "master", Sha: "22ccae53032027186ba739dfaa473ee61a82b298"},
+						Head:      git.Head{Ref: "newnew", Sha: "3196ccc37bcae94852079b04fcbfaf928341d6e9"},
+						Mergeable: true,
+						State:     git.PullRequestStateOpen,
+					},
+					BlockerStatus: git.CommitStatusStateSuccess,
+					Statuses: map[string]git.CommitStatus{
+						"test-1": {Context: "test-1", State: git.CommitStatusStateSuccess, Description: "Job is successful    BaseSHA:22ccae53032027186ba739dfaa473ee61a82b298"},
+					},
+				},
+			},
+			freeze:           &cicdv1.MergeFreeze{Reason: "release"},
+			expectedPRMerged: false,
+		},
 		"baseUpdates": {
 			baseSHA: "32cd89e8d07e37ab26d8c735090ae763884283db",
 			prs: []*PullRequest{
@@ -111,6 +167,66 @@ func TestBlocker_retestAndMergeOnePool(t *testing.T) {
 			},
 			expectedBatchCreated: true,
 		},
+		"baseUpdatesUpdateBranch": {
+			baseSHA: "32cd89e8d07e37ab26d8c735090ae763884283db",
+			prs: []*PullRequest{
+				{
+					PullRequest: git.PullRequest{
+						ID:        12,
+						Base:      git.Base{Ref: "master", Sha: "22ccae53032027186ba739dfaa473ee61a82b298"},
+						Head:      git.Head{Ref: "newnew", Sha: "3196ccc37bcae94852079b04fcbfaf928341d6e9"},
+						Mergeable: true,
+						State:     git.PullRequestStateOpen,
+					},
+					BlockerStatus: git.CommitStatusStateSuccess,
+					Statuses: map[string]git.CommitStatus{
+						"test-1": {Context: "test-1", State: git.CommitStatusStateSuccess, Description: "Job is successful    BaseSHA:22ccae53032027186ba739dfaa473ee61a82b298"},
+					},
+				},
+			},
+			updateBranch:          true,
+			expectedBranchUpdated: true,
+		},
+		"fastForward": {
+			baseSHA: "22ccae53032027186ba739dfaa473ee61a82b298",
+			prs: []*PullRequest{
+				{
+					PullRequest: git.PullRequest{
+						ID:        12,
+						Base:      git.Base{Ref: "master", Sha: "22ccae53032027186ba739dfaa473ee61a82b298"},
+						Head:      git.Head{Ref: "newnew", Sha: "3196ccc37bcae94852079b04fcbfaf928341d6e9"},
+						Mergeable: true,
+						State:     git.PullRequestStateOpen,
+					},
+					BlockerStatus: git.CommitStatusStateSuccess,
+					Statuses: map[string]git.CommitStatus{
+						"test-1": {Context: "test-1", State: git.CommitStatusStateSuccess, Description: "Job is successful    BaseSHA:22ccae53032027186ba739dfaa473ee61a82b298"},
+					},
+				},
+			},
+			method:           git.MergeMethodFastForward,
+			expectedPRMerged: true,
+		},
+		"fastForwardBaseUpdates": {
+			baseSHA: "32cd89e8d07e37ab26d8c735090ae763884283db",
+			prs: []*PullRequest{
+				{
+					PullRequest: git.PullRequest{
+						ID:        12,
+						Base:      git.Base{Ref: "master", Sha: "22ccae53032027186ba739dfaa473ee61a82b298"},
+						Head:      git.Head{Ref: "newnew", Sha: "3196ccc37bcae94852079b04fcbfaf928341d6e9"},
+						Mergeable: true,
+						State:     git.PullRequestStateOpen,
+					},
+					BlockerStatus: git.CommitStatusStateSuccess,
+					Statuses: map[string]git.CommitStatus{
+						"test-1": {Context: "test-1", State: git.CommitStatusStateSuccess, Description: "Job is successful    BaseSHA:22ccae53032027186ba739dfaa473ee61a82b298"},
+					},
+				},
+			},
+			method:                git.MergeMethodFastForward,
+			expectedBranchUpdated: true,
+		},
 		"multiplePRsRetest": {
 			baseSHA: "32cd89e8d07e37ab26d8c735090ae763884283db",
 			prs: []*PullRequest{
@@ -222,8 +338,12 @@ func TestBlocker_retestAndMergeOnePool(t *testing.T) {
 			// Init
 			ic, cli := mergeTestConfig()
 			b := New(cli)
+			ic.Spec.MergeConfig.Freeze = c.freeze
+			ic.Spec.MergeConfig.Method = c.method
+			ic.Spec.MergeConfig.UpdateBranch = c.updateBranch
+			require.NoError(t, cli.Update(context.Background(), ic))
 			gitfake.Repos = map[string]*gitfake.Repo{
-				ic.Spec.Git.Repository: {PullRequests: map[int]*git.PullRequest{}, Commits: map[string][]git.Commit{}},
+				ic.Spec.Git.Repository: {PullRequests: map[int]*git.PullRequest{}, Commits: map[string][]git.Commit{}, CommitStatuses: map[string][]git.CommitStatus{}},
 			}
 			gitfake.Branches = map[string]*git.Branch{
 				"master": {CommitID: c.baseSHA},
@@ -277,6 +397,21 @@ func TestBlocker_retestAndMergeOnePool(t *testing.T) {
 					require.Equal(t, git.PullRequestStateOpen, gitfake.Repos[ic.Spec.Git.Repository].PullRequests[pr.ID].State)
 				}
 			}
+			for _, pr := range c.prs {
+				if c.expectedBranchUpdated {
+					require.Equal(t, c.baseSHA, gitfake.Repos[ic.Spec.Git.Repository].PullRequests[pr.ID].Base.Sha)
+					require.Equal(t, git.CommitStatusStatePending, pr.BlockerStatus)
+				} else if c.freeze == nil {
+					require.Equal(t, git.CommitStatusStateSuccess, pr.BlockerStatus)
+				}
+			}
+			if c.freeze != nil {
+				for _, pr := range c.prs {
+					statuses := gitfake.Repos[ic.Spec.Git.Repository].CommitStatuses[pr.Head.Sha]
+					require.NotEmpty(t, statuses)
+					require.Equal(t, git.CommitStatusStatePending, statuses[len(statuses)-1].State)
+				}
+			}
 		})
 	}
 }
@@ -327,6 +462,160 @@ func TestBlocker_handleBatch(t *testing.T) {
 		}
 		assert.Equal(t, true, pool.CurrentBatch == nil, "CurrentBatch cleared")
 	})
+
+	// TEST 3 - Batch IJ is deleted
+	t.Run("batch_ij_not_found", func(t *testing.T) {
+		pool.CurrentBatch = &Batch{
+			PRs: []*PullRequest{{PullRequest: git.PullRequest{ID: 12}}},
+			Job: types.NamespacedName{Name: "test-ij-deleted", Namespace: testICNamespace},
+		}
+		if err := b.handleBatch(pool, ic, gitCli); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, true, pool.CurrentBatch == nil, "CurrentBatch dropped")
+	})
+}
+
+func TestBlocker_bisectBatch(t *testing.T) {
+	ic, cli := mergeTestConfig()
+	ic.Spec.MergeConfig.BatchStrategy = cicdv1.BatchStrategyBisect
+	gitfake.Repos = map[string]*gitfake.Repo{
+		testRepo: {Comments: map[int][]git.IssueComment{}},
+	}
+	gitCli, _ := utils.GetGitCli(ic, cli)
+	b := New(cli)
+	pool := NewPRPool(testICNamespace, testICName)
+
+	var prs []*PullRequest
+	for _, id := range []int{12, 23, 37} {
+		pr := &PullRequest{PullRequest: git.PullRequest{ID: id, Head: git.Head{Sha: testSHA}}, BlockerStatus: git.CommitStatusStateSuccess}
+		pool.PullRequests[id] = pr
+		pool.MergePool.Add(pr)
+		prs = append(prs, pr)
+	}
+
+	failIJ := &cicdv1.IntegrationJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-ij-bisect", Namespace: testICNamespace},
+	}
+	require.NoError(t, cli.Create(context.Background(), failIJ))
+	failIJ.Status.State = cicdv1.IntegrationJobStateFailed
+	failIJ.Status.Jobs = []cicdv1.JobStatus{{Name: "test-1", State: cicdv1.CommitStatusStateFailure}, {Name: "test-2", State: cicdv1.CommitStatusStateSuccess}}
+	require.NoError(t, cli.Status().Update(context.Background(), failIJ))
+
+	// Whole batch fails - bisect
+	pool.CurrentBatch = &Batch{PRs: prs, Job: types.NamespacedName{Name: failIJ.Name, Namespace: testICNamespace}}
+	require.NoError(t, b.handleBatch(pool, ic, gitCli))
+	require.Equal(t, []int{12, 23}, getPRIDs(pool.CurrentBatch.PRs))
+	require.Len(t, pool.CurrentBatch.Remaining, 1)
+	require.Equal(t, []int{37}, getPRIDs(pool.CurrentBatch.Remaining[0]))
+	require.True(t, pool.CurrentBatch.Contains(37))
+
+	// First half fails - bisect again
+	pool.CurrentBatch.Job = types.NamespacedName{Name: failIJ.Name, Namespace: testICNamespace}
+	require.NoError(t, b.handleBatch(pool, ic, gitCli))
+	require.Equal(t, []int{12}, getPRIDs(pool.CurrentBatch.PRs))
+	require.Len(t, pool.CurrentBatch.Remaining, 2)
+	require.Equal(t, []int{23}, getPRIDs(pool.CurrentBatch.Remaining[0]))
+
+	// Single PR fails - culprit is kicked out and the next group is tested
+	pool.CurrentBatch.Job = types.NamespacedName{Name: failIJ.Name, Namespace: testICNamespace}
+	require.NoError(t, b.handleBatch(pool, ic, gitCli))
+	require.Equal(t, []int{23}, getPRIDs(pool.CurrentBatch.PRs))
+	require.Len(t, pool.CurrentBatch.Remaining, 1)
+	require.NotContains(t, pool.MergePool[git.CommitStatusStateSuccess], 12)
+	require.Contains(t, pool.MergePool[git.CommitStatusStatePending], 12)
+	require.Len(t, gitfake.Repos[testRepo].Comments[12], 1)
+	require.Contains(t, gitfake.Repos[testRepo].Comments[12][0].Comment.Body, "/report/default/test-ij-bisect/test-1)")
+	require.NotContains(t, gitfake.Repos[testRepo].Comments[12][0].Comment.Body, "test-2")
+}
+
+func TestUpdatePullRequestBranch(t *testing.T) {
+	tc := map[string]struct {
+		gitType  cicdv1.GitType
+		method   git.MergeMethod
+		noPR     bool
+		expected string
+
+		expectedErrMsg string
+		expectedHeld   bool
+		expectedBase   string
+	}{
+		"updated": {
+			gitType:      cicdv1.GitTypeFake,
+			method:       git.MergeMethodFastForward,
+			expected:     "Branch is updated onto the latest base. Waiting for the tests.",
+			expectedBase: "32cd89e8d07e37ab26d8c735090ae763884283db",
+		},
+		"githubFastForward": {
+			gitType:      cicdv1.GitTypeGitHub,
+			method:       git.MergeMethodFastForward,
+			expected:     "Branch is behind the base. Rebase it onto the latest base to be fast-forwarded.",
+			expectedHeld: true,
+			expectedBase: "22ccae53032027186ba739dfaa473ee61a82b298",
+		},
+		"updateFailed": {
+			gitType:        cicdv1.GitTypeFake,
+			method:         git.MergeMethodMerge,
+			noPR:           true,
+			expected:       "Failed to update the branch onto the latest base. Please update it manually.",
+			expectedErrMsg: "404 no such pr",
+			expectedHeld:   true,
+			expectedBase:   "22ccae53032027186ba739dfaa473ee61a82b298",
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			ic, _ := mergeTestConfig()
+			ic.Spec.Git.Type = c.gitType
+			ic.Spec.MergeConfig.Method = c.method
+
+			pr := &PullRequest{
+				PullRequest: git.PullRequest{
+					ID:   12,
+					Base: git.Base{Ref: "master", Sha: "22ccae53032027186ba739dfaa473ee61a82b298"},
+					Head: git.Head{Ref: "newnew", Sha: "3196ccc37bcae94852079b04fcbfaf928341d6e9"},
+				},
+				BlockerStatus: git.CommitStatusStateSuccess,
+			}
+			gitfake.Repos = map[string]*gitfake.Repo{
+				ic.Spec.Git.Repository: {PullRequests: map[int]*git.PullRequest{}},
+			}
+			if !c.noPR {
+				gitfake.Repos[ic.Spec.Git.Repository].PullRequests[pr.ID] = &pr.PullRequest
+			}
+			gitfake.Branches = map[string]*git.Branch{
+				"master": {CommitID: "32cd89e8d07e37ab26d8c735090ae763884283db"},
+			}
+			pool := NewPRPool(testICNamespace, testICName)
+			pool.PullRequests[pr.ID] = pr
+			pool.MergePool.Add(pr)
+
+			err := updatePullRequestBranch(pool, pr, ic, &gitfake.Client{IntegrationConfig: ic})
+			if c.expectedErrMsg != "" {
+				require.Error(t, err)
+				require.Equal(t, c.expectedErrMsg, err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, c.expectedBase, pr.Base.Sha)
+			require.NotNil(t, pool.MergePool[git.CommitStatusStatePending][pr.ID])
+			require.Equal(t, c.expected, pr.BlockerDescription)
+			if c.expectedHeld {
+				require.Equal(t, pr.Head.Sha, pr.heldSha)
+			} else {
+				require.Empty(t, pr.heldSha)
+			}
+		})
+	}
+}
+
+func TestGetBatchSize(t *testing.T) {
+	ic := &cicdv1.IntegrationConfig{Spec: cicdv1.IntegrationConfigSpec{MergeConfig: &cicdv1.MergeConfig{}}}
+	require.Equal(t, defaultBatchSize, getBatchSize(ic))
+
+	ic.Spec.MergeConfig.BatchSize = 4
+	require.Equal(t, 4, getBatchSize(ic))
 }
 
 func TestBlocker_mergePullRequest(t *testing.T) {
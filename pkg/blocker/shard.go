@@ -0,0 +1,56 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package blocker
+
+import (
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strconv"
+)
+
+// Shard is a shard of the blocker. When the blocker is scaled out, each shard handles only the pools whose key's
+// hash modulo Total equals Index, so that git API calls are spread over the replicas
+type Shard struct {
+	Index int
+	Total int
+}
+
+// owns checks if the pool belongs to the shard
+func (s Shard) owns(key poolKey) bool {
+	if s.Total <= 1 {
+		return true
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum32()%uint32(s.Total)) == s.Index
+}
+
+var ordinalRegexp = regexp.MustCompile(`-([0-9]+)$`)
+
+// ShardIndexFromHostname gets the shard index from the ordinal of the StatefulSet pod's hostname (e.g., blocker-3)
+func ShardIndexFromHostname(hostname string, total int) (int, error) {
+	match := ordinalRegexp.FindStringSubmatch(hostname)
+	if match == nil {
+		return 0, fmt.Errorf("hostname %s does not have an ordinal suffix", hostname)
+	}
+	ordinal, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, err
+	}
+	return ordinal % total, nil
+}
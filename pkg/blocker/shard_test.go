@@ -0,0 +1,85 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package blocker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShard_owns(t *testing.T) {
+	keys := []poolKey{"api.github.com/tmax-cloud/cicd-operator", "api.github.com/tmax-cloud/hypercloud", "gitlab.com/tmax/test", "github.com/a/b"}
+
+	// Single shard owns every pool
+	for _, key := range keys {
+		require.True(t, Shard{}.owns(key))
+		require.True(t, Shard{Index: 0, Total: 1}.owns(key))
+	}
+
+	// Each pool is owned by exactly one shard
+	for _, key := range keys {
+		owners := 0
+		for i := 0; i < 3; i++ {
+			if (Shard{Index: i, Total: 3}).owns(key) {
+				owners++
+			}
+		}
+		require.Equal(t, 1, owners, string(key))
+	}
+}
+
+func TestShardIndexFromHostname(t *testing.T) {
+	tc := map[string]struct {
+		hostname string
+		total    int
+
+		errorOccurs   bool
+		errorMessage  string
+		expectedIndex int
+	}{
+		"normal": {
+			hostname:      "blocker-1",
+			total:         2,
+			expectedIndex: 1,
+		},
+		"modulo": {
+			hostname:      "blocker-5",
+			total:         2,
+			expectedIndex: 1,
+		},
+		"noOrdinal": {
+			hostname:     "blocker-5d8f7c9b6-x2kqp",
+			total:        2,
+			errorOccurs:  true,
+			errorMessage: "hostname blocker-5d8f7c9b6-x2kqp does not have an ordinal suffix",
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			index, err := ShardIndexFromHostname(c.hostname, c.total)
+			if c.errorOccurs {
+				require.Error(t, err)
+				require.Equal(t, c.errorMessage, err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, c.expectedIndex, index)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package blocker
+
+import (
+	"fmt"
+
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/pkg/git"
+)
+
+// stack.go contains methods for the stacked PRs, i.e., PRs whose base branch is the head branch of another open PR.
+// A stacked PR is held in the merge pool until its parent PR is merged.
+// After the parent is merged, the stacked PRs are retargeted to the parent's base branch and are merged in order.
+
+// findParent finds the parent of the PR, i.e., an open PR whose head branch is the base branch of the PR.
+// The base branch should point to the parent's head commit, so that a PR from a fork whose branch has the same name
+// is not mistaken for the parent. pool.lock should be held by the caller
+func findParent(pool *PRPool, pr *PullRequest, gitCli git.Client) (*PullRequest, error) {
+	branch := cicdv1.GitRef(pr.Base.Ref).GetBranch()
+
+	var candidates []*PullRequest
+	for _, p := range sortPullRequestByID(pool.PullRequests) {
+		if p.ID != pr.ID && p.State == git.PullRequestStateOpen && cicdv1.GitRef(p.Head.Ref).GetBranch() == branch {
+			candidates = append(candidates, p)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	base, err := gitCli.GetBranch(branch)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range candidates {
+		if p.Head.Sha == base.CommitID {
+			return p, nil
+		}
+	}
+	return nil, nil
+}
+
+// retargetChildren retargets the PRs stacked on the merged PR to its base branch.
+// pool.lock should be held by the caller
+func (b *blocker) retargetChildren(pool *PRPool, ic *cicdv1.IntegrationConfig, parent *PullRequest, gitCli git.Client) {
+	log := b.log.WithName("merger").WithValues("repo", genPoolKey(ic))
+
+	branch := cicdv1.GitRef(parent.Head.Ref).GetBranch()
+	baseBranch := cicdv1.GitRef(parent.Base.Ref).GetBranch()
+
+	var children []*PullRequest
+	for _, pr := range sortPullRequestByID(pool.PullRequests) {
+		if pr.ID != parent.ID && cicdv1.GitRef(pr.Base.Ref).GetBranch() == branch {
+			children = append(children, pr)
+		}
+	}
+	if len(children) == 0 {
+		return
+	}
+
+	// Skip if the head branch is not the one of this repository (i.e., a branch of a fork).
+	// If the head branch is deleted right after the merge, the git server retargets the children by itself
+	head, err := gitCli.GetBranch(branch)
+	if err != nil {
+		if !git.IsNotFound(err) {
+			log.Error(err, "")
+		}
+		return
+	}
+	if head.CommitID != parent.Head.Sha {
+		return
+	}
+
+	for _, pr := range children {
+		log.Info(fmt.Sprintf("Retargeting PR #%d to %s, as its parent PR #%d is merged", pr.ID, baseBranch, parent.ID))
+		if err := gitCli.UpdatePullRequestBase(pr.ID, baseBranch); err != nil {
+			log.Error(err, "")
+			continue
+		}
+		pr.Base.Ref = parent.Base.Ref
+	}
+}
@@ -0,0 +1,159 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package blocker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmax-cloud/cicd-operator/internal/utils"
+	"github.com/tmax-cloud/cicd-operator/pkg/git"
+	gitfake "github.com/tmax-cloud/cicd-operator/pkg/git/fake"
+)
+
+func TestFindParent(t *testing.T) {
+	tc := map[string]struct {
+		prs      []git.PullRequest
+		branches map[string]*git.Branch
+		id       int
+
+		expectedParent int
+		expectedErrMsg string
+	}{
+		"notStacked": {
+			prs: []git.PullRequest{
+				{ID: 1, State: git.PullRequestStateOpen, Base: git.Base{Ref: "master"}, Head: git.Head{Ref: "feat-1", Sha: "sha-1"}},
+				{ID: 2, State: git.PullRequestStateOpen, Base: git.Base{Ref: "master"}, Head: git.Head{Ref: "feat-2", Sha: "sha-2"}},
+			},
+			id: 2,
+		},
+		"stacked": {
+			prs: []git.PullRequest{
+				{ID: 1, State: git.PullRequestStateOpen, Base: git.Base{Ref: "master"}, Head: git.Head{Ref: "feat-1", Sha: "sha-1"}},
+				{ID: 2, State: git.PullRequestStateOpen, Base: git.Base{Ref: "feat-1"}, Head: git.Head{Ref: "feat-2", Sha: "sha-2"}},
+			},
+			branches:       map[string]*git.Branch{"feat-1": {Name: "feat-1", CommitID: "sha-1"}},
+			id:             2,
+			expectedParent: 1,
+		},
+		"forkSameBranchName": {
+			prs: []git.PullRequest{
+				{ID: 1, State: git.PullRequestStateOpen, Base: git.Base{Ref: "master"}, Head: git.Head{Ref: "release-1", Sha: "sha-fork"}},
+				{ID: 2, State: git.PullRequestStateOpen, Base: git.Base{Ref: "release-1"}, Head: git.Head{Ref: "fix", Sha: "sha-2"}},
+			},
+			branches: map[string]*git.Branch{"release-1": {Name: "release-1", CommitID: "sha-release"}},
+			id:       2,
+		},
+		"parentClosed": {
+			prs: []git.PullRequest{
+				{ID: 1, State: git.PullRequestStateClosed, Base: git.Base{Ref: "master"}, Head: git.Head{Ref: "feat-1", Sha: "sha-1"}},
+				{ID: 2, State: git.PullRequestStateOpen, Base: git.Base{Ref: "feat-1"}, Head: git.Head{Ref: "feat-2", Sha: "sha-2"}},
+			},
+			id: 2,
+		},
+		"noBranch": {
+			prs: []git.PullRequest{
+				{ID: 1, State: git.PullRequestStateOpen, Base: git.Base{Ref: "master"}, Head: git.Head{Ref: "feat-1", Sha: "sha-1"}},
+				{ID: 2, State: git.PullRequestStateOpen, Base: git.Base{Ref: "feat-1"}, Head: git.Head{Ref: "feat-2", Sha: "sha-2"}},
+			},
+			branches:       map[string]*git.Branch{},
+			id:             2,
+			expectedErrMsg: "404 no such branch (feat-1)",
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			ic, cli := mergeTestConfig()
+			gitCli, err := utils.GetGitCli(ic, cli)
+			require.NoError(t, err)
+			gitfake.Branches = c.branches
+
+			pool := NewPRPool(testICNamespace, testICName)
+			for _, pr := range c.prs {
+				pool.PullRequests[pr.ID] = &PullRequest{PullRequest: pr}
+			}
+
+			parent, err := findParent(pool, pool.PullRequests[c.id], gitCli)
+			if c.expectedErrMsg != "" {
+				require.Error(t, err)
+				require.Equal(t, c.expectedErrMsg, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			if c.expectedParent == 0 {
+				require.Nil(t, parent)
+			} else {
+				require.NotNil(t, parent)
+				require.Equal(t, c.expectedParent, parent.ID)
+			}
+		})
+	}
+}
+
+func TestBlocker_retargetChildren(t *testing.T) {
+	tc := map[string]struct {
+		branches map[string]*git.Branch
+
+		expectedBases map[int]string
+	}{
+		"retargeted": {
+			branches:      map[string]*git.Branch{"feat-1": {Name: "feat-1", CommitID: "sha-1"}},
+			expectedBases: map[int]string{2: "master", 3: "master", 4: "feat-2"},
+		},
+		"headBranchDeleted": {
+			branches:      map[string]*git.Branch{},
+			expectedBases: map[int]string{2: "feat-1", 3: "feat-1", 4: "feat-2"},
+		},
+		"forkSameBranchName": {
+			branches:      map[string]*git.Branch{"feat-1": {Name: "feat-1", CommitID: "sha-other"}},
+			expectedBases: map[int]string{2: "feat-1", 3: "feat-1", 4: "feat-2"},
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			ic, cli := mergeTestConfig()
+			gitCli, err := utils.GetGitCli(ic, cli)
+			require.NoError(t, err)
+			b := New(cli)
+			gitfake.Branches = c.branches
+			gitfake.Repos = map[string]*gitfake.Repo{
+				ic.Spec.Git.Repository: {PullRequests: map[int]*git.PullRequest{}},
+			}
+
+			pool := NewPRPool(testICNamespace, testICName)
+			for _, pr := range []git.PullRequest{
+				{ID: 1, State: git.PullRequestStateOpen, Base: git.Base{Ref: "master"}, Head: git.Head{Ref: "feat-1", Sha: "sha-1"}},
+				{ID: 2, State: git.PullRequestStateOpen, Base: git.Base{Ref: "feat-1"}, Head: git.Head{Ref: "feat-2", Sha: "sha-2"}},
+				{ID: 3, State: git.PullRequestStateOpen, Base: git.Base{Ref: "feat-1"}, Head: git.Head{Ref: "feat-3", Sha: "sha-3"}},
+				{ID: 4, State: git.PullRequestStateOpen, Base: git.Base{Ref: "feat-2"}, Head: git.Head{Ref: "feat-4", Sha: "sha-4"}},
+			} {
+				p := pr
+				pool.PullRequests[p.ID] = &PullRequest{PullRequest: p}
+				gitfake.Repos[ic.Spec.Git.Repository].PullRequests[p.ID] = &p
+			}
+
+			b.retargetChildren(pool, ic, pool.PullRequests[1], gitCli)
+
+			for id, base := range c.expectedBases {
+				require.Equal(t, base, pool.PullRequests[id].Base.Ref, "pool PR #%d", id)
+				require.Equal(t, base, gitfake.Repos[ic.Spec.Git.Repository].PullRequests[id].Base.Ref, "git PR #%d", id)
+			}
+		})
+	}
+}
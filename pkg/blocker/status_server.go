@@ -19,6 +19,7 @@ package blocker
 import (
 	"fmt"
 	"github.com/gorilla/mux"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
 	"github.com/tmax-cloud/cicd-operator/internal/utils"
 	"github.com/tmax-cloud/cicd-operator/pkg/git"
 	"net/http"
@@ -30,6 +31,13 @@ import (
 const StatusPort = 8808
 
 func (b *blocker) StartBlockerStatusServer() {
+	token, err := ensureEventToken(b.client)
+	if err != nil {
+		b.log.Error(err, "cannot get the event token")
+		os.Exit(1)
+	}
+	b.eventToken = token
+
 	if err := http.ListenAndServe(fmt.Sprintf("0.0.0.0:%d", StatusPort), b.newRouter()); err != nil {
 		b.log.Error(err, "")
 		os.Exit(1)
@@ -40,6 +48,11 @@ func (b *blocker) newRouter() *mux.Router {
 	router := mux.NewRouter()
 	router.HandleFunc("/status", b.handleStatusList)
 	router.PathPrefix("/status").HandlerFunc(b.handleStatus)
+	router.HandleFunc("/pools", b.handlePoolList).Methods(http.MethodGet)
+	router.HandleFunc("/pools/{namespace}/{config}", b.handlePool).Methods(http.MethodGet)
+	router.HandleFunc("/dashboard", b.handleDashboard).Methods(http.MethodGet)
+	router.HandleFunc("/dashboard/{namespace}/{config}", b.handleDashboardPool).Methods(http.MethodGet)
+	router.HandleFunc(EventPath, b.handleEvent).Methods(http.MethodPost)
 	return router
 }
 
@@ -96,6 +109,7 @@ func (b *blocker) handleStatus(w http.ResponseWriter, req *http.Request) {
 		PullRequests:     prs,
 		MergePoolSuccess: poolSuccess,
 		MergePoolPending: poolPending,
+		Queue:            generateMergeQueueStatus(pool).PullRequests,
 		Retesting:        pool.CurrentBatch != nil,
 		RetestingBatch:   batch,
 	})
@@ -107,6 +121,9 @@ type statusEntity struct {
 	MergePoolSuccess []int `json:"merge_pool_success"`
 	MergePoolPending []int `json:"merge_pool_pending"`
 
+	// Queue is the PRs in the merge pool, with their positions in the queue
+	Queue []cicdv1.MergeQueuePullRequest `json:"queue"`
+
 	Retesting      bool  `json:"retesting"`
 	RetestingBatch []int `json:"retesting_batch"`
 }
@@ -27,7 +27,7 @@ import (
 )
 
 // sync_pool.go contains blocker's methods for synchronizing PR pools.
-// It lists pull requests for each IntegrationConfig periodically.
+// It lists pull requests for each IntegrationConfig periodically, as a safety net for the events (see event.go).
 // Then, it checks if the pull requests meet the conditions to be merged. (e.g., branch, label, author, ...)
 // (Commit status, merge conflict is not checked here, because those information is not available in list API)
 // If all the conditions are met, it is added to a merge pool.
@@ -35,8 +35,12 @@ import (
 func (b *blocker) loopSyncPRs() {
 	b.log.Info("Starting syncPR loop")
 	for {
-		<-time.After(time.Until(b.lastPoolSync.Add(time.Duration(configs.MergeSyncPeriod) * time.Minute)))
-		b.syncPRs()
+		select {
+		case <-time.After(time.Until(b.lastPoolSync.Add(time.Duration(configs.MergeResyncPeriod) * time.Minute))):
+			b.syncPRs()
+		case ev := <-b.events:
+			b.syncEvent(ev)
+		}
 	}
 }
 
@@ -60,6 +64,10 @@ func (b *blocker) syncPRs() {
 		if ic.Spec.Git.Token == nil || ic.Spec.MergeConfig == nil {
 			continue
 		}
+		// Skip if the pool belongs to another shard
+		if !b.shard.owns(genPoolKey(&ic)) {
+			continue
+		}
 		key := string(genPoolKey(&ic))
 		_, done := doneKeys[key]
 		if done {
@@ -71,8 +79,11 @@ func (b *blocker) syncPRs() {
 	}
 
 	// Delete redundant pools (i.e., pools for deleted IntegrationConfigs)
-	for key := range b.Pools {
+	for key, pool := range b.Pools {
 		if _, done := doneKeys[string(key)]; !done {
+			if err := b.deleteMergeQueue(pool); err != nil {
+				log.Error(err, "")
+			}
 			delete(b.Pools, key)
 		}
 	}
@@ -95,7 +106,12 @@ func (b *blocker) syncOnePool(ic *cicdv1.IntegrationConfig) {
 	// Init PRPool
 	key := genPoolKey(ic)
 	if b.Pools[key] == nil {
-		b.Pools[key] = NewPRPool(ic.Namespace, ic.Name)
+		newPool := NewPRPool(ic.Namespace, ic.Name)
+		// Restore the current batch from the MergeQueue, in case the blocker is restarted
+		if err := b.restoreMergeQueue(newPool); err != nil {
+			log.Error(err, "")
+		}
+		b.Pools[key] = newPool
 	}
 
 	pool := b.Pools[key]
@@ -108,76 +124,101 @@ func (b *blocker) syncOnePool(ic *cicdv1.IntegrationConfig) {
 
 	pool.lock.Lock()
 	defer pool.lock.Unlock()
+	defer pool.updateStatus(ic)
+
+	if err := b.loadMergeQueueSpec(pool); err != nil {
+		log.Error(err, "")
+	}
 
 	// For each PRs - check status
 	prIDs := map[int]struct{}{}
 	for _, rawPR := range prs {
 		prIDs[rawPR.ID] = struct{}{}
+		b.syncOnePR(pool, ic, rawPR)
+	}
 
-		// Initiate PullRequest object
-		pr := pool.PullRequests[rawPR.ID]
-		if pr == nil {
-			// This should be the one and only place where a PullRequest is created/added to pool.PullRequests
-			pr = &PullRequest{
-				BlockerStatus:      git.CommitStatusStatePending,
-				BlockerDescription: defaultBlockerMessage,
-				LatestSHA:          rawPR.Head.Sha,
-			}
-			pool.PullRequests[rawPR.ID] = pr
+	// Delete redundant PRs (i.e., closed/merged ones)
+	for id := range pool.PullRequests {
+		_, exist := prIDs[id]
+		if !exist {
+			pool.MergePool.Delete(id)
+			delete(pool.PullRequests, id)
 		}
-		pr.PullRequest = rawPR
+	}
+	linkBatch(pool)
 
-		// Check conditions (labels, author, branch, conflict)
-		isCandidate, addMsg := checkConditionsSimple(ic.Spec.MergeConfig.Query, &rawPR)
+	if err := b.saveMergeQueue(pool, ic); err != nil {
+		log.Error(err, "")
+	}
+}
 
-		// If it's a re-test from merge pool (i.e., in the merge pool and is in WaitingBatchTest),
-		// set it as a candidate and keep it in the merge pool.
-		// merger will remove it from the merge pool
-		if pool.CurrentBatch != nil && pool.CurrentBatch.Contains(rawPR.ID) {
-			isCandidate = true
+// syncOnePR updates a PR in the pool and adds it to/deletes it from the merge pool.
+// pool.lock should be held by the caller
+func (b *blocker) syncOnePR(pool *PRPool, ic *cicdv1.IntegrationConfig, rawPR git.PullRequest) *PullRequest {
+	log := b.log.WithName("pool").WithValues("repo", genPoolKey(ic))
+
+	// Initiate PullRequest object
+	pr := pool.PullRequests[rawPR.ID]
+	if pr == nil {
+		// This should be the one and only place where a PullRequest is created/added to pool.PullRequests
+		pr = &PullRequest{
+			BlockerStatus:      git.CommitStatusStatePending,
+			BlockerDescription: defaultBlockerMessage,
+			LatestSHA:          rawPR.Head.Sha,
 		}
+		pool.PullRequests[rawPR.ID] = pr
+	}
+	pr.PullRequest = rawPR
 
-		// Add to/delete from merge Pool
-		if isCandidate {
-			// Check if it's in merge pool and if not, add to it
-			if pool.MergePool.Search(pr.ID) == nil {
-				pool.MergePool.Add(pr)
-			}
-			// Don't set status here!
-			// Sync_status will do the job for the prs in the merge pool
-		} else {
-			// Delete from merge pool
-			pool.MergePool.Delete(pr.ID)
-
-			// Set status
-			if pr.BlockerStatus != git.CommitStatusStatePending {
-				pr.blockerCacheDirty = true
-			}
-			pr.BlockerStatus = git.CommitStatusStatePending
+	// Check conditions (labels, author, branch, conflict)
+	isCandidate, addMsg := checkConditionsSimple(ic.Spec.MergeConfig.Query, &rawPR)
 
-			// Append msg
-			desc := fmt.Sprintf("%s %s", defaultBlockerMessage, addMsg)
-			if pr.BlockerDescription != desc {
-				pr.blockerCacheDirty = true
-			}
-			pr.BlockerDescription = desc
+	// Exclude it if it's removed from the queue manually, until its head is changed
+	if sha, removed := pool.Removed[rawPR.ID]; removed && sha == rawPR.Head.Sha {
+		isCandidate = false
+		addMsg = "Removed from the merge queue."
+	}
 
-			// Latest SHA
-			if pr.LatestSHA != rawPR.Head.Sha {
-				pr.blockerCacheDirty = true
-			}
-			pr.LatestSHA = rawPR.Head.Sha
+	// If it's a re-test from merge pool (i.e., in the merge pool and is in WaitingBatchTest),
+	// set it as a candidate and keep it in the merge pool.
+	// merger will remove it from the merge pool
+	if pool.CurrentBatch != nil && pool.CurrentBatch.Contains(rawPR.ID) {
+		isCandidate = true
+	}
+
+	// Add to/delete from merge Pool
+	if isCandidate {
+		// Check if it's in merge pool and if not, add to it
+		if pool.MergePool.Search(pr.ID) == nil {
+			pool.MergePool.Add(pr)
+		}
+		// Don't set status here!
+		// Sync_status will do the job for the prs in the merge pool
+	} else {
+		// Delete from merge pool
+		pool.MergePool.Delete(pr.ID)
+
+		// Set status
+		if pr.BlockerStatus != git.CommitStatusStatePending {
+			pr.blockerCacheDirty = true
 		}
+		pr.BlockerStatus = git.CommitStatusStatePending
 
-		log.Info(fmt.Sprintf("\t[#%d](%.20s) - merge candidate: %t (%s)", pr.ID, pr.Title, isCandidate, pr.BlockerDescription))
-	}
+		// Append msg
+		desc := fmt.Sprintf("%s %s", defaultBlockerMessage, addMsg)
+		if pr.BlockerDescription != desc {
+			pr.blockerCacheDirty = true
+		}
+		pr.BlockerDescription = desc
 
-	// Delete redundant PRs (i.e., closed/merged ones)
-	for id := range pool.PullRequests {
-		_, exist := prIDs[id]
-		if !exist {
-			pool.MergePool.Delete(id)
-			delete(pool.PullRequests, id)
+		// Latest SHA
+		if pr.LatestSHA != rawPR.Head.Sha {
+			pr.blockerCacheDirty = true
 		}
+		pr.LatestSHA = rawPR.Head.Sha
 	}
+
+	log.Info(fmt.Sprintf("\t[#%d](%.20s) - merge candidate: %t (%s)", pr.ID, pr.Title, isCandidate, pr.BlockerDescription))
+
+	return pr
 }
@@ -60,6 +60,14 @@ func TestBlocker_syncPRs(t *testing.T) {
 	assert.Equal(t, 1, len(pools[genPoolKey(ic)].PullRequests), "PRList length")
 	assert.Equal(t, 1, len(pools[genPoolKey(ic)].MergePool[git.CommitStatusStatePending]), "Pending merge pool length")
 
+	// Removed from the queue
+	blocker.Pools[genPoolKey(ic)].Removed = map[int]string{testPRID: ""}
+	blocker.syncOnePR(pools[genPoolKey(ic)], ic, *gitfake.Repos[testRepo].PullRequests[testPRID])
+	assert.Equal(t, 0, len(pools[genPoolKey(ic)].MergePool[git.CommitStatusStatePending]), "Pending merge pool length")
+	blocker.Pools[genPoolKey(ic)].Removed = nil
+	blocker.syncPRs()
+	assert.Equal(t, 1, len(pools[genPoolKey(ic)].MergePool[git.CommitStatusStatePending]), "Pending merge pool length")
+
 	// New PR
 	newPRID := 24
 	gitfake.Repos[testRepo].PullRequests[newPRID] = &git.PullRequest{
@@ -98,6 +106,20 @@ func TestBlocker_syncPRs(t *testing.T) {
 	assert.Equal(t, 0, len(pools), "IC length")
 }
 
+func TestBlocker_syncPRs_shard(t *testing.T) {
+	fakeCli, ic := syncPoolTestEnv()
+	key := genPoolKey(ic)
+
+	for i := 0; i < 2; i++ {
+		blocker := New(fakeCli)
+		blocker.SetShard(Shard{Index: i, Total: 2})
+		blocker.syncPRs()
+
+		_, exist := blocker.Pools[key]
+		assert.Equal(t, blocker.shard.owns(key), exist, "Pool exists only in its shard")
+	}
+}
+
 func syncPoolTestEnv() (client.Client, *cicdv1.IntegrationConfig) {
 	if _, exist := os.LookupEnv("CI"); !exist {
 		ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
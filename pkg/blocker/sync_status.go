@@ -22,6 +22,8 @@ import (
 	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
 	"github.com/tmax-cloud/cicd-operator/internal/utils"
 	"github.com/tmax-cloud/cicd-operator/pkg/git"
+	"strings"
+	"time"
 )
 
 // sync_pool.go includes methods for synchronizing PR's commit status/merge conflicts status
@@ -73,56 +75,103 @@ func (b *blocker) syncMergePoolStatus() {
 func (b *blocker) syncOneMergePoolStatus(pool *PRPool, ic *cicdv1.IntegrationConfig, gitCli git.Client) {
 	pool.lock.Lock()
 	defer pool.lock.Unlock()
+	defer pool.updateStatus(ic)
 
 	log := b.log.WithName("status").WithValues("repo", genPoolKey(ic))
 
 	// Loop merge pool per blocker status (pending, success)
+	permissions := writePermissions{}
 	for oldStatus := range pool.MergePool {
 		// For each PR
-		for prID, pr := range pool.MergePool[oldStatus] {
-			// Fetch PR's status, commit statuses
-			if err := b.reflectPRStatus(pr, gitCli); err != nil {
-				log.Error(err, "")
-				continue
-			}
-			newStatusB, removeFromMergePool, newDescription := checkConditionsFull(ic.Spec.MergeConfig.Query, pr)
-
-			var newStatus git.CommitStatusState
-			if newStatusB {
-				newStatus = git.CommitStatusStateSuccess
-				newDescription = "In merge pool."
-			} else {
-				newStatus = git.CommitStatusStatePending
-			}
+		for _, pr := range pool.MergePool[oldStatus] {
+			b.syncOnePRStatus(pool, ic, pr, oldStatus, gitCli, permissions)
+		}
+	}
 
-			// Remove from merge pool if simple test fails
-			// But, if the PR is being re-tested by merger, keep it in the merge pool
-			if removeFromMergePool && (pool.CurrentBatch == nil || !pool.CurrentBatch.Contains(prID)) {
-				delete(pool.MergePool[oldStatus], prID)
-			}
+	if err := b.saveMergeQueue(pool, ic); err != nil {
+		log.Error(err, "")
+	}
+}
 
-			// Move PR status in the pool
-			if newStatus != oldStatus {
-				delete(pool.MergePool[oldStatus], prID)
-				pool.MergePool[newStatus][prID] = pr
-			}
+// syncOnePRStatus checks the full conditions of a PR in the merge pool and moves it in the pool.
+// pool.lock should be held by the caller. permissions caches the reviewers' write permissions during a sync
+func (b *blocker) syncOnePRStatus(pool *PRPool, ic *cicdv1.IntegrationConfig, pr *PullRequest, oldStatus git.CommitStatusState, gitCli git.Client, permissions writePermissions) {
+	log := b.log.WithName("status").WithValues("repo", genPoolKey(ic))
+	prID := pr.ID
 
-			// Update status cache
-			if newStatus != pr.BlockerStatus {
-				pr.BlockerStatus = newStatus
-				pr.blockerCacheDirty = true
-			}
-			if newDescription != pr.BlockerDescription {
-				pr.BlockerDescription = newDescription
-				pr.blockerCacheDirty = true
-			}
+	// Fetch PR's status, commit statuses
+	if err := b.reflectPRStatus(pr, ic.Spec.MergeConfig.Query, gitCli, permissions); err != nil {
+		log.Error(err, "")
+		return
+	}
+	newStatusB, removeFromMergePool, newDescription := checkConditionsFull(ic.Spec.MergeConfig.Query, pr)
+
+	// Hold the stacked PR until its parent is merged
+	if newStatusB {
+		parent, err := findParent(pool, pr, gitCli)
+		if err != nil {
+			log.Error(err, "")
+			return
+		}
+		if parent != nil {
+			newStatusB = false
+			newDescription = fmt.Sprintf("Waiting for the parent PR #%d to be merged.", parent.ID)
+		}
+	}
 
-			log.Info(fmt.Sprintf("\t[#%d](%.20s) - %s/%s", pr.ID, pr.Title, pr.BlockerStatus, pr.BlockerDescription))
+	// Hold the PR until its head is changed
+	if newStatusB && pr.heldSha != "" && pr.heldSha == pr.Head.Sha {
+		newStatusB = false
+		newDescription = pr.heldDescription
+	}
+
+	var newStatus git.CommitStatusState
+	if newStatusB {
+		newStatus = git.CommitStatusStateSuccess
+		newDescription = mergePoolDescription
+	} else {
+		newStatus = git.CommitStatusStatePending
+	}
+
+	// Remove from merge pool if simple test fails
+	// But, if the PR is being re-tested by merger, keep it in the merge pool
+	if removeFromMergePool && (pool.CurrentBatch == nil || !pool.CurrentBatch.Contains(prID)) {
+		delete(pool.MergePool[oldStatus], prID)
+	}
+
+	// Move PR status in the pool
+	if newStatus != oldStatus {
+		delete(pool.MergePool[oldStatus], prID)
+		pool.MergePool[newStatus][prID] = pr
+	}
+
+	// Update status cache
+	setBlockerStatus(pr, ic.Spec.MergeConfig, newStatus, newDescription)
+
+	log.Info(fmt.Sprintf("\t[#%d](%.20s) - %s/%s", pr.ID, pr.Title, pr.BlockerStatus, pr.BlockerDescription))
+}
+
+// setBlockerStatus updates the blocker status cache of a PR in the merge pool.
+// PRs in the success pool are reported as pending during the merge freeze, so that they are not merged manually either
+func setBlockerStatus(pr *PullRequest, cfg *cicdv1.MergeConfig, status git.CommitStatusState, description string) {
+	if status == git.CommitStatusStateSuccess {
+		if freeze := getMergeFreeze(cfg, pr.Base.Ref, time.Now()); freeze != nil {
+			status = git.CommitStatusStatePending
+			description = freeze.description()
 		}
 	}
+
+	if status != pr.BlockerStatus {
+		pr.BlockerStatus = status
+		pr.blockerCacheDirty = true
+	}
+	if description != pr.BlockerDescription {
+		pr.BlockerDescription = description
+		pr.blockerCacheDirty = true
+	}
 }
 
-func (b *blocker) reflectPRStatus(pull *PullRequest, gitCli git.Client) error {
+func (b *blocker) reflectPRStatus(pull *PullRequest, q cicdv1.MergeQuery, gitCli git.Client, permissions writePermissions) error {
 	// GET PullRequest
 	pr, err := gitCli.GetPullRequest(pull.ID)
 	if err != nil {
@@ -139,13 +188,116 @@ func (b *blocker) reflectPRStatus(pull *PullRequest, gitCli git.Client) error {
 	for _, c := range checksSlice {
 		pull.Statuses[c.Context] = c
 	}
+
+	// GET reviews, only if approvals are required
+	if q.MinApprovals > 0 || q.RequireCodeOwners {
+		reviews, err := gitCli.ListPullRequestReviews(pr.ID)
+		if err != nil {
+			return err
+		}
+		pull.Reviews = reviews
+	}
+
+	// Approvals are only counted for the users who can write to the repository
+	if q.MinApprovals > 0 {
+		pull.NonWriters = map[string]struct{}{}
+		for _, a := range getApprovers(pull) {
+			canWrite, err := permissions.canWrite(a, gitCli)
+			if err != nil {
+				return err
+			}
+			if !canWrite {
+				pull.NonWriters[a.Name] = struct{}{}
+			}
+		}
+	}
+
+	// GET changed files and code owners, only if approvals of code owners are required
+	if q.RequireCodeOwners {
+		diff, err := gitCli.GetPullRequestDiff(pr.ID)
+		if err != nil {
+			return err
+		}
+		pull.ChangedFiles = diff.Files()
+
+		codeOwners, err := getCodeOwners(pr.Base.Ref, gitCli)
+		if err != nil {
+			return err
+		}
+		pull.CodeOwners = codeOwners
+
+		approvedTeams, err := getApprovedTeams(pull, gitCli)
+		if err != nil {
+			return err
+		}
+		pull.ApprovedTeams = approvedTeams
+	}
 	return nil
 }
 
+// getApprovedTeams resolves the team owners (org/team) of the changed files, which have at least one approver as a member.
+// Keys are lower-cased team names
+func getApprovedTeams(pull *PullRequest, gitCli git.Client) (map[string]struct{}, error) {
+	if pull.CodeOwners == nil {
+		return nil, nil
+	}
+
+	approvers := getApprovers(pull)
+	teams := map[string]struct{}{}
+	checked := map[string]struct{}{}
+	for _, f := range pull.ChangedFiles {
+		for _, o := range pull.CodeOwners.Owners(f) {
+			key := strings.ToLower(o)
+			if _, exist := checked[key]; exist || !isTeamOwner(o) {
+				continue
+			}
+			checked[key] = struct{}{}
+
+			for _, a := range approvers {
+				member, err := gitCli.IsUserInGroup(a, o)
+				if err != nil {
+					return nil, err
+				}
+				if member {
+					teams[key] = struct{}{}
+					break
+				}
+			}
+		}
+	}
+	return teams, nil
+}
+
+// isTeamOwner decides if the code owner is a team (org/team), not a user or an email
+func isTeamOwner(owner string) bool {
+	return strings.Contains(owner, "/") && !strings.Contains(owner, "@")
+}
+
+// getCodeOwners reads the CODEOWNERS file of the branch. Returns nil if there is no CODEOWNERS file
+func getCodeOwners(branch string, gitCli git.Client) (*git.CodeOwners, error) {
+	for _, path := range git.CodeOwnersPaths {
+		content, err := gitCli.GetFileContent(strings.TrimPrefix(branch, "refs/heads/"), path)
+		if err != nil {
+			if git.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		return git.ParseCodeOwners(string(content))
+	}
+	return nil, nil
+}
+
 func (b *blocker) reportCommitStatus(pool *PRPool, ic *cicdv1.IntegrationConfig, gitCli git.Client) {
 	pool.lock.Lock()
 	defer pool.lock.Unlock()
 
+	b.setDirtyCommitStatuses(pool, ic, gitCli)
+}
+
+// setDirtyCommitStatuses sets commit statuses of the PRs whose blocker status cache is changed.
+// pool.lock should be held by the caller
+func (b *blocker) setDirtyCommitStatuses(pool *PRPool, ic *cicdv1.IntegrationConfig, gitCli git.Client) {
 	log := b.log.WithName("status").WithValues("repo", genPoolKey(ic))
 
 	// Update commit status
@@ -162,3 +314,19 @@ func (b *blocker) reportCommitStatus(pool *PRPool, ic *cicdv1.IntegrationConfig,
 		}
 	}
 }
+
+// writePermissions caches the users' write permissions on a repository, keyed by the user names
+type writePermissions map[string]bool
+
+// canWrite decides if the user can write to the repository, calling the git API only if it's not cached yet
+func (w writePermissions) canWrite(user git.User, gitCli git.Client) (bool, error) {
+	if canWrite, exist := w[user.Name]; exist {
+		return canWrite, nil
+	}
+	canWrite, err := gitCli.CanUserWriteToRepo(user)
+	if err != nil {
+		return false, err
+	}
+	w[user.Name] = canWrite
+	return canWrite, nil
+}
@@ -90,6 +90,90 @@ func TestBlocker_syncMergePoolStatus(t *testing.T) {
 	assert.Equal(t, 0, len(pool.MergePool[git.CommitStatusStatePending]), "Pending length")
 	assert.Equal(t, 1, len(pool.MergePool[git.CommitStatusStateSuccess]), "Success length")
 	assert.Equal(t, "In merge pool.", pool.PullRequests[25].BlockerDescription, "Blocker status description")
+
+	// Test 4 - stacked on an open PR
+	pool.PullRequests[24] = &PullRequest{
+		PullRequest: git.PullRequest{
+			ID:    24,
+			State: git.PullRequestStateOpen,
+			Head:  git.Head{Ref: "master", Sha: "3196ccc37bcae94852079b04fcbfaf928341d6e9"},
+			Base:  git.Base{Ref: "release", Sha: git.FakeSha},
+		},
+	}
+	gitfake.Branches = map[string]*git.Branch{"master": {Name: "master", CommitID: "3196ccc37bcae94852079b04fcbfaf928341d6e9"}}
+	blocker.syncMergePoolStatus()
+	assert.Equal(t, 1, len(pool.MergePool[git.CommitStatusStatePending]), "Pending length")
+	assert.Equal(t, 0, len(pool.MergePool[git.CommitStatusStateSuccess]), "Success length")
+	assert.Equal(t, "Waiting for the parent PR #24 to be merged.", pool.PullRequests[25].BlockerDescription, "Blocker status description")
+
+	// Test 5 - held until the head is changed
+	delete(pool.PullRequests, 24)
+	pr.heldSha = testSHA
+	pr.heldDescription = "Rebase it."
+	blocker.syncMergePoolStatus()
+	assert.Equal(t, 1, len(pool.MergePool[git.CommitStatusStatePending]), "Pending length")
+	assert.Equal(t, "Rebase it.", pool.PullRequests[25].BlockerDescription, "Blocker status description")
+
+	pr.heldSha = "3196ccc37bcae94852079b04fcbfaf928341d6e9"
+	blocker.syncMergePoolStatus()
+	assert.Equal(t, 1, len(pool.MergePool[git.CommitStatusStateSuccess]), "Success length")
+	assert.Equal(t, "In merge pool.", pool.PullRequests[25].BlockerDescription, "Blocker status description")
+}
+
+func TestBlocker_reflectPRStatus(t *testing.T) {
+	fakeCli, ic := syncStatusTestEnv()
+	blocker := New(fakeCli)
+
+	gitfake.Repos[testRepo].CommitStatuses[testSHA] = []git.CommitStatus{}
+	gitfake.Repos[testRepo].PullRequestReviews = map[int][]git.PullRequestReview{
+		testPRID: {{Author: git.User{Name: "reviewer"}, State: git.PullRequestReviewStateApproved}},
+	}
+	gitfake.Repos[testRepo].PullRequestDiffs = map[int]*git.Diff{
+		testPRID: {Changes: []git.Change{{Filename: "main.go", OldFilename: "main.go"}, {Filename: "pkg/a.go", OldFilename: "pkg/b.go"}}},
+	}
+	gitfake.Repos[testRepo].Files = map[string]map[string]string{
+		"master": {"CODEOWNERS": "* @reviewer\n"},
+	}
+
+	gitCli := &gitfake.Client{IntegrationConfig: ic, K8sClient: fakeCli}
+	ic.Spec.MergeConfig.Query.RequireCodeOwners = true
+
+	pr := &PullRequest{PullRequest: git.PullRequest{ID: testPRID}}
+	assert.Equal(t, nil, blocker.reflectPRStatus(pr, ic.Spec.MergeConfig.Query, gitCli, writePermissions{}))
+	assert.Equal(t, 1, len(pr.Reviews), "Reviews")
+	assert.Equal(t, []string{"main.go", "pkg/a.go", "pkg/b.go"}, pr.ChangedFiles, "Changed files")
+	assert.NotEqual(t, (*git.CodeOwners)(nil), pr.CodeOwners, "Code owners")
+	assert.Equal(t, []string{"reviewer"}, pr.CodeOwners.Owners("main.go"), "Owners")
+
+	// Team owners
+	gitfake.Groups = map[string][]string{"tmax-cloud/reviewers": {"reviewer"}}
+	gitfake.Repos[testRepo].Files = map[string]map[string]string{
+		"master": {"CODEOWNERS": "* @tmax-cloud/reviewers\n/pkg/ @tmax-cloud/others\n"},
+	}
+	assert.Equal(t, nil, blocker.reflectPRStatus(pr, ic.Spec.MergeConfig.Query, gitCli, writePermissions{}))
+	assert.Equal(t, map[string]struct{}{"tmax-cloud/reviewers": {}}, pr.ApprovedTeams, "Approved teams")
+	gitfake.Groups = nil
+
+	// No CODEOWNERS file
+	gitfake.Repos[testRepo].Files = nil
+	assert.Equal(t, nil, blocker.reflectPRStatus(pr, ic.Spec.MergeConfig.Query, gitCli, writePermissions{}))
+	assert.Equal(t, (*git.CodeOwners)(nil), pr.CodeOwners, "Code owners")
+
+	// Approvers without write permission
+	ic.Spec.MergeConfig.Query.RequireCodeOwners = false
+	ic.Spec.MergeConfig.Query.MinApprovals = 1
+	gitfake.Repos[testRepo].PullRequestReviews[testPRID] = append(gitfake.Repos[testRepo].PullRequestReviews[testPRID],
+		git.PullRequestReview{Author: git.User{Name: "outsider"}, State: git.PullRequestReviewStateApproved})
+	gitfake.Repos[testRepo].UserCanWrite = map[string]bool{"reviewer": true, "outsider": false}
+	permissions := writePermissions{}
+	assert.Equal(t, nil, blocker.reflectPRStatus(pr, ic.Spec.MergeConfig.Query, gitCli, permissions))
+	assert.Equal(t, map[string]struct{}{"outsider": {}}, pr.NonWriters, "Non-writers")
+	assert.Equal(t, writePermissions{"reviewer": true, "outsider": false}, permissions, "Cached permissions")
+
+	// Cached permissions are used during a sync
+	gitfake.Repos[testRepo].UserCanWrite = map[string]bool{}
+	assert.Equal(t, nil, blocker.reflectPRStatus(pr, ic.Spec.MergeConfig.Query, gitCli, permissions))
+	assert.Equal(t, map[string]struct{}{"outsider": {}}, pr.NonWriters, "Non-writers")
 }
 
 func syncStatusTestEnv() (client.Client, *cicdv1.IntegrationConfig) {
@@ -0,0 +1,131 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package chatops
+
+import (
+	"fmt"
+	"strings"
+
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/pkg/git"
+)
+
+// Authorize decides if the sender is allowed to call the command, using the permission matrix of the IntegrationConfig.
+// defaultAuthorize is called if there is no permission for the command or the permission's policy is default.
+// The users and the groups of the permission are allowed regardless of the policy
+func Authorize(command string, cfg *cicdv1.IntegrationConfig, sender git.User, gitCli git.Client, defaultAuthorize func() error) error {
+	perm := GetPermission(cfg, command)
+	if perm == nil {
+		return defaultAuthorize()
+	}
+
+	listed, err := isUserListed(sender, perm.Users, perm.Groups, gitCli)
+	if err != nil {
+		return err
+	}
+	if listed {
+		return nil
+	}
+
+	allowed := false
+	switch perm.Policy {
+	case "", cicdv1.ChatOpsPolicyDefault:
+		return defaultAuthorize()
+	case cicdv1.ChatOpsPolicyAnyone:
+		allowed = true
+	case cicdv1.ChatOpsPolicyWriters:
+		allowed, err = gitCli.CanUserWriteToRepo(sender)
+	case cicdv1.ChatOpsPolicyTrusted:
+		allowed, err = IsTrustedContributor(cfg, sender, gitCli)
+	case cicdv1.ChatOpsPolicyListed:
+		allowed = false
+	default:
+		return fmt.Errorf("chat-ops policy %s is not supported", perm.Policy)
+	}
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return &git.UnauthorizedError{User: sender.Name, Repo: cfg.Spec.Git.Repository}
+	}
+	return nil
+}
+
+// GetPermission returns the permission of the command. Returns nil if the permission is not configured
+func GetPermission(cfg *cicdv1.IntegrationConfig, command string) *cicdv1.ChatOpsPermission {
+	if cfg.Spec.ChatOps == nil {
+		return nil
+	}
+	perm, exist := cfg.Spec.ChatOps.Permissions[command]
+	if !exist {
+		return nil
+	}
+	return &perm
+}
+
+// IsTrustedContributor decides if the user is a trusted contributor of the repository.
+// Members of the repository's owner (organization or group) and users who have write permission on the repository
+// are always trusted
+func IsTrustedContributor(cfg *cicdv1.IntegrationConfig, user git.User, gitCli git.Client) (bool, error) {
+	if cfg.Spec.ChatOps != nil && cfg.Spec.ChatOps.TrustedContributors != nil {
+		trusted := cfg.Spec.ChatOps.TrustedContributors
+		listed, err := isUserListed(user, trusted.Users, trusted.Groups, gitCli)
+		if err != nil {
+			return false, err
+		}
+		if listed {
+			return true, nil
+		}
+	}
+
+	// The owner is a user for personal repositories, in which case the user is never a member of it
+	if idx := strings.LastIndex(cfg.Spec.Git.Repository, "/"); idx > 0 {
+		member, err := gitCli.IsUserInGroup(user, cfg.Spec.Git.Repository[:idx])
+		if err != nil {
+			return false, err
+		}
+		if member {
+			return true, nil
+		}
+	}
+
+	return gitCli.CanUserWriteToRepo(user)
+}
+
+// isUserListed checks if the user is in the users or is a member of the groups
+func isUserListed(user git.User, users, groups []string, gitCli git.Client) (bool, error) {
+	if git.ContainsUser(users, user.Name) {
+		return true, nil
+	}
+	for _, g := range groups {
+		member, err := gitCli.IsUserInGroup(user, g)
+		if err != nil {
+			return false, err
+		}
+		if member {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GenerateUnauthorizedComment generates a comment body for a user who is not allowed to call the command
+// by the permission matrix of the IntegrationConfig
+func GenerateUnauthorizedComment(command, user string) string {
+	return fmt.Sprintf("User `%s` is not allowed to call `/%s` on this repository.\n\n"+
+		"The permission of the command is configured in the IntegrationConfig's `spec.chatOps.permissions`.\n", user, command)
+}
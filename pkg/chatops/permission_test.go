@@ -0,0 +1,87 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package chatops
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/pkg/git"
+	gitfake "github.com/tmax-cloud/cicd-operator/pkg/git/fake"
+)
+
+func TestAuthorize(t *testing.T) {
+	gitfake.Repos = map[string]*gitfake.Repo{
+		"test/repo": {UserCanWrite: map[string]bool{"writer": true, "reader": false, "member": false, "org-member": false, "stranger": false}},
+	}
+	gitfake.Groups = map[string][]string{"tmax-cloud/maintainers": {"member"}, "test": {"org-member"}}
+	defer func() { gitfake.Groups = nil }()
+
+	errDefault := fmt.Errorf("default")
+
+	tc := map[string]struct {
+		chatOps *cicdv1.ChatOpsConfig
+		user    string
+
+		expectedDefault      bool
+		expectedUnauthorized bool
+	}{
+		"noConfig":          {user: "stranger", expectedDefault: true},
+		"noPermission":      {chatOps: &cicdv1.ChatOpsConfig{}, user: "stranger", expectedDefault: true},
+		"defaultPolicy":     {chatOps: buildTestChatOps(cicdv1.ChatOpsPolicyDefault), user: "stranger", expectedDefault: true},
+		"defaultListedUser": {chatOps: buildTestChatOps(cicdv1.ChatOpsPolicyDefault), user: "listed"},
+		"writers":           {chatOps: buildTestChatOps(cicdv1.ChatOpsPolicyWriters), user: "writer"},
+		"writersFail":       {chatOps: buildTestChatOps(cicdv1.ChatOpsPolicyWriters), user: "reader", expectedUnauthorized: true},
+		"anyone":            {chatOps: buildTestChatOps(cicdv1.ChatOpsPolicyAnyone), user: "stranger"},
+		"listedGroup":       {chatOps: buildTestChatOps(cicdv1.ChatOpsPolicyListed), user: "member"},
+		"listedFail":        {chatOps: buildTestChatOps(cicdv1.ChatOpsPolicyListed), user: "writer", expectedUnauthorized: true},
+		"trustedWriter":     {chatOps: buildTestChatOps(cicdv1.ChatOpsPolicyTrusted), user: "writer"},
+		"trustedUser":       {chatOps: buildTestChatOps(cicdv1.ChatOpsPolicyTrusted), user: "trusted"},
+		"trustedOrgMember":  {chatOps: buildTestChatOps(cicdv1.ChatOpsPolicyTrusted), user: "org-member"},
+		"trustedFail":       {chatOps: buildTestChatOps(cicdv1.ChatOpsPolicyTrusted), user: "reader", expectedUnauthorized: true},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			ic := &cicdv1.IntegrationConfig{Spec: cicdv1.IntegrationConfigSpec{Git: cicdv1.GitConfig{Repository: "test/repo"}, ChatOps: c.chatOps}}
+			gitCli := &gitfake.Client{IntegrationConfig: ic}
+			gitfake.Repos["test/repo"].UserCanWrite["listed"] = false
+			gitfake.Repos["test/repo"].UserCanWrite["trusted"] = false
+
+			err := Authorize("test", ic, git.User{Name: c.user}, gitCli, func() error { return errDefault })
+			if c.expectedDefault {
+				require.Equal(t, errDefault, err)
+			} else if c.expectedUnauthorized {
+				_, ok := err.(*git.UnauthorizedError)
+				require.True(t, ok)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func buildTestChatOps(policy cicdv1.ChatOpsPolicy) *cicdv1.ChatOpsConfig {
+	return &cicdv1.ChatOpsConfig{
+		Permissions: map[string]cicdv1.ChatOpsPermission{
+			"test": {Policy: policy, Users: []string{"listed"}, Groups: []string{"tmax-cloud/maintainers"}},
+		},
+		TrustedContributors: &cicdv1.TrustedContributors{Users: []string{"trusted"}},
+	}
+}
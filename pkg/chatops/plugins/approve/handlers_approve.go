@@ -79,12 +79,25 @@ func (h *Handler) Handle(wh *git.Webhook, ic *cicdv1.IntegrationConfig) error {
 		return h.handleLabelEvent(wh, ic, gitCli)
 	}
 
+	owners, files, err := loadOwners(wh.IssueComment.Issue.PullRequest, gitCli)
+	if err != nil {
+		return err
+	}
+
+	// Reviews of the author and the users who are not approvers of the changed files are ignored
+	if strings.EqualFold(wh.Sender.Name, wh.IssueComment.Issue.PullRequest.Author.Name) {
+		return nil
+	}
+	if owners != nil && !canApproveAny(owners, files, wh.Sender.Name) {
+		return nil
+	}
+
 	// For approve/cancel event
 	switch wh.IssueComment.ReviewState {
 	case git.PullRequestReviewStateApproved:
-		return h.handleApproveCommand(wh.IssueComment, gitCli)
+		return h.handleApproveCommand(wh.IssueComment, owners, files, gitCli)
 	case git.PullRequestReviewStateUnapproved:
-		return h.handleApproveCancelCommand(wh.IssueComment, gitCli)
+		return h.handleApproveCancelCommand(wh.IssueComment, owners, files, gitCli)
 	}
 
 	return nil
@@ -108,14 +121,23 @@ func (h *Handler) HandleChatOps(command chatops.Command, webhook *git.Webhook, c
 		return err
 	}
 
+	owners, files, err := loadOwners(issueComment.Issue.PullRequest, gitCli)
+	if err != nil {
+		return err
+	}
+
 	// Authorize or exit
-	if err := h.authorize(config, webhook.Sender, issueComment.Issue.PullRequest.Author, gitCli); err != nil {
+	if err := h.authorizeCommand(config, webhook.Sender, issueComment.Issue.PullRequest.Author, owners, files, gitCli); err != nil {
 		unAuthErr, ok := err.(*git.UnauthorizedError)
 		if !ok {
 			return err
 		}
 
-		if err := gitCli.RegisterComment(git.IssueTypePullRequest, issueComment.Issue.PullRequest.ID, "", generateUserUnauthorizedComment(unAuthErr.User)); err != nil {
+		comment := generateUnauthorizedComment(unAuthErr.User, owners)
+		if chatops.GetPermission(config, CommandTypeApprove) != nil && webhook.Sender.ID != issueComment.Issue.PullRequest.Author.ID {
+			comment = "[APPROVE ALERT]\n\n" + chatops.GenerateUnauthorizedComment(command.Type, unAuthErr.User)
+		}
+		if err := gitCli.RegisterComment(git.IssueTypePullRequest, issueComment.Issue.PullRequest.ID, "", comment); err != nil {
 			return err
 		}
 		return nil
@@ -123,17 +145,17 @@ func (h *Handler) HandleChatOps(command chatops.Command, webhook *git.Webhook, c
 
 	// /approve
 	if len(command.Args) == 0 {
-		return h.handleApproveCommand(issueComment, gitCli)
+		return h.handleApproveCommand(issueComment, owners, files, gitCli)
 	}
 
 	// /approve cancel
 	if len(command.Args) == 1 && command.Args[0] == "cancel" {
-		return h.handleApproveCancelCommand(issueComment, gitCli)
+		return h.handleApproveCancelCommand(issueComment, owners, files, gitCli)
 	}
 
 	// /approve check
 	if len(command.Args) == 1 && command.Args[0] == "check" {
-		return h.handleApproveCheckCommand(issueComment, gitCli)
+		return h.handleApproveCheckCommand(issueComment, owners, files, gitCli)
 	}
 
 	// Default - malformed comment
@@ -171,8 +193,13 @@ func (h *Handler) handleLabelEvent(wh *git.Webhook, ic *cicdv1.IntegrationConfig
 		}
 	}
 
+	owners, files, err := loadOwners(pr, gitCli)
+	if err != nil {
+		return err
+	}
+
 	// Authorize or exit
-	if err := h.authorize(ic, wh.Sender, pr.Author, gitCli); err != nil {
+	if err := h.authorizeLabel(ic, wh.Sender, pr.Author, owners, files, gitCli); err != nil {
 		unAuthErr, ok := err.(*git.UnauthorizedError)
 		if !ok {
 			return err
@@ -190,7 +217,7 @@ func (h *Handler) handleLabelEvent(wh *git.Webhook, ic *cicdv1.IntegrationConfig
 				return err
 			}
 		}
-		if err := gitCli.RegisterComment(git.IssueTypePullRequest, pr.ID, "", generateUserUnauthorizedComment(unAuthErr.User)); err != nil {
+		if err := gitCli.RegisterComment(git.IssueTypePullRequest, pr.ID, "", generateUnauthorizedComment(unAuthErr.User, owners)); err != nil {
 			return err
 		}
 		return nil
@@ -199,8 +226,18 @@ func (h *Handler) handleLabelEvent(wh *git.Webhook, ic *cicdv1.IntegrationConfig
 }
 
 // handleApproveCommand handles '/approve' command
-func (h *Handler) handleApproveCommand(issueComment *git.IssueComment, gitCli git.Client) error {
+// If there are OWNERS files, the pull request is labeled only if all the changed files are approved by their approvers
+func (h *Handler) handleApproveCommand(issueComment *git.IssueComment, owners *git.Owners, files []string, gitCli git.Client) error {
 	log.Info(fmt.Sprintf("%s approved %s", issueComment.Author.Name, issueComment.Issue.PullRequest.URL))
+	if owners != nil {
+		state, err := newApprovalState(issueComment.Issue.PullRequest, owners, files, gitCli)
+		if err != nil {
+			return err
+		}
+		state.approve(issueComment.Author.Name)
+		return h.applyApprovalState(issueComment.Issue.PullRequest.ID, state, generateApprovedComment(issueComment.Author.Name), gitCli)
+	}
+
 	// Register approved label
 	if err := gitCli.SetLabel(git.IssueTypePullRequest, issueComment.Issue.PullRequest.ID, approvedLabel); err != nil {
 		return err
@@ -214,8 +251,17 @@ func (h *Handler) handleApproveCommand(issueComment *git.IssueComment, gitCli gi
 }
 
 // handleApproveCancelCommand handles '/approve cancel] command
-func (h *Handler) handleApproveCancelCommand(issueComment *git.IssueComment, gitCli git.Client) error {
+func (h *Handler) handleApproveCancelCommand(issueComment *git.IssueComment, owners *git.Owners, files []string, gitCli git.Client) error {
 	log.Info(fmt.Sprintf("%s canceled approval on %s", issueComment.Author.Name, issueComment.Issue.PullRequest.URL))
+	if owners != nil {
+		state, err := newApprovalState(issueComment.Issue.PullRequest, owners, files, gitCli)
+		if err != nil {
+			return err
+		}
+		state.cancel(issueComment.Author.Name)
+		return h.applyApprovalState(issueComment.Issue.PullRequest.ID, state, generateApproveCanceledComment(issueComment.Author.Name), gitCli)
+	}
+
 	// Delete approved label
 	if err := gitCli.DeleteLabel(git.IssueTypePullRequest, issueComment.Issue.PullRequest.ID, approvedLabel); err != nil && !strings.Contains(err.Error(), "Label does not exist") {
 		return err
@@ -228,8 +274,16 @@ func (h *Handler) handleApproveCancelCommand(issueComment *git.IssueComment, git
 	return nil
 }
 
-func (h *Handler) handleApproveCheckCommand(issueComment *git.IssueComment, gitCli git.Client) error {
+func (h *Handler) handleApproveCheckCommand(issueComment *git.IssueComment, owners *git.Owners, files []string, gitCli git.Client) error {
 	log.Info(fmt.Sprintf("%s check approval status on %s", issueComment.Author.Name, issueComment.Issue.PullRequest.URL))
+	if owners != nil {
+		state, err := newApprovalState(issueComment.Issue.PullRequest, owners, files, gitCli)
+		if err != nil {
+			return err
+		}
+		return h.applyApprovalState(issueComment.Issue.PullRequest.ID, state, "[APPROVE ALERT]", gitCli)
+	}
+
 	// Check approved label
 	labels, err := gitCli.ListLabels(issueComment.Issue.PullRequest.ID)
 	if err != nil {
@@ -263,18 +317,37 @@ func (h *Handler) handleApproveCheckCommand(issueComment *git.IssueComment, gitC
 
 func (h *Handler) syncApproval(label, comment bool, issueComment *git.IssueComment, gitCli git.Client) error {
 	if comment && !label {
-		if err := h.handleApproveCommand(issueComment, gitCli); err != nil {
+		if err := h.handleApproveCommand(issueComment, nil, nil, gitCli); err != nil {
 			return err
 		}
 	}
 	if !comment && label {
-		if err := h.handleApproveCancelCommand(issueComment, gitCli); err != nil {
+		if err := h.handleApproveCancelCommand(issueComment, nil, nil, gitCli); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// applyApprovalState sets or deletes the approved label depending on the approval state,
+// and registers a comment with the OWNERS whose approvals are still required
+func (h *Handler) applyApprovalState(prID int, state *approvalState, message string, gitCli git.Client) error {
+	if state.approved() {
+		if err := gitCli.SetLabel(git.IssueTypePullRequest, prID, approvedLabel); err != nil {
+			return err
+		}
+	} else {
+		if err := gitCli.DeleteLabel(git.IssueTypePullRequest, prID, approvedLabel); err != nil && !strings.Contains(err.Error(), "Label does not exist") {
+			return err
+		}
+	}
+
+	if err := gitCli.RegisterComment(git.IssueTypePullRequest, prID, "", message+"\n\n"+generateOwnersNeededComment(state.neededOwners())); err != nil {
+		return err
+	}
+	return nil
+}
+
 func checkApproval(comments []git.IssueComment) bool {
 	var comment git.IssueComment
 	for _, comment = range comments {
@@ -298,12 +371,21 @@ func checkApproval(comments []git.IssueComment) bool {
 }
 
 // authorize decides if the sender is authorized to approve the PR
-func (h *Handler) authorize(cfg *cicdv1.IntegrationConfig, sender git.User, author git.User, gitCli git.Client) error {
+// If there are OWNERS files, the sender should be an approver of any of the changed files
+func (h *Handler) authorize(cfg *cicdv1.IntegrationConfig, sender git.User, author git.User, owners *git.Owners, files []string, gitCli git.Client) error {
 	// Check if it's PR's author
 	if sender.ID == author.ID {
 		return &git.UnauthorizedError{User: sender.Name, Repo: cfg.Spec.Git.Repository}
 	}
 
+	// Check if it's an approver in the OWNERS files
+	if owners != nil {
+		if canApproveAny(owners, files, sender.Name) {
+			return nil
+		}
+		return &git.UnauthorizedError{User: sender.Name, Repo: cfg.Spec.Git.Repository}
+	}
+
 	// Check if it's repo's maintainer
 	ok, err := gitCli.CanUserWriteToRepo(sender)
 	if err != nil {
@@ -315,6 +397,41 @@ func (h *Handler) authorize(cfg *cicdv1.IntegrationConfig, sender git.User, auth
 	return &git.UnauthorizedError{User: sender.Name, Repo: cfg.Spec.Git.Repository}
 }
 
+// authorizeCommand decides if the sender is authorized to call the approve command.
+// If the permission of the command is configured in the IntegrationConfig, it's used instead of the default rule,
+// but the author still cannot approve the PR. OWNERS files still decide which files the approval counts for
+func (h *Handler) authorizeCommand(cfg *cicdv1.IntegrationConfig, sender git.User, author git.User, owners *git.Owners, files []string, gitCli git.Client) error {
+	if sender.ID == author.ID {
+		return &git.UnauthorizedError{User: sender.Name, Repo: cfg.Spec.Git.Repository}
+	}
+	return chatops.Authorize(CommandTypeApprove, cfg, sender, gitCli, func() error {
+		return h.authorize(cfg, sender, author, owners, files, gitCli)
+	})
+}
+
+// authorizeLabel decides if the sender is authorized to set/unset the approved label manually
+// If there are OWNERS files, the sender should be an approver of all the changed files
+func (h *Handler) authorizeLabel(cfg *cicdv1.IntegrationConfig, sender git.User, author git.User, owners *git.Owners, files []string, gitCli git.Client) error {
+	if owners != nil && sender.ID != author.ID && !canApproveAll(owners, files, sender.Name) {
+		return &git.UnauthorizedError{User: sender.Name, Repo: cfg.Spec.Git.Repository}
+	}
+	return h.authorize(cfg, sender, author, owners, files, gitCli)
+}
+
+func generateUnauthorizedComment(user string, owners *git.Owners) string {
+	if owners != nil {
+		return generateNotOwnerComment(user)
+	}
+	return generateUserUnauthorizedComment(user)
+}
+
+func generateNotOwnerComment(user string) string {
+	return fmt.Sprintf("[APPROVE ALERT]\n\nUser `%s` is not allowed to approve/cancel approve this pull request.\n\n"+
+		"Users who meet the following conditions can approve the pull request.\n"+
+		"- Not an author of the pull request\n"+
+		"- Be an approver of the changed files in the OWNERS files\n", user)
+}
+
 func generateUserUnauthorizedComment(user string) string {
 	return fmt.Sprintf("[APPROVE ALERT]\n\nUser `%s` is not allowed to approve/cancel approve this pull request.\n\n"+
 		"Users who meet the following conditions can approve the pull request.\n"+
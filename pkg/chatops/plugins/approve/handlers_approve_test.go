@@ -48,6 +48,11 @@ const (
 	testUser2ID    = 111
 	testUser2Name  = "new-user"
 	testUser2Email = "new@test.com"
+
+	testRootApproverID   = 222
+	testRootApproverName = "root-approver"
+	testOtherUserID      = 333
+	testOtherUserName    = "other-user"
 )
 
 type approvalTestCase struct {
@@ -91,6 +96,16 @@ func TestHandler_Handle(t *testing.T) {
 				require.Equal(t, "approved", repo.PullRequests[testPRID].Labels[0].Name, "Approved label exists")
 			},
 		},
+		"authorApprove": {
+			preFunc: func(wh *git.Webhook) {
+				gitfake.Repos[testRepo].UserCanWrite[testUserName] = true
+			},
+			verifyFunc: func(t *testing.T) {
+				repo := gitfake.Repos[testRepo]
+				require.Len(t, repo.Comments[testPRID], 0, "Comment length")
+				require.Len(t, repo.PullRequests[testPRID].Labels, 0, "Label length")
+			},
+		},
 		"successApproveCancel": {
 			preFunc: func(wh *git.Webhook) {
 				gitfake.Repos[testRepo].UserCanWrite[testUser2Name] = true
@@ -305,6 +320,132 @@ func TestChatOps_handleApprove(t *testing.T) {
 	}
 }
 
+func TestChatOps_handleApproveOwners(t *testing.T) {
+	if _, exist := os.LookupEnv("CI"); !exist {
+		ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
+	}
+	s := runtime.NewScheme()
+	utilruntime.Must(cicdv1.AddToScheme(s))
+
+	ic := buildTestConfigForApprove()
+	fakeCli := fake.NewClientBuilder().WithScheme(s).WithObjects(ic).Build()
+	handler := &Handler{Client: fakeCli}
+
+	tc := map[string]chatOpsApprovalTestCase{
+		"failNotOwner": {
+			command: chatops.Command{Type: "approve"},
+			preFunc: func(wh *git.Webhook) {
+				gitfake.Repos[testRepo].UserCanWrite[testOtherUserName] = true
+				wh.Sender = *gitfake.Users[testOtherUserName]
+				wh.IssueComment.Author = wh.Sender
+			},
+			verifyFunc: func(t *testing.T) {
+				repo := gitfake.Repos[testRepo]
+				require.Len(t, repo.Comments[testPRID], 1, "Comment length")
+				require.Equal(t, generateNotOwnerComment(testOtherUserName), repo.Comments[testPRID][0].Comment.Body)
+				require.Len(t, repo.PullRequests[testPRID].Labels, 0, "Label length")
+			},
+		},
+		"partialApprove": {
+			command: chatops.Command{Type: "approve"},
+			preFunc: func(wh *git.Webhook) {
+				wh.Sender = *gitfake.Users[testUser2Name]
+				wh.IssueComment.Author = wh.Sender
+			},
+			verifyFunc: func(t *testing.T) {
+				repo := gitfake.Repos[testRepo]
+				require.Len(t, repo.Comments[testPRID], 1, "Comment length")
+				require.Equal(t, generateApprovedComment(testUser2Name)+"\n\n"+generateOwnersNeededComment(map[string][]string{"": {testRootApproverName}}), repo.Comments[testPRID][0].Comment.Body)
+				require.Contains(t, repo.Comments[testPRID][0].Comment.Body, "- `/`: `root-approver`")
+				require.Len(t, repo.PullRequests[testPRID].Labels, 0, "Label length")
+			},
+		},
+		"fullApprove": {
+			command: chatops.Command{Type: "approve"},
+			preFunc: func(wh *git.Webhook) {
+				gitfake.Repos[testRepo].Comments[testPRID] = append(gitfake.Repos[testRepo].Comments[testPRID], git.IssueComment{Comment: git.Comment{Body: "/approve"}, Author: *gitfake.Users[testRootApproverName]})
+				wh.Sender = *gitfake.Users[testUser2Name]
+				wh.IssueComment.Author = wh.Sender
+			},
+			verifyFunc: func(t *testing.T) {
+				repo := gitfake.Repos[testRepo]
+				require.Len(t, repo.Comments[testPRID], 2, "Comment length")
+				require.Equal(t, generateApprovedComment(testUser2Name)+"\n\n"+generateOwnersNeededComment(nil), repo.Comments[testPRID][1].Comment.Body)
+				require.Len(t, repo.PullRequests[testPRID].Labels, 1, "Label length")
+				require.Equal(t, "approved", repo.PullRequests[testPRID].Labels[0].Name, "Approved label exists")
+			},
+		},
+		"cancel": {
+			command: chatops.Command{Type: "approve", Args: []string{"cancel"}},
+			preFunc: func(wh *git.Webhook) {
+				gitfake.Repos[testRepo].PullRequests[testPRID].Labels = append(gitfake.Repos[testRepo].PullRequests[testPRID].Labels, git.IssueLabel{Name: "approved"})
+				gitfake.Repos[testRepo].Comments[testPRID] = append(gitfake.Repos[testRepo].Comments[testPRID],
+					git.IssueComment{Comment: git.Comment{Body: "/approve"}, Author: *gitfake.Users[testRootApproverName]},
+					git.IssueComment{ReviewState: git.PullRequestReviewStateApproved, Author: *gitfake.Users[testUser2Name]})
+				wh.Sender = *gitfake.Users[testRootApproverName]
+				wh.IssueComment.Author = wh.Sender
+			},
+			verifyFunc: func(t *testing.T) {
+				repo := gitfake.Repos[testRepo]
+				require.Len(t, repo.Comments[testPRID], 3, "Comment length")
+				require.Equal(t, generateApproveCanceledComment(testRootApproverName)+"\n\n"+generateOwnersNeededComment(map[string][]string{"": {testRootApproverName}}), repo.Comments[testPRID][2].Comment.Body)
+				require.Len(t, repo.PullRequests[testPRID].Labels, 0, "Label length")
+			},
+		},
+		"authorApprover": {
+			command: chatops.Command{Type: "approve"},
+			preFunc: func(wh *git.Webhook) {
+				// The author is the root approver, whose own approvals don't count
+				wh.IssueComment.Issue.PullRequest.Author = *gitfake.Users[testRootApproverName]
+				gitfake.Repos[testRepo].Comments[testPRID] = append(gitfake.Repos[testRepo].Comments[testPRID],
+					git.IssueComment{Comment: git.Comment{Body: "/approve"}, Author: *gitfake.Users[testRootApproverName]},
+					git.IssueComment{ReviewState: git.PullRequestReviewStateApproved, Author: *gitfake.Users[testRootApproverName]},
+					git.IssueComment{Comment: git.Comment{Body: "/approve"}, Author: *gitfake.Users[testOtherUserName]})
+				wh.Sender = *gitfake.Users[testUser2Name]
+				wh.IssueComment.Author = wh.Sender
+			},
+			verifyFunc: func(t *testing.T) {
+				repo := gitfake.Repos[testRepo]
+				require.Len(t, repo.Comments[testPRID], 4, "Comment length")
+				require.Equal(t, generateApprovedComment(testUser2Name)+"\n\n"+generateOwnersNeededComment(map[string][]string{"": {testRootApproverName}}), repo.Comments[testPRID][3].Comment.Body)
+				require.Len(t, repo.PullRequests[testPRID].Labels, 0, "Label length")
+			},
+		},
+		"check": {
+			command: chatops.Command{Type: "approve", Args: []string{"check"}},
+			preFunc: func(wh *git.Webhook) {
+				gitfake.Repos[testRepo].Comments[testPRID] = append(gitfake.Repos[testRepo].Comments[testPRID],
+					git.IssueComment{Comment: git.Comment{Body: "/approve"}, Author: *gitfake.Users[testRootApproverName]},
+					git.IssueComment{Comment: git.Comment{Body: "/approve"}, Author: *gitfake.Users[testUser2Name]},
+					git.IssueComment{Comment: git.Comment{Body: "/approve cancel"}, Author: *gitfake.Users[testUser2Name]})
+				wh.Sender = *gitfake.Users[testRootApproverName]
+				wh.IssueComment.Author = wh.Sender
+			},
+			verifyFunc: func(t *testing.T) {
+				repo := gitfake.Repos[testRepo]
+				require.Len(t, repo.Comments[testPRID], 4, "Comment length")
+				require.Equal(t, "[APPROVE ALERT]\n\n"+generateOwnersNeededComment(map[string][]string{"pkg": {testUser2Name}}), repo.Comments[testPRID][3].Comment.Body)
+				require.Len(t, repo.PullRequests[testPRID].Labels, 0, "Label length")
+			},
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			// Init fake git
+			initFakeGitOwners()
+
+			// Initialize webhook
+			wh := buildTestWebhookCommentApprove()
+			c.preFunc(wh)
+
+			err := handler.HandleChatOps(c.command, wh, ic)
+			require.NoError(t, err)
+			c.verifyFunc(t)
+		})
+	}
+}
+
 func initFakeGit() {
 	gitfake.Users = map[string]*git.User{
 		testUserName:  {ID: testUserID, Name: testUserName, Email: testUserEmail},
@@ -318,12 +459,30 @@ func initFakeGit() {
 			PullRequests: map[int]*git.PullRequest{
 				testPRID: {},
 			},
+			PullRequestDiffs: map[int]*git.Diff{
+				testPRID: {},
+			},
 			CommitStatuses: map[string][]git.CommitStatus{},
 			Comments:       map[int][]git.IssueComment{},
 		},
 	}
 }
 
+func initFakeGitOwners() {
+	initFakeGit()
+	gitfake.Users[testRootApproverName] = &git.User{ID: testRootApproverID, Name: testRootApproverName}
+	gitfake.Users[testOtherUserName] = &git.User{ID: testOtherUserID, Name: testOtherUserName}
+
+	repo := gitfake.Repos[testRepo]
+	repo.PullRequestDiffs[testPRID] = &git.Diff{Changes: []git.Change{{Filename: "main.go"}, {Filename: "pkg/a.go"}}}
+	repo.Files = map[string]map[string]string{
+		"master": {
+			"OWNERS":     "approvers:\n- root-approver\n",
+			"pkg/OWNERS": "approvers:\n- new-user\noptions:\n  no_parent_owners: true\n",
+		},
+	}
+}
+
 func buildTestConfigForApprove() *cicdv1.IntegrationConfig {
 	return &cicdv1.IntegrationConfig{
 		ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,200 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package approve
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tmax-cloud/cicd-operator/pkg/chatops"
+	"github.com/tmax-cloud/cicd-operator/pkg/git"
+)
+
+// approvalState is an approval state of a pull request, against the OWNERS files of the base branch
+type approvalState struct {
+	owners *git.Owners
+	files  []string
+
+	// author is the author of the pull request, whose approvals are not counted
+	author string
+
+	// approvers is a list of users who approved the pull request
+	approvers []string
+}
+
+// loadOwners reads the changed files of the pull request and the OWNERS files of the base branch.
+// Returns nil owners if there is no OWNERS file
+func loadOwners(pr *git.PullRequest, gitCli git.Client) (*git.Owners, []string, error) {
+	diff, err := gitCli.GetPullRequestDiff(pr.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	files := diff.Files()
+
+	owners, err := git.LoadOwners(gitCli, strings.TrimPrefix(pr.Base.Ref, "refs/heads/"), files)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !owners.Exists() {
+		return nil, files, nil
+	}
+	return owners, files, nil
+}
+
+// newApprovalState builds the approval state from the comments and the reviews of the pull request.
+// Only the approvals of the approvers of the changed files are counted, except for the author's
+func newApprovalState(pr *git.PullRequest, owners *git.Owners, files []string, gitCli git.Client) (*approvalState, error) {
+	comments, err := gitCli.ListComments(pr.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sort oldest comment to latest comment
+	sorted := make([]git.IssueComment, len(comments))
+	copy(sorted, comments)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Comment.CreatedAt.Before(sorted[j].Comment.CreatedAt)
+	})
+
+	state := &approvalState{owners: owners, files: files, author: pr.Author.Name}
+	for _, comment := range sorted {
+		user := comment.Author.Name
+		if user == "" {
+			continue
+		}
+		switch comment.ReviewState {
+		case git.PullRequestReviewStateApproved:
+			state.approve(user)
+			continue
+		case git.PullRequestReviewStateUnapproved:
+			state.cancel(user)
+			continue
+		}
+		for _, command := range chatops.ExtractCommands(comment.Comment.Body) {
+			if command.Type != CommandTypeApprove && command.Type != CommandTypeGitLabApprove {
+				continue
+			}
+			if len(command.Args) == 0 {
+				state.approve(user)
+			} else if len(command.Args) == 1 && command.Args[0] == "cancel" {
+				state.cancel(user)
+			}
+		}
+	}
+	return state, nil
+}
+
+func (s *approvalState) approve(user string) {
+	if strings.EqualFold(user, s.author) || !canApproveAny(s.owners, s.files, user) {
+		return
+	}
+	if !git.ContainsUser(s.approvers, user) {
+		s.approvers = append(s.approvers, user)
+	}
+}
+
+func (s *approvalState) cancel(user string) {
+	for i, u := range s.approvers {
+		if strings.EqualFold(u, user) {
+			s.approvers = append(s.approvers[:i], s.approvers[i+1:]...)
+			return
+		}
+	}
+}
+
+// approved returns true if all the changed files are approved by their approvers
+func (s *approvalState) approved() bool {
+	return len(s.neededOwners()) == 0
+}
+
+// neededOwners returns the directories not approved yet, with the approvers who can approve them
+func (s *approvalState) neededOwners() map[string][]string {
+	needed := map[string][]string{}
+	for _, f := range s.files {
+		approvers := s.owners.Approvers(f)
+		// Files without any approvers do not require approvals
+		if len(approvers) == 0 || isApprovedBy(approvers, s.approvers) {
+			continue
+		}
+		dir := s.owners.ApproverDir(f)
+		for _, a := range approvers {
+			if !git.ContainsUser(needed[dir], a) {
+				needed[dir] = append(needed[dir], a)
+			}
+		}
+	}
+	return needed
+}
+
+func isApprovedBy(approvers, users []string) bool {
+	for _, u := range users {
+		if git.ContainsUser(approvers, u) {
+			return true
+		}
+	}
+	return false
+}
+
+// canApproveAny returns true if the user is an approver of any of the changed files
+func canApproveAny(owners *git.Owners, files []string, user string) bool {
+	// The root OWNERS file is considered if there is no changed file
+	if len(files) == 0 {
+		return git.ContainsUser(owners.Approvers(""), user)
+	}
+	for _, f := range files {
+		if git.ContainsUser(owners.Approvers(f), user) {
+			return true
+		}
+	}
+	return false
+}
+
+// canApproveAll returns true if the user is an approver of all the changed files
+func canApproveAll(owners *git.Owners, files []string, user string) bool {
+	if len(files) == 0 {
+		return git.ContainsUser(owners.Approvers(""), user)
+	}
+	for _, f := range files {
+		approvers := owners.Approvers(f)
+		if len(approvers) > 0 && !git.ContainsUser(approvers, user) {
+			return false
+		}
+	}
+	return true
+}
+
+func generateOwnersNeededComment(needed map[string][]string) string {
+	if len(needed) == 0 {
+		return "All the changed files are approved by their OWNERS."
+	}
+
+	var dirs []string
+	for dir := range needed {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	var b strings.Builder
+	b.WriteString("Approvals from the following OWNERS are still required.\n")
+	for _, dir := range dirs {
+		approvers := needed[dir]
+		sort.Strings(approvers)
+		b.WriteString(fmt.Sprintf("- `/%s`: `%s`\n", dir, strings.Join(approvers, "`, `")))
+	}
+	return b.String()
+}
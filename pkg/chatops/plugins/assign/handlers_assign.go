@@ -0,0 +1,132 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package assign
+
+import (
+	"fmt"
+	"strings"
+
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/utils"
+	"github.com/tmax-cloud/cicd-operator/pkg/chatops"
+	"github.com/tmax-cloud/cicd-operator/pkg/git"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Command types for assign handler
+const (
+	CommandTypeAssign   = "assign"
+	CommandTypeUnassign = "unassign"
+	CommandTypeCC       = "cc"
+	CommandTypeUnCC     = "uncc"
+)
+
+var log = logf.Log.WithName("assign-plugin")
+
+// Handler is an implementation of a ChatOps Handler
+type Handler struct {
+	Client client.Client
+}
+
+// HandleChatOps handles /assign, /unassign, /cc and /uncc comment commands
+func (h *Handler) HandleChatOps(command chatops.Command, webhook *git.Webhook, config *cicdv1.IntegrationConfig) error {
+	issueComment := webhook.IssueComment
+	// Do nothing if it's not pull request's comment or it's closed
+	if issueComment.Issue.PullRequest == nil || issueComment.Issue.PullRequest.State != git.PullRequestStateOpen {
+		return nil
+	}
+
+	// Skip if token is empty
+	if config.Spec.Git.Token == nil {
+		return nil
+	}
+
+	gitCli, err := utils.GetGitCli(config, h.Client)
+	if err != nil {
+		return err
+	}
+
+	pr := issueComment.Issue.PullRequest
+	users := parseUsers(command.Args, webhook.Sender)
+
+	// Authorize or exit
+	if err := chatops.Authorize(command.Type, config, webhook.Sender, gitCli, func() error {
+		return authorizeDefault(config, webhook.Sender, pr, users, gitCli)
+	}); err != nil {
+		unAuthErr, ok := err.(*git.UnauthorizedError)
+		if !ok {
+			return err
+		}
+		return gitCli.RegisterComment(git.IssueTypePullRequest, pr.ID, "", "[ASSIGN ALERT]\n\n"+chatops.GenerateUnauthorizedComment(command.Type, unAuthErr.User))
+	}
+
+	log.Info(fmt.Sprintf("%s called /%s %s on %s", issueComment.Author.Name, command.Type, strings.Join(users, " "), pr.URL))
+
+	switch command.Type {
+	case CommandTypeAssign:
+		return gitCli.AddAssignees(pr.ID, users)
+	case CommandTypeUnassign:
+		return gitCli.RemoveAssignees(pr.ID, users)
+	case CommandTypeCC:
+		return gitCli.RequestReviews(pr.ID, users)
+	case CommandTypeUnCC:
+		return gitCli.CancelReviewRequests(pr.ID, users)
+	}
+
+	return nil
+}
+
+// authorizeDefault is a default rule of the assign handler.
+// Anyone can (un)assign or (un)cc oneself. The PR's author or the repo's maintainers can do it for the others
+func authorizeDefault(config *cicdv1.IntegrationConfig, sender git.User, pr *git.PullRequest, users []string, gitCli git.Client) error {
+	onlySelf := true
+	for _, u := range users {
+		if !strings.EqualFold(u, sender.Name) {
+			onlySelf = false
+			break
+		}
+	}
+	if onlySelf || sender.ID == pr.Author.ID {
+		return nil
+	}
+
+	ok, err := gitCli.CanUserWriteToRepo(sender)
+	if err != nil {
+		return err
+	} else if ok {
+		return nil
+	}
+
+	return &git.UnauthorizedError{User: sender.Name, Repo: config.Spec.Git.Repository}
+}
+
+// parseUsers parses the user names from the command arguments. The sender is the target if no user is specified
+func parseUsers(args []string, sender git.User) []string {
+	var users []string
+	for _, arg := range args {
+		u := strings.TrimPrefix(strings.TrimSpace(arg), "@")
+		if u == "" || git.ContainsUser(users, u) {
+			continue
+		}
+		users = append(users, u)
+	}
+	if len(users) == 0 {
+		users = append(users, sender.Name)
+	}
+	return users
+}
@@ -0,0 +1,136 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package assign
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/pkg/chatops"
+	"github.com/tmax-cloud/cicd-operator/pkg/git"
+	gitfake "github.com/tmax-cloud/cicd-operator/pkg/git/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testRepo = "test/repo"
+	testPRID = 11
+)
+
+func TestHandler_HandleChatOps(t *testing.T) {
+	s := runtime.NewScheme()
+	utilruntime.Must(cicdv1.AddToScheme(s))
+
+	ic := &cicdv1.IntegrationConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-ic", Namespace: "default"},
+		Spec: cicdv1.IntegrationConfigSpec{
+			Git: cicdv1.GitConfig{Type: cicdv1.GitTypeFake, Repository: testRepo, Token: &cicdv1.GitToken{Value: "dummy"}},
+		},
+	}
+	handler := &Handler{Client: fake.NewClientBuilder().WithScheme(s).WithObjects(ic).Build()}
+
+	tc := map[string]struct {
+		command        chatops.Command
+		sender         git.User
+		assignees      []string
+		reviewRequests []string
+
+		expectedAssignees      []string
+		expectedReviewRequests []string
+		expectedComment        string
+	}{
+		"assignSelf": {
+			command:           chatops.Command{Type: CommandTypeAssign},
+			sender:            git.User{ID: 3, Name: "stranger"},
+			expectedAssignees: []string{"stranger"},
+		},
+		"assignOthersUnauthorized": {
+			command:         chatops.Command{Type: CommandTypeAssign, Args: []string{"@maintainer"}},
+			sender:          git.User{ID: 3, Name: "stranger"},
+			expectedComment: "[ASSIGN ALERT]\n\n" + chatops.GenerateUnauthorizedComment(CommandTypeAssign, "stranger"),
+		},
+		"assignOthersAuthor": {
+			command:           chatops.Command{Type: CommandTypeAssign, Args: []string{"@maintainer", "stranger"}},
+			sender:            git.User{ID: 1, Name: "author"},
+			expectedAssignees: []string{"maintainer", "stranger"},
+		},
+		"unassign": {
+			command:           chatops.Command{Type: CommandTypeUnassign, Args: []string{"@stranger"}},
+			sender:            git.User{ID: 2, Name: "maintainer"},
+			assignees:         []string{"maintainer", "stranger"},
+			expectedAssignees: []string{"maintainer"},
+		},
+		"cc": {
+			command:                chatops.Command{Type: CommandTypeCC, Args: []string{"@stranger"}},
+			sender:                 git.User{ID: 2, Name: "maintainer"},
+			expectedReviewRequests: []string{"stranger"},
+		},
+		"uncc": {
+			command:        chatops.Command{Type: CommandTypeUnCC},
+			sender:         git.User{ID: 3, Name: "stranger"},
+			reviewRequests: []string{"stranger"},
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			gitfake.Repos = map[string]*gitfake.Repo{
+				testRepo: {
+					UserCanWrite:   map[string]bool{"maintainer": true, "stranger": false},
+					PullRequests:   map[int]*git.PullRequest{testPRID: {ID: testPRID}},
+					Comments:       map[int][]git.IssueComment{},
+					Assignees:      map[int][]string{testPRID: c.assignees},
+					ReviewRequests: map[int][]string{testPRID: c.reviewRequests},
+				},
+			}
+
+			require.NoError(t, handler.HandleChatOps(c.command, buildTestWebhook(c.sender), ic))
+			require.ElementsMatch(t, c.expectedAssignees, gitfake.Repos[testRepo].Assignees[testPRID])
+			require.ElementsMatch(t, c.expectedReviewRequests, gitfake.Repos[testRepo].ReviewRequests[testPRID])
+			if c.expectedComment == "" {
+				require.Empty(t, gitfake.Repos[testRepo].Comments[testPRID])
+			} else {
+				require.Len(t, gitfake.Repos[testRepo].Comments[testPRID], 1)
+				require.Equal(t, c.expectedComment, gitfake.Repos[testRepo].Comments[testPRID][0].Comment.Body)
+			}
+		})
+	}
+}
+
+func buildTestWebhook(sender git.User) *git.Webhook {
+	return &git.Webhook{
+		EventType: git.EventTypeIssueComment,
+		Repo:      git.Repository{Name: testRepo},
+		Sender:    sender,
+		IssueComment: &git.IssueComment{
+			Comment: git.Comment{CreatedAt: &metav1.Time{Time: time.Now()}},
+			Author:  sender,
+			Issue: git.Issue{
+				PullRequest: &git.PullRequest{
+					ID:     testPRID,
+					State:  git.PullRequestStateOpen,
+					Author: git.User{ID: 1, Name: "author"},
+				},
+			},
+		},
+	}
+}
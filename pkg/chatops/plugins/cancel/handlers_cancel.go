@@ -0,0 +1,269 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cancel
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/utils"
+	"github.com/tmax-cloud/cicd-operator/pkg/chatops"
+	"github.com/tmax-cloud/cicd-operator/pkg/git"
+	"github.com/tmax-cloud/cicd-operator/pkg/pipelinemanager"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// CommandTypeCancel is a cancel command type
+const (
+	CommandTypeCancel = "cancel"
+)
+
+var log = logf.Log.WithName("cancel-plugin")
+
+// Handler is an implementation of a ChatOps Handler
+type Handler struct {
+	Client client.Client
+}
+
+// HandleChatOps handles /cancel comment commands
+func (h *Handler) HandleChatOps(command chatops.Command, webhook *git.Webhook, config *cicdv1.IntegrationConfig) error {
+	issueComment := webhook.IssueComment
+	// Do nothing if it's not pull request's comment or it's closed
+	if issueComment.Issue.PullRequest == nil || issueComment.Issue.PullRequest.State != git.PullRequestStateOpen {
+		return nil
+	}
+
+	// Skip if token is empty
+	if config.Spec.Git.Token == nil {
+		return nil
+	}
+
+	gitCli, err := utils.GetGitCli(config, h.Client)
+	if err != nil {
+		return err
+	}
+
+	pr := issueComment.Issue.PullRequest
+
+	// Authorize or exit. The PR's author or the repo's maintainers can cancel the jobs by default
+	if err := chatops.Authorize(CommandTypeCancel, config, webhook.Sender, gitCli, func() error {
+		if webhook.Sender.ID == pr.Author.ID {
+			return nil
+		}
+		ok, err := gitCli.CanUserWriteToRepo(webhook.Sender)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return &git.UnauthorizedError{User: webhook.Sender.Name, Repo: config.Spec.Git.Repository}
+		}
+		return nil
+	}); err != nil {
+		unAuthErr, ok := err.(*git.UnauthorizedError)
+		if !ok {
+			return err
+		}
+		return gitCli.RegisterComment(git.IssueTypePullRequest, pr.ID, "", "[CANCEL ALERT]\n\n"+chatops.GenerateUnauthorizedComment(CommandTypeCancel, unAuthErr.User))
+	}
+
+	// Malformed comment
+	if len(command.Args) > 1 {
+		return gitCli.RegisterComment(git.IssueTypePullRequest, pr.ID, "", generateHelpComment())
+	}
+	jobName := ""
+	if len(command.Args) == 1 {
+		jobName = command.Args[0]
+	}
+
+	ijList := &cicdv1.IntegrationJobList{}
+	if err := h.Client.List(context.Background(), ijList, client.InNamespace(config.Namespace), client.MatchingLabels{cicdv1.JobLabelConfig: config.Name}); err != nil {
+		return err
+	}
+
+	var canceled []string
+	for i := range ijList.Items {
+		ij := &ijList.Items[i]
+		if !isCancelTarget(ij, pr.ID, jobName) {
+			continue
+		}
+
+		// Cancel only the job, if it's specified
+		if jobName != "" {
+			ok, err := h.cancelJob(ij, jobName)
+			if err != nil {
+				return err
+			}
+			if ok {
+				log.Info(fmt.Sprintf("%s canceled %s of %s on %s", issueComment.Author.Name, jobName, ij.Name, pr.URL))
+				canceled = append(canceled, ij.Name)
+			}
+			continue
+		}
+
+		log.Info(fmt.Sprintf("%s canceled %s on %s", issueComment.Author.Name, ij.Name, pr.URL))
+		if err := h.cancelIntegrationJob(ij, issueComment.Author.Name, config, gitCli); err != nil {
+			return err
+		}
+		canceled = append(canceled, ij.Name)
+	}
+
+	return gitCli.RegisterComment(git.IssueTypePullRequest, pr.ID, "", generateCanceledComment(issueComment.Author.Name, jobName, canceled))
+}
+
+// isCancelTarget decides if the IntegrationJob is a pending/running preSubmit job of the pull request.
+// If jobName is not empty, the IntegrationJob should be running the job
+func isCancelTarget(ij *cicdv1.IntegrationJob, prID int, jobName string) bool {
+	if ij.Spec.ConfigRef.Type != cicdv1.JobTypePreSubmit {
+		return false
+	}
+	if ij.Status.State != cicdv1.IntegrationJobStatePending && ij.Status.State != cicdv1.IntegrationJobStateRunning {
+		return false
+	}
+	// A job can only be canceled by its TaskRun, which exists only while the IntegrationJob is running
+	if jobName != "" && ij.Status.State != cicdv1.IntegrationJobStateRunning {
+		return false
+	}
+
+	isPRJob := false
+	for _, p := range ij.Spec.Refs.Pulls {
+		if p.ID == prID {
+			isPRJob = true
+			break
+		}
+	}
+	if !isPRJob {
+		return false
+	}
+
+	if jobName == "" {
+		return true
+	}
+	for _, j := range ij.Status.Jobs {
+		if j.Name == jobName {
+			return j.State == cicdv1.CommitStatusStatePending
+		}
+	}
+	return false
+}
+
+// +kubebuilder:rbac:groups=tekton.dev,resources=taskruns,verbs=get;list;watch;patch
+
+// cancelJob cancels a job of the running IntegrationJob by cancelling its TaskRun.
+// The other jobs keep running, and the jobs depending on it are skipped.
+// Returns false if the job's TaskRun is not created yet or is already done
+func (h *Handler) cancelJob(ij *cicdv1.IntegrationJob, jobName string) (bool, error) {
+	trList := &tektonv1beta1.TaskRunList{}
+	if err := h.Client.List(context.Background(), trList, client.InNamespace(ij.Namespace), client.MatchingLabels{
+		pipeline.GroupName + pipeline.PipelineRunLabelKey:  pipelinemanager.Name(ij),
+		pipeline.GroupName + pipeline.PipelineTaskLabelKey: jobName,
+	}); err != nil {
+		return false, err
+	}
+
+	canceled := false
+	for i := range trList.Items {
+		tr := &trList.Items[i]
+		if tr.IsDone() || tr.IsCancelled() {
+			continue
+		}
+		original := tr.DeepCopy()
+		tr.Spec.Status = tektonv1beta1.TaskRunSpecStatusCancelled
+		if err := h.Client.Patch(context.Background(), tr, client.MergeFrom(original)); err != nil {
+			return false, err
+		}
+		canceled = true
+	}
+	return canceled, nil
+}
+
+// cancelIntegrationJob cancels the IntegrationJob.
+// A running job is canceled by cancelling its PipelineRun, and a pending job is marked as failed not to be scheduled.
+// For a pending job, the jobs' commit statuses are reported here, as no PipelineRun will report them
+func (h *Handler) cancelIntegrationJob(ij *cicdv1.IntegrationJob, user string, config *cicdv1.IntegrationConfig, gitCli git.Client) error {
+	if ij.Status.State == cicdv1.IntegrationJobStateRunning {
+		pr := &tektonv1beta1.PipelineRun{}
+		err := h.Client.Get(context.Background(), types.NamespacedName{Name: pipelinemanager.Name(ij), Namespace: ij.Namespace}, pr)
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		if err == nil {
+			original := pr.DeepCopy()
+			pr.Spec.Status = tektonv1beta1.PipelineRunSpecStatusCancelled
+			return h.Client.Patch(context.Background(), pr, client.MergeFrom(original))
+		}
+	}
+
+	message := fmt.Sprintf("Canceled by %s", user)
+	original := ij.DeepCopy()
+	ij.Status.State = cicdv1.IntegrationJobStateFailed
+	ij.Status.Message = message
+	ij.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+	if len(ij.Status.Jobs) != len(ij.Spec.Jobs) {
+		ij.Status.Jobs = nil
+		for _, j := range ij.Spec.Jobs {
+			ij.Status.Jobs = append(ij.Status.Jobs, cicdv1.JobStatus{Name: j.Name})
+		}
+	}
+	for i := range ij.Status.Jobs {
+		ij.Status.Jobs[i].State = cicdv1.CommitStatusStateFailure
+		ij.Status.Jobs[i].Message = message
+	}
+	if err := h.Client.Status().Patch(context.Background(), ij, client.MergeFrom(original)); err != nil {
+		return err
+	}
+
+	// Commit statuses of batch jobs are not reported, same as the pipeline manager
+	if len(ij.Spec.Refs.Pulls) != 1 {
+		return nil
+	}
+	for _, j := range ij.Status.Jobs {
+		status := git.CommitStatus{Context: j.Name, State: git.CommitStatusStateFailure, Description: message, TargetURL: ij.GetReportServerAddress(j.Name)}
+		if err := gitCli.SetCommitStatus(ij.Spec.Refs.Pulls[0].Sha, status); err != nil {
+			log.Error(err, "", "repo", config.Spec.Git.Repository)
+		}
+	}
+	return nil
+}
+
+func generateCanceledComment(user, jobName string, canceled []string) string {
+	if len(canceled) == 0 {
+		if jobName != "" {
+			return fmt.Sprintf("[CANCEL ALERT]\n\nThere is no running job `%s` for the pull request.\n", jobName)
+		}
+		return "[CANCEL ALERT]\n\nThere is no running job for the pull request.\n"
+	}
+	if jobName != "" {
+		return fmt.Sprintf("[CANCEL ALERT]\n\n`%s` canceled the job `%s` of the integration jobs `%s`.\n", user, jobName, strings.Join(canceled, "`, `"))
+	}
+	return fmt.Sprintf("[CANCEL ALERT]\n\n`%s` canceled the integration jobs `%s`.\n", user, strings.Join(canceled, "`, `"))
+}
+
+func generateHelpComment() string {
+	return "[CANCEL ALERT]\n\nCancel comment is malformed\n\n" +
+		"You can cancel the running jobs of the pull request by commenting...\n" +
+		"- `/cancel`\n" +
+		"- `/cancel <job>`\n"
+}
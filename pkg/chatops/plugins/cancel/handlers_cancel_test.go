@@ -0,0 +1,209 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cancel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/pkg/chatops"
+	"github.com/tmax-cloud/cicd-operator/pkg/git"
+	gitfake "github.com/tmax-cloud/cicd-operator/pkg/git/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testRepo       = "test/repo"
+	testPRID       = 11
+	testNamespace  = "default"
+	testConfigName = "test-ic"
+)
+
+func TestHandler_HandleChatOps(t *testing.T) {
+	s := runtime.NewScheme()
+	utilruntime.Must(cicdv1.AddToScheme(s))
+	utilruntime.Must(tektonv1beta1.AddToScheme(s))
+
+	ic := &cicdv1.IntegrationConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: testConfigName, Namespace: testNamespace},
+		Spec: cicdv1.IntegrationConfigSpec{
+			Git: cicdv1.GitConfig{Type: cicdv1.GitTypeFake, Repository: testRepo, Token: &cicdv1.GitToken{Value: "dummy"}},
+		},
+	}
+
+	tc := map[string]struct {
+		command chatops.Command
+		sender  git.User
+
+		expectedCanceled        []string
+		expectedTaskRunCanceled bool
+		expectedComment         string
+	}{
+		"cancelAll": {
+			command:          chatops.Command{Type: CommandTypeCancel},
+			sender:           git.User{ID: 1, Name: "author"},
+			expectedCanceled: []string{"ij-running", "ij-pending"},
+			expectedComment:  "[CANCEL ALERT]\n\n`author` canceled the integration jobs `ij-pending`, `ij-running`.\n",
+		},
+		"cancelJob": {
+			command:                 chatops.Command{Type: CommandTypeCancel, Args: []string{"test-lint"}},
+			sender:                  git.User{ID: 2, Name: "maintainer"},
+			expectedTaskRunCanceled: true,
+			expectedComment:         "[CANCEL ALERT]\n\n`maintainer` canceled the job `test-lint` of the integration jobs `ij-running`.\n",
+		},
+		"pendingJob": {
+			command:         chatops.Command{Type: CommandTypeCancel, Args: []string{"test-unit"}},
+			sender:          git.User{ID: 2, Name: "maintainer"},
+			expectedComment: "[CANCEL ALERT]\n\nThere is no running job `test-unit` for the pull request.\n",
+		},
+		"noJob": {
+			command:         chatops.Command{Type: CommandTypeCancel, Args: []string{"test-e2e"}},
+			sender:          git.User{ID: 1, Name: "author"},
+			expectedComment: "[CANCEL ALERT]\n\nThere is no running job `test-e2e` for the pull request.\n",
+		},
+		"unauthorized": {
+			command:         chatops.Command{Type: CommandTypeCancel},
+			sender:          git.User{ID: 3, Name: "stranger"},
+			expectedComment: "[CANCEL ALERT]\n\n" + chatops.GenerateUnauthorizedComment(CommandTypeCancel, "stranger"),
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			fakeCli := fake.NewClientBuilder().WithScheme(s).WithObjects(
+				ic,
+				buildTestIJ("ij-running", testPRID, cicdv1.JobTypePreSubmit, cicdv1.IntegrationJobStateRunning, "test-lint"),
+				buildTestIJ("ij-pending", testPRID, cicdv1.JobTypePreSubmit, cicdv1.IntegrationJobStatePending, "test-unit"),
+				buildTestIJ("ij-completed", testPRID, cicdv1.JobTypePreSubmit, cicdv1.IntegrationJobStateCompleted, "test-unit"),
+				buildTestIJ("ij-other-pr", testPRID+1, cicdv1.JobTypePreSubmit, cicdv1.IntegrationJobStateRunning, "test-unit"),
+				&tektonv1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "ij-running", Namespace: testNamespace}},
+				&tektonv1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "ij-running-test-lint", Namespace: testNamespace, Labels: map[string]string{
+					"tekton.dev/pipelineRun":  "ij-running",
+					"tekton.dev/pipelineTask": "test-lint",
+				}}},
+			).Build()
+			handler := &Handler{Client: fakeCli}
+
+			gitfake.Repos = map[string]*gitfake.Repo{
+				testRepo: {
+					UserCanWrite:   map[string]bool{"maintainer": true, "stranger": false},
+					PullRequests:   map[int]*git.PullRequest{testPRID: {ID: testPRID}},
+					Comments:       map[int][]git.IssueComment{},
+					CommitStatuses: map[string][]git.CommitStatus{},
+				},
+			}
+
+			require.NoError(t, handler.HandleChatOps(c.command, buildTestWebhook(c.sender), ic))
+
+			// Running job is canceled by its PipelineRun
+			pr := &tektonv1beta1.PipelineRun{}
+			require.NoError(t, fakeCli.Get(context.Background(), types.NamespacedName{Name: "ij-running", Namespace: testNamespace}, pr))
+			require.Equal(t, containsString(c.expectedCanceled, "ij-running"), pr.Spec.Status == tektonv1beta1.PipelineRunSpecStatusCancelled)
+
+			// Single job is canceled by its TaskRun
+			tr := &tektonv1beta1.TaskRun{}
+			require.NoError(t, fakeCli.Get(context.Background(), types.NamespacedName{Name: "ij-running-test-lint", Namespace: testNamespace}, tr))
+			require.Equal(t, c.expectedTaskRunCanceled, tr.Spec.Status == tektonv1beta1.TaskRunSpecStatusCancelled)
+
+			// Pending job is marked as failed, and its commit status is reported
+			ij := &cicdv1.IntegrationJob{}
+			require.NoError(t, fakeCli.Get(context.Background(), types.NamespacedName{Name: "ij-pending", Namespace: testNamespace}, ij))
+			require.Equal(t, containsString(c.expectedCanceled, "ij-pending"), ij.Status.State == cicdv1.IntegrationJobStateFailed)
+			statuses := gitfake.Repos[testRepo].CommitStatuses["ij-pending-sha"]
+			if containsString(c.expectedCanceled, "ij-pending") {
+				require.Len(t, ij.Status.Jobs, 1)
+				require.Equal(t, cicdv1.CommitStatusStateFailure, ij.Status.Jobs[0].State)
+				require.Len(t, statuses, 1)
+				require.Equal(t, git.CommitStatus{Context: "test-unit", State: git.CommitStatusStateFailure, Description: "Canceled by " + c.sender.Name, TargetURL: ij.GetReportServerAddress("test-unit")}, statuses[0])
+			} else {
+				require.Empty(t, statuses)
+			}
+
+			ijList := &cicdv1.IntegrationJobList{}
+			require.NoError(t, fakeCli.List(context.Background(), ijList, client.InNamespace(testNamespace)))
+			for _, j := range ijList.Items {
+				if j.Name == "ij-running" || j.Name == "ij-pending" {
+					continue
+				}
+				require.NotEqual(t, cicdv1.IntegrationJobStateFailed, j.Status.State, j.Name)
+			}
+
+			require.Len(t, gitfake.Repos[testRepo].Comments[testPRID], 1)
+			require.Equal(t, c.expectedComment, gitfake.Repos[testRepo].Comments[testPRID][0].Comment.Body)
+		})
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
+
+func buildTestIJ(name string, prID int, jobType cicdv1.JobType, state cicdv1.IntegrationJobState, jobName string) *cicdv1.IntegrationJob {
+	ij := &cicdv1.IntegrationJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			Labels:    map[string]string{cicdv1.JobLabelConfig: testConfigName},
+		},
+		Spec: cicdv1.IntegrationJobSpec{
+			ConfigRef: cicdv1.IntegrationJobConfigRef{Name: testConfigName, Type: jobType},
+			Refs: cicdv1.IntegrationJobRefs{
+				Pulls: []cicdv1.IntegrationJobRefsPull{{ID: prID, Sha: name + "-sha"}},
+			},
+			Jobs: cicdv1.Jobs{{}},
+		},
+		Status: cicdv1.IntegrationJobStatus{State: state},
+	}
+	if state != cicdv1.IntegrationJobStatePending {
+		ij.Status.Jobs = []cicdv1.JobStatus{{Name: jobName, State: cicdv1.CommitStatusStatePending}}
+	}
+	ij.Spec.Jobs[0].Name = jobName
+	return ij
+}
+
+func buildTestWebhook(sender git.User) *git.Webhook {
+	return &git.Webhook{
+		EventType: git.EventTypeIssueComment,
+		Repo:      git.Repository{Name: testRepo},
+		Sender:    sender,
+		IssueComment: &git.IssueComment{
+			Comment: git.Comment{CreatedAt: &metav1.Time{Time: time.Now()}},
+			Author:  sender,
+			Issue: git.Issue{
+				PullRequest: &git.PullRequest{
+					ID:     testPRID,
+					State:  git.PullRequestStateOpen,
+					Author: git.User{ID: 1, Name: "author"},
+				},
+			},
+		},
+	}
+}
@@ -58,6 +58,19 @@ func (h *Handler) HandleChatOps(command chatops.Command, webhook *git.Webhook, c
 		return err
 	}
 
+	// Authorize or exit. Anyone can hold the pull request by default
+	if err := chatops.Authorize(CommandTypeHold, config, webhook.Sender, gitCli, func() error { return nil }); err != nil {
+		unAuthErr, ok := err.(*git.UnauthorizedError)
+		if !ok {
+			return err
+		}
+
+		if err := gitCli.RegisterComment(git.IssueTypePullRequest, issueComment.Issue.PullRequest.ID, "", "[HOLD ALERT]\n\n"+chatops.GenerateUnauthorizedComment(CommandTypeHold, unAuthErr.User)); err != nil {
+			return err
+		}
+		return nil
+	}
+
 	// /hold
 	if len(command.Args) == 0 {
 		return h.handleHoldCommand(issueComment, gitCli)
@@ -0,0 +1,153 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package label
+
+import (
+	"fmt"
+	"strings"
+
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/configs"
+	"github.com/tmax-cloud/cicd-operator/internal/utils"
+	"github.com/tmax-cloud/cicd-operator/pkg/chatops"
+	"github.com/tmax-cloud/cicd-operator/pkg/git"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Command types for label handler
+const (
+	CommandTypeLabel       = "label"
+	CommandTypeRemoveLabel = "remove-label"
+)
+
+var log = logf.Log.WithName("label-plugin")
+
+// reservedLabels gate the tests and the merges, so they cannot be set or removed by the label commands,
+// even if they are in the allowed labels. They are managed by their own commands, which check the permissions
+var reservedLabels = []string{"ok-to-test", "approved", "lgtm", "hold"}
+
+// Handler is an implementation of a ChatOps Handler
+type Handler struct {
+	Client client.Client
+}
+
+// HandleChatOps handles /label and /remove-label comment commands
+func (h *Handler) HandleChatOps(command chatops.Command, webhook *git.Webhook, config *cicdv1.IntegrationConfig) error {
+	issueComment := webhook.IssueComment
+	// Do nothing if it's not pull request's comment or it's closed
+	if issueComment.Issue.PullRequest == nil || issueComment.Issue.PullRequest.State != git.PullRequestStateOpen {
+		return nil
+	}
+
+	// Skip if token is empty
+	if config.Spec.Git.Token == nil {
+		return nil
+	}
+
+	gitCli, err := utils.GetGitCli(config, h.Client)
+	if err != nil {
+		return err
+	}
+
+	prID := issueComment.Issue.PullRequest.ID
+
+	// Authorize or exit. Anyone can set the allowed labels by default
+	if err := chatops.Authorize(command.Type, config, webhook.Sender, gitCli, func() error { return nil }); err != nil {
+		unAuthErr, ok := err.(*git.UnauthorizedError)
+		if !ok {
+			return err
+		}
+		return gitCli.RegisterComment(git.IssueTypePullRequest, prID, "", "[LABEL ALERT]\n\n"+chatops.GenerateUnauthorizedComment(command.Type, unAuthErr.User))
+	}
+
+	// Malformed comment
+	if len(command.Args) == 0 {
+		return gitCli.RegisterComment(git.IssueTypePullRequest, prID, "", generateHelpComment())
+	}
+
+	// Only the allowed labels can be set/removed
+	allowedLabels := getAllowedLabels(config)
+	var notAllowed []string
+	for _, l := range command.Args {
+		if !containsLabel(allowedLabels, l) {
+			notAllowed = append(notAllowed, l)
+		}
+	}
+	if len(notAllowed) > 0 {
+		return gitCli.RegisterComment(git.IssueTypePullRequest, prID, "", generateNotAllowedComment(notAllowed, allowedLabels))
+	}
+
+	for _, l := range command.Args {
+		if command.Type == CommandTypeRemoveLabel {
+			log.Info(fmt.Sprintf("%s removed label %s from %s", issueComment.Author.Name, l, issueComment.Issue.PullRequest.URL))
+			if err := gitCli.DeleteLabel(git.IssueTypePullRequest, prID, l); err != nil && !strings.Contains(err.Error(), "Label does not exist") {
+				return err
+			}
+			continue
+		}
+		log.Info(fmt.Sprintf("%s labeled %s on %s", issueComment.Author.Name, l, issueComment.Issue.PullRequest.URL))
+		if err := gitCli.SetLabel(git.IssueTypePullRequest, prID, l); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getAllowedLabels returns the allowed labels of the config, except for the reserved labels
+func getAllowedLabels(config *cicdv1.IntegrationConfig) []string {
+	if config.Spec.ChatOps == nil {
+		return nil
+	}
+	var labels []string
+	for _, l := range config.Spec.ChatOps.AllowedLabels {
+		if isReservedLabel(l) {
+			continue
+		}
+		labels = append(labels, l)
+	}
+	return labels
+}
+
+func isReservedLabel(label string) bool {
+	return containsLabel(reservedLabels, label) || label == configs.MergeBlockLabel
+}
+
+func containsLabel(labels []string, label string) bool {
+	for _, l := range labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+func generateNotAllowedComment(labels, allowedLabels []string) string {
+	comment := fmt.Sprintf("[LABEL ALERT]\n\nLabel `%s` is not allowed to be set or removed by the chat-ops commands.\n\n", strings.Join(labels, "`, `"))
+	if len(allowedLabels) == 0 {
+		return comment + "No label is allowed. Allowed labels are configured in the IntegrationConfig's `spec.chatOps.allowedLabels`.\n"
+	}
+	return comment + fmt.Sprintf("Allowed labels are `%s`.\n", strings.Join(allowedLabels, "`, `"))
+}
+
+func generateHelpComment() string {
+	return "[LABEL ALERT]\n\nLabel comment is malformed\n\n" +
+		"You can set or remove labels by commenting...\n" +
+		"- `/label <label> [<label>...]`\n" +
+		"- `/remove-label <label> [<label>...]`\n"
+}
@@ -0,0 +1,127 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package label
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/pkg/chatops"
+	"github.com/tmax-cloud/cicd-operator/pkg/git"
+	gitfake "github.com/tmax-cloud/cicd-operator/pkg/git/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testRepo = "test/repo"
+	testPRID = 11
+
+	testUserID   = 32
+	testUserName = "test-user"
+)
+
+func TestHandler_HandleChatOps(t *testing.T) {
+	s := runtime.NewScheme()
+	utilruntime.Must(cicdv1.AddToScheme(s))
+
+	ic := &cicdv1.IntegrationConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-ic", Namespace: "default"},
+		Spec: cicdv1.IntegrationConfigSpec{
+			Git:     cicdv1.GitConfig{Type: cicdv1.GitTypeFake, Repository: testRepo, Token: &cicdv1.GitToken{Value: "dummy"}},
+			ChatOps: &cicdv1.ChatOpsConfig{AllowedLabels: []string{"kind/bug", "kind/feature", "lgtm"}},
+		},
+	}
+	handler := &Handler{Client: fake.NewClientBuilder().WithScheme(s).WithObjects(ic).Build()}
+
+	tc := map[string]struct {
+		command chatops.Command
+		labels  []git.IssueLabel
+
+		expectedLabels  []git.IssueLabel
+		expectedComment string
+	}{
+		"label": {
+			command:        chatops.Command{Type: CommandTypeLabel, Args: []string{"kind/bug", "kind/feature"}},
+			expectedLabels: []git.IssueLabel{{Name: "kind/bug"}, {Name: "kind/feature"}},
+		},
+		"removeLabel": {
+			command:        chatops.Command{Type: CommandTypeRemoveLabel, Args: []string{"kind/bug"}},
+			labels:         []git.IssueLabel{{Name: "kind/bug"}, {Name: "kind/feature"}},
+			expectedLabels: []git.IssueLabel{{Name: "kind/feature"}},
+		},
+		"notAllowed": {
+			command:         chatops.Command{Type: CommandTypeLabel, Args: []string{"kind/bug", "approved"}},
+			expectedComment: "[LABEL ALERT]\n\nLabel `approved` is not allowed to be set or removed by the chat-ops commands.\n\nAllowed labels are `kind/bug`, `kind/feature`.\n",
+		},
+		"reserved": {
+			command:         chatops.Command{Type: CommandTypeRemoveLabel, Args: []string{"lgtm"}},
+			labels:          []git.IssueLabel{{Name: "lgtm"}},
+			expectedLabels:  []git.IssueLabel{{Name: "lgtm"}},
+			expectedComment: "[LABEL ALERT]\n\nLabel `lgtm` is not allowed to be set or removed by the chat-ops commands.\n\nAllowed labels are `kind/bug`, `kind/feature`.\n",
+		},
+		"malformed": {
+			command:         chatops.Command{Type: CommandTypeLabel},
+			expectedComment: generateHelpComment(),
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			gitfake.Repos = map[string]*gitfake.Repo{
+				testRepo: {
+					PullRequests: map[int]*git.PullRequest{testPRID: {ID: testPRID, Labels: c.labels}},
+					Comments:     map[int][]git.IssueComment{},
+				},
+			}
+
+			require.NoError(t, handler.HandleChatOps(c.command, buildTestWebhook(), ic))
+			require.ElementsMatch(t, c.expectedLabels, gitfake.Repos[testRepo].PullRequests[testPRID].Labels)
+			if c.expectedComment == "" {
+				require.Empty(t, gitfake.Repos[testRepo].Comments[testPRID])
+			} else {
+				require.Len(t, gitfake.Repos[testRepo].Comments[testPRID], 1)
+				require.Equal(t, c.expectedComment, gitfake.Repos[testRepo].Comments[testPRID][0].Comment.Body)
+			}
+		})
+	}
+}
+
+func buildTestWebhook() *git.Webhook {
+	user := git.User{ID: testUserID, Name: testUserName}
+	return &git.Webhook{
+		EventType: git.EventTypeIssueComment,
+		Repo:      git.Repository{Name: testRepo},
+		Sender:    user,
+		IssueComment: &git.IssueComment{
+			Comment: git.Comment{CreatedAt: &metav1.Time{Time: time.Now()}},
+			Author:  user,
+			Issue: git.Issue{
+				PullRequest: &git.PullRequest{
+					ID:     testPRID,
+					State:  git.PullRequestStateOpen,
+					Author: git.User{ID: 1, Name: "author"},
+					Head:   git.Head{Ref: "new-feat", Sha: "sfoj39jfsidjf93jfsiljf20"},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,251 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package lgtm
+
+import (
+	"fmt"
+	"strings"
+
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/utils"
+	"github.com/tmax-cloud/cicd-operator/pkg/chatops"
+	"github.com/tmax-cloud/cicd-operator/pkg/git"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// CommandTypeLGTM is a lgtm command type
+const (
+	CommandTypeLGTM = "lgtm"
+)
+
+const lgtmLabel = "lgtm"
+
+// Handler is an implementation of both ChatOps Handler and Webhook Plugin for lgtm
+type Handler struct {
+	Client client.Client
+}
+
+var log = logf.Log.WithName("lgtm-plugin")
+
+// Name returns a name of the lgtm plugin
+func (h *Handler) Name() string {
+	return "lgtm"
+}
+
+// Handle handles a raw webhook. The lgtm label is deleted if new commits are pushed to the pull request
+func (h *Handler) Handle(wh *git.Webhook, ic *cicdv1.IntegrationConfig) error {
+	// Skip if token is empty
+	if ic.Spec.Git.Token == nil {
+		return nil
+	}
+
+	if wh.EventType != git.EventTypePullRequest || wh.PullRequest == nil || wh.PullRequest.Action != git.PullRequestActionSynchronize {
+		return nil
+	}
+
+	isLGTMLabeled := false
+	for _, l := range wh.PullRequest.Labels {
+		if l.Name == lgtmLabel {
+			isLGTMLabeled = true
+			break
+		}
+	}
+	if !isLGTMLabeled {
+		return nil
+	}
+
+	gitCli, err := utils.GetGitCli(ic, h.Client)
+	if err != nil {
+		return err
+	}
+
+	log.Info(fmt.Sprintf("new commits are pushed to %s, deleting lgtm label", wh.PullRequest.URL))
+	if err := gitCli.DeleteLabel(git.IssueTypePullRequest, wh.PullRequest.ID, lgtmLabel); err != nil && !strings.Contains(err.Error(), "Label does not exist") {
+		return err
+	}
+	if err := gitCli.RegisterComment(git.IssueTypePullRequest, wh.PullRequest.ID, "", generateLGTMResetComment()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// HandleChatOps handles /lgtm and /lgtm cancel comment commands
+func (h *Handler) HandleChatOps(command chatops.Command, webhook *git.Webhook, config *cicdv1.IntegrationConfig) error {
+	issueComment := webhook.IssueComment
+	// Do nothing if it's not pull request's comment or it's closed
+	if issueComment.Issue.PullRequest == nil || issueComment.Issue.PullRequest.State != git.PullRequestStateOpen {
+		return nil
+	}
+
+	// Skip if token is empty
+	if config.Spec.Git.Token == nil {
+		return nil
+	}
+
+	gitCli, err := utils.GetGitCli(config, h.Client)
+	if err != nil {
+		return err
+	}
+
+	isCancel := len(command.Args) == 1 && command.Args[0] == "cancel"
+
+	// Default - malformed comment
+	if len(command.Args) != 0 && !isCancel {
+		if err := gitCli.RegisterComment(git.IssueTypePullRequest, issueComment.Issue.PullRequest.ID, "", generateHelpComment()); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	// Authorize or exit
+	if err := h.authorize(config, webhook.Sender, issueComment.Issue.PullRequest, isCancel, gitCli); err != nil {
+		unAuthErr, ok := err.(*git.UnauthorizedError)
+		if !ok {
+			return err
+		}
+
+		comment := generateUserUnauthorizedComment(unAuthErr.User)
+		if chatops.GetPermission(config, CommandTypeLGTM) != nil && webhook.Sender.ID != issueComment.Issue.PullRequest.Author.ID {
+			comment = "[LGTM ALERT]\n\n" + chatops.GenerateUnauthorizedComment(CommandTypeLGTM, unAuthErr.User)
+		}
+		if err := gitCli.RegisterComment(git.IssueTypePullRequest, issueComment.Issue.PullRequest.ID, "", comment); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	// /lgtm cancel
+	if isCancel {
+		return h.handleLGTMCancelCommand(issueComment, gitCli)
+	}
+
+	// /lgtm
+	return h.handleLGTMCommand(issueComment, gitCli)
+}
+
+// handleLGTMCommand handles '/lgtm' command
+func (h *Handler) handleLGTMCommand(issueComment *git.IssueComment, gitCli git.Client) error {
+	log.Info(fmt.Sprintf("%s lgtm'ed %s", issueComment.Author.Name, issueComment.Issue.PullRequest.URL))
+	// Register lgtm label
+	if err := gitCli.SetLabel(git.IssueTypePullRequest, issueComment.Issue.PullRequest.ID, lgtmLabel); err != nil {
+		return err
+	}
+
+	// Register comment
+	if err := gitCli.RegisterComment(git.IssueTypePullRequest, issueComment.Issue.PullRequest.ID, "", generateLGTMComment(issueComment.Author.Name)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// handleLGTMCancelCommand handles '/lgtm cancel' command
+func (h *Handler) handleLGTMCancelCommand(issueComment *git.IssueComment, gitCli git.Client) error {
+	log.Info(fmt.Sprintf("%s canceled lgtm on %s", issueComment.Author.Name, issueComment.Issue.PullRequest.URL))
+	// Delete lgtm label
+	if err := gitCli.DeleteLabel(git.IssueTypePullRequest, issueComment.Issue.PullRequest.ID, lgtmLabel); err != nil && !strings.Contains(err.Error(), "Label does not exist") {
+		return err
+	}
+
+	// Register comment
+	if err := gitCli.RegisterComment(git.IssueTypePullRequest, issueComment.Issue.PullRequest.ID, "", generateLGTMCanceledComment(issueComment.Author.Name)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// authorize decides if the sender is authorized to lgtm the PR
+// If the permission of the command is configured in the IntegrationConfig, it's used instead of the default rule.
+// The author can only cancel the lgtm
+func (h *Handler) authorize(cfg *cicdv1.IntegrationConfig, sender git.User, pr *git.PullRequest, isCancel bool, gitCli git.Client) error {
+	// Check if it's PR's author
+	if sender.ID == pr.Author.ID {
+		if isCancel {
+			return nil
+		}
+		return &git.UnauthorizedError{User: sender.Name, Repo: cfg.Spec.Git.Repository}
+	}
+
+	return chatops.Authorize(CommandTypeLGTM, cfg, sender, gitCli, func() error {
+		return h.authorizeDefault(cfg, sender, pr, gitCli)
+	})
+}
+
+// authorizeDefault is a default rule of lgtm.
+// If there are OWNERS files, the sender should be a reviewer or an approver of any of the changed files.
+// If not, the sender should have a write permission on the repository
+func (h *Handler) authorizeDefault(cfg *cicdv1.IntegrationConfig, sender git.User, pr *git.PullRequest, gitCli git.Client) error {
+	diff, err := gitCli.GetPullRequestDiff(pr.ID)
+	if err != nil {
+		return err
+	}
+	files := diff.Files()
+	owners, err := git.LoadOwners(gitCli, strings.TrimPrefix(pr.Base.Ref, "refs/heads/"), files)
+	if err != nil {
+		return err
+	}
+
+	// Check if it's a reviewer or an approver in the OWNERS files
+	if owners.Exists() {
+		if len(files) == 0 {
+			files = []string{""}
+		}
+		for _, f := range files {
+			if git.ContainsUser(owners.Reviewers(f), sender.Name) || git.ContainsUser(owners.Approvers(f), sender.Name) {
+				return nil
+			}
+		}
+		return &git.UnauthorizedError{User: sender.Name, Repo: cfg.Spec.Git.Repository}
+	}
+
+	// Check if it's repo's maintainer
+	ok, err := gitCli.CanUserWriteToRepo(sender)
+	if err != nil {
+		return err
+	} else if ok {
+		return nil
+	}
+
+	return &git.UnauthorizedError{User: sender.Name, Repo: cfg.Spec.Git.Repository}
+}
+
+func generateUserUnauthorizedComment(user string) string {
+	return fmt.Sprintf("[LGTM ALERT]\n\nUser `%s` is not allowed to lgtm this pull request.\n\n"+
+		"Users who meet the following conditions can lgtm the pull request.\n"+
+		"- Not an author of the pull request\n"+
+		"- (If there are OWNERS files) Be a reviewer or an approver of the changed files\n"+
+		"- (If there is no OWNERS file) Have write permission on the repository\n", user)
+}
+
+func generateLGTMComment(user string) string {
+	return fmt.Sprintf("[LGTM ALERT]\n\nUser `%s` lgtm'ed this pull request!", user)
+}
+
+func generateLGTMCanceledComment(user string) string {
+	return fmt.Sprintf("[LGTM ALERT]\n\nUser `%s` canceled the lgtm.", user)
+}
+
+func generateLGTMResetComment() string {
+	return "[LGTM ALERT]\n\nNew commits are pushed. Label `lgtm` is removed."
+}
+
+func generateHelpComment() string {
+	return "[LGTM ALERT]\n\nLGTM comment is malformed\n\n" +
+		"You can lgtm or cancel the lgtm the pull request by commenting...\n" +
+		"- `/lgtm`\n" +
+		"- `/lgtm cancel`\n"
+}
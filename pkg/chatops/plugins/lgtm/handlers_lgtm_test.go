@@ -0,0 +1,281 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package lgtm
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/pkg/chatops"
+	"github.com/tmax-cloud/cicd-operator/pkg/git"
+	gitfake "github.com/tmax-cloud/cicd-operator/pkg/git/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+const (
+	testRepo = "test/repo"
+	testPRID = 11
+
+	testNamespace  = "default"
+	testConfigName = "test-ic"
+
+	testUserID   = 32
+	testUserName = "test-user"
+
+	testReviewerID   = 111
+	testReviewerName = "reviewer"
+
+	testOtherUserID   = 222
+	testOtherUserName = "other-user"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	if _, exist := os.LookupEnv("CI"); !exist {
+		ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
+	}
+	s := runtime.NewScheme()
+	utilruntime.Must(cicdv1.AddToScheme(s))
+
+	ic := buildTestConfigForLGTM()
+	fakeCli := fake.NewClientBuilder().WithScheme(s).WithObjects(ic).Build()
+	handler := &Handler{Client: fakeCli}
+
+	tc := map[string]struct {
+		action       git.PullRequestAction
+		labels       []git.IssueLabel
+		expectedLGTM bool
+	}{
+		"synchronize":        {action: git.PullRequestActionSynchronize, labels: []git.IssueLabel{{Name: "lgtm"}}, expectedLGTM: false},
+		"synchronizeNoLabel": {action: git.PullRequestActionSynchronize},
+		"opened":             {action: git.PullRequestActionOpen, labels: []git.IssueLabel{{Name: "lgtm"}}, expectedLGTM: true},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			initFakeGit(false)
+			repo := gitfake.Repos[testRepo]
+			repo.PullRequests[testPRID].Labels = append(repo.PullRequests[testPRID].Labels, c.labels...)
+
+			wh := &git.Webhook{
+				EventType: git.EventTypePullRequest,
+				Repo:      git.Repository{Name: testRepo},
+				PullRequest: &git.PullRequest{
+					ID:     testPRID,
+					State:  git.PullRequestStateOpen,
+					Action: c.action,
+					Labels: c.labels,
+				},
+			}
+			require.NoError(t, handler.Handle(wh, ic))
+
+			if c.expectedLGTM {
+				require.Len(t, repo.PullRequests[testPRID].Labels, 1)
+				require.Len(t, repo.Comments[testPRID], 0)
+			} else {
+				require.Len(t, repo.PullRequests[testPRID].Labels, 0)
+				if len(c.labels) > 0 {
+					require.Len(t, repo.Comments[testPRID], 1)
+					require.Equal(t, generateLGTMResetComment(), repo.Comments[testPRID][0].Comment.Body)
+				}
+			}
+		})
+	}
+}
+
+func TestHandler_HandleChatOps(t *testing.T) {
+	if _, exist := os.LookupEnv("CI"); !exist {
+		ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
+	}
+	s := runtime.NewScheme()
+	utilruntime.Must(cicdv1.AddToScheme(s))
+
+	ic := buildTestConfigForLGTM()
+	fakeCli := fake.NewClientBuilder().WithScheme(s).WithObjects(ic).Build()
+	handler := &Handler{Client: fakeCli}
+
+	tc := map[string]struct {
+		command  chatops.Command
+		sender   string
+		owners   bool
+		canWrite bool
+		labels   []git.IssueLabel
+
+		expectedComment string
+		expectedLGTM    bool
+	}{
+		"failAuthor": {
+			command:         chatops.Command{Type: "lgtm"},
+			sender:          testUserName,
+			canWrite:        true,
+			expectedComment: generateUserUnauthorizedComment(testUserName),
+		},
+		"failUnauthorized": {
+			command:         chatops.Command{Type: "lgtm"},
+			sender:          testOtherUserName,
+			expectedComment: generateUserUnauthorizedComment(testOtherUserName),
+		},
+		"failNotOwner": {
+			command:         chatops.Command{Type: "lgtm"},
+			sender:          testOtherUserName,
+			owners:          true,
+			canWrite:        true,
+			expectedComment: generateUserUnauthorizedComment(testOtherUserName),
+		},
+		"failMalformed": {
+			command:         chatops.Command{Type: "lgtm", Args: []string{"asd"}},
+			sender:          testReviewerName,
+			canWrite:        true,
+			expectedComment: generateHelpComment(),
+		},
+		"lgtm": {
+			command:         chatops.Command{Type: "lgtm"},
+			sender:          testOtherUserName,
+			canWrite:        true,
+			expectedComment: generateLGTMComment(testOtherUserName),
+			expectedLGTM:    true,
+		},
+		"lgtmOwners": {
+			command:         chatops.Command{Type: "lgtm"},
+			sender:          testReviewerName,
+			owners:          true,
+			expectedComment: generateLGTMComment(testReviewerName),
+			expectedLGTM:    true,
+		},
+		"cancel": {
+			command:         chatops.Command{Type: "lgtm", Args: []string{"cancel"}},
+			sender:          testReviewerName,
+			owners:          true,
+			labels:          []git.IssueLabel{{Name: "lgtm"}},
+			expectedComment: generateLGTMCanceledComment(testReviewerName),
+		},
+		"cancelAuthor": {
+			command:         chatops.Command{Type: "lgtm", Args: []string{"cancel"}},
+			sender:          testUserName,
+			labels:          []git.IssueLabel{{Name: "lgtm"}},
+			expectedComment: generateLGTMCanceledComment(testUserName),
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			initFakeGit(c.owners)
+			repo := gitfake.Repos[testRepo]
+			repo.UserCanWrite[c.sender] = c.canWrite
+			repo.PullRequests[testPRID].Labels = append(repo.PullRequests[testPRID].Labels, c.labels...)
+
+			wh := buildTestWebhookComment()
+			wh.Sender = *gitfake.Users[c.sender]
+			wh.IssueComment.Author = wh.Sender
+
+			require.NoError(t, handler.HandleChatOps(c.command, wh, ic))
+
+			require.Len(t, repo.Comments[testPRID], 1)
+			require.Equal(t, c.expectedComment, repo.Comments[testPRID][0].Comment.Body)
+			if c.expectedLGTM {
+				require.Len(t, repo.PullRequests[testPRID].Labels, 1)
+				require.Equal(t, "lgtm", repo.PullRequests[testPRID].Labels[0].Name)
+			} else {
+				require.Len(t, repo.PullRequests[testPRID].Labels, 0)
+			}
+		})
+	}
+}
+
+func initFakeGit(owners bool) {
+	gitfake.Users = map[string]*git.User{
+		testUserName:      {ID: testUserID, Name: testUserName},
+		testReviewerName:  {ID: testReviewerID, Name: testReviewerName},
+		testOtherUserName: {ID: testOtherUserID, Name: testOtherUserName},
+	}
+	gitfake.Repos = map[string]*gitfake.Repo{
+		testRepo: {
+			UserCanWrite: map[string]bool{},
+			PullRequests: map[int]*git.PullRequest{
+				testPRID: {},
+			},
+			PullRequestDiffs: map[int]*git.Diff{
+				testPRID: {Changes: []git.Change{{Filename: "pkg/a.go"}}},
+			},
+			Comments: map[int][]git.IssueComment{},
+		},
+	}
+	if owners {
+		gitfake.Repos[testRepo].Files = map[string]map[string]string{
+			"master": {
+				"OWNERS":     "approvers:\n- root-approver\n",
+				"pkg/OWNERS": "reviewers:\n- reviewer\n",
+			},
+		}
+	}
+}
+
+func buildTestConfigForLGTM() *cicdv1.IntegrationConfig {
+	return &cicdv1.IntegrationConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testConfigName,
+			Namespace: testNamespace,
+		},
+		Spec: cicdv1.IntegrationConfigSpec{
+			Git: cicdv1.GitConfig{
+				Type:       cicdv1.GitTypeFake,
+				Repository: testRepo,
+				Token:      &cicdv1.GitToken{Value: "dummy"},
+			},
+		},
+	}
+}
+
+func buildTestWebhookComment() *git.Webhook {
+	return &git.Webhook{
+		EventType: git.EventTypeIssueComment,
+		Repo: git.Repository{
+			Name: testRepo,
+		},
+		IssueComment: &git.IssueComment{
+			Comment: git.Comment{
+				CreatedAt: &metav1.Time{Time: time.Now()},
+			},
+			Issue: git.Issue{
+				PullRequest: &git.PullRequest{
+					ID:    testPRID,
+					Title: "test-pull-request",
+					State: git.PullRequestStateOpen,
+					Author: git.User{
+						ID:   testUserID,
+						Name: testUserName,
+					},
+					URL: "https://github.com/tmax-cloud/cicd-operator/pulls/1",
+					Base: git.Base{
+						Ref: "master",
+					},
+					Head: git.Head{
+						Ref: "new-feat",
+						Sha: "sfoj39jfsidjf93jfsiljf20",
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,147 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package override
+
+import (
+	"fmt"
+	"strings"
+
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/utils"
+	"github.com/tmax-cloud/cicd-operator/pkg/chatops"
+	"github.com/tmax-cloud/cicd-operator/pkg/git"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// CommandTypeOverride is an override command type
+const (
+	CommandTypeOverride = "override"
+)
+
+var log = logf.Log.WithName("override-plugin")
+
+// Handler is an implementation of a ChatOps Handler
+type Handler struct {
+	Client client.Client
+}
+
+// HandleChatOps handles /override comment commands
+func (h *Handler) HandleChatOps(command chatops.Command, webhook *git.Webhook, config *cicdv1.IntegrationConfig) error {
+	issueComment := webhook.IssueComment
+	// Do nothing if it's not pull request's comment or it's closed
+	if issueComment.Issue.PullRequest == nil || issueComment.Issue.PullRequest.State != git.PullRequestStateOpen {
+		return nil
+	}
+
+	// Skip if token is empty
+	if config.Spec.Git.Token == nil {
+		return nil
+	}
+
+	gitCli, err := utils.GetGitCli(config, h.Client)
+	if err != nil {
+		return err
+	}
+
+	pr := issueComment.Issue.PullRequest
+
+	// Authorize or exit. Only the repo's admins can override the statuses by default.
+	// Writers can be allowed by the 'writers' policy of the command
+	if err := chatops.Authorize(CommandTypeOverride, config, webhook.Sender, gitCli, func() error {
+		ok, err := gitCli.IsUserRepoAdmin(webhook.Sender)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return &git.UnauthorizedError{User: webhook.Sender.Name, Repo: config.Spec.Git.Repository}
+		}
+		return nil
+	}); err != nil {
+		unAuthErr, ok := err.(*git.UnauthorizedError)
+		if !ok {
+			return err
+		}
+		return gitCli.RegisterComment(git.IssueTypePullRequest, pr.ID, "", "[OVERRIDE ALERT]\n\n"+chatops.GenerateUnauthorizedComment(CommandTypeOverride, unAuthErr.User))
+	}
+
+	// Malformed comment
+	if len(command.Args) == 0 {
+		return gitCli.RegisterComment(git.IssueTypePullRequest, pr.ID, "", generateHelpComment())
+	}
+
+	statuses, err := gitCli.ListCommitStatuses(pr.Head.Sha)
+	if err != nil {
+		return err
+	}
+
+	// Only the existing contexts can be overridden
+	var unknown []string
+	for _, ctx := range command.Args {
+		if findStatus(statuses, ctx) == nil {
+			unknown = append(unknown, ctx)
+		}
+	}
+	if len(unknown) > 0 {
+		return gitCli.RegisterComment(git.IssueTypePullRequest, pr.ID, "", generateUnknownContextComment(unknown, statuses))
+	}
+
+	for _, ctx := range command.Args {
+		status := findStatus(statuses, ctx)
+		if status.State == git.CommitStatusStateSuccess {
+			continue
+		}
+		log.Info(fmt.Sprintf("%s overrode %s (%s) on %s", issueComment.Author.Name, ctx, status.State, pr.URL))
+		if err := gitCli.SetCommitStatus(pr.Head.Sha, git.CommitStatus{
+			Context:     ctx,
+			State:       git.CommitStatusStateSuccess,
+			Description: fmt.Sprintf("Overridden by %s", issueComment.Author.Name),
+			TargetURL:   status.TargetURL,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func findStatus(statuses []git.CommitStatus, context string) *git.CommitStatus {
+	for i := range statuses {
+		if statuses[i].Context == context {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
+
+func generateUnknownContextComment(unknown []string, statuses []git.CommitStatus) string {
+	comment := fmt.Sprintf("[OVERRIDE ALERT]\n\nThere is no commit status `%s` for the pull request.\n\n", strings.Join(unknown, "`, `"))
+	if len(statuses) == 0 {
+		return comment + "The pull request doesn't have any commit status.\n"
+	}
+	var contexts []string
+	for _, s := range statuses {
+		contexts = append(contexts, s.Context)
+	}
+	return comment + fmt.Sprintf("Available commit statuses are `%s`.\n", strings.Join(contexts, "`, `"))
+}
+
+func generateHelpComment() string {
+	return "[OVERRIDE ALERT]\n\nOverride comment is malformed\n\n" +
+		"You can override the commit statuses of the pull request by commenting...\n" +
+		"- `/override <context> [<context>...]`\n"
+}
@@ -0,0 +1,157 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package override
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/pkg/chatops"
+	"github.com/tmax-cloud/cicd-operator/pkg/git"
+	gitfake "github.com/tmax-cloud/cicd-operator/pkg/git/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testRepo = "test/repo"
+	testPRID = 11
+	testSha  = "sfoj39jfsidjf93jfsiljf20"
+)
+
+func TestHandler_HandleChatOps(t *testing.T) {
+	s := runtime.NewScheme()
+	utilruntime.Must(cicdv1.AddToScheme(s))
+
+	ic := &cicdv1.IntegrationConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-ic", Namespace: "default"},
+		Spec: cicdv1.IntegrationConfigSpec{
+			Git: cicdv1.GitConfig{Type: cicdv1.GitTypeFake, Repository: testRepo, Token: &cicdv1.GitToken{Value: "dummy"}},
+		},
+	}
+	handler := &Handler{Client: fake.NewClientBuilder().WithScheme(s).WithObjects(ic).Build()}
+
+	tc := map[string]struct {
+		command chatops.Command
+		sender  git.User
+		policy  cicdv1.ChatOpsPolicy
+
+		expectedStatuses []git.CommitStatus
+		expectedComment  string
+	}{
+		"override": {
+			command: chatops.Command{Type: CommandTypeOverride, Args: []string{"test-lint"}},
+			sender:  git.User{ID: 2, Name: "maintainer"},
+			expectedStatuses: []git.CommitStatus{
+				{Context: "test-lint", State: git.CommitStatusStateFailure, TargetURL: "https://test.io/lint"},
+				{Context: "test-unit", State: git.CommitStatusStateSuccess},
+				{Context: "test-lint", State: git.CommitStatusStateSuccess, Description: "Overridden by maintainer", TargetURL: "https://test.io/lint"},
+			},
+		},
+		"unauthorized": {
+			command:         chatops.Command{Type: CommandTypeOverride, Args: []string{"test-lint"}},
+			sender:          git.User{ID: 1, Name: "author"},
+			expectedComment: "[OVERRIDE ALERT]\n\n" + chatops.GenerateUnauthorizedComment(CommandTypeOverride, "author"),
+		},
+		"writer": {
+			command:         chatops.Command{Type: CommandTypeOverride, Args: []string{"test-lint"}},
+			sender:          git.User{ID: 3, Name: "writer"},
+			expectedComment: "[OVERRIDE ALERT]\n\n" + chatops.GenerateUnauthorizedComment(CommandTypeOverride, "writer"),
+		},
+		"writerPolicy": {
+			command: chatops.Command{Type: CommandTypeOverride, Args: []string{"test-lint"}},
+			sender:  git.User{ID: 3, Name: "writer"},
+			policy:  cicdv1.ChatOpsPolicyWriters,
+			expectedStatuses: []git.CommitStatus{
+				{Context: "test-lint", State: git.CommitStatusStateFailure, TargetURL: "https://test.io/lint"},
+				{Context: "test-unit", State: git.CommitStatusStateSuccess},
+				{Context: "test-lint", State: git.CommitStatusStateSuccess, Description: "Overridden by writer", TargetURL: "https://test.io/lint"},
+			},
+		},
+		"unknownContext": {
+			command:         chatops.Command{Type: CommandTypeOverride, Args: []string{"test-e2e"}},
+			sender:          git.User{ID: 2, Name: "maintainer"},
+			expectedComment: "[OVERRIDE ALERT]\n\nThere is no commit status `test-e2e` for the pull request.\n\nAvailable commit statuses are `test-lint`, `test-unit`.\n",
+		},
+		"malformed": {
+			command:         chatops.Command{Type: CommandTypeOverride},
+			sender:          git.User{ID: 2, Name: "maintainer"},
+			expectedComment: generateHelpComment(),
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			gitfake.Repos = map[string]*gitfake.Repo{
+				testRepo: {
+					UserCanWrite: map[string]bool{"maintainer": true, "writer": true, "author": false},
+					UserIsAdmin:  map[string]bool{"maintainer": true},
+					PullRequests: map[int]*git.PullRequest{testPRID: {ID: testPRID}},
+					Comments:     map[int][]git.IssueComment{},
+					CommitStatuses: map[string][]git.CommitStatus{
+						testSha: {
+							{Context: "test-lint", State: git.CommitStatusStateFailure, TargetURL: "https://test.io/lint"},
+							{Context: "test-unit", State: git.CommitStatusStateSuccess},
+						},
+					},
+				},
+			}
+
+			cfg := ic.DeepCopy()
+			if c.policy != "" {
+				cfg.Spec.ChatOps = &cicdv1.ChatOpsConfig{Permissions: map[string]cicdv1.ChatOpsPermission{CommandTypeOverride: {Policy: c.policy}}}
+			}
+
+			require.NoError(t, handler.HandleChatOps(c.command, buildTestWebhook(c.sender), cfg))
+			if c.expectedStatuses != nil {
+				require.Equal(t, c.expectedStatuses, gitfake.Repos[testRepo].CommitStatuses[testSha])
+			} else {
+				require.Len(t, gitfake.Repos[testRepo].CommitStatuses[testSha], 2)
+			}
+			if c.expectedComment == "" {
+				require.Empty(t, gitfake.Repos[testRepo].Comments[testPRID])
+			} else {
+				require.Len(t, gitfake.Repos[testRepo].Comments[testPRID], 1)
+				require.Equal(t, c.expectedComment, gitfake.Repos[testRepo].Comments[testPRID][0].Comment.Body)
+			}
+		})
+	}
+}
+
+func buildTestWebhook(sender git.User) *git.Webhook {
+	return &git.Webhook{
+		EventType: git.EventTypeIssueComment,
+		Repo:      git.Repository{Name: testRepo},
+		Sender:    sender,
+		IssueComment: &git.IssueComment{
+			Comment: git.Comment{CreatedAt: &metav1.Time{Time: time.Now()}},
+			Author:  sender,
+			Issue: git.Issue{
+				PullRequest: &git.PullRequest{
+					ID:     testPRID,
+					State:  git.PullRequestStateOpen,
+					Author: git.User{ID: 1, Name: "author"},
+					Head:   git.Head{Ref: "new-feat", Sha: testSha},
+				},
+			},
+		},
+	}
+}
@@ -19,6 +19,8 @@ package trigger
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
 	"github.com/tmax-cloud/cicd-operator/internal/utils"
@@ -30,8 +32,10 @@ import (
 
 // Command types for trigger handler
 const (
-	CommandTypeTest   = "test"
-	CommandTypeRetest = "retest"
+	CommandTypeTest      = "test"
+	CommandTypeRetest    = "retest"
+	CommandTypeRetestAll = "retest-all"
+	CommandTypeOkToTest  = "ok-to-test"
 )
 
 // Handler is an implementation of a ChatOps Handler
@@ -39,7 +43,7 @@ type Handler struct {
 	Client client.Client
 }
 
-// HandleChatOps handles /test and /retest comment commands
+// HandleChatOps handles /test, /retest, /retest-all and /ok-to-test comment commands
 func (h *Handler) HandleChatOps(command chatops.Command, webhook *git.Webhook, config *cicdv1.IntegrationConfig) error {
 	issueComment := webhook.IssueComment
 	// Do nothing if it's not pull request's comment, commit comment, nor it's closed
@@ -47,20 +51,29 @@ func (h *Handler) HandleChatOps(command chatops.Command, webhook *git.Webhook, c
 		return nil
 	}
 
+	if command.Type == CommandTypeOkToTest {
+		return h.handleOkToTestCommand(webhook, config)
+	}
+
 	// Authorize or exit
-	if err := h.authorize(config, &webhook.Sender, issueComment); err != nil {
+	if err := h.authorize(config, command.Type, &webhook.Sender, issueComment); err != nil {
 		id := -1
 		if issueComment.Issue.PullRequest != nil {
 			id = issueComment.Issue.PullRequest.ID
 		}
-		if err := h.registerUnauthorizedComment(config, id, issueComment.Issue.CommitID, err); err != nil {
+		if err := h.registerUnauthorizedComment(config, command.Type, id, issueComment.Issue.CommitID, err); err != nil {
 			return err
 		}
 		return nil
 	}
 
-	// Test all (=retest)
-	if len(command.Args) == 0 {
+	// Retest only the failed jobs
+	if command.Type == CommandTypeRetest && len(command.Args) == 0 && webhook.IssueComment.Issue.PullRequest != nil {
+		return h.handleRetestFailedCommand(webhook, config)
+	}
+
+	// Test all (=retest-all)
+	if len(command.Args) == 0 || command.Type == CommandTypeRetestAll {
 		return h.handleRetestCommand(webhook, config)
 	}
 
@@ -102,7 +115,7 @@ func (h *Handler) handleTestCommand(command chatops.Command, webhook *git.Webhoo
 	return nil
 }
 
-// handleTestCommand handles '/retest' command
+// handleRetestCommand handles '/retest-all' command. It triggers all the jobs
 func (h *Handler) handleRetestCommand(webhook *git.Webhook, config *cicdv1.IntegrationConfig) error {
 	var job *cicdv1.IntegrationJob
 	// Generate IntegrationJob for the PullRequest
@@ -127,18 +140,218 @@ func (h *Handler) handleRetestCommand(webhook *git.Webhook, config *cicdv1.Integ
 	return nil
 }
 
+// handleRetestFailedCommand handles '/retest' command of a pull request.
+// It triggers only the failed jobs of the head commit, the jobs which depend on them and their prerequisite jobs.
+// If no job has run for the head commit, it triggers all the jobs
+func (h *Handler) handleRetestFailedCommand(webhook *git.Webhook, config *cicdv1.IntegrationConfig) error {
+	pr := webhook.IssueComment.Issue.PullRequest
+
+	statuses, err := h.latestJobStatuses(pr, config)
+	if err != nil {
+		return err
+	}
+	if len(statuses) == 0 {
+		return h.handleRetestCommand(webhook, config)
+	}
+
+	job := dispatcher.GeneratePreSubmit([]git.PullRequest{*pr}, &webhook.Repo, &webhook.Sender, config)
+	if job == nil {
+		return nil
+	}
+
+	// Filter only failed jobs and the jobs after them (transitively), with their prerequisite jobs
+	graph, err := job.Spec.Jobs.GetGraph()
+	if err != nil {
+		return err
+	}
+	failed := map[string]struct{}{}
+	for name, state := range statuses {
+		if state != cicdv1.CommitStatusStateFailure && state != cicdv1.CommitStatusStateError {
+			continue
+		}
+		failed[name] = struct{}{}
+		for _, p := range graph.GetPosts(name) {
+			failed[p] = struct{}{}
+		}
+	}
+	targets := map[string]struct{}{}
+	for name := range failed {
+		dependents, err := dependentJobs(name, job.Spec.Jobs)
+		if err != nil {
+			return err
+		}
+		for d := range dependents {
+			targets[d] = struct{}{}
+		}
+	}
+
+	filteredJobs := cicdv1.Jobs{}
+	for _, j := range job.Spec.Jobs {
+		if _, ok := targets[j.Name]; ok {
+			filteredJobs = append(filteredJobs, j)
+		}
+	}
+	job.Spec.Jobs = filteredJobs
+
+	if len(job.Spec.Jobs) == 0 {
+		return h.registerNothingToRetestComment(config, pr)
+	}
+
+	// Create it
+	if err := h.Client.Create(context.Background(), job); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// latestJobStatuses returns the latest state of each preSubmit job run for the head commit of the pull request.
+// A job may be run by several IntegrationJobs (e.g., '/test <job>'), so the state of the latest one is used
+func (h *Handler) latestJobStatuses(pr *git.PullRequest, config *cicdv1.IntegrationConfig) (map[string]cicdv1.CommitStatusState, error) {
+	ijList := &cicdv1.IntegrationJobList{}
+	if err := h.Client.List(context.Background(), ijList, client.InNamespace(config.Namespace), client.MatchingLabels{cicdv1.JobLabelConfig: config.Name}); err != nil {
+		return nil, err
+	}
+
+	var ijs []*cicdv1.IntegrationJob
+	for i := range ijList.Items {
+		ij := &ijList.Items[i]
+		if ij.Spec.ConfigRef.Type != cicdv1.JobTypePreSubmit || len(ij.Spec.Refs.Pulls) != 1 {
+			continue
+		}
+		if ij.Spec.Refs.Pulls[0].ID != pr.ID || ij.Spec.Refs.Pulls[0].Sha != pr.Head.Sha {
+			continue
+		}
+		ijs = append(ijs, ij)
+	}
+	sort.Slice(ijs, func(i, j int) bool {
+		return ijs[j].CreationTimestamp.Before(&ijs[i].CreationTimestamp)
+	})
+
+	statuses := map[string]cicdv1.CommitStatusState{}
+	for _, ij := range ijs {
+		for _, j := range ij.Spec.Jobs {
+			if _, exist := statuses[j.Name]; exist {
+				continue
+			}
+			statuses[j.Name] = cicdv1.CommitStatusStatePending
+			for _, st := range ij.Status.Jobs {
+				if st.Name == j.Name {
+					statuses[j.Name] = st.State
+					break
+				}
+			}
+		}
+	}
+	return statuses, nil
+}
+
+// registerNothingToRetestComment registers comment that there is no failed job to retest
+func (h *Handler) registerNothingToRetestComment(config *cicdv1.IntegrationConfig, pr *git.PullRequest) error {
+	// Skip if token is empty
+	if config.Spec.Git.Token == nil {
+		return nil
+	}
+
+	gitCli, err := utils.GetGitCli(config, h.Client)
+	if err != nil {
+		return err
+	}
+
+	return gitCli.RegisterComment(git.IssueTypePullRequest, pr.ID, "", generateNothingToRetestComment(pr.Head.Sha))
+}
+
+// handleOkToTestCommand handles '/ok-to-test' command.
+// It allows the preSubmit jobs of the pull request from an untrusted contributor, and triggers them
+func (h *Handler) handleOkToTestCommand(webhook *git.Webhook, config *cicdv1.IntegrationConfig) error {
+	pr := webhook.IssueComment.Issue.PullRequest
+	// Only for the open pull requests
+	if pr == nil || pr.State != git.PullRequestStateOpen {
+		return nil
+	}
+
+	// Skip if token is empty
+	if config.Spec.Git.Token == nil {
+		return nil
+	}
+
+	gitCli, err := utils.GetGitCli(config, h.Client)
+	if err != nil {
+		return err
+	}
+
+	// Authorize or exit. Only the trusted contributors can call it by default
+	if err := chatops.Authorize(CommandTypeOkToTest, config, webhook.Sender, gitCli, func() error {
+		trusted, err := chatops.IsTrustedContributor(config, webhook.Sender, gitCli)
+		if err != nil {
+			return err
+		}
+		if !trusted {
+			return &git.UnauthorizedError{User: webhook.Sender.Name, Repo: config.Spec.Git.Repository}
+		}
+		return nil
+	}); err != nil {
+		if err := h.registerUnauthorizedComment(config, CommandTypeOkToTest, pr.ID, "", err); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if err := gitCli.DeleteLabel(git.IssueTypePullRequest, pr.ID, dispatcher.LabelNeedsOkToTest); err != nil && !strings.Contains(err.Error(), "Label does not exist") {
+		return err
+	}
+	if err := gitCli.SetLabel(git.IssueTypePullRequest, pr.ID, dispatcher.LabelOkToTest); err != nil {
+		return err
+	}
+
+	return h.handleRetestCommand(webhook, config)
+}
+
 // authorize decides if the sender is authorized to trigger the tests
-func (h *Handler) authorize(cfg *cicdv1.IntegrationConfig, sender *git.User, issueComment *git.IssueComment) error {
+// If the permission of the command is configured in the IntegrationConfig, it's used instead of the default rule
+func (h *Handler) authorize(cfg *cicdv1.IntegrationConfig, command string, sender *git.User, issueComment *git.IssueComment) error {
+	if chatops.GetPermission(cfg, command) == nil {
+		return h.authorizeDefault(cfg, sender, issueComment)
+	}
+
+	g, err := utils.GetGitCli(cfg, h.Client)
+	if err != nil {
+		return err
+	}
+	return chatops.Authorize(command, cfg, *sender, g, func() error {
+		return h.authorizeDefault(cfg, sender, issueComment)
+	})
+}
+
+// authorizeDefault is a default rule of the trigger. The PR's author or the repo's maintainers can trigger the tests
+// If ok-to-test is required, the author should be trusted or the PR should be allowed by '/ok-to-test'
+func (h *Handler) authorizeDefault(cfg *cicdv1.IntegrationConfig, sender *git.User, issueComment *git.IssueComment) error {
+	pr := issueComment.Issue.PullRequest
 	// Check if it's PR's author
-	if issueComment.Issue.PullRequest != nil && sender.ID == issueComment.Issue.PullRequest.Author.ID {
+	if pr != nil && sender.ID == pr.Author.ID && !dispatcher.RequiresOkToTest(cfg) {
 		return nil
 	}
 
-	// Check if it's repo's maintainer
 	g, err := utils.GetGitCli(cfg, h.Client)
 	if err != nil {
 		return err
 	}
+
+	if pr != nil && sender.ID == pr.Author.ID {
+		labels, err := g.ListLabels(pr.ID)
+		if err != nil {
+			return err
+		}
+		ok, err := dispatcher.IsOkToTest(pr, labels, cfg, g)
+		if err != nil {
+			return err
+		} else if ok {
+			return nil
+		}
+		return &git.UnauthorizedError{User: sender.Name, Repo: cfg.Spec.Git.Repository}
+	}
+
+	// Check if it's repo's maintainer
 	ok, err := g.CanUserWriteToRepo(*sender)
 	if err != nil {
 		return err
@@ -187,7 +400,7 @@ func dependentJobs(wanted string, jobs cicdv1.Jobs) (map[string]struct{}, error)
 }
 
 // registerUnauthorizedComment registers comment that the user cannot trigger the test
-func (h *Handler) registerUnauthorizedComment(config *cicdv1.IntegrationConfig, issueID int, sha string, err error) error {
+func (h *Handler) registerUnauthorizedComment(config *cicdv1.IntegrationConfig, command string, issueID int, sha string, err error) error {
 	unAuthErr, ok := err.(*git.UnauthorizedError)
 	if !ok {
 		return err
@@ -210,7 +423,14 @@ func (h *Handler) registerUnauthorizedComment(config *cicdv1.IntegrationConfig,
 		issueType = git.IssueTypePullRequest
 	}
 
-	if err := gitCli.RegisterComment(issueType, issueID, sha, generateUnauthorizedComment(unAuthErr.User, unAuthErr.Repo)); err != nil {
+	comment := generateUnauthorizedComment(unAuthErr.User, unAuthErr.Repo)
+	if command == CommandTypeOkToTest {
+		comment = generateOkToTestUnauthorizedComment(unAuthErr.User, unAuthErr.Repo)
+	}
+	if chatops.GetPermission(config, command) != nil {
+		comment = chatops.GenerateUnauthorizedComment(command, unAuthErr.User)
+	}
+	if err := gitCli.RegisterComment(issueType, issueID, sha, comment); err != nil {
 		return err
 	}
 
@@ -224,3 +444,15 @@ func generateUnauthorizedComment(user, repo string) string {
 		"- (For GitHub) Have write permission on the repository\n"+
 		"- (For GitLab) Be Developer, Maintainer, or Owner\n", user, repo)
 }
+
+func generateNothingToRetestComment(sha string) string {
+	return fmt.Sprintf("[RETEST ALERT]\n\nThere is no failed job to retest for the commit `%s`.\n\n"+
+		"If you want to trigger all the jobs again, comment `/retest-all`.\n", sha)
+}
+
+func generateOkToTestUnauthorizedComment(user, repo string) string {
+	return fmt.Sprintf("User `%s` is not allowed to call `/ok-to-test` for the repository `%s`\n\n"+
+		"Only the trusted contributors can allow the tests of the pull request.\n"+
+		"- Have write permission on the repository\n"+
+		"- Or be listed in the IntegrationConfig's `spec.chatOps.trustedContributors`\n", user, repo)
+}
@@ -22,9 +22,12 @@ import (
 	"time"
 
 	"github.com/bmizerany/assert"
+	"github.com/stretchr/testify/require"
 	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
 	"github.com/tmax-cloud/cicd-operator/pkg/chatops"
+	"github.com/tmax-cloud/cicd-operator/pkg/dispatcher"
 	"github.com/tmax-cloud/cicd-operator/pkg/git"
+	gitfake "github.com/tmax-cloud/cicd-operator/pkg/git/fake"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -102,6 +105,234 @@ func TestChatOps_handleTrigger(t *testing.T) {
 	})
 }
 
+func TestChatOps_handleOkToTest(t *testing.T) {
+	s := runtime.NewScheme()
+	utilruntime.Must(cicdv1.AddToScheme(s))
+
+	const (
+		maintainerID   = 111
+		maintainerName = "maintainer"
+		strangerID     = 222
+		strangerName   = "stranger"
+	)
+
+	tc := map[string]struct {
+		command chatops.Command
+		sender  git.User
+		labels  []git.IssueLabel
+
+		expectedJob     bool
+		expectedComment string
+		expectedLabels  []git.IssueLabel
+	}{
+		"authorTestNotOk": {
+			command:         chatops.Command{Type: "test"},
+			sender:          git.User{ID: testUserID, Name: testUserName},
+			labels:          []git.IssueLabel{{Name: dispatcher.LabelNeedsOkToTest}},
+			expectedComment: generateUnauthorizedComment(testUserName, "tmax-cloud/cicd-operator"),
+			expectedLabels:  []git.IssueLabel{{Name: dispatcher.LabelNeedsOkToTest}},
+		},
+		"authorTestOk": {
+			command:        chatops.Command{Type: "test"},
+			sender:         git.User{ID: testUserID, Name: testUserName},
+			labels:         []git.IssueLabel{{Name: dispatcher.LabelOkToTest}},
+			expectedJob:    true,
+			expectedLabels: []git.IssueLabel{{Name: dispatcher.LabelOkToTest}},
+		},
+		"okToTest": {
+			command:        chatops.Command{Type: "ok-to-test"},
+			sender:         git.User{ID: maintainerID, Name: maintainerName},
+			labels:         []git.IssueLabel{{Name: dispatcher.LabelNeedsOkToTest}},
+			expectedJob:    true,
+			expectedLabels: []git.IssueLabel{{Name: dispatcher.LabelOkToTest}},
+		},
+		"okToTestUnauthorized": {
+			command:         chatops.Command{Type: "ok-to-test"},
+			sender:          git.User{ID: strangerID, Name: strangerName},
+			labels:          []git.IssueLabel{{Name: dispatcher.LabelNeedsOkToTest}},
+			expectedComment: generateOkToTestUnauthorizedComment(strangerName, "tmax-cloud/cicd-operator"),
+			expectedLabels:  []git.IssueLabel{{Name: dispatcher.LabelNeedsOkToTest}},
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			ic := buildTestJobs()
+			ic.Spec.Git = cicdv1.GitConfig{Type: cicdv1.GitTypeFake, Repository: "tmax-cloud/cicd-operator", Token: &cicdv1.GitToken{Value: "dummy"}}
+			ic.Spec.ChatOps = &cicdv1.ChatOpsConfig{RequireOkToTest: true}
+			fakeCli := fake.NewClientBuilder().WithScheme(s).WithObjects(ic).Build()
+			handler := &Handler{Client: fakeCli}
+
+			gitfake.Repos = map[string]*gitfake.Repo{
+				"tmax-cloud/cicd-operator": {
+					UserCanWrite: map[string]bool{testUserName: false, maintainerName: true, strangerName: false},
+					PullRequests: map[int]*git.PullRequest{0: {Labels: append([]git.IssueLabel{}, c.labels...)}},
+					Comments:     map[int][]git.IssueComment{},
+				},
+			}
+
+			wh := buildTestWebhookForTrigger()
+			wh.Sender = c.sender
+			require.NoError(t, handler.HandleChatOps(c.command, wh, ic))
+
+			var ijList cicdv1.IntegrationJobList
+			require.NoError(t, fakeCli.List(context.Background(), &ijList))
+			if c.expectedJob {
+				require.Len(t, ijList.Items, 1)
+			} else {
+				require.Len(t, ijList.Items, 0)
+			}
+
+			repo := gitfake.Repos["tmax-cloud/cicd-operator"]
+			if c.expectedComment != "" {
+				require.Len(t, repo.Comments[0], 1)
+				require.Equal(t, c.expectedComment, repo.Comments[0][0].Comment.Body)
+			} else {
+				require.Len(t, repo.Comments[0], 0)
+			}
+			require.Equal(t, c.expectedLabels, repo.PullRequests[0].Labels)
+		})
+	}
+}
+
+func TestChatOps_handleRetestFailed(t *testing.T) {
+	s := runtime.NewScheme()
+	utilruntime.Must(cicdv1.AddToScheme(s))
+
+	const (
+		headSha = "sfoj39jfsidjf93jfsiljf20"
+		oldSha  = "1234567890abcdef1234567890abcdef"
+	)
+
+	now := time.Now()
+	buildIJ := func(name, sha string, created time.Time, states map[string]cicdv1.CommitStatusState) *cicdv1.IntegrationJob {
+		ij := &cicdv1.IntegrationJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         testNamespace,
+				Labels:            map[string]string{cicdv1.JobLabelConfig: testConfigName},
+				CreationTimestamp: metav1.Time{Time: created},
+			},
+			Spec: cicdv1.IntegrationJobSpec{
+				ConfigRef: cicdv1.IntegrationJobConfigRef{Name: testConfigName, Type: cicdv1.JobTypePreSubmit},
+				Refs:      cicdv1.IntegrationJobRefs{Pulls: []cicdv1.IntegrationJobRefsPull{{ID: 0, Sha: sha}}},
+			},
+		}
+		for _, j := range []string{"a-1", "a-2", "a-3", "a-4", "b-1", "b-2"} {
+			st, ok := states[j]
+			if !ok {
+				continue
+			}
+			job := cicdv1.Job{}
+			job.Name = j
+			ij.Spec.Jobs = append(ij.Spec.Jobs, job)
+			ij.Status.Jobs = append(ij.Status.Jobs, cicdv1.JobStatus{Name: j, State: st})
+		}
+		return ij
+	}
+	allSuccess := func() map[string]cicdv1.CommitStatusState {
+		return map[string]cicdv1.CommitStatusState{
+			"a-1": cicdv1.CommitStatusStateSuccess, "a-2": cicdv1.CommitStatusStateSuccess, "a-3": cicdv1.CommitStatusStateSuccess,
+			"a-4": cicdv1.CommitStatusStateSuccess, "b-1": cicdv1.CommitStatusStateSuccess, "b-2": cicdv1.CommitStatusStateSuccess,
+		}
+	}
+	withState := func(states map[string]cicdv1.CommitStatusState, job string, state cicdv1.CommitStatusState) map[string]cicdv1.CommitStatusState {
+		states[job] = state
+		return states
+	}
+
+	tc := map[string]struct {
+		command chatops.Command
+		ijs     []*cicdv1.IntegrationJob
+
+		expectedJobs    []string
+		expectedComment string
+	}{
+		"noPreviousRun": {
+			command:      chatops.Command{Type: CommandTypeRetest},
+			ijs:          []*cicdv1.IntegrationJob{buildIJ("old", oldSha, now, withState(allSuccess(), "a-2", cicdv1.CommitStatusStateFailure))},
+			expectedJobs: []string{"a-1", "a-2", "a-3", "a-4", "b-1", "b-2"},
+		},
+		"failure": {
+			command:      chatops.Command{Type: CommandTypeRetest},
+			ijs:          []*cicdv1.IntegrationJob{buildIJ("first", headSha, now, withState(allSuccess(), "a-2", cicdv1.CommitStatusStateFailure))},
+			expectedJobs: []string{"a-1", "a-2", "a-3", "a-4"},
+		},
+		"failureWithDependent": {
+			command:      chatops.Command{Type: CommandTypeRetest},
+			ijs:          []*cicdv1.IntegrationJob{buildIJ("first", headSha, now, withState(allSuccess(), "b-1", cicdv1.CommitStatusStateFailure))},
+			expectedJobs: []string{"b-1", "b-2"},
+		},
+		"error": {
+			command:      chatops.Command{Type: CommandTypeRetest},
+			ijs:          []*cicdv1.IntegrationJob{buildIJ("first", headSha, now, withState(withState(allSuccess(), "a-4", cicdv1.CommitStatusStateError), "b-1", cicdv1.CommitStatusStateFailure))},
+			expectedJobs: []string{"a-1", "a-2", "a-3", "a-4", "b-1", "b-2"},
+		},
+		"alreadyRetested": {
+			command: chatops.Command{Type: CommandTypeRetest},
+			ijs: []*cicdv1.IntegrationJob{
+				buildIJ("first", headSha, now.Add(-time.Minute), withState(allSuccess(), "a-2", cicdv1.CommitStatusStateFailure)),
+				buildIJ("second", headSha, now, map[string]cicdv1.CommitStatusState{"a-1": cicdv1.CommitStatusStateSuccess, "a-2": cicdv1.CommitStatusStateSuccess}),
+			},
+			expectedComment: generateNothingToRetestComment(headSha),
+		},
+		"retestAll": {
+			command:      chatops.Command{Type: CommandTypeRetestAll},
+			ijs:          []*cicdv1.IntegrationJob{buildIJ("first", headSha, now, allSuccess())},
+			expectedJobs: []string{"a-1", "a-2", "a-3", "a-4", "b-1", "b-2"},
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			ic := buildTestJobs()
+			ic.Spec.Git = cicdv1.GitConfig{Type: cicdv1.GitTypeFake, Repository: "tmax-cloud/cicd-operator", Token: &cicdv1.GitToken{Value: "dummy"}}
+			objs := []client.Object{ic}
+			for _, ij := range c.ijs {
+				objs = append(objs, ij)
+			}
+			fakeCli := fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).Build()
+			handler := &Handler{Client: fakeCli}
+
+			gitfake.Repos = map[string]*gitfake.Repo{
+				"tmax-cloud/cicd-operator": {
+					PullRequests: map[int]*git.PullRequest{0: {}},
+					Comments:     map[int][]git.IssueComment{},
+				},
+			}
+
+			require.NoError(t, handler.HandleChatOps(c.command, buildTestWebhookForTrigger(), ic))
+
+			var ijList cicdv1.IntegrationJobList
+			require.NoError(t, fakeCli.List(context.Background(), &ijList))
+			var created *cicdv1.IntegrationJob
+			for i := range ijList.Items {
+				if ijList.Items[i].Spec.ConfigRef.Name == testConfigName && ijList.Items[i].Status.Jobs == nil {
+					created = &ijList.Items[i]
+				}
+			}
+			if c.expectedJobs == nil {
+				require.Nil(t, created)
+			} else {
+				require.NotNil(t, created)
+				var names []string
+				for _, j := range created.Spec.Jobs {
+					names = append(names, j.Name)
+				}
+				require.Equal(t, c.expectedJobs, names)
+			}
+
+			comments := gitfake.Repos["tmax-cloud/cicd-operator"].Comments[0]
+			if c.expectedComment == "" {
+				require.Empty(t, comments)
+			} else {
+				require.Len(t, comments, 1)
+				require.Equal(t, c.expectedComment, comments[0].Comment.Body)
+			}
+		})
+	}
+}
+
 type testTriggerVerifier func(ij *cicdv1.IntegrationJob)
 
 func testJobTrigger(t *testing.T, handler *Handler, fakeCli client.Client, wh *git.Webhook, ic *cicdv1.IntegrationConfig, command chatops.Command, verifyFunc testTriggerVerifier) {
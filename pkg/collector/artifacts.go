@@ -0,0 +1,89 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package collector
+
+import (
+	"context"
+	"fmt"
+
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/configs"
+	"github.com/tmax-cloud/cicd-operator/pkg/artifacts"
+)
+
+// artifactCleanupScript deletes the IntegrationJobs' artifact directories (i.e., <namespace>/<job>), which are older than TTL
+const artifactCleanupScript = `#!/bin/sh
+ARTIFACT_ROOT="%s"
+TTL_MINUTES=%d
+
+find "$ARTIFACT_ROOT" -mindepth 2 -maxdepth 2 -type d -mmin +"$TTL_MINUTES" -print -exec rm -rf {} +
+`
+
+// cleanupArtifacts runs artifact cleanup pods for the IntegrationConfigs storing artifacts in PVCs
+func (c *collector) cleanupArtifacts() {
+	if configs.ArtifactStorage != configs.ArtifactStoragePVC {
+		return
+	}
+
+	icList := &cicdv1.IntegrationConfigList{}
+	if err := c.client.List(context.Background(), icList); err != nil {
+		log.Error(err, "")
+		return
+	}
+
+	for i := range icList.Items {
+		ic := &icList.Items[i]
+		if !ic.Spec.Jobs.HasArtifacts() {
+			continue
+		}
+		log.Info(fmt.Sprintf("Cleaning up artifacts of IntegrationConfig %s/%s", ic.Namespace, ic.Name))
+		script := fmt.Sprintf(artifactCleanupScript, pvcMountPath, configs.IntegrationJobTTL*60)
+		pvcName := cicdv1.GetArtifactPVCName(ic.Name)
+		// Cache image is used, as the cleanup only needs a shell
+		if err := c.runPVCPod(ic, cicdv1.ArtifactCleanupLabelConfig, pvcName+"-cleanup-", configs.CacheImage, script, pvcName); err != nil {
+			log.Error(err, "")
+		}
+	}
+}
+
+// deleteArtifacts deletes the IntegrationJob's artifacts stored in the S3 storage.
+// All objects under the jobs' prefixes are deleted, as the artifact list in the status may be truncated
+func (c *collector) deleteArtifacts(ij *cicdv1.IntegrationJob) error {
+	if configs.ArtifactStorage != configs.ArtifactStorageS3 || !ij.Spec.Jobs.HasArtifacts() {
+		return nil
+	}
+
+	s3, err := artifacts.NewS3(c.client)
+	if err != nil {
+		return err
+	}
+	for _, j := range ij.Spec.Jobs {
+		if len(j.Artifacts) == 0 {
+			continue
+		}
+		keys, err := s3.List(artifacts.Prefix(ij, j.Name) + "/")
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if err := s3.Delete(key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package collector
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/configs"
+	"github.com/tmax-cloud/cicd-operator/internal/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_collector_cleanupArtifacts(t *testing.T) {
+	s := runtime.NewScheme()
+	utilruntime.Must(corev1.AddToScheme(s))
+	utilruntime.Must(cicdv1.AddToScheme(s))
+
+	configs.IntegrationJobTTL = 24
+
+	artifactIC := &cicdv1.IntegrationConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "artifact-ic", Namespace: "test-ns"},
+		Spec: cicdv1.IntegrationConfigSpec{
+			Jobs: cicdv1.IntegrationConfigJobs{
+				PostSubmit: cicdv1.Jobs{{Container: corev1.Container{Name: "test"}, Artifacts: []string{"bin/*"}}},
+			},
+		},
+	}
+	noArtifactIC := &cicdv1.IntegrationConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "no-artifact-ic", Namespace: "test-ns"},
+	}
+
+	tc := map[string]struct {
+		storage string
+
+		expectedPods int
+	}{
+		"pvc": {
+			storage:      configs.ArtifactStoragePVC,
+			expectedPods: 1,
+		},
+		"s3": {
+			storage:      configs.ArtifactStorageS3,
+			expectedPods: 0,
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			configs.ArtifactStorage = c.storage
+			defer func() { configs.ArtifactStorage = "" }()
+
+			gc := &collector{client: fake.NewClientBuilder().WithScheme(s).WithObjects(artifactIC.DeepCopy(), noArtifactIC.DeepCopy()).Build()}
+			gc.cleanupArtifacts()
+
+			podList := &corev1.PodList{}
+			require.NoError(t, gc.client.List(context.Background(), podList))
+			require.Len(t, podList.Items, c.expectedPods)
+			if c.expectedPods == 0 {
+				return
+			}
+
+			pod := podList.Items[0]
+			require.Equal(t, "artifact-ic", pod.Labels[cicdv1.ArtifactCleanupLabelConfig])
+			require.Equal(t, "artifact-ic-artifacts-cleanup-", pod.GenerateName)
+			require.Equal(t, "artifact-ic-artifacts", pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+			require.Contains(t, pod.Spec.Containers[0].Command[2], "TTL_MINUTES=1440")
+		})
+	}
+}
+
+func Test_collector_deleteArtifacts(t *testing.T) {
+	s := runtime.NewScheme()
+	utilruntime.Must(corev1.AddToScheme(s))
+	utilruntime.Must(cicdv1.AddToScheme(s))
+
+	var deleted []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			// Only the first artifact is listed in the status, as the list is truncated
+			require.Equal(t, "test-ns/test-ij/build/", r.URL.Query().Get("prefix"))
+			_, _ = w.Write([]byte(`<ListBucketResult><Contents><Key>test-ns/test-ij/build/bin/app</Key></Contents>` +
+				`<Contents><Key>test-ns/test-ij/build/report.xml</Key></Contents></ListBucketResult>`))
+			return
+		}
+		deleted = append(deleted, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	configs.ArtifactStorage = configs.ArtifactStorageS3
+	configs.ArtifactS3Endpoint = srv.URL
+	configs.ArtifactS3Bucket = "artifacts"
+	configs.ArtifactS3Secret = "artifact-secret"
+	defer func() { configs.ArtifactStorage = "" }()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "artifact-secret", Namespace: utils.Namespace()},
+		Data:       map[string][]byte{"accessKey": []byte("access"), "secretKey": []byte("secret")},
+	}
+	gc := &collector{client: fake.NewClientBuilder().WithScheme(s).WithObjects(secret).Build()}
+
+	ij := &cicdv1.IntegrationJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-ij", Namespace: "test-ns"},
+		Spec: cicdv1.IntegrationJobSpec{
+			Jobs: cicdv1.Jobs{
+				{Container: corev1.Container{Name: "build"}, Artifacts: []string{"bin/*", "report.xml"}},
+				{Container: corev1.Container{Name: "test"}},
+			},
+		},
+		Status: cicdv1.IntegrationJobStatus{
+			Jobs: []cicdv1.JobStatus{
+				{Name: "build", Artifacts: []string{"bin/app"}},
+				{Name: "test"},
+			},
+		},
+	}
+	require.NoError(t, gc.deleteArtifacts(ij))
+	require.Equal(t, []string{"/artifacts/test-ns/test-ij/build/bin/app", "/artifacts/test-ns/test-ij/build/report.xml"}, deleted)
+}
@@ -0,0 +1,71 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package collector
+
+import (
+	"context"
+	"fmt"
+
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/configs"
+)
+
+// cacheEvictionScript deletes caches which are not used for TTL, and then deletes the least recently used caches
+// until the total size gets smaller than the max size
+const cacheEvictionScript = `#!/bin/sh
+CACHE_ROOT="%s"
+TTL_MINUTES=%d
+MAX_SIZE_MB=%d
+
+find "$CACHE_ROOT" -type f -name '*.tar' -mmin +"$TTL_MINUTES" -print -exec rm -f {} \;
+
+while [ "$(du -sm "$CACHE_ROOT" | cut -f1)" -gt "$MAX_SIZE_MB" ]; do
+  OLDEST="$(find "$CACHE_ROOT" -type f -name '*.tar' -exec ls -1tr {} + | head -n 1)"
+  if [ -z "$OLDEST" ]; then
+    break
+  fi
+  echo "$OLDEST"
+  rm -f "$OLDEST"
+done
+`
+
+// +kubebuilder:rbac:groups=cicd.tmax.io,resources=integrationconfigs,verbs=get;list;watch
+
+// evictCaches runs cache eviction pods for the IntegrationConfigs using caches
+func (c *collector) evictCaches() {
+	icList := &cicdv1.IntegrationConfigList{}
+	if err := c.client.List(context.Background(), icList); err != nil {
+		log.Error(err, "")
+		return
+	}
+
+	for i := range icList.Items {
+		ic := &icList.Items[i]
+		if !ic.Spec.Jobs.HasCache() {
+			continue
+		}
+		if err := c.evictCache(ic); err != nil {
+			log.Error(err, "")
+		}
+	}
+}
+
+func (c *collector) evictCache(ic *cicdv1.IntegrationConfig) error {
+	log.Info(fmt.Sprintf("Evicting caches of IntegrationConfig %s/%s", ic.Namespace, ic.Name))
+	script := fmt.Sprintf(cacheEvictionScript, pvcMountPath, configs.CacheTTL*60, configs.CacheMaxSize)
+	return c.runPVCPod(ic, cicdv1.CacheEvictionLabelConfig, cicdv1.GetCachePVCName(ic.Name)+"-eviction-", configs.CacheImage, script, cicdv1.GetCachePVCName(ic.Name))
+}
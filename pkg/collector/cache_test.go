@@ -0,0 +1,106 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/configs"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_collector_evictCaches(t *testing.T) {
+	s := runtime.NewScheme()
+	utilruntime.Must(corev1.AddToScheme(s))
+	utilruntime.Must(cicdv1.AddToScheme(s))
+
+	configs.CacheTTL = 24
+	configs.CacheMaxSize = 1024
+
+	cacheIC := &cicdv1.IntegrationConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "cache-ic", Namespace: "test-ns"},
+		Spec: cicdv1.IntegrationConfigSpec{
+			Jobs: cicdv1.IntegrationConfigJobs{
+				PreSubmit: cicdv1.Jobs{{Container: corev1.Container{Name: "test"}, Cache: &cicdv1.JobCache{Key: "key", Paths: []string{".cache"}}}},
+			},
+		},
+	}
+	noCacheIC := &cicdv1.IntegrationConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "no-cache-ic", Namespace: "test-ns"},
+	}
+	evictionPod := func(name string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns", Labels: map[string]string{cicdv1.CacheEvictionLabelConfig: "cache-ic"}},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+	}
+
+	tc := map[string]struct {
+		pods []client.Object
+
+		expectedPods int
+		expectedNew  bool
+	}{
+		"create": {
+			expectedPods: 1,
+			expectedNew:  true,
+		},
+		"deleteFinished": {
+			pods:         []client.Object{evictionPod("succeeded", corev1.PodSucceeded), evictionPod("failed", corev1.PodFailed)},
+			expectedPods: 1,
+			expectedNew:  true,
+		},
+		"running": {
+			pods:         []client.Object{evictionPod("running", corev1.PodRunning), evictionPod("succeeded", corev1.PodSucceeded)},
+			expectedPods: 1,
+			expectedNew:  false,
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			objs := append([]client.Object{cacheIC.DeepCopy(), noCacheIC.DeepCopy()}, c.pods...)
+			gc := &collector{client: fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).Build()}
+
+			gc.evictCaches()
+
+			podList := &corev1.PodList{}
+			require.NoError(t, gc.client.List(context.Background(), podList))
+			require.Len(t, podList.Items, c.expectedPods)
+
+			pod := podList.Items[0]
+			require.Equal(t, "cache-ic", pod.Labels[cicdv1.CacheEvictionLabelConfig])
+			if c.expectedNew {
+				require.Equal(t, "cache-ic-cache-eviction-", pod.GenerateName)
+				require.Equal(t, "cache-ic-cache", pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+				require.Contains(t, pod.Spec.Containers[0].Command[2], "TTL_MINUTES=1440")
+				require.Contains(t, pod.Spec.Containers[0].Command[2], "MAX_SIZE_MB=1024")
+				require.Len(t, pod.OwnerReferences, 1)
+			} else {
+				require.Equal(t, "running", pod.Name)
+			}
+		})
+	}
+}
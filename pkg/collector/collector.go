@@ -114,6 +114,9 @@ func (c *collector) collect() {
 
 		// Collect if it's ttl is over
 		if j.Status.CompletionTime.Time.Add(time.Duration(configs.IntegrationJobTTL) * time.Hour).Before(now) {
+			if err := c.deleteArtifacts(&j); err != nil {
+				log.Error(err, "cannot delete artifacts")
+			}
 			log.Info(fmt.Sprintf("Deleting IntegrationJob %s/%s", j.Namespace, j.Name))
 			if err := c.client.Delete(context.Background(), &j); err != nil {
 				log.Error(err, "")
@@ -121,6 +124,12 @@ func (c *collector) collect() {
 			}
 		}
 	}
+
+	// Evict old caches
+	c.evictCaches()
+
+	// Clean up old artifacts
+	c.cleanupArtifacts()
 }
 
 func parseGcPeriod() string {
@@ -0,0 +1,88 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package collector
+
+import (
+	"context"
+
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const pvcMountPath = "/data"
+
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;create;delete
+
+// runPVCPod runs a pod executing the script on the IntegrationConfig's PVC, which is mounted at pvcMountPath
+// Finished pods are deleted, and a new pod is not created if the previous one is still running
+func (c *collector) runPVCPod(ic *cicdv1.IntegrationConfig, labelKey, generateName, image, script, pvcName string) error {
+	podList := &corev1.PodList{}
+	if err := c.client.List(context.Background(), podList, client.InNamespace(ic.Namespace), client.MatchingLabels{labelKey: ic.Name}); err != nil {
+		return err
+	}
+	running := false
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+			running = true
+			continue
+		}
+		if err := c.client.Delete(context.Background(), pod); err != nil {
+			return err
+		}
+	}
+	if running {
+		return nil
+	}
+
+	pod := generatePVCPod(ic, labelKey, generateName, image, script, pvcName)
+	if err := controllerutil.SetControllerReference(ic, pod, c.client.Scheme()); err != nil {
+		return err
+	}
+	return c.client.Create(context.Background(), pod)
+}
+
+func generatePVCPod(ic *cicdv1.IntegrationConfig, labelKey, generateName, image, script, pvcName string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: generateName,
+			Namespace:    ic.Namespace,
+			Labels:       map[string]string{labelKey: ic.Name},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{{
+				Name:    "main",
+				Image:   image,
+				Command: []string{"/bin/sh", "-c", script},
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "data",
+					MountPath: pvcMountPath,
+				}},
+			}},
+			Volumes: []corev1.Volume{{
+				Name: "data",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: pvcName},
+				},
+			}},
+		},
+	}
+}
@@ -51,6 +51,21 @@ func (d Dispatcher) Handle(webhook *git.Webhook, config *cicdv1.IntegrationConfi
 
 	if webhook.EventType == git.EventTypePullRequest && pr != nil {
 		if pr.Action == git.PullRequestActionOpen || pr.Action == git.PullRequestActionSynchronize || pr.Action == git.PullRequestActionReOpen {
+			// Check if the pull request is from a trusted contributor or is allowed via /ok-to-test
+			if RequiresOkToTest(config) {
+				gitCli, err := utils.GetGitCli(config, d.Client)
+				if err != nil {
+					return err
+				}
+				ok, err := IsOkToTest(pr, pr.Labels, config, gitCli)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return requestOkToTest(pr, gitCli)
+				}
+			}
+
 			prs := []git.PullRequest{*pr}
 			job = GeneratePreSubmit(prs, &webhook.Repo, &webhook.Sender, config)
 		}
@@ -120,9 +135,10 @@ func GeneratePreSubmit(prs []git.PullRequest, repo *git.Repository, sender *git.
 				},
 				Pulls: generatePulls(prs),
 			},
-			PodTemplate: config.Spec.PodTemplate,
-			Timeout:     config.GetDuration(),
-			ParamConfig: config.Spec.ParamConfig,
+			PodTemplate:     config.Spec.PodTemplate,
+			Timeout:         config.GetDuration(),
+			PipelineTimeout: config.Spec.IJManageSpec.PipelineTimeout,
+			ParamConfig:     config.Spec.ParamConfig,
 		},
 	}
 }
@@ -135,6 +151,10 @@ func GeneratePostSubmit(push *git.Push, repo *git.Repository, sender *git.User,
 		return nil
 	}
 	jobID := utils.RandomString(20)
+	var author *cicdv1.IntegrationJobRefsBaseAuthor
+	if push.Author.Name != "" || push.Author.Email != "" {
+		author = &cicdv1.IntegrationJobRefsBaseAuthor{Name: push.Author.Name, Email: push.Author.Email}
+	}
 	return &cicdv1.IntegrationJob{
 		ObjectMeta: generateMeta(config.Name, config.Namespace, push.Sha, jobID),
 		Spec: cicdv1.IntegrationJobSpec{
@@ -153,14 +173,16 @@ func GeneratePostSubmit(push *git.Push, repo *git.Repository, sender *git.User,
 					Email: sender.Email,
 				},
 				Base: cicdv1.IntegrationJobRefsBase{
-					Ref:  cicdv1.GitRef(push.Ref),
-					Link: repo.URL,
-					Sha:  push.Sha,
+					Ref:    cicdv1.GitRef(push.Ref),
+					Link:   repo.URL,
+					Sha:    push.Sha,
+					Author: author,
 				},
 			},
-			PodTemplate: config.Spec.PodTemplate,
-			Timeout:     config.GetDuration(),
-			ParamConfig: config.Spec.ParamConfig,
+			PodTemplate:     config.Spec.PodTemplate,
+			Timeout:         config.GetDuration(),
+			PipelineTimeout: config.Spec.IJManageSpec.PipelineTimeout,
+			ParamConfig:     config.Spec.ParamConfig,
 		},
 	}
 }
@@ -192,7 +214,8 @@ func generatePull(pr git.PullRequest) cicdv1.IntegrationJobRefsPull {
 		Sha:  pr.Head.Sha,
 		Link: pr.URL,
 		Author: cicdv1.IntegrationJobRefsPullAuthor{
-			Name: pr.Author.Name,
+			Name:  pr.Author.Name,
+			Email: pr.Author.Email,
 		},
 	}
 }
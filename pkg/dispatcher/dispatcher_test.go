@@ -17,12 +17,18 @@
 package dispatcher
 
 import (
+	"context"
 	"testing"
 
 	"github.com/bmizerany/assert"
 	"github.com/stretchr/testify/require"
 	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
 	"github.com/tmax-cloud/cicd-operator/pkg/git"
+	gitfake "github.com/tmax-cloud/cicd-operator/pkg/git/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestGeneratePreSubmit(t *testing.T) {
@@ -127,6 +133,85 @@ func TestGeneratePreSubmit(t *testing.T) {
 	}
 }
 
+func TestDispatcher_HandleOkToTest(t *testing.T) {
+	s := runtime.NewScheme()
+	utilruntime.Must(cicdv1.AddToScheme(s))
+
+	tc := map[string]struct {
+		requireOkToTest bool
+		author          string
+		labels          []git.IssueLabel
+
+		expectedJob    bool
+		expectedLabels []git.IssueLabel
+	}{
+		"notRequired":         {requireOkToTest: false, author: "stranger", expectedJob: true},
+		"trustedAuthor":       {requireOkToTest: true, author: "maintainer", expectedJob: true},
+		"listedAuthor":        {requireOkToTest: true, author: "contributor", expectedJob: true},
+		"orgMemberAuthor":     {requireOkToTest: true, author: "member", expectedJob: true},
+		"okToTestLabel":       {requireOkToTest: true, author: "stranger", labels: []git.IssueLabel{{Name: LabelOkToTest}}, expectedJob: true, expectedLabels: []git.IssueLabel{{Name: LabelOkToTest}}},
+		"untrusted":           {requireOkToTest: true, author: "stranger", expectedLabels: []git.IssueLabel{{Name: LabelNeedsOkToTest}}},
+		"untrustedLabeledAlr": {requireOkToTest: true, author: "stranger", labels: []git.IssueLabel{{Name: LabelNeedsOkToTest}}, expectedLabels: []git.IssueLabel{{Name: LabelNeedsOkToTest}}},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			ic := &cicdv1.IntegrationConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-ic", Namespace: "default"},
+				Spec: cicdv1.IntegrationConfigSpec{
+					Git: cicdv1.GitConfig{Type: cicdv1.GitTypeFake, Repository: "test/repo", Token: &cicdv1.GitToken{Value: "dummy"}},
+					Jobs: cicdv1.IntegrationConfigJobs{
+						PreSubmit: cicdv1.Jobs{{}},
+					},
+					ChatOps: &cicdv1.ChatOpsConfig{
+						RequireOkToTest:     c.requireOkToTest,
+						TrustedContributors: &cicdv1.TrustedContributors{Users: []string{"contributor"}},
+					},
+				},
+			}
+			ic.Spec.Jobs.PreSubmit[0].Name = "test"
+			fakeCli := fake.NewClientBuilder().WithScheme(s).WithObjects(ic).Build()
+			d := Dispatcher{Client: fakeCli}
+
+			gitfake.Repos = map[string]*gitfake.Repo{
+				"test/repo": {
+					UserCanWrite: map[string]bool{"maintainer": true, "contributor": false, "member": false, "stranger": false},
+					PullRequests: map[int]*git.PullRequest{1: {ID: 1, Labels: append([]git.IssueLabel{}, c.labels...)}},
+					Comments:     map[int][]git.IssueComment{},
+				},
+			}
+			gitfake.Groups = map[string][]string{"test": {"member"}}
+			defer func() { gitfake.Groups = nil }()
+
+			pr := &git.PullRequest{
+				ID:     1,
+				State:  git.PullRequestStateOpen,
+				Action: git.PullRequestActionOpen,
+				Author: git.User{Name: c.author},
+				Base:   git.Base{Ref: "master"},
+				Head:   git.Head{Ref: "feat", Sha: "sfoj39jfsidjf93jfsiljf20"},
+				Labels: c.labels,
+			}
+			require.NoError(t, d.Handle(&git.Webhook{EventType: git.EventTypePullRequest, PullRequest: pr}, ic))
+
+			var ijList cicdv1.IntegrationJobList
+			require.NoError(t, fakeCli.List(context.Background(), &ijList))
+			repo := gitfake.Repos["test/repo"]
+			if c.expectedJob {
+				require.Len(t, ijList.Items, 1)
+				require.Len(t, repo.Comments[1], 0)
+			} else {
+				require.Len(t, ijList.Items, 0)
+			}
+			if len(c.labels) == 0 && !c.expectedJob {
+				require.Len(t, repo.Comments[1], 1)
+				require.Equal(t, generateNeedsOkToTestComment(c.author), repo.Comments[1][0].Comment.Body)
+			}
+			require.ElementsMatch(t, c.expectedLabels, repo.PullRequests[1].Labels)
+		})
+	}
+}
+
 func TestGeneratePostSubmit(t *testing.T) {
 	tc := map[string]struct {
 		push   *git.Push
@@ -134,8 +219,9 @@ func TestGeneratePostSubmit(t *testing.T) {
 		sender *git.User
 		config *cicdv1.IntegrationConfig
 
-		expectedNil  bool
-		expectedName string
+		expectedNil    bool
+		expectedName   string
+		expectedAuthor *cicdv1.IntegrationJobRefsBaseAuthor
 	}{
 		"noPostSubmitJobs": {
 			push: &git.Push{
@@ -177,6 +263,31 @@ func TestGeneratePostSubmit(t *testing.T) {
 			expectedName: "0kokp",
 			expectedNil:  false,
 		},
+		"headCommitAuthor": {
+			push: &git.Push{
+				Sha:    "0kokpenadiugpowkqe0qlemaogor",
+				Ref:    "test",
+				Author: git.User{Name: "author", Email: "author@tmax.co.kr"},
+			},
+			repo:   &git.Repository{},
+			sender: &git.User{Name: "sender"},
+			config: &cicdv1.IntegrationConfig{
+				Spec: cicdv1.IntegrationConfigSpec{
+					Jobs: cicdv1.IntegrationConfigJobs{
+						PostSubmit: cicdv1.Jobs{
+							cicdv1.Job{
+								When: &cicdv1.JobWhen{
+									Branch: []string{"test"},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			expectedName:   "0kokp",
+			expectedAuthor: &cicdv1.IntegrationJobRefsBaseAuthor{Name: "author", Email: "author@tmax.co.kr"},
+		},
 	}
 
 	for name, c := range tc {
@@ -186,6 +297,7 @@ func TestGeneratePostSubmit(t *testing.T) {
 				require.Nil(t, ij)
 			} else {
 				require.Contains(t, ij.Name, c.expectedName)
+				require.Equal(t, c.expectedAuthor, ij.Spec.Refs.Base.Author)
 			}
 		})
 	}
@@ -0,0 +1,74 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"fmt"
+
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/pkg/chatops"
+	"github.com/tmax-cloud/cicd-operator/pkg/git"
+)
+
+// Labels for the ok-to-test gating
+const (
+	LabelOkToTest      = "ok-to-test"
+	LabelNeedsOkToTest = "needs-ok-to-test"
+)
+
+// RequiresOkToTest returns true if the preSubmit jobs of untrusted contributors need '/ok-to-test'
+func RequiresOkToTest(config *cicdv1.IntegrationConfig) bool {
+	return config.Spec.ChatOps != nil && config.Spec.ChatOps.RequireOkToTest
+}
+
+// IsOkToTest decides if the preSubmit jobs of the pull request can be run.
+// It's ok if the pull request is labeled with ok-to-test or its author is a trusted contributor
+func IsOkToTest(pr *git.PullRequest, labels []git.IssueLabel, config *cicdv1.IntegrationConfig, gitCli git.Client) (bool, error) {
+	if !RequiresOkToTest(config) {
+		return true, nil
+	}
+
+	for _, l := range labels {
+		if l.Name == LabelOkToTest {
+			return true, nil
+		}
+	}
+
+	return chatops.IsTrustedContributor(config, pr.Author, gitCli)
+}
+
+// requestOkToTest labels needs-ok-to-test on the pull request and asks the trusted contributors to check it
+func requestOkToTest(pr *git.PullRequest, gitCli git.Client) error {
+	for _, l := range pr.Labels {
+		if l.Name == LabelNeedsOkToTest {
+			return nil
+		}
+	}
+
+	if err := gitCli.SetLabel(git.IssueTypePullRequest, pr.ID, LabelNeedsOkToTest); err != nil {
+		return err
+	}
+	if err := gitCli.RegisterComment(git.IssueTypePullRequest, pr.ID, "", generateNeedsOkToTestComment(pr.Author.Name)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func generateNeedsOkToTestComment(user string) string {
+	return fmt.Sprintf("[OK-TO-TEST ALERT]\n\nUser `%s` is not a trusted contributor of this repository, so the jobs are not run automatically.\n\n"+
+		"Once a trusted contributor verifies that the pull request is safe to test, comment `/ok-to-test` to run the jobs.\n", user)
+}
@@ -0,0 +1,133 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// CodeOwnersPaths are the paths where the CODEOWNERS file is looked up, in order
+var CodeOwnersPaths = []string{".github/CODEOWNERS", ".gitlab/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// CodeOwners is a parsed CODEOWNERS file
+type CodeOwners struct {
+	rules []codeOwnersRule
+}
+
+type codeOwnersRule struct {
+	pattern *regexp.Regexp
+	owners  []string
+}
+
+// ParseCodeOwners parses a CODEOWNERS file of GitHub/GitLab/Gitea.
+// Each line is a path pattern (in gitignore style) followed by owners. GitLab's section headers are ignored.
+func ParseCodeOwners(content string) (*CodeOwners, error) {
+	codeOwners := &CodeOwners{}
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		pattern, err := compileCodeOwnersPattern(strings.TrimPrefix(fields[0], "\\"))
+		if err != nil {
+			return nil, err
+		}
+
+		rule := codeOwnersRule{pattern: pattern}
+		for _, owner := range fields[1:] {
+			if strings.HasPrefix(owner, "#") { // Inline comment
+				break
+			}
+			rule.owners = append(rule.owners, strings.TrimPrefix(owner, "@"))
+		}
+		codeOwners.rules = append(codeOwners.rules, rule)
+	}
+	return codeOwners, nil
+}
+
+// Owners returns the owners of a file. The last matching pattern takes the precedence.
+// Owners are user names, team names (org/team) or emails, without the leading '@'
+func (c *CodeOwners) Owners(path string) []string {
+	path = strings.TrimPrefix(path, "/")
+	for i := len(c.rules) - 1; i >= 0; i-- {
+		if c.rules[i].pattern.MatchString(path) {
+			return c.rules[i].owners
+		}
+	}
+	return nil
+}
+
+// compileCodeOwnersPattern converts a gitignore-style pattern into a regular expression
+func compileCodeOwnersPattern(pattern string) (*regexp.Regexp, error) {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+
+	// A pattern with a slash in the beginning or in the middle is relative to the root
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	segments := strings.Split(pattern, "/")
+	var expr strings.Builder
+	for i, seg := range segments {
+		last := i == len(segments)-1
+		if seg == "**" {
+			if last {
+				expr.WriteString(".*")
+			} else {
+				expr.WriteString("(.*/)?")
+			}
+			continue
+		}
+		for _, r := range seg {
+			switch r {
+			case '*':
+				expr.WriteString("[^/]*")
+			case '?':
+				expr.WriteString("[^/]")
+			default:
+				expr.WriteString(regexp.QuoteMeta(string(r)))
+			}
+		}
+		if !last {
+			expr.WriteString("/")
+		}
+	}
+
+	prefix := "^"
+	if !anchored {
+		prefix = "^(.*/)?"
+	}
+
+	// A pattern matching a directory matches all the files under it.
+	// But a wildcard in the last segment only matches the files right under the parent directory
+	suffix := "(/.*)?$"
+	if dirOnly {
+		suffix = "/.*$"
+	} else if strings.Contains(segments[len(segments)-1], "*") {
+		suffix = "$"
+	}
+
+	re, err := regexp.Compile(prefix + expr.String() + suffix)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse CODEOWNERS pattern %s: %s", pattern, err.Error())
+	}
+	return re, nil
+}
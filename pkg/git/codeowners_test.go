@@ -0,0 +1,67 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testCodeOwners = `# Default owners
+*       @global-owner1 @global-owner2
+
+*.js    @js-owner # JavaScript files
+/build/logs/ @doctocat
+docs/*  docs@example.com
+apps/   @octocat
+**/logs @logs-owner
+/scripts/**/*.sh @tmax-cloud/scripts
+
+[Documentation]
+/empty/
+`
+
+func TestParseCodeOwners(t *testing.T) {
+	codeOwners, err := ParseCodeOwners(testCodeOwners)
+	require.NoError(t, err)
+
+	tc := map[string]struct {
+		path           string
+		expectedOwners []string
+	}{
+		"default":             {path: "main.go", expectedOwners: []string{"global-owner1", "global-owner2"}},
+		"defaultNested":       {path: "pkg/git/git.go", expectedOwners: []string{"global-owner1", "global-owner2"}},
+		"extension":           {path: "web/src/index.js", expectedOwners: []string{"js-owner"}},
+		"anchoredDir":         {path: "build/logs/a/b.log", expectedOwners: []string{"logs-owner"}},
+		"anchoredDirNotMatch": {path: "src/build/logs.txt", expectedOwners: []string{"global-owner1", "global-owner2"}},
+		"wildcardFile":        {path: "docs/getting-started.md", expectedOwners: []string{"docs@example.com"}},
+		"wildcardNested":      {path: "docs/build-app/troubleshooting.md", expectedOwners: []string{"global-owner1", "global-owner2"}},
+		"dir":                 {path: "apps/main.go", expectedOwners: []string{"octocat"}},
+		"dirNested":           {path: "src/apps/main.go", expectedOwners: []string{"octocat"}},
+		"doubleAsterisk":      {path: "deeply/nested/logs/a.txt", expectedOwners: []string{"logs-owner"}},
+		"doubleAsteriskMid":   {path: "scripts/a/b/run.sh", expectedOwners: []string{"tmax-cloud/scripts"}},
+		"noOwners":            {path: "empty/a.txt", expectedOwners: nil},
+		"leadingSlash":        {path: "/apps/main.go", expectedOwners: []string{"octocat"}},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, c.expectedOwners, codeOwners.Owners(c.path))
+		})
+	}
+}
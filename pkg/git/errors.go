@@ -16,7 +16,10 @@
 
 package git
 
-import "fmt"
+import (
+	"fmt"
+	"net/http"
+)
 
 // UnauthorizedError is an error struct for git clients
 type UnauthorizedError struct {
@@ -28,3 +31,22 @@ type UnauthorizedError struct {
 func (e *UnauthorizedError) Error() string {
 	return fmt.Sprintf("%s is not authorized for %s", e.User, e.Repo)
 }
+
+// HTTPError is an error struct for the failed git api calls
+type HTTPError struct {
+	Method  string
+	URI     string
+	Code    int
+	Message string
+}
+
+// Error returns error string
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("error requesting api [%s] %s, code %d, msg %s", e.Method, e.URI, e.Code, e.Message)
+}
+
+// IsNotFound returns true if the error is caused by a 404 response of the git api
+func IsNotFound(err error) bool {
+	httpErr, ok := err.(*HTTPError)
+	return ok && httpErr.Code == http.StatusNotFound
+}
@@ -31,19 +31,31 @@ var (
 	Users    map[string]*git.User
 	Repos    map[string]*Repo
 	Branches map[string]*git.Branch
+
+	// Groups is a map of group -> member names
+	Groups map[string][]string
 )
 
 // Repo is a repository storage
 type Repo struct {
 	Webhooks     map[int]*git.WebhookEntry
 	UserCanWrite map[string]bool
+	UserIsAdmin  map[string]bool
 
 	PullRequests       map[int]*git.PullRequest
 	PullRequestDiffs   map[int]*git.Diff
 	PullRequestCommits map[int][]git.Commit
+	PullRequestReviews map[int][]git.PullRequestReview
 	Commits            map[string][]git.Commit
 	CommitStatuses     map[string][]git.CommitStatus
 	Comments           map[int][]git.IssueComment
+
+	// Assignees and ReviewRequests are maps of issue id -> user names
+	Assignees      map[int][]string
+	ReviewRequests map[int][]string
+
+	// Files is a map of ref -> path -> content
+	Files map[string]map[string]string
 }
 
 // Client is a gitlab client struct
@@ -189,6 +201,29 @@ func (c *Client) CanUserWriteToRepo(user git.User) (bool, error) {
 	return privilege, nil
 }
 
+// IsUserRepoAdmin decides if the user has admin permission on the repo
+func (c *Client) IsUserRepoAdmin(user git.User) (bool, error) {
+	if Repos == nil {
+		return false, fmt.Errorf("repos not initialized")
+	}
+	repo, repoExist := Repos[c.IntegrationConfig.Spec.Git.Repository]
+	if !repoExist {
+		return false, fmt.Errorf("404 no such repository")
+	}
+
+	return repo.UserIsAdmin[user.Name], nil
+}
+
+// IsUserInGroup decides if the user is a member of the group
+func (c *Client) IsUserInGroup(user git.User, group string) (bool, error) {
+	for _, m := range Groups[group] {
+		if m == user.Name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // RegisterComment registers comment to an issue
 func (c *Client) RegisterComment(_ git.IssueType, issueNo int, sha, body string) error {
 	if Repos == nil {
@@ -205,7 +240,7 @@ func (c *Client) RegisterComment(_ git.IssueType, issueNo int, sha, body string)
 
 	t := metav1.Now()
 	repo.Comments[issueNo] = append(repo.Comments[issueNo], git.IssueComment{
-		Comment: git.Comment{Body: body, CreatedAt: &t},
+		Comment: git.Comment{ID: len(repo.Comments[issueNo]) + 1, Body: body, CreatedAt: &t},
 		Issue: git.Issue{
 			PullRequest: &git.PullRequest{
 				ID: issueNo,
@@ -215,6 +250,25 @@ func (c *Client) RegisterComment(_ git.IssueType, issueNo int, sha, body string)
 	return nil
 }
 
+// EditComment edits the comment's body
+func (c *Client) EditComment(_ git.IssueType, issueNo, commentID int, body string) error {
+	if Repos == nil {
+		return fmt.Errorf("repos not initialized")
+	}
+	repo, repoExist := Repos[c.IntegrationConfig.Spec.Git.Repository]
+	if !repoExist {
+		return fmt.Errorf("404 no such repository")
+	}
+
+	for i := range repo.Comments[issueNo] {
+		if repo.Comments[issueNo][i].Comment.ID == commentID {
+			repo.Comments[issueNo][i].Comment.Body = body
+			return nil
+		}
+	}
+	return fmt.Errorf("404 no such comment")
+}
+
 // ListComments lists comments of the issue id
 func (c *Client) ListComments(issueNo int) ([]git.IssueComment, error) {
 	if Repos == nil {
@@ -269,7 +323,7 @@ func (c *Client) GetPullRequest(id int) (*git.PullRequest, error) {
 }
 
 // MergePullRequest merges a pull request
-func (c *Client) MergePullRequest(id int, _ string, _ git.MergeMethod, message string) error {
+func (c *Client) MergePullRequest(id int, _ string, method git.MergeMethod, message string) error {
 	if Repos == nil {
 		return fmt.Errorf("repos not initialized")
 	}
@@ -283,6 +337,13 @@ func (c *Client) MergePullRequest(id int, _ string, _ git.MergeMethod, message s
 		return fmt.Errorf("404 no such pr")
 	}
 
+	// Fast-forward merge is only possible if the pr is based on the latest base commit
+	if method == git.MergeMethodFastForward {
+		if base, ok := Branches[pr.Base.Ref]; ok && base.CommitID != pr.Base.Sha {
+			return fmt.Errorf("405 pr is not fast-forwardable")
+		}
+	}
+
 	repo.PullRequests[id].Mergeable = false
 	repo.PullRequests[id].State = git.PullRequestStateClosed
 	commit := git.Commit{
@@ -299,6 +360,50 @@ func (c *Client) MergePullRequest(id int, _ string, _ git.MergeMethod, message s
 	return nil
 }
 
+// UpdatePullRequestBranch rebases the pr onto the latest base commit
+func (c *Client) UpdatePullRequestBranch(id int, sha string) error {
+	if Repos == nil {
+		return fmt.Errorf("repos not initialized")
+	}
+	repo, repoExist := Repos[c.IntegrationConfig.Spec.Git.Repository]
+	if !repoExist {
+		return fmt.Errorf("404 no such repository")
+	}
+
+	pr, exist := repo.PullRequests[id]
+	if !exist {
+		return fmt.Errorf("404 no such pr")
+	}
+	if sha != "" && pr.Head.Sha != sha {
+		return fmt.Errorf("422 head sha mismatch")
+	}
+
+	base, exist := Branches[pr.Base.Ref]
+	if !exist {
+		return fmt.Errorf("404 no such branch")
+	}
+	pr.Base.Sha = base.CommitID
+	return nil
+}
+
+// UpdatePullRequestBase changes the base branch of the pr
+func (c *Client) UpdatePullRequestBase(id int, base string) error {
+	if Repos == nil {
+		return fmt.Errorf("repos not initialized")
+	}
+	repo, repoExist := Repos[c.IntegrationConfig.Spec.Git.Repository]
+	if !repoExist {
+		return fmt.Errorf("404 no such repository")
+	}
+
+	pr, exist := repo.PullRequests[id]
+	if !exist {
+		return fmt.Errorf("404 no such pr")
+	}
+	pr.Base.Ref = base
+	return nil
+}
+
 // GetPullRequestDiff gets diff of the pull request
 func (c *Client) GetPullRequestDiff(id int) (*git.Diff, error) {
 	if Repos == nil {
@@ -343,6 +448,19 @@ func (c *Client) ListPullRequestCommits(id int) ([]git.Commit, error) {
 	return commits, nil
 }
 
+// ListPullRequestReviews lists reviews of a pull request
+func (c *Client) ListPullRequestReviews(id int) ([]git.PullRequestReview, error) {
+	if Repos == nil {
+		return nil, fmt.Errorf("repos not initialized")
+	}
+	repo, repoExist := Repos[c.IntegrationConfig.Spec.Git.Repository]
+	if !repoExist {
+		return nil, fmt.Errorf("404 no such repository")
+	}
+
+	return repo.PullRequestReviews[id], nil
+}
+
 // ListLabels lists labels of pr id
 func (c *Client) ListLabels(id int) ([]git.IssueLabel, error) {
 	if Repos == nil {
@@ -385,6 +503,90 @@ func (c *Client) DeleteLabel(_ git.IssueType, id int, label string) error {
 	return DeleteLabel(c.IntegrationConfig.Spec.Git.Repository, id, label)
 }
 
+// AddAssignees adds assignees to the issue id
+func (c *Client) AddAssignees(id int, users []string) error {
+	repo, err := c.getPullRequestRepo(id)
+	if err != nil {
+		return err
+	}
+	if repo.Assignees == nil {
+		repo.Assignees = map[int][]string{}
+	}
+	repo.Assignees[id] = addUsers(repo.Assignees[id], users)
+	return nil
+}
+
+// RemoveAssignees removes assignees from the issue id
+func (c *Client) RemoveAssignees(id int, users []string) error {
+	repo, err := c.getPullRequestRepo(id)
+	if err != nil {
+		return err
+	}
+	repo.Assignees[id] = removeUsers(repo.Assignees[id], users)
+	return nil
+}
+
+// RequestReviews requests reviews of the pull request to the users
+func (c *Client) RequestReviews(id int, users []string) error {
+	repo, err := c.getPullRequestRepo(id)
+	if err != nil {
+		return err
+	}
+	if repo.ReviewRequests == nil {
+		repo.ReviewRequests = map[int][]string{}
+	}
+	repo.ReviewRequests[id] = addUsers(repo.ReviewRequests[id], users)
+	return nil
+}
+
+// CancelReviewRequests cancels the review requests of the pull request to the users
+func (c *Client) CancelReviewRequests(id int, users []string) error {
+	repo, err := c.getPullRequestRepo(id)
+	if err != nil {
+		return err
+	}
+	repo.ReviewRequests[id] = removeUsers(repo.ReviewRequests[id], users)
+	return nil
+}
+
+func (c *Client) getPullRequestRepo(id int) (*Repo, error) {
+	if Repos == nil {
+		return nil, fmt.Errorf("repos not initialized")
+	}
+	repo, repoExist := Repos[c.IntegrationConfig.Spec.Git.Repository]
+	if !repoExist {
+		return nil, fmt.Errorf("404 no such repository")
+	}
+
+	if repo.PullRequests == nil {
+		return nil, fmt.Errorf("pull requests not initialized")
+	}
+
+	if _, exist := repo.PullRequests[id]; !exist {
+		return nil, fmt.Errorf("404 no such PR")
+	}
+	return repo, nil
+}
+
+func addUsers(current, users []string) []string {
+	for _, u := range users {
+		if !git.ContainsUser(current, u) {
+			current = append(current, u)
+		}
+	}
+	return current
+}
+
+func removeUsers(current, users []string) []string {
+	var result []string
+	for _, u := range current {
+		if !git.ContainsUser(users, u) {
+			result = append(result, u)
+		}
+	}
+	return result
+}
+
 // GetBranch returns branch info
 func (c *Client) GetBranch(branch string) (*git.Branch, error) {
 	if Branches == nil {
@@ -397,6 +599,24 @@ func (c *Client) GetBranch(branch string) (*git.Branch, error) {
 	return b, nil
 }
 
+// GetFileContent gets the content of a file in the ref
+func (c *Client) GetFileContent(ref, path string) ([]byte, error) {
+	if Repos == nil {
+		return nil, fmt.Errorf("repos not initialized")
+	}
+	repo, repoExist := Repos[c.IntegrationConfig.Spec.Git.Repository]
+	if !repoExist {
+		return nil, fmt.Errorf("404 no such repository")
+	}
+
+	content, exist := repo.Files[ref][path]
+	if !exist {
+		return nil, &git.HTTPError{Method: http.MethodGet, URI: path, Code: http.StatusNotFound, Message: "no such file"}
+	}
+
+	return []byte(content), nil
+}
+
 // DeleteLabel deletes label from a pull request
 func DeleteLabel(repoName string, id int, label string) error {
 	if Repos == nil {
@@ -40,19 +40,25 @@ type Client interface {
 
 	GetUserInfo(user string) (*User, error)
 	CanUserWriteToRepo(user User) (bool, error)
+	IsUserRepoAdmin(user User) (bool, error)
+	IsUserInGroup(user User, group string) (bool, error)
 
 	// Comments
 
 	RegisterComment(issueType IssueType, issueNo int, sha, body string) error
 	ListComments(issueNo int) ([]IssueComment, error)
+	EditComment(issueType IssueType, issueNo, commentID int, body string) error
 
 	// Pull Request
 
 	ListPullRequests(onlyOpen bool) ([]PullRequest, error)
 	GetPullRequest(id int) (*PullRequest, error)
 	MergePullRequest(id int, sha string, method MergeMethod, message string) error
+	UpdatePullRequestBranch(id int, sha string) error
+	UpdatePullRequestBase(id int, base string) error
 	GetPullRequestDiff(id int) (*Diff, error)
 	ListPullRequestCommits(id int) ([]Commit, error)
+	ListPullRequestReviews(id int) ([]PullRequestReview, error)
 
 	// Issue Labels
 
@@ -60,9 +66,20 @@ type Client interface {
 	ListLabels(id int) ([]IssueLabel, error)
 	DeleteLabel(issueType IssueType, id int, label string) error
 
+	// Assignees & Reviewers
+
+	AddAssignees(id int, users []string) error
+	RemoveAssignees(id int, users []string) error
+	RequestReviews(id int, users []string) error
+	CancelReviewRequests(id int, users []string) error
+
 	// Branch
 
 	GetBranch(branch string) (*Branch, error)
+
+	// Repository Contents
+
+	GetFileContent(ref, path string) ([]byte, error)
 }
 
 // IssueType is a type of the issue
@@ -98,6 +115,8 @@ type MergeMethod string
 
 // MergeMethod types
 const (
-	MergeMethodSquash = MergeMethod("squash")
-	MergeMethodMerge  = MergeMethod("merge")
+	MergeMethodSquash      = MergeMethod("squash")
+	MergeMethodMerge       = MergeMethod("merge")
+	MergeMethodRebase      = MergeMethod("rebase")
+	MergeMethodFastForward = MergeMethod("ff-only")
 )
@@ -38,6 +38,7 @@ const (
 	EventTypePullRequestReview        = EventType("pull_request_review")
 	EventTypePullRequestReviewComment = EventType("pull_request_review_comment")
 	EventTypeCommitComment            = EventType("commit_comment")
+	EventTypeStatus                   = EventType("status")
 )
 
 // Pull Request states
@@ -60,6 +61,8 @@ const (
 const (
 	PullRequestReviewStateApproved   = PullRequestReviewState("approved")
 	PullRequestReviewStateUnapproved = PullRequestReviewState("changes_requested")
+	PullRequestReviewStateCommented  = PullRequestReviewState("commented")
+	PullRequestReviewStateDismissed  = PullRequestReviewState("dismissed")
 )
 
 // Webhook is a common structure for git webhooks
@@ -72,6 +75,7 @@ type Webhook struct {
 	Push         *Push
 	PullRequest  *PullRequest
 	IssueComment *IssueComment
+	Status       *Status
 	RequestBody  string
 }
 
@@ -79,6 +83,15 @@ type Webhook struct {
 type Push struct {
 	Ref string
 	Sha string
+
+	// Author is an author of the head commit
+	Author User
+}
+
+// Status is a common structure for commit status events
+type Status struct {
+	Sha          string
+	CommitStatus CommitStatus
 }
 
 // PullRequest is a common structure for pull request events
@@ -98,11 +111,32 @@ type PullRequest struct {
 	LabelChanged []IssueLabel
 }
 
+// PullRequestReview is a review of a pull request
+type PullRequestReview struct {
+	ID          int
+	Author      User
+	State       PullRequestReviewState
+	Sha         string
+	SubmittedAt *metav1.Time
+}
+
 // Diff is a diff between commits or of a pull-request
 type Diff struct {
 	Changes []Change
 }
 
+// Files returns the names of the changed files, including the old names of the renamed files
+func (d *Diff) Files() []string {
+	var files []string
+	for _, c := range d.Changes {
+		files = append(files, c.Filename)
+		if c.OldFilename != "" && c.OldFilename != c.Filename {
+			files = append(files, c.OldFilename)
+		}
+	}
+	return files
+}
+
 // Commit is a commit structure
 type Commit struct {
 	SHA       string
@@ -135,6 +169,8 @@ type IssueLabel struct {
 
 // Comment is a comment body
 type Comment struct {
+	// ID is an id of the comment. It is only set for the comments which can be edited by EditComment
+	ID   int
 	Body string
 
 	CreatedAt *metav1.Time
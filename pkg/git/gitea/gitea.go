@@ -24,6 +24,7 @@ import (
 	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
 	"github.com/tmax-cloud/cicd-operator/pkg/git"
 	"net/http"
+	"net/url"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"strconv"
 	"strings"
@@ -215,20 +216,77 @@ func (c *Client) GetUserInfo(userName string) (*git.User, error) {
 
 // CanUserWriteToRepo decides if the user has write permission on the repo
 func (c *Client) CanUserWriteToRepo(user git.User) (bool, error) {
+	permission, err := c.getUserPermission(user)
+	if err != nil {
+		return false, err
+	}
+	return permission == "admin" || permission == "write", nil
+}
+
+// IsUserRepoAdmin decides if the user has admin permission on the repo
+func (c *Client) IsUserRepoAdmin(user git.User) (bool, error) {
+	permission, err := c.getUserPermission(user)
+	if err != nil {
+		return false, err
+	}
+	return permission == "admin", nil
+}
+
+func (c *Client) getUserPermission(user git.User) (string, error) {
 	// userName is string!
 	apiURL := fmt.Sprintf("%s/api/v1/repos/%s/collaborators/%s/permission", c.IntegrationConfig.Spec.Git.GetAPIUrl(), c.IntegrationConfig.Spec.Git.Repository, user.Name)
 
 	result, _, err := c.requestHTTP(http.MethodGet, apiURL, nil)
 	if err != nil {
-		return false, err
+		return "", err
 	}
 
 	var permission UserPermission
 	if err := json.Unmarshal(result, &permission); err != nil {
-		return false, err
+		return "", err
 	}
 
-	return permission.Permission == "admin" || permission.Permission == "write", nil
+	return permission.Permission, nil
+}
+
+// IsUserInGroup decides if the user is a member of the organization (org) or the team (org/team)
+func (c *Client) IsUserInGroup(user git.User, group string) (bool, error) {
+	tokens := strings.SplitN(group, "/", 2)
+	apiURL := fmt.Sprintf("%s/api/v1/orgs/%s/members/%s", c.IntegrationConfig.Spec.Git.GetAPIUrl(), group, user.Name)
+	if len(tokens) == 2 {
+		// Find the team's id
+		searchURL := fmt.Sprintf("%s/api/v1/orgs/%s/teams/search?q=%s", c.IntegrationConfig.Spec.Git.GetAPIUrl(), tokens[0], url.QueryEscape(tokens[1]))
+		result, _, err := c.requestHTTP(http.MethodGet, searchURL, nil)
+		if err != nil {
+			if git.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		var teams TeamSearchResponse
+		if err := json.Unmarshal(result, &teams); err != nil {
+			return false, err
+		}
+		teamID := -1
+		for _, t := range teams.Data {
+			if t.Name == tokens[1] {
+				teamID = t.ID
+				break
+			}
+		}
+		if teamID == -1 {
+			return false, nil
+		}
+		apiURL = fmt.Sprintf("%s/api/v1/teams/%d/members/%s", c.IntegrationConfig.Spec.Git.GetAPIUrl(), teamID, user.Name)
+	}
+
+	if _, _, err := c.requestHTTP(http.MethodGet, apiURL, nil); err != nil {
+		if git.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
 }
 
 // RegisterComment registers comment to an issue
@@ -247,6 +305,20 @@ func (c *Client) RegisterComment(issueType git.IssueType, issueNo int, sha, body
 	return nil
 }
 
+// EditComment edits the comment's body
+func (c *Client) EditComment(issueType git.IssueType, _, commentID int, body string) error {
+	if issueType != git.IssueTypePullRequest {
+		return fmt.Errorf("issue type %s is not supported", issueType)
+	}
+	apiUrl := fmt.Sprintf("%s//api/v1/repos/%s/issues/comments/%d", c.IntegrationConfig.Spec.Git.GetAPIUrl(), c.IntegrationConfig.Spec.Git.Repository, commentID)
+
+	commentBody := &CommentBody{Body: body}
+	if _, _, err := c.requestHTTP(http.MethodPatch, apiUrl, commentBody); err != nil {
+		return err
+	}
+	return nil
+}
+
 // ListComments lists comments of the issue id
 func (c *Client) ListComments(issueNo int) ([]git.IssueComment, error) {
 	var comments []git.IssueComment
@@ -266,9 +338,11 @@ func (c *Client) ListComments(issueNo int) ([]git.IssueComment, error) {
 	for _, issueComment := range issueComments {
 		comments = append(comments, git.IssueComment{
 			Comment: git.Comment{
+				ID:        issueComment.ID,
 				Body:      issueComment.Body,
 				CreatedAt: issueComment.CreatedAt,
 			},
+			Author: git.User{ID: issueComment.User.ID, Name: issueComment.User.Name},
 		})
 	}
 
@@ -286,6 +360,7 @@ func (c *Client) ListComments(issueNo int) ([]git.IssueComment, error) {
 				Body:      prComment.Body,
 				CreatedAt: prComment.CreatedAt,
 			},
+			Author: git.User{ID: prComment.User.ID, Name: prComment.User.Name},
 		})
 	}
 
@@ -303,7 +378,8 @@ func (c *Client) ListComments(issueNo int) ([]git.IssueComment, error) {
 				Body:      prReview.Body,
 				CreatedAt: prReview.SubmittedAt,
 			},
-			ReviewState: prReview.State,
+			Author:      git.User{ID: prReview.User.ID, Name: prReview.User.Name},
+			ReviewState: convertReviewState(string(prReview.State)),
 		})
 	}
 	return comments, nil
@@ -363,7 +439,7 @@ func (c *Client) MergePullRequest(id int, sha string, method git.MergeMethod, me
 
 	body := &MergeRequest{
 		CommitTitle: tokens[0],
-		MergeMethod: string(method),
+		MergeMethod: convertMergeMethod(method),
 		Sha:         sha,
 	}
 
@@ -379,6 +455,30 @@ func (c *Client) MergePullRequest(id int, sha string, method git.MergeMethod, me
 	return nil
 }
 
+// UpdatePullRequestBranch rebases the head branch of the pull request onto the base branch
+func (c *Client) UpdatePullRequestBranch(id int, _ string) error {
+	apiURL := fmt.Sprintf("%s//api/v1/repos/%s/pulls/%d/update?style=rebase", c.IntegrationConfig.Spec.Git.GetAPIUrl(), c.IntegrationConfig.Spec.Git.Repository, id)
+
+	_, _, err := c.requestHTTP(http.MethodPost, apiURL, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdatePullRequestBase changes the base branch of the pull request
+func (c *Client) UpdatePullRequestBase(id int, base string) error {
+	apiURL := fmt.Sprintf("%s//api/v1/repos/%s/pulls/%d", c.IntegrationConfig.Spec.Git.GetAPIUrl(), c.IntegrationConfig.Spec.Git.Repository, id)
+
+	_, _, err := c.requestHTTP(http.MethodPatch, apiURL, &UpdatePullRequest{Base: base})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // GetPullRequestDiff gets diff of the pull request
 func (c *Client) GetPullRequestDiff(id int) (*git.Diff, error) {
 	apiURL := fmt.Sprintf("%s//api/v1/repos/%s/pulls/%d/files", c.IntegrationConfig.Spec.Git.GetAPIUrl(), c.IntegrationConfig.Spec.Git.Repository, id)
@@ -483,6 +583,86 @@ func (c *Client) DeleteLabel(_ git.IssueType, id int, label string) error {
 	return nil
 }
 
+// AddAssignees adds assignees to the issue id
+func (c *Client) AddAssignees(id int, users []string) error {
+	assignees, err := c.listAssignees(id)
+	if err != nil {
+		return err
+	}
+
+	for _, u := range users {
+		if !git.ContainsUser(assignees, u) {
+			assignees = append(assignees, u)
+		}
+	}
+	return c.updateAssignees(id, assignees)
+}
+
+// RemoveAssignees removes assignees from the issue id
+func (c *Client) RemoveAssignees(id int, users []string) error {
+	assignees, err := c.listAssignees(id)
+	if err != nil {
+		return err
+	}
+
+	remaining := []string{}
+	for _, a := range assignees {
+		if !git.ContainsUser(users, a) {
+			remaining = append(remaining, a)
+		}
+	}
+	return c.updateAssignees(id, remaining)
+}
+
+func (c *Client) listAssignees(id int) ([]string, error) {
+	apiURL := fmt.Sprintf("%s/api/v1/repos/%s/issues/%d", c.IntegrationConfig.Spec.Git.GetAPIUrl(), c.IntegrationConfig.Spec.Git.Repository, id)
+
+	raw, _, err := c.requestHTTP(http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &IssueResponse{}
+	if err := json.Unmarshal(raw, resp); err != nil {
+		return nil, err
+	}
+
+	var assignees []string
+	for _, a := range resp.Assignees {
+		assignees = append(assignees, a.Name)
+	}
+	return assignees, nil
+}
+
+func (c *Client) updateAssignees(id int, assignees []string) error {
+	apiURL := fmt.Sprintf("%s/api/v1/repos/%s/issues/%d", c.IntegrationConfig.Spec.Git.GetAPIUrl(), c.IntegrationConfig.Spec.Git.Repository, id)
+
+	if _, _, err := c.requestHTTP(http.MethodPatch, apiURL, &UpdateIssueAssignees{Assignees: assignees}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// RequestReviews requests reviews of the pull request to the users
+func (c *Client) RequestReviews(id int, users []string) error {
+	apiURL := fmt.Sprintf("%s/api/v1/repos/%s/pulls/%d/requested_reviewers", c.IntegrationConfig.Spec.Git.GetAPIUrl(), c.IntegrationConfig.Spec.Git.Repository, id)
+
+	if _, _, err := c.requestHTTP(http.MethodPost, apiURL, &ReviewersBody{Reviewers: users}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// CancelReviewRequests cancels the review requests of the pull request to the users
+func (c *Client) CancelReviewRequests(id int, users []string) error {
+	apiURL := fmt.Sprintf("%s/api/v1/repos/%s/pulls/%d/requested_reviewers", c.IntegrationConfig.Spec.Git.GetAPIUrl(), c.IntegrationConfig.Spec.Git.Repository, id)
+
+	if _, _, err := c.requestHTTP(http.MethodDelete, apiURL, &ReviewersBody{Reviewers: users}); err != nil {
+		return err
+	}
+	return nil
+}
+
 // GetBranch gets branch info
 func (c *Client) GetBranch(branch string) (*git.Branch, error) {
 	apiURL := fmt.Sprintf("%s//api/v1/repos/%s/branches/%s", c.IntegrationConfig.Spec.Git.GetAPIUrl(), c.IntegrationConfig.Spec.Git.Repository, branch)
@@ -500,6 +680,65 @@ func (c *Client) GetBranch(branch string) (*git.Branch, error) {
 	return &git.Branch{Name: resp.Name, CommitID: resp.Commit.Sha}, nil
 }
 
+// ListPullRequestReviews lists reviews of a pull request
+func (c *Client) ListPullRequestReviews(id int) ([]git.PullRequestReview, error) {
+	apiURL := fmt.Sprintf("%s//api/v1/repos/%s/pulls/%d/reviews", c.IntegrationConfig.Spec.Git.GetAPIUrl(), c.IntegrationConfig.Spec.Git.Repository, id)
+
+	raw, _, err := c.requestHTTP(http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []ReviewResponse
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, err
+	}
+
+	var reviews []git.PullRequestReview
+	for _, r := range resp {
+		reviews = append(reviews, git.PullRequestReview{
+			ID:          r.ID,
+			Author:      git.User{ID: r.User.ID, Name: r.User.Name},
+			State:       convertReviewState(string(r.State)),
+			Sha:         r.CommitID,
+			SubmittedAt: r.SubmittedAt,
+		})
+	}
+
+	return reviews, nil
+}
+
+// GetFileContent gets the content of a file in the ref
+func (c *Client) GetFileContent(ref, path string) ([]byte, error) {
+	apiURL := fmt.Sprintf("%s//api/v1/repos/%s/raw/%s?ref=%s", c.IntegrationConfig.Spec.Git.GetAPIUrl(), c.IntegrationConfig.Spec.Git.Repository, strings.TrimPrefix(path, "/"), url.QueryEscape(ref))
+
+	raw, _, err := c.requestHTTP(http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return raw, nil
+}
+
+// convertMergeMethod converts the merge method to gitea's merge style
+func convertMergeMethod(method git.MergeMethod) string {
+	if method == git.MergeMethodFastForward {
+		return "fast-forward-only"
+	}
+	return string(method)
+}
+
+func convertReviewState(original string) git.PullRequestReviewState {
+	switch original {
+	case "APPROVED":
+		return git.PullRequestReviewStateApproved
+	case "REQUEST_CHANGES":
+		return git.PullRequestReviewStateUnapproved
+	default:
+		return git.PullRequestReviewStateCommented
+	}
+}
+
 func convertPullRequestToShared(pr *PullRequest) *git.PullRequest {
 	var labels []git.IssueLabel
 	for _, l := range pr.Labels {
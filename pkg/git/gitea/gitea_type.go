@@ -33,6 +33,14 @@ type UserPermission struct {
 	Permission string `json:"permission"`
 }
 
+// TeamSearchResponse is a response of the team search api
+type TeamSearchResponse struct {
+	Data []struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"data"`
+}
+
 // CommitStatusRequest is an API body for setting commits' status
 type CommitStatusRequest struct {
 	State       string `json:"state"`
@@ -67,6 +75,26 @@ type BranchResponse struct {
 	} `json:"commit"`
 }
 
+// UpdatePullRequest is a request struct to update a pull request
+type UpdatePullRequest struct {
+	Base string `json:"base,omitempty"`
+}
+
+// IssueResponse is a response struct of an issue
+type IssueResponse struct {
+	Assignees []User `json:"assignees"`
+}
+
+// UpdateIssueAssignees is a request struct to update assignees of an issue
+type UpdateIssueAssignees struct {
+	Assignees []string `json:"assignees"`
+}
+
+// ReviewersBody is a body structure for requesting/removing reviews to/from prs
+type ReviewersBody struct {
+	Reviewers []string `json:"reviewers"`
+}
+
 // MergeRequest is a request struct to merge a pull request
 type MergeRequest struct {
 	CommitTitle   string `json:"commit_title,omitempty"`
@@ -105,12 +133,17 @@ type CommitResponse struct {
 
 // CommentResponse is a comment list response
 type CommentResponse struct {
+	ID        int      `json:"id"`
+	User      User     `json:"user"`
 	Body      string   `json:"body"`
 	CreatedAt *v1.Time `json:"created_at"`
 }
 
 // ReviewResponse is a review list response
 type ReviewResponse struct {
+	ID          int                        `json:"id"`
+	User        User                       `json:"user"`
+	CommitID    string                     `json:"commit_id"`
 	Body        string                     `json:"body"`
 	SubmittedAt *v1.Time                   `json:"submitted_at"`
 	State       git.PullRequestReviewState `json:"state"`
@@ -63,7 +63,7 @@ func (c *Client) parsePushWebhook(jsonString []byte) (*git.Webhook, error) {
 		return nil, nil
 	}
 	sender := git.User{Name: data.Sender.Name, ID: data.Sender.ID}
-	push := git.Push{Ref: data.Ref, Sha: data.Sha}
+	push := git.Push{Ref: data.Ref, Sha: data.Sha, Author: git.User{Name: data.HeadCommit.Author.UserName, Email: data.HeadCommit.Author.Email}}
 
 	// Get sender email
 	userInfo, err := c.GetUserInfo(data.Sender.Name)
@@ -55,10 +55,20 @@ type PullRequestWebhook struct {
 
 // PushWebhook is a gitea-specific push event webhook body
 type PushWebhook struct {
-	Ref    string `json:"ref"`
-	Repo   Repo   `json:"repository"`
-	Sender User   `json:"sender"`
-	Sha    string `json:"after"`
+	Ref        string `json:"ref"`
+	Repo       Repo   `json:"repository"`
+	Sender     User   `json:"sender"`
+	Sha        string `json:"after"`
+	HeadCommit struct {
+		Author CommitAuthor `json:"author"`
+	} `json:"head_commit"`
+}
+
+// CommitAuthor is an author of a commit in the push event webhook body
+type CommitAuthor struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	UserName string `json:"username"`
 }
 
 // IssueCommentWebhook is a gitea-specific issue_comment webhook body
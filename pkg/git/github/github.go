@@ -19,9 +19,11 @@ package github
 import (
 	"crypto/hmac"
 	"crypto/sha1"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 
@@ -74,6 +76,8 @@ func (c *Client) ParseWebhook(header http.Header, jsonString []byte) (*git.Webho
 		return c.parsePullRequestReviewCommentWebhook(jsonString)
 	case git.EventTypeCommitComment:
 		return c.parseCommitCommentWebhook(jsonString)
+	case git.EventTypeStatus:
+		return c.parseStatusWebhook(jsonString)
 	}
 	return nil, nil
 }
@@ -218,20 +222,67 @@ func (c *Client) GetUserInfo(userName string) (*git.User, error) {
 
 // CanUserWriteToRepo decides if the user has write permission on the repo
 func (c *Client) CanUserWriteToRepo(user git.User) (bool, error) {
+	permission, err := c.getUserPermission(user)
+	if err != nil {
+		return false, err
+	}
+	return permission == "admin" || permission == "write", nil
+}
+
+// IsUserRepoAdmin decides if the user has admin permission on the repo
+func (c *Client) IsUserRepoAdmin(user git.User) (bool, error) {
+	permission, err := c.getUserPermission(user)
+	if err != nil {
+		return false, err
+	}
+	return permission == "admin", nil
+}
+
+func (c *Client) getUserPermission(user git.User) (string, error) {
 	// userName is string!
 	apiURL := fmt.Sprintf("%s/repos/%s/collaborators/%s/permission", c.IntegrationConfig.Spec.Git.GetAPIUrl(), c.IntegrationConfig.Spec.Git.Repository, user.Name)
 
 	result, _, err := c.requestHTTP(http.MethodGet, apiURL, nil)
 	if err != nil {
-		return false, err
+		return "", err
 	}
 
 	var permission UserPermission
 	if err := json.Unmarshal(result, &permission); err != nil {
+		return "", err
+	}
+
+	return permission.Permission, nil
+}
+
+// IsUserInGroup decides if the user is a member of the organization (org) or the team (org/team)
+func (c *Client) IsUserInGroup(user git.User, group string) (bool, error) {
+	tokens := strings.SplitN(group, "/", 2)
+	if len(tokens) == 1 {
+		apiURL := fmt.Sprintf("%s/orgs/%s/members/%s", c.IntegrationConfig.Spec.Git.GetAPIUrl(), group, user.Name)
+		if _, _, err := c.requestHTTP(http.MethodGet, apiURL, nil); err != nil {
+			if git.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	}
+
+	apiURL := fmt.Sprintf("%s/orgs/%s/teams/%s/memberships/%s", c.IntegrationConfig.Spec.Git.GetAPIUrl(), tokens[0], tokens[1], user.Name)
+	result, _, err := c.requestHTTP(http.MethodGet, apiURL, nil)
+	if err != nil {
+		if git.IsNotFound(err) {
+			return false, nil
+		}
 		return false, err
 	}
 
-	return permission.Permission == "admin" || permission.Permission == "write", nil
+	var membership TeamMembership
+	if err := json.Unmarshal(result, &membership); err != nil {
+		return false, err
+	}
+	return membership.State == "active", nil
 }
 
 // RegisterComment registers comment to an issue
@@ -250,6 +301,22 @@ func (c *Client) RegisterComment(issueType git.IssueType, issueNo int, sha, body
 	return nil
 }
 
+// EditComment edits the comment's body
+func (c *Client) EditComment(issueType git.IssueType, _, commentID int, body string) error {
+	var apiUrl string
+	if issueType == git.IssueTypePullRequest {
+		apiUrl = fmt.Sprintf("%s/repos/%s/issues/comments/%d", c.IntegrationConfig.Spec.Git.GetAPIUrl(), c.IntegrationConfig.Spec.Git.Repository, commentID)
+	} else if issueType == git.IssueTypeCommit {
+		apiUrl = fmt.Sprintf("%s/repos/%s/comments/%d", c.IntegrationConfig.Spec.Git.GetAPIUrl(), c.IntegrationConfig.Spec.Git.Repository, commentID)
+	}
+
+	commentBody := &CommentBody{Body: body}
+	if _, _, err := c.requestHTTP(http.MethodPatch, apiUrl, commentBody); err != nil {
+		return err
+	}
+	return nil
+}
+
 // ListComments lists comments of the issue id
 func (c *Client) ListComments(issueNo int) ([]git.IssueComment, error) {
 	var comments []git.IssueComment
@@ -269,9 +336,11 @@ func (c *Client) ListComments(issueNo int) ([]git.IssueComment, error) {
 	for _, issueComment := range issueComments {
 		comments = append(comments, git.IssueComment{
 			Comment: git.Comment{
+				ID:        issueComment.ID,
 				Body:      issueComment.Body,
 				CreatedAt: issueComment.CreatedAt,
 			},
+			Author: git.User{ID: issueComment.User.ID, Name: issueComment.User.Name},
 		})
 	}
 
@@ -289,6 +358,7 @@ func (c *Client) ListComments(issueNo int) ([]git.IssueComment, error) {
 				Body:      prComment.Body,
 				CreatedAt: prComment.CreatedAt,
 			},
+			Author: git.User{ID: prComment.User.ID, Name: prComment.User.Name},
 		})
 	}
 
@@ -306,7 +376,8 @@ func (c *Client) ListComments(issueNo int) ([]git.IssueComment, error) {
 				Body:      prReview.Body,
 				CreatedAt: prReview.SubmittedAt,
 			},
-			ReviewState: prReview.State,
+			Author:      git.User{ID: prReview.User.ID, Name: prReview.User.Name},
+			ReviewState: git.PullRequestReviewState(strings.ToLower(string(prReview.State))),
 		})
 	}
 	return comments, nil
@@ -360,6 +431,11 @@ func (c *Client) GetPullRequest(id int) (*git.PullRequest, error) {
 
 // MergePullRequest merges a pull request
 func (c *Client) MergePullRequest(id int, sha string, method git.MergeMethod, message string) error {
+	// GitHub doesn't provide fast-forward merge. Move the base branch to the head commit, instead
+	if method == git.MergeMethodFastForward {
+		return c.fastForwardPullRequest(id, sha)
+	}
+
 	apiURL := fmt.Sprintf("%s/repos/%s/pulls/%d/merge", c.IntegrationConfig.Spec.Git.GetAPIUrl(), c.IntegrationConfig.Spec.Git.Repository, id)
 
 	tokens := strings.Split(message, "\n\n")
@@ -382,6 +458,58 @@ func (c *Client) MergePullRequest(id int, sha string, method git.MergeMethod, me
 	return nil
 }
 
+// fastForwardPullRequest updates the base branch's reference to the head commit of the pull request.
+// It fails if the head commit is not a descendant of the base branch, as the update is not forced
+func (c *Client) fastForwardPullRequest(id int, sha string) error {
+	pr, err := c.GetPullRequest(id)
+	if err != nil {
+		return err
+	}
+	if sha != "" && pr.Head.Sha != sha {
+		return fmt.Errorf("head of pull request #%d is %s, not %s", id, pr.Head.Sha, sha)
+	}
+
+	apiURL := fmt.Sprintf("%s/repos/%s/git/refs/heads/%s", c.IntegrationConfig.Spec.Git.GetAPIUrl(), c.IntegrationConfig.Spec.Git.Repository, cicdv1.GitRef(pr.Base.Ref).GetBranch())
+	body := &UpdateRefRequest{
+		Sha:   pr.Head.Sha,
+		Force: false,
+	}
+	_, _, err = c.requestHTTP(http.MethodPatch, apiURL, body)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdatePullRequestBranch updates the head branch of the pull request with the latest base branch.
+// GitHub merges the base branch into the head branch, as its API does not support rebasing it
+func (c *Client) UpdatePullRequestBranch(id int, sha string) error {
+	apiURL := fmt.Sprintf("%s/repos/%s/pulls/%d/update-branch", c.IntegrationConfig.Spec.Git.GetAPIUrl(), c.IntegrationConfig.Spec.Git.Repository, id)
+
+	body := &UpdateBranchRequest{
+		ExpectedHeadSha: sha,
+	}
+	_, _, err := c.requestHTTP(http.MethodPut, apiURL, body)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdatePullRequestBase changes the base branch of the pull request
+func (c *Client) UpdatePullRequestBase(id int, base string) error {
+	apiURL := fmt.Sprintf("%s/repos/%s/pulls/%d", c.IntegrationConfig.Spec.Git.GetAPIUrl(), c.IntegrationConfig.Spec.Git.Repository, id)
+
+	_, _, err := c.requestHTTP(http.MethodPatch, apiURL, &UpdatePullRequest{Base: base})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // GetPullRequestDiff gets diff of the pull request
 func (c *Client) GetPullRequestDiff(id int) (*git.Diff, error) {
 	apiURL := fmt.Sprintf("%s/repos/%s/pulls/%d/files", c.IntegrationConfig.Spec.Git.GetAPIUrl(), c.IntegrationConfig.Spec.Git.Repository, id)
@@ -486,6 +614,54 @@ func (c *Client) DeleteLabel(_ git.IssueType, id int, label string) error {
 	return nil
 }
 
+// AddAssignees adds assignees to the issue id
+func (c *Client) AddAssignees(id int, users []string) error {
+	apiURL := fmt.Sprintf("%s/repos/%s/issues/%d/assignees", c.IntegrationConfig.Spec.Git.GetAPIUrl(), c.IntegrationConfig.Spec.Git.Repository, id)
+
+	_, _, err := c.requestHTTP(http.MethodPost, apiURL, &AssigneesBody{Assignees: users})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RemoveAssignees removes assignees from the issue id
+func (c *Client) RemoveAssignees(id int, users []string) error {
+	apiURL := fmt.Sprintf("%s/repos/%s/issues/%d/assignees", c.IntegrationConfig.Spec.Git.GetAPIUrl(), c.IntegrationConfig.Spec.Git.Repository, id)
+
+	_, _, err := c.requestHTTP(http.MethodDelete, apiURL, &AssigneesBody{Assignees: users})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RequestReviews requests reviews of the pull request to the users
+func (c *Client) RequestReviews(id int, users []string) error {
+	apiURL := fmt.Sprintf("%s/repos/%s/pulls/%d/requested_reviewers", c.IntegrationConfig.Spec.Git.GetAPIUrl(), c.IntegrationConfig.Spec.Git.Repository, id)
+
+	_, _, err := c.requestHTTP(http.MethodPost, apiURL, &ReviewersBody{Reviewers: users})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CancelReviewRequests cancels the review requests of the pull request to the users
+func (c *Client) CancelReviewRequests(id int, users []string) error {
+	apiURL := fmt.Sprintf("%s/repos/%s/pulls/%d/requested_reviewers", c.IntegrationConfig.Spec.Git.GetAPIUrl(), c.IntegrationConfig.Spec.Git.Repository, id)
+
+	_, _, err := c.requestHTTP(http.MethodDelete, apiURL, &ReviewersBody{Reviewers: users})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // GetBranch gets branch info
 func (c *Client) GetBranch(branch string) (*git.Branch, error) {
 	apiURL := fmt.Sprintf("%s/repos/%s/branches/%s", c.IntegrationConfig.Spec.Git.GetAPIUrl(), c.IntegrationConfig.Spec.Git.Repository, branch)
@@ -503,6 +679,57 @@ func (c *Client) GetBranch(branch string) (*git.Branch, error) {
 	return &git.Branch{Name: resp.Name, CommitID: resp.Commit.Sha}, nil
 }
 
+// ListPullRequestReviews lists reviews of a pull request
+func (c *Client) ListPullRequestReviews(id int) ([]git.PullRequestReview, error) {
+	apiURL := fmt.Sprintf("%s/repos/%s/pulls/%d/reviews", c.IntegrationConfig.Spec.Git.GetAPIUrl(), c.IntegrationConfig.Spec.Git.Repository, id)
+
+	var reviews []ReviewResponse
+	tlsConfig := c.IntegrationConfig.GetTLSConfig()
+
+	err := git.GetPaginatedRequest(apiURL, tlsConfig, c.header, func() interface{} {
+		return &[]ReviewResponse{}
+	}, func(i interface{}) {
+		reviews = append(reviews, *i.(*[]ReviewResponse)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []git.PullRequestReview
+	for _, r := range reviews {
+		result = append(result, git.PullRequestReview{
+			ID:          r.ID,
+			Author:      git.User{ID: r.User.ID, Name: r.User.Name},
+			State:       git.PullRequestReviewState(strings.ToLower(string(r.State))),
+			Sha:         r.CommitID,
+			SubmittedAt: r.SubmittedAt,
+		})
+	}
+
+	return result, nil
+}
+
+// GetFileContent gets the content of a file in the ref
+func (c *Client) GetFileContent(ref, path string) ([]byte, error) {
+	apiURL := fmt.Sprintf("%s/repos/%s/contents/%s?ref=%s", c.IntegrationConfig.Spec.Git.GetAPIUrl(), c.IntegrationConfig.Spec.Git.Repository, strings.TrimPrefix(path, "/"), url.QueryEscape(ref))
+
+	raw, _, err := c.requestHTTP(http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ContentResponse{}
+	if err := json.Unmarshal(raw, resp); err != nil {
+		return nil, err
+	}
+
+	if resp.Encoding != "base64" {
+		return nil, fmt.Errorf("encoding %s is not supported", resp.Encoding)
+	}
+
+	return base64.StdEncoding.DecodeString(strings.ReplaceAll(resp.Content, "\n", ""))
+}
+
 func convertPullRequestToShared(pr *PullRequest) *git.PullRequest {
 	var labels []git.IssueLabel
 	for _, l := range pr.Labels {
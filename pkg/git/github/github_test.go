@@ -41,7 +41,7 @@ const (
 	samplePRCommits                      = "[\n  {\n    \"sha\": \"bfa929712952e60d5ad5d3b73376f6ba392f8b50\",\n    \"commit\": {\n      \"author\": {\n        \"name\": \"Sunghyun Kim\",\n        \"email\": \"cqbqdd11519@gmail.com\",\n        \"date\": \"2021-08-24T07:16:13Z\"\n      },\n      \"committer\": {\n        \"name\": \"Sunghyun Kim\",\n        \"email\": \"cqbqdd11519@gmail.com\",\n        \"date\": \"2021-08-25T04:34:17Z\"\n      },\n      \"message\": \"[fix] Batch pull requests properly\\n\\nfix #270\\n\\n- Fix critical typo\\n- Remove a PR from the batch right away after merging it.\\n  This is to avoid an infinite error, when a PR is already merged, but\\n  is still in the CurrentBatch in the next loop (because of one of the\\n  next PRs fails to merge)\"\n    }\n  }\n]"
 	sampleLabelLists                     = "[\n  {\n    \"id\": 3048006488,\n    \"node_id\": \"MDU6TGFiZWwzMDQ4MDA2NDg4\",\n    \"url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/labels/approved\",\n    \"name\": \"approved\",\n    \"color\": \"ededed\",\n    \"default\": false,\n    \"description\": null\n  },\n  {\n    \"id\": 3187077209,\n    \"node_id\": \"MDU6TGFiZWwzMTg3MDc3MjA5\",\n    \"url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/labels/size/L\",\n    \"name\": \"size/L\",\n    \"color\": \"ededed\",\n    \"default\": false,\n    \"description\": null\n  }\n]"
 	samplePRComments                     = "[\n  {\n    \"url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/comments/771113606\",\n    \"pull_request_review_id\": 834849190,\n    \"id\": 771113606,\n    \"node_id\": \"PRRC_kwDOEm6Tx84t9kKG\",\n    \"diff_hunk\": \"@@ -20,89 +20,10 @@ import (\\n \\t\\\"testing\\\"\\n \\n \\t\\\"github.com/stretchr/testify/require\\\"\\n-\\ttektonv1beta1 \\\"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1\\\"\\n \\t\\\"github.com/tmax-cloud/cicd-operator/internal/configs\\\"\\n \\tmetav1 \\\"k8s.io/apimachinery/pkg/apis/meta/v1\\\"\\n )\\n \\n-func TestConvertToTektonParamSpecs(t *testing.T) {\",\n    \"path\": \"api/v1/integrationjob_types_test.go\",\n    \"position\": 9,\n    \"original_position\": 9,\n    \"commit_id\": \"d3b2006b7a2ab28268b248429bc215854a497d24\",\n    \"original_commit_id\": \"654761e79f45e62ef8ca4d94c47cf7adc1756122\",\n    \"user\": {\n      \"login\": \"eddy-kor-92\",\n      \"id\": 33279734,\n      \"node_id\": \"MDQ6VXNlcjMzMjc5NzM0\",\n      \"avatar_url\": \"https://avatars.githubusercontent.com/u/33279734?v=4\",\n      \"gravatar_id\": \"\",\n      \"url\": \"https://api.github.com/users/eddy-kor-92\",\n      \"html_url\": \"https://github.com/eddy-kor-92\",\n      \"followers_url\": \"https://api.github.com/users/eddy-kor-92/followers\",\n      \"following_url\": \"https://api.github.com/users/eddy-kor-92/following{/other_user}\",\n      \"gists_url\": \"https://api.github.com/users/eddy-kor-92/gists{/gist_id}\",\n      \"starred_url\": \"https://api.github.com/users/eddy-kor-92/starred{/owner}{/repo}\",\n      \"subscriptions_url\": \"https://api.github.com/users/eddy-kor-92/subscriptions\",\n      \"organizations_url\": \"https://api.github.com/users/eddy-kor-92/orgs\",\n      \"repos_url\": \"https://api.github.com/users/eddy-kor-92/repos\",\n      \"events_url\": \"https://api.github.com/users/eddy-kor-92/events{/privacy}\",\n      \"received_events_url\": \"https://api.github.com/users/eddy-kor-92/received_events\",\n      \"type\": \"User\",\n      \"site_admin\": false\n    },\n    \"body\": \"이 Test 함수가 원래 integrationconfig_types_test에 있는게 맞는거죠? 그래서 옮기신거죠?\",\n    \"created_at\": \"2021-12-17T05:29:08Z\",\n    \"updated_at\": \"2021-12-17T05:31:38Z\",\n    \"html_url\": \"https://github.com/tmax-cloud/cicd-operator/pull/324#discussion_r771113606\",\n    \"pull_request_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/324\",\n    \"author_association\": \"NONE\",\n    \"_links\": {\n      \"self\": {\n        \"href\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/comments/771113606\"\n      },\n      \"html\": {\n        \"href\": \"https://github.com/tmax-cloud/cicd-operator/pull/324#discussion_r771113606\"\n      },\n      \"pull_request\": {\n        \"href\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/324\"\n      }\n    },\n    \"reactions\": {\n      \"url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/comments/771113606/reactions\",\n      \"total_count\": 0,\n      \"+1\": 0,\n      \"-1\": 0,\n      \"laugh\": 0,\n      \"hooray\": 0,\n      \"confused\": 0,\n      \"heart\": 0,\n      \"rocket\": 0,\n      \"eyes\": 0\n    },\n    \"start_line\": null,\n    \"original_start_line\": null,\n    \"start_side\": null,\n    \"line\": 28,\n    \"original_line\": 28,\n    \"side\": \"LEFT\"\n  },\n  {\n    \"url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/comments/771114018\",\n    \"pull_request_review_id\": 834849190,\n    \"id\": 771114018,\n    \"node_id\": \"PRRC_kwDOEm6Tx84t9kQi\",\n    \"diff_hunk\": \"@@ -127,18 +130,33 @@ func (p *pipelineManager) Generate(job *cicdv1.IntegrationJob) (*tektonv1beta1.P\\n \\t\\t\\t\\tResources:  specResources,\\n \\t\\t\\t\\tTasks:      tasks,\\n \\t\\t\\t\\tWorkspaces: workspaceDefs,\\n-\\t\\t\\t\\tParams:     cicdv1.ConvertToTektonParamSpecs(job.Spec.ParamConfig.ParamDefine),\\n+\\t\\t\\t\\tParams:     paramDefine,\\n \\t\\t\\t},\\n \\t\\t\\tPodTemplate: job.Spec.PodTemplate,\\n \\t\\t\\tWorkspaces:  job.Spec.Workspaces,\\n \\t\\t\\tTimeout: &metav1.Duration{\\n \\t\\t\\t\\tDuration: job.Spec.Timeout.Duration,\\n \\t\\t\\t},\\n-\\t\\t\\tParams: cicdv1.ConvertToTektonParams(job.Spec.ParamConfig.ParamValue),\\n+\\t\\t\\tParams: paramValue,\\n \\t\\t},\\n \\t}, nil\\n }\\n \\n+func getParams(job *cicdv1.IntegrationJob) ([]tektonv1beta1.ParamSpec, []tektonv1beta1.Param) {\",\n    \"path\": \"pkg/pipelinemanager/pipelinemanager.go\",\n    \"position\": 28,\n    \"original_position\": 28,\n    \"commit_id\": \"d3b2006b7a2ab28268b248429bc215854a497d24\",\n    \"original_commit_id\": \"654761e79f45e62ef8ca4d94c47cf7adc1756122\",\n    \"user\": {\n      \"login\": \"eddy-kor-92\",\n      \"id\": 33279734,\n      \"node_id\": \"MDQ6VXNlcjMzMjc5NzM0\",\n      \"avatar_url\": \"https://avatars.githubusercontent.com/u/33279734?v=4\",\n      \"gravatar_id\": \"\",\n      \"url\": \"https://api.github.com/users/eddy-kor-92\",\n      \"html_url\": \"https://github.com/eddy-kor-92\",\n      \"followers_url\": \"https://api.github.com/users/eddy-kor-92/followers\",\n      \"following_url\": \"https://api.github.com/users/eddy-kor-92/following{/other_user}\",\n      \"gists_url\": \"https://api.github.com/users/eddy-kor-92/gists{/gist_id}\",\n      \"starred_url\": \"https://api.github.com/users/eddy-kor-92/starred{/owner}{/repo}\",\n      \"subscriptions_url\": \"https://api.github.com/users/eddy-kor-92/subscriptions\",\n      \"organizations_url\": \"https://api.github.com/users/eddy-kor-92/orgs\",\n      \"repos_url\": \"https://api.github.com/users/eddy-kor-92/repos\",\n      \"events_url\": \"https://api.github.com/users/eddy-kor-92/events{/privacy}\",\n      \"received_events_url\": \"https://api.github.com/users/eddy-kor-92/received_events\",\n      \"type\": \"User\",\n      \"site_admin\": false\n    },\n    \"body\": \"nil 체크를 하는게 이 함수의 목적인거 같은데, parameter를 직접 사용하는 함수에서 parameter validation을 하는게 더 낫지 않을까요? ConvertToTektonParamSpecs랑 ConvertToTektonParams 함수에서요.\",\n    \"created_at\": \"2021-12-17T05:30:31Z\",\n    \"updated_at\": \"2021-12-17T05:31:38Z\",\n    \"html_url\": \"https://github.com/tmax-cloud/cicd-operator/pull/324#discussion_r771114018\",\n    \"pull_request_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/324\",\n    \"author_association\": \"NONE\",\n    \"_links\": {\n      \"self\": {\n        \"href\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/comments/771114018\"\n      },\n      \"html\": {\n        \"href\": \"https://github.com/tmax-cloud/cicd-operator/pull/324#discussion_r771114018\"\n      },\n      \"pull_request\": {\n        \"href\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/324\"\n      }\n    },\n    \"reactions\": {\n      \"url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/comments/771114018/reactions\",\n      \"total_count\": 0,\n      \"+1\": 0,\n      \"-1\": 0,\n      \"laugh\": 0,\n      \"hooray\": 0,\n      \"confused\": 0,\n      \"heart\": 0,\n      \"rocket\": 0,\n      \"eyes\": 0\n    },\n    \"start_line\": null,\n    \"original_start_line\": null,\n    \"start_side\": null,\n    \"line\": 145,\n    \"original_line\": 145,\n    \"side\": \"RIGHT\"\n  },\n  {\n    \"url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/comments/771115644\",\n    \"pull_request_review_id\": 834851875,\n    \"id\": 771115644,\n    \"node_id\": \"PRRC_kwDOEm6Tx84t9kp8\",\n    \"diff_hunk\": \"@@ -20,89 +20,10 @@ import (\\n \\t\\\"testing\\\"\\n \\n \\t\\\"github.com/stretchr/testify/require\\\"\\n-\\ttektonv1beta1 \\\"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1\\\"\\n \\t\\\"github.com/tmax-cloud/cicd-operator/internal/configs\\\"\\n \\tmetav1 \\\"k8s.io/apimachinery/pkg/apis/meta/v1\\\"\\n )\\n \\n-func TestConvertToTektonParamSpecs(t *testing.T) {\",\n    \"path\": \"api/v1/integrationjob_types_test.go\",\n    \"position\": 9,\n    \"original_position\": 9,\n    \"commit_id\": \"d3b2006b7a2ab28268b248429bc215854a497d24\",\n    \"original_commit_id\": \"654761e79f45e62ef8ca4d94c47cf7adc1756122\",\n    \"user\": {\n      \"login\": \"changjjjjjjj\",\n      \"id\": 56624551,\n      \"node_id\": \"MDQ6VXNlcjU2NjI0NTUx\",\n      \"avatar_url\": \"https://avatars.githubusercontent.com/u/56624551?v=4\",\n      \"gravatar_id\": \"\",\n      \"url\": \"https://api.github.com/users/changjjjjjjj\",\n      \"html_url\": \"https://github.com/changjjjjjjj\",\n      \"followers_url\": \"https://api.github.com/users/changjjjjjjj/followers\",\n      \"following_url\": \"https://api.github.com/users/changjjjjjjj/following{/other_user}\",\n      \"gists_url\": \"https://api.github.com/users/changjjjjjjj/gists{/gist_id}\",\n      \"starred_url\": \"https://api.github.com/users/changjjjjjjj/starred{/owner}{/repo}\",\n      \"subscriptions_url\": \"https://api.github.com/users/changjjjjjjj/subscriptions\",\n      \"organizations_url\": \"https://api.github.com/users/changjjjjjjj/orgs\",\n      \"repos_url\": \"https://api.github.com/users/changjjjjjjj/repos\",\n      \"events_url\": \"https://api.github.com/users/changjjjjjjj/events{/privacy}\",\n      \"received_events_url\": \"https://api.github.com/users/changjjjjjjj/received_events\",\n      \"type\": \"User\",\n      \"site_admin\": false\n    },\n    \"body\": \"네 잘못 들어가있어서 옮겼습니다\",\n    \"created_at\": \"2021-12-17T05:36:07Z\",\n    \"updated_at\": \"2021-12-17T05:36:07Z\",\n    \"html_url\": \"https://github.com/tmax-cloud/cicd-operator/pull/324#discussion_r771115644\",\n    \"pull_request_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/324\",\n    \"author_association\": \"COLLABORATOR\",\n    \"_links\": {\n      \"self\": {\n        \"href\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/comments/771115644\"\n      },\n      \"html\": {\n        \"href\": \"https://github.com/tmax-cloud/cicd-operator/pull/324#discussion_r771115644\"\n      },\n      \"pull_request\": {\n        \"href\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/324\"\n      }\n    },\n    \"reactions\": {\n      \"url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/comments/771115644/reactions\",\n      \"total_count\": 0,\n      \"+1\": 0,\n      \"-1\": 0,\n      \"laugh\": 0,\n      \"hooray\": 0,\n      \"confused\": 0,\n      \"heart\": 0,\n      \"rocket\": 0,\n      \"eyes\": 0\n    },\n    \"start_line\": null,\n    \"original_start_line\": null,\n    \"start_side\": null,\n    \"line\": 28,\n    \"original_line\": 28,\n    \"side\": \"LEFT\",\n    \"in_reply_to_id\": 771113606\n  },\n  {\n    \"url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/comments/771122149\",\n    \"pull_request_review_id\": 834860063,\n    \"id\": 771122149,\n    \"node_id\": \"PRRC_kwDOEm6Tx84t9mPl\",\n    \"diff_hunk\": \"@@ -127,18 +130,33 @@ func (p *pipelineManager) Generate(job *cicdv1.IntegrationJob) (*tektonv1beta1.P\\n \\t\\t\\t\\tResources:  specResources,\\n \\t\\t\\t\\tTasks:      tasks,\\n \\t\\t\\t\\tWorkspaces: workspaceDefs,\\n-\\t\\t\\t\\tParams:     cicdv1.ConvertToTektonParamSpecs(job.Spec.ParamConfig.ParamDefine),\\n+\\t\\t\\t\\tParams:     paramDefine,\\n \\t\\t\\t},\\n \\t\\t\\tPodTemplate: job.Spec.PodTemplate,\\n \\t\\t\\tWorkspaces:  job.Spec.Workspaces,\\n \\t\\t\\tTimeout: &metav1.Duration{\\n \\t\\t\\t\\tDuration: job.Spec.Timeout.Duration,\\n \\t\\t\\t},\\n-\\t\\t\\tParams: cicdv1.ConvertToTektonParams(job.Spec.ParamConfig.ParamValue),\\n+\\t\\t\\tParams: paramValue,\\n \\t\\t},\\n \\t}, nil\\n }\\n \\n+func getParams(job *cicdv1.IntegrationJob) ([]tektonv1beta1.ParamSpec, []tektonv1beta1.Param) {\",\n    \"path\": \"pkg/pipelinemanager/pipelinemanager.go\",\n    \"position\": 28,\n    \"original_position\": 28,\n    \"commit_id\": \"d3b2006b7a2ab28268b248429bc215854a497d24\",\n    \"original_commit_id\": \"654761e79f45e62ef8ca4d94c47cf7adc1756122\",\n    \"user\": {\n      \"login\": \"changjjjjjjj\",\n      \"id\": 56624551,\n      \"node_id\": \"MDQ6VXNlcjU2NjI0NTUx\",\n      \"avatar_url\": \"https://avatars.githubusercontent.com/u/56624551?v=4\",\n      \"gravatar_id\": \"\",\n      \"url\": \"https://api.github.com/users/changjjjjjjj\",\n      \"html_url\": \"https://github.com/changjjjjjjj\",\n      \"followers_url\": \"https://api.github.com/users/changjjjjjjj/followers\",\n      \"following_url\": \"https://api.github.com/users/changjjjjjjj/following{/other_user}\",\n      \"gists_url\": \"https://api.github.com/users/changjjjjjjj/gists{/gist_id}\",\n      \"starred_url\": \"https://api.github.com/users/changjjjjjjj/starred{/owner}{/repo}\",\n      \"subscriptions_url\": \"https://api.github.com/users/changjjjjjjj/subscriptions\",\n      \"organizations_url\": \"https://api.github.com/users/changjjjjjjj/orgs\",\n      \"repos_url\": \"https://api.github.com/users/changjjjjjjj/repos\",\n      \"events_url\": \"https://api.github.com/users/changjjjjjjj/events{/privacy}\",\n      \"received_events_url\": \"https://api.github.com/users/changjjjjjjj/received_events\",\n      \"type\": \"User\",\n      \"site_admin\": false\n    },\n    \"body\": \"paramConfig nil 은 체크해야 해서 함수는 남겨뒀고 생각해보니까 paramDefine이랑 paramValue는  getParams에서 nil 체크 안해도 돼서 삭제했습니다.\",\n    \"created_at\": \"2021-12-17T05:57:08Z\",\n    \"updated_at\": \"2021-12-17T05:57:08Z\",\n    \"html_url\": \"https://github.com/tmax-cloud/cicd-operator/pull/324#discussion_r771122149\",\n    \"pull_request_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/324\",\n    \"author_association\": \"COLLABORATOR\",\n    \"_links\": {\n      \"self\": {\n        \"href\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/comments/771122149\"\n      },\n      \"html\": {\n        \"href\": \"https://github.com/tmax-cloud/cicd-operator/pull/324#discussion_r771122149\"\n      },\n      \"pull_request\": {\n        \"href\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/324\"\n      }\n    },\n    \"reactions\": {\n      \"url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/comments/771122149/reactions\",\n      \"total_count\": 0,\n      \"+1\": 0,\n      \"-1\": 0,\n      \"laugh\": 0,\n      \"hooray\": 0,\n      \"confused\": 0,\n      \"heart\": 0,\n      \"rocket\": 0,\n      \"eyes\": 0\n    },\n    \"start_line\": null,\n    \"original_start_line\": null,\n    \"start_side\": null,\n    \"line\": 145,\n    \"original_line\": 145,\n    \"side\": \"RIGHT\",\n    \"in_reply_to_id\": 771114018\n  }\n]"
-	samplePRReviews                      = "[\n  {\n    \"id\": 834849190,\n    \"node_id\": \"PRR_kwDOEm6Tx84xwsmm\",\n    \"user\": {\n      \"login\": \"eddy-kor-92\",\n      \"id\": 33279734,\n      \"node_id\": \"MDQ6VXNlcjMzMjc5NzM0\",\n      \"avatar_url\": \"https://avatars.githubusercontent.com/u/33279734?u=bed3bf0df30f21a34b1d88dac4bdea053d2edafa&v=4\",\n      \"gravatar_id\": \"\",\n      \"url\": \"https://api.github.com/users/eddy-kor-92\",\n      \"html_url\": \"https://github.com/eddy-kor-92\",\n      \"followers_url\": \"https://api.github.com/users/eddy-kor-92/followers\",\n      \"following_url\": \"https://api.github.com/users/eddy-kor-92/following{/other_user}\",\n      \"gists_url\": \"https://api.github.com/users/eddy-kor-92/gists{/gist_id}\",\n      \"starred_url\": \"https://api.github.com/users/eddy-kor-92/starred{/owner}{/repo}\",\n      \"subscriptions_url\": \"https://api.github.com/users/eddy-kor-92/subscriptions\",\n      \"organizations_url\": \"https://api.github.com/users/eddy-kor-92/orgs\",\n      \"repos_url\": \"https://api.github.com/users/eddy-kor-92/repos\",\n      \"events_url\": \"https://api.github.com/users/eddy-kor-92/events{/privacy}\",\n      \"received_events_url\": \"https://api.github.com/users/eddy-kor-92/received_events\",\n      \"type\": \"User\",\n      \"site_admin\": false\n    },\n    \"body\": \"\",\n    \"state\": \"COMMENTED\",\n    \"html_url\": \"https://github.com/tmax-cloud/cicd-operator/pull/324#pullrequestreview-834849190\",\n    \"pull_request_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/324\",\n    \"author_association\": \"NONE\",\n    \"_links\": {\n      \"html\": {\n        \"href\": \"https://github.com/tmax-cloud/cicd-operator/pull/324#pullrequestreview-834849190\"\n      },\n      \"pull_request\": {\n        \"href\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/324\"\n      }\n    },\n    \"submitted_at\": \"2021-12-17T05:31:38Z\",\n    \"commit_id\": \"654761e79f45e62ef8ca4d94c47cf7adc1756122\"\n  },\n  {\n    \"id\": 834851875,\n    \"node_id\": \"PRR_kwDOEm6Tx84xwtQj\",\n    \"user\": {\n      \"login\": \"changjjjjjjj\",\n      \"id\": 56624551,\n      \"node_id\": \"MDQ6VXNlcjU2NjI0NTUx\",\n      \"avatar_url\": \"https://avatars.githubusercontent.com/u/56624551?v=4\",\n      \"gravatar_id\": \"\",\n      \"url\": \"https://api.github.com/users/changjjjjjjj\",\n      \"html_url\": \"https://github.com/changjjjjjjj\",\n      \"followers_url\": \"https://api.github.com/users/changjjjjjjj/followers\",\n      \"following_url\": \"https://api.github.com/users/changjjjjjjj/following{/other_user}\",\n      \"gists_url\": \"https://api.github.com/users/changjjjjjjj/gists{/gist_id}\",\n      \"starred_url\": \"https://api.github.com/users/changjjjjjjj/starred{/owner}{/repo}\",\n      \"subscriptions_url\": \"https://api.github.com/users/changjjjjjjj/subscriptions\",\n      \"organizations_url\": \"https://api.github.com/users/changjjjjjjj/orgs\",\n      \"repos_url\": \"https://api.github.com/users/changjjjjjjj/repos\",\n      \"events_url\": \"https://api.github.com/users/changjjjjjjj/events{/privacy}\",\n      \"received_events_url\": \"https://api.github.com/users/changjjjjjjj/received_events\",\n      \"type\": \"User\",\n      \"site_admin\": false\n    },\n    \"body\": \"\",\n    \"state\": \"COMMENTED\",\n    \"html_url\": \"https://github.com/tmax-cloud/cicd-operator/pull/324#pullrequestreview-834851875\",\n    \"pull_request_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/324\",\n    \"author_association\": \"COLLABORATOR\",\n    \"_links\": {\n      \"html\": {\n        \"href\": \"https://github.com/tmax-cloud/cicd-operator/pull/324#pullrequestreview-834851875\"\n      },\n      \"pull_request\": {\n        \"href\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/324\"\n      }\n    },\n    \"submitted_at\": \"2021-12-17T05:36:07Z\",\n    \"commit_id\": \"654761e79f45e62ef8ca4d94c47cf7adc1756122\"\n  },\n  {\n    \"id\": 834860063,\n    \"node_id\": \"PRR_kwDOEm6Tx84xwvQf\",\n    \"user\": {\n      \"login\": \"changjjjjjjj\",\n      \"id\": 56624551,\n      \"node_id\": \"MDQ6VXNlcjU2NjI0NTUx\",\n      \"avatar_url\": \"https://avatars.githubusercontent.com/u/56624551?v=4\",\n      \"gravatar_id\": \"\",\n      \"url\": \"https://api.github.com/users/changjjjjjjj\",\n      \"html_url\": \"https://github.com/changjjjjjjj\",\n      \"followers_url\": \"https://api.github.com/users/changjjjjjjj/followers\",\n      \"following_url\": \"https://api.github.com/users/changjjjjjjj/following{/other_user}\",\n      \"gists_url\": \"https://api.github.com/users/changjjjjjjj/gists{/gist_id}\",\n      \"starred_url\": \"https://api.github.com/users/changjjjjjjj/starred{/owner}{/repo}\",\n      \"subscriptions_url\": \"https://api.github.com/users/changjjjjjjj/subscriptions\",\n      \"organizations_url\": \"https://api.github.com/users/changjjjjjjj/orgs\",\n      \"repos_url\": \"https://api.github.com/users/changjjjjjjj/repos\",\n      \"events_url\": \"https://api.github.com/users/changjjjjjjj/events{/privacy}\",\n      \"received_events_url\": \"https://api.github.com/users/changjjjjjjj/received_events\",\n      \"type\": \"User\",\n      \"site_admin\": false\n    },\n    \"body\": \"\",\n    \"state\": \"COMMENTED\",\n    \"html_url\": \"https://github.com/tmax-cloud/cicd-operator/pull/324#pullrequestreview-834860063\",\n    \"pull_request_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/324\",\n    \"author_association\": \"COLLABORATOR\",\n    \"_links\": {\n      \"html\": {\n        \"href\": \"https://github.com/tmax-cloud/cicd-operator/pull/324#pullrequestreview-834860063\"\n      },\n      \"pull_request\": {\n        \"href\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/324\"\n      }\n    },\n    \"submitted_at\": \"2021-12-17T05:57:08Z\",\n    \"commit_id\": \"d3b2006b7a2ab28268b248429bc215854a497d24\"\n  },\n  {\n    \"id\": 834871251,\n    \"node_id\": \"PRR_kwDOEm6Tx84xwx_T\",\n    \"user\": {\n      \"login\": \"yxzzzxh\",\n      \"id\": 36444454,\n      \"node_id\": \"MDQ6VXNlcjM2NDQ0NDU0\",\n      \"avatar_url\": \"https://avatars.githubusercontent.com/u/36444454?u=bbc82e004d2e79434274c1fc4ac97c1d2b6f249e&v=4\",\n      \"gravatar_id\": \"\",\n      \"url\": \"https://api.github.com/users/yxzzzxh\",\n      \"html_url\": \"https://github.com/yxzzzxh\",\n      \"followers_url\": \"https://api.github.com/users/yxzzzxh/followers\",\n      \"following_url\": \"https://api.github.com/users/yxzzzxh/following{/other_user}\",\n      \"gists_url\": \"https://api.github.com/users/yxzzzxh/gists{/gist_id}\",\n      \"starred_url\": \"https://api.github.com/users/yxzzzxh/starred{/owner}{/repo}\",\n      \"subscriptions_url\": \"https://api.github.com/users/yxzzzxh/subscriptions\",\n      \"organizations_url\": \"https://api.github.com/users/yxzzzxh/orgs\",\n      \"repos_url\": \"https://api.github.com/users/yxzzzxh/repos\",\n      \"events_url\": \"https://api.github.com/users/yxzzzxh/events{/privacy}\",\n      \"received_events_url\": \"https://api.github.com/users/yxzzzxh/received_events\",\n      \"type\": \"User\",\n      \"site_admin\": false\n    },\n    \"body\": \"/approve\",\n    \"state\": \"COMMENTED\",\n    \"html_url\": \"https://github.com/tmax-cloud/cicd-operator/pull/324#pullrequestreview-834871251\",\n    \"pull_request_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/324\",\n    \"author_association\": \"CONTRIBUTOR\",\n    \"_links\": {\n      \"html\": {\n        \"href\": \"https://github.com/tmax-cloud/cicd-operator/pull/324#pullrequestreview-834871251\"\n      },\n      \"pull_request\": {\n        \"href\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/324\"\n      }\n    },\n    \"submitted_at\": \"2021-12-17T06:21:13Z\",\n    \"commit_id\": \"d3b2006b7a2ab28268b248429bc215854a497d24\"\n  }\n]"
+	samplePRReviews                      = "[\n  {\n    \"id\": 834849190,\n    \"node_id\": \"PRR_kwDOEm6Tx84xwsmm\",\n    \"user\": {\n      \"login\": \"eddy-kor-92\",\n      \"id\": 33279734,\n      \"node_id\": \"MDQ6VXNlcjMzMjc5NzM0\",\n      \"avatar_url\": \"https://avatars.githubusercontent.com/u/33279734?u=bed3bf0df30f21a34b1d88dac4bdea053d2edafa&v=4\",\n      \"gravatar_id\": \"\",\n      \"url\": \"https://api.github.com/users/eddy-kor-92\",\n      \"html_url\": \"https://github.com/eddy-kor-92\",\n      \"followers_url\": \"https://api.github.com/users/eddy-kor-92/followers\",\n      \"following_url\": \"https://api.github.com/users/eddy-kor-92/following{/other_user}\",\n      \"gists_url\": \"https://api.github.com/users/eddy-kor-92/gists{/gist_id}\",\n      \"starred_url\": \"https://api.github.com/users/eddy-kor-92/starred{/owner}{/repo}\",\n      \"subscriptions_url\": \"https://api.github.com/users/eddy-kor-92/subscriptions\",\n      \"organizations_url\": \"https://api.github.com/users/eddy-kor-92/orgs\",\n      \"repos_url\": \"https://api.github.com/users/eddy-kor-92/repos\",\n      \"events_url\": \"https://api.github.com/users/eddy-kor-92/events{/privacy}\",\n      \"received_events_url\": \"https://api.github.com/users/eddy-kor-92/received_events\",\n      \"type\": \"User\",\n      \"site_admin\": false\n    },\n    \"body\": \"\",\n    \"state\": \"COMMENTED\",\n    \"html_url\": \"https://github.com/tmax-cloud/cicd-operator/pull/324#pullrequestreview-834849190\",\n    \"pull_request_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/324\",\n    \"author_association\": \"NONE\",\n    \"_links\": {\n      \"html\": {\n        \"href\": \"https://github.com/tmax-cloud/cicd-operator/pull/324#pullrequestreview-834849190\"\n      },\n      \"pull_request\": {\n        \"href\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/324\"\n      }\n    },\n    \"submitted_at\": \"2021-12-17T05:31:38Z\",\n    \"commit_id\": \"654761e79f45e62ef8ca4d94c47cf7adc1756122\"\n  },\n  {\n    \"id\": 834851875,\n    \"node_id\": \"PRR_kwDOEm6Tx84xwtQj\",\n    \"user\": {\n      \"login\": \"changjjjjjjj\",\n      \"id\": 56624551,\n      \"node_id\": \"MDQ6VXNlcjU2NjI0NTUx\",\n      \"avatar_url\": \"https://avatars.githubusercontent.com/u/56624551?v=4\",\n      \"gravatar_id\": \"\",\n      \"url\": \"https://api.github.com/users/changjjjjjjj\",\n      \"html_url\": \"https://github.com/changjjjjjjj\",\n      \"followers_url\": \"https://api.github.com/users/changjjjjjjj/followers\",\n      \"following_url\": \"https://api.github.com/users/changjjjjjjj/following{/other_user}\",\n      \"gists_url\": \"https://api.github.com/users/changjjjjjjj/gists{/gist_id}\",\n      \"starred_url\": \"https://api.github.com/users/changjjjjjjj/starred{/owner}{/repo}\",\n      \"subscriptions_url\": \"https://api.github.com/users/changjjjjjjj/subscriptions\",\n      \"organizations_url\": \"https://api.github.com/users/changjjjjjjj/orgs\",\n      \"repos_url\": \"https://api.github.com/users/changjjjjjjj/repos\",\n      \"events_url\": \"https://api.github.com/users/changjjjjjjj/events{/privacy}\",\n      \"received_events_url\": \"https://api.github.com/users/changjjjjjjj/received_events\",\n      \"type\": \"User\",\n      \"site_admin\": false\n    },\n    \"body\": \"\",\n    \"state\": \"COMMENTED\",\n    \"html_url\": \"https://github.com/tmax-cloud/cicd-operator/pull/324#pullrequestreview-834851875\",\n    \"pull_request_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/324\",\n    \"author_association\": \"COLLABORATOR\",\n    \"_links\": {\n      \"html\": {\n        \"href\": \"https://github.com/tmax-cloud/cicd-operator/pull/324#pullrequestreview-834851875\"\n      },\n      \"pull_request\": {\n        \"href\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/324\"\n      }\n    },\n    \"submitted_at\": \"2021-12-17T05:36:07Z\",\n    \"commit_id\": \"654761e79f45e62ef8ca4d94c47cf7adc1756122\"\n  },\n  {\n    \"id\": 834860063,\n    \"node_id\": \"PRR_kwDOEm6Tx84xwvQf\",\n    \"user\": {\n      \"login\": \"changjjjjjjj\",\n      \"id\": 56624551,\n      \"node_id\": \"MDQ6VXNlcjU2NjI0NTUx\",\n      \"avatar_url\": \"https://avatars.githubusercontent.com/u/56624551?v=4\",\n      \"gravatar_id\": \"\",\n      \"url\": \"https://api.github.com/users/changjjjjjjj\",\n      \"html_url\": \"https://github.com/changjjjjjjj\",\n      \"followers_url\": \"https://api.github.com/users/changjjjjjjj/followers\",\n      \"following_url\": \"https://api.github.com/users/changjjjjjjj/following{/other_user}\",\n      \"gists_url\": \"https://api.github.com/users/changjjjjjjj/gists{/gist_id}\",\n      \"starred_url\": \"https://api.github.com/users/changjjjjjjj/starred{/owner}{/repo}\",\n      \"subscriptions_url\": \"https://api.github.com/users/changjjjjjjj/subscriptions\",\n      \"organizations_url\": \"https://api.github.com/users/changjjjjjjj/orgs\",\n      \"repos_url\": \"https://api.github.com/users/changjjjjjjj/repos\",\n      \"events_url\": \"https://api.github.com/users/changjjjjjjj/events{/privacy}\",\n      \"received_events_url\": \"https://api.github.com/users/changjjjjjjj/received_events\",\n      \"type\": \"User\",\n      \"site_admin\": false\n    },\n    \"body\": \"\",\n    \"state\": \"COMMENTED\",\n    \"html_url\": \"https://github.com/tmax-cloud/cicd-operator/pull/324#pullrequestreview-834860063\",\n    \"pull_request_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/324\",\n    \"author_association\": \"COLLABORATOR\",\n    \"_links\": {\n      \"html\": {\n        \"href\": \"https://github.com/tmax-cloud/cicd-operator/pull/324#pullrequestreview-834860063\"\n      },\n      \"pull_request\": {\n        \"href\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/324\"\n      }\n    },\n    \"submitted_at\": \"2021-12-17T05:57:08Z\",\n    \"commit_id\": \"d3b2006b7a2ab28268b248429bc215854a497d24\"\n  },\n  {\n    \"id\": 834871251,\n    \"node_id\": \"PRR_kwDOEm6Tx84xwx_T\",\n    \"user\": {\n      \"login\": \"yxzzzxh\",\n      \"id\": 36444454,\n      \"node_id\": \"MDQ6VXNlcjM2NDQ0NDU0\",\n      \"avatar_url\": \"https://avatars.githubusercontent.com/u/36444454?u=bbc82e004d2e79434274c1fc4ac97c1d2b6f249e&v=4\",\n      \"gravatar_id\": \"\",\n      \"url\": \"https://api.github.com/users/yxzzzxh\",\n      \"html_url\": \"https://github.com/yxzzzxh\",\n      \"followers_url\": \"https://api.github.com/users/yxzzzxh/followers\",\n      \"following_url\": \"https://api.github.com/users/yxzzzxh/following{/other_user}\",\n      \"gists_url\": \"https://api.github.com/users/yxzzzxh/gists{/gist_id}\",\n      \"starred_url\": \"https://api.github.com/users/yxzzzxh/starred{/owner}{/repo}\",\n      \"subscriptions_url\": \"https://api.github.com/users/yxzzzxh/subscriptions\",\n      \"organizations_url\": \"https://api.github.com/users/yxzzzxh/orgs\",\n      \"repos_url\": \"https://api.github.com/users/yxzzzxh/repos\",\n      \"events_url\": \"https://api.github.com/users/yxzzzxh/events{/privacy}\",\n      \"received_events_url\": \"https://api.github.com/users/yxzzzxh/received_events\",\n      \"type\": \"User\",\n      \"site_admin\": false\n    },\n    \"body\": \"/approve\",\n    \"state\": \"APPROVED\",\n    \"html_url\": \"https://github.com/tmax-cloud/cicd-operator/pull/324#pullrequestreview-834871251\",\n    \"pull_request_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/324\",\n    \"author_association\": \"CONTRIBUTOR\",\n    \"_links\": {\n      \"html\": {\n        \"href\": \"https://github.com/tmax-cloud/cicd-operator/pull/324#pullrequestreview-834871251\"\n      },\n      \"pull_request\": {\n        \"href\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls/324\"\n      }\n    },\n    \"submitted_at\": \"2021-12-17T06:21:13Z\",\n    \"commit_id\": \"d3b2006b7a2ab28268b248429bc215854a497d24\"\n  }\n]"
 	sampleIssueComments                  = "[\n  {\n    \"url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/issues/comments/996468306\",\n    \"html_url\": \"https://github.com/tmax-cloud/cicd-operator/pull/324#issuecomment-996468306\",\n    \"issue_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/issues/324\",\n    \"id\": 996468306,\n    \"node_id\": \"IC_kwDOEm6Tx847ZOZS\",\n    \"user\": {\n      \"login\": \"tmax-cloud-bot\",\n      \"id\": 76757421,\n      \"node_id\": \"MDQ6VXNlcjc2NzU3NDIx\",\n      \"avatar_url\": \"https://avatars.githubusercontent.com/u/76757421?v=4\",\n      \"gravatar_id\": \"\",\n      \"url\": \"https://api.github.com/users/tmax-cloud-bot\",\n      \"html_url\": \"https://github.com/tmax-cloud-bot\",\n      \"followers_url\": \"https://api.github.com/users/tmax-cloud-bot/followers\",\n      \"following_url\": \"https://api.github.com/users/tmax-cloud-bot/following{/other_user}\",\n      \"gists_url\": \"https://api.github.com/users/tmax-cloud-bot/gists{/gist_id}\",\n      \"starred_url\": \"https://api.github.com/users/tmax-cloud-bot/starred{/owner}{/repo}\",\n      \"subscriptions_url\": \"https://api.github.com/users/tmax-cloud-bot/subscriptions\",\n      \"organizations_url\": \"https://api.github.com/users/tmax-cloud-bot/orgs\",\n      \"repos_url\": \"https://api.github.com/users/tmax-cloud-bot/repos\",\n      \"events_url\": \"https://api.github.com/users/tmax-cloud-bot/events{/privacy}\",\n      \"received_events_url\": \"https://api.github.com/users/tmax-cloud-bot/received_events\",\n      \"type\": \"User\",\n      \"site_admin\": false\n    },\n    \"created_at\": \"2021-12-17T06:21:16Z\",\n    \"updated_at\": \"2021-12-17T06:21:16Z\",\n    \"author_association\": \"NONE\",\n    \"body\": \"[APPROVE ALERT]\\n\\nUser `yxzzzxh` approved this pull request!\",\n    \"reactions\": {\n      \"url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/issues/comments/996468306/reactions\",\n      \"total_count\": 0,\n      \"+1\": 0,\n      \"-1\": 0,\n      \"laugh\": 0,\n      \"hooray\": 0,\n      \"confused\": 0,\n      \"heart\": 0,\n      \"rocket\": 0,\n      \"eyes\": 0\n    },\n    \"performed_via_github_app\": null\n  }\n]"
 	sampleUserInfo                       = "{\"id\":123456,\"login\":\"changjjjjjjj\",\"email\":\"sample@email.com\"}"
 	samplePermissionTrue                 = "{\"permission\":\"admin\"}"
@@ -66,6 +66,8 @@ const (
 	sampleCommitCommentWebhook           = "{\n  \"action\": \"created\",\n  \"comment\": {\n    \"url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/comments/69331665\",\n    \"html_url\": \"https://github.com/tmax-cloud/cicd-operator/commit/a5cf330e39fdfdfcbb8a0341edd4bae38d49c47f#commitcomment-69331665\",\n    \"id\": 69331665,\n    \"node_id\": \"CC_kwDOEm6Tx84EIerR\",\n    \"user\": {\n      \"login\": \"changjjjjjjj\",\n      \"id\": 56624551,\n      \"node_id\": \"MDQ6VXNlcjU2NjI0NTUx\",\n      \"avatar_url\": \"https://avatars.githubusercontent.com/u/56624551?v=4\",\n      \"gravatar_id\": \"\",\n      \"url\": \"https://api.github.com/users/changjjjjjjj\",\n      \"html_url\": \"https://github.com/changjjjjjjj\",\n      \"followers_url\": \"https://api.github.com/users/changjjjjjjj/followers\",\n      \"following_url\": \"https://api.github.com/users/changjjjjjjj/following{/other_user}\",\n      \"gists_url\": \"https://api.github.com/users/changjjjjjjj/gists{/gist_id}\",\n      \"starred_url\": \"https://api.github.com/users/changjjjjjjj/starred{/owner}{/repo}\",\n      \"subscriptions_url\": \"https://api.github.com/users/changjjjjjjj/subscriptions\",\n      \"organizations_url\": \"https://api.github.com/users/changjjjjjjj/orgs\",\n      \"repos_url\": \"https://api.github.com/users/changjjjjjjj/repos\",\n      \"events_url\": \"https://api.github.com/users/changjjjjjjj/events{/privacy}\",\n      \"received_events_url\": \"https://api.github.com/users/changjjjjjjj/received_events\",\n      \"type\": \"User\",\n      \"site_admin\": false\n    },\n    \"position\": null,\n    \"line\": null,\n    \"path\": null,\n    \"commit_id\": \"a5cf330e39fdfdfcbb8a0341edd4bae38d49c47f\",\n    \"created_at\": \"2022-03-23T08:58:43Z\",\n    \"updated_at\": \"2022-03-23T08:58:43Z\",\n    \"author_association\": \"COLLABORATOR\",\n    \"body\": \"test\",\n    \"reactions\": {\n      \"url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/comments/69331665/reactions\",\n      \"total_count\": 0,\n      \"+1\": 0,\n      \"-1\": 0,\n      \"laugh\": 0,\n      \"hooray\": 0,\n      \"confused\": 0,\n      \"heart\": 0,\n      \"rocket\": 0,\n      \"eyes\": 0\n    }\n  },\n  \"repository\": {\n    \"id\": 309236679,\n    \"node_id\": \"MDEwOlJlcG9zaXRvcnkzMDkyMzY2Nzk=\",\n    \"name\": \"cicd-operator\",\n    \"full_name\": \"tmax-cloud/cicd-operator\",\n    \"private\": false,\n    \"owner\": {\n      \"login\": \"tmax-cloud\",\n      \"id\": 60682780,\n      \"node_id\": \"MDEyOk9yZ2FuaXphdGlvbjYwNjgyNzgw\",\n      \"avatar_url\": \"https://avatars.githubusercontent.com/u/60682780?v=4\",\n      \"gravatar_id\": \"\",\n      \"url\": \"https://api.github.com/users/tmax-cloud\",\n      \"html_url\": \"https://github.com/tmax-cloud\",\n      \"followers_url\": \"https://api.github.com/users/tmax-cloud/followers\",\n      \"following_url\": \"https://api.github.com/users/tmax-cloud/following{/other_user}\",\n      \"gists_url\": \"https://api.github.com/users/tmax-cloud/gists{/gist_id}\",\n      \"starred_url\": \"https://api.github.com/users/tmax-cloud/starred{/owner}{/repo}\",\n      \"subscriptions_url\": \"https://api.github.com/users/tmax-cloud/subscriptions\",\n      \"organizations_url\": \"https://api.github.com/users/tmax-cloud/orgs\",\n      \"repos_url\": \"https://api.github.com/users/tmax-cloud/repos\",\n      \"events_url\": \"https://api.github.com/users/tmax-cloud/events{/privacy}\",\n      \"received_events_url\": \"https://api.github.com/users/tmax-cloud/received_events\",\n      \"type\": \"Organization\",\n      \"site_admin\": false\n    },\n    \"html_url\": \"https://github.com/tmax-cloud/cicd-operator\",\n    \"description\": \"K8s-native CI/CD operator\",\n    \"fork\": false,\n    \"url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator\",\n    \"forks_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/forks\",\n    \"keys_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/keys{/key_id}\",\n    \"collaborators_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/collaborators{/collaborator}\",\n    \"teams_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/teams\",\n    \"hooks_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/hooks\",\n    \"issue_events_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/issues/events{/number}\",\n    \"events_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/events\",\n    \"assignees_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/assignees{/user}\",\n    \"branches_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/branches{/branch}\",\n    \"tags_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/tags\",\n    \"blobs_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/git/blobs{/sha}\",\n    \"git_tags_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/git/tags{/sha}\",\n    \"git_refs_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/git/refs{/sha}\",\n    \"trees_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/git/trees{/sha}\",\n    \"statuses_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/statuses/{sha}\",\n    \"languages_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/languages\",\n    \"stargazers_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/stargazers\",\n    \"contributors_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/contributors\",\n    \"subscribers_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/subscribers\",\n    \"subscription_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/subscription\",\n    \"commits_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/commits{/sha}\",\n    \"git_commits_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/git/commits{/sha}\",\n    \"comments_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/comments{/number}\",\n    \"issue_comment_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/issues/comments{/number}\",\n    \"contents_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/contents/{+path}\",\n    \"compare_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/compare/{base}...{head}\",\n    \"merges_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/merges\",\n    \"archive_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/{archive_format}{/ref}\",\n    \"downloads_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/downloads\",\n    \"issues_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/issues{/number}\",\n    \"pulls_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls{/number}\",\n    \"milestones_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/milestones{/number}\",\n    \"notifications_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/notifications{?since,all,participating}\",\n    \"labels_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/labels{/name}\",\n    \"releases_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/releases{/id}\",\n    \"deployments_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/deployments\",\n    \"created_at\": \"2020-11-02T02:27:33Z\",\n    \"updated_at\": \"2022-03-16T14:10:56Z\",\n    \"pushed_at\": \"2022-03-23T08:54:18Z\",\n    \"git_url\": \"git://github.com/tmax-cloud/cicd-operator.git\",\n    \"ssh_url\": \"git@github.com:tmax-cloud/cicd-operator.git\",\n    \"clone_url\": \"https://github.com/tmax-cloud/cicd-operator.git\",\n    \"svn_url\": \"https://github.com/tmax-cloud/cicd-operator\",\n    \"homepage\": \"\",\n    \"size\": 1870,\n    \"stargazers_count\": 13,\n    \"watchers_count\": 13,\n    \"language\": \"Go\",\n    \"has_issues\": true,\n    \"has_projects\": true,\n    \"has_downloads\": true,\n    \"has_wiki\": true,\n    \"has_pages\": false,\n    \"forks_count\": 4,\n    \"mirror_url\": null,\n    \"archived\": false,\n    \"disabled\": false,\n    \"open_issues_count\": 7,\n    \"license\": {\n      \"key\": \"apache-2.0\",\n      \"name\": \"Apache License 2.0\",\n      \"spdx_id\": \"Apache-2.0\",\n      \"url\": \"https://api.github.com/licenses/apache-2.0\",\n      \"node_id\": \"MDc6TGljZW5zZTI=\"\n    },\n    \"allow_forking\": true,\n    \"is_template\": false,\n    \"topics\": [\n\n    ],\n    \"visibility\": \"public\",\n    \"forks\": 4,\n    \"open_issues\": 7,\n    \"watchers\": 13,\n    \"default_branch\": \"master\"\n  },\n  \"organization\": {\n    \"login\": \"tmax-cloud\",\n    \"id\": 60682780,\n    \"node_id\": \"MDEyOk9yZ2FuaXphdGlvbjYwNjgyNzgw\",\n    \"url\": \"https://api.github.com/orgs/tmax-cloud\",\n    \"repos_url\": \"https://api.github.com/orgs/tmax-cloud/repos\",\n    \"events_url\": \"https://api.github.com/orgs/tmax-cloud/events\",\n    \"hooks_url\": \"https://api.github.com/orgs/tmax-cloud/hooks\",\n    \"issues_url\": \"https://api.github.com/orgs/tmax-cloud/issues\",\n    \"members_url\": \"https://api.github.com/orgs/tmax-cloud/members{/member}\",\n    \"public_members_url\": \"https://api.github.com/orgs/tmax-cloud/public_members{/member}\",\n    \"avatar_url\": \"https://avatars.githubusercontent.com/u/60682780?v=4\",\n    \"description\": \"\"\n  },\n  \"sender\": {\n    \"login\": \"changjjjjjjj\",\n    \"id\": 56624551,\n    \"node_id\": \"MDQ6VXNlcjU2NjI0NTUx\",\n    \"avatar_url\": \"https://avatars.githubusercontent.com/u/56624551?v=4\",\n    \"gravatar_id\": \"\",\n    \"url\": \"https://api.github.com/users/changjjjjjjj\",\n    \"html_url\": \"https://github.com/changjjjjjjj\",\n    \"followers_url\": \"https://api.github.com/users/changjjjjjjj/followers\",\n    \"following_url\": \"https://api.github.com/users/changjjjjjjj/following{/other_user}\",\n    \"gists_url\": \"https://api.github.com/users/changjjjjjjj/gists{/gist_id}\",\n    \"starred_url\": \"https://api.github.com/users/changjjjjjjj/starred{/owner}{/repo}\",\n    \"subscriptions_url\": \"https://api.github.com/users/changjjjjjjj/subscriptions\",\n    \"organizations_url\": \"https://api.github.com/users/changjjjjjjj/orgs\",\n    \"repos_url\": \"https://api.github.com/users/changjjjjjjj/repos\",\n    \"events_url\": \"https://api.github.com/users/changjjjjjjj/events{/privacy}\",\n    \"received_events_url\": \"https://api.github.com/users/changjjjjjjj/received_events\",\n    \"type\": \"User\",\n    \"site_admin\": false\n  }\n}"
 	sampleCommitCommentWebhookNotCreated = "{\n  \"action\": \"submitted\",\n  \"comment\": {\n    \"url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/comments/69331665\",\n    \"html_url\": \"https://github.com/tmax-cloud/cicd-operator/commit/a5cf330e39fdfdfcbb8a0341edd4bae38d49c47f#commitcomment-69331665\",\n    \"id\": 69331665,\n    \"node_id\": \"CC_kwDOEm6Tx84EIerR\",\n    \"user\": {\n      \"login\": \"changjjjjjjj\",\n      \"id\": 56624551,\n      \"node_id\": \"MDQ6VXNlcjU2NjI0NTUx\",\n      \"avatar_url\": \"https://avatars.githubusercontent.com/u/56624551?v=4\",\n      \"gravatar_id\": \"\",\n      \"url\": \"https://api.github.com/users/changjjjjjjj\",\n      \"html_url\": \"https://github.com/changjjjjjjj\",\n      \"followers_url\": \"https://api.github.com/users/changjjjjjjj/followers\",\n      \"following_url\": \"https://api.github.com/users/changjjjjjjj/following{/other_user}\",\n      \"gists_url\": \"https://api.github.com/users/changjjjjjjj/gists{/gist_id}\",\n      \"starred_url\": \"https://api.github.com/users/changjjjjjjj/starred{/owner}{/repo}\",\n      \"subscriptions_url\": \"https://api.github.com/users/changjjjjjjj/subscriptions\",\n      \"organizations_url\": \"https://api.github.com/users/changjjjjjjj/orgs\",\n      \"repos_url\": \"https://api.github.com/users/changjjjjjjj/repos\",\n      \"events_url\": \"https://api.github.com/users/changjjjjjjj/events{/privacy}\",\n      \"received_events_url\": \"https://api.github.com/users/changjjjjjjj/received_events\",\n      \"type\": \"User\",\n      \"site_admin\": false\n    },\n    \"position\": null,\n    \"line\": null,\n    \"path\": null,\n    \"commit_id\": \"a5cf330e39fdfdfcbb8a0341edd4bae38d49c47f\",\n    \"created_at\": \"2022-03-23T08:58:43Z\",\n    \"updated_at\": \"2022-03-23T08:58:43Z\",\n    \"author_association\": \"COLLABORATOR\",\n    \"body\": \"test\",\n    \"reactions\": {\n      \"url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/comments/69331665/reactions\",\n      \"total_count\": 0,\n      \"+1\": 0,\n      \"-1\": 0,\n      \"laugh\": 0,\n      \"hooray\": 0,\n      \"confused\": 0,\n      \"heart\": 0,\n      \"rocket\": 0,\n      \"eyes\": 0\n    }\n  },\n  \"repository\": {\n    \"id\": 309236679,\n    \"node_id\": \"MDEwOlJlcG9zaXRvcnkzMDkyMzY2Nzk=\",\n    \"name\": \"cicd-operator\",\n    \"full_name\": \"tmax-cloud/cicd-operator\",\n    \"private\": false,\n    \"owner\": {\n      \"login\": \"tmax-cloud\",\n      \"id\": 60682780,\n      \"node_id\": \"MDEyOk9yZ2FuaXphdGlvbjYwNjgyNzgw\",\n      \"avatar_url\": \"https://avatars.githubusercontent.com/u/60682780?v=4\",\n      \"gravatar_id\": \"\",\n      \"url\": \"https://api.github.com/users/tmax-cloud\",\n      \"html_url\": \"https://github.com/tmax-cloud\",\n      \"followers_url\": \"https://api.github.com/users/tmax-cloud/followers\",\n      \"following_url\": \"https://api.github.com/users/tmax-cloud/following{/other_user}\",\n      \"gists_url\": \"https://api.github.com/users/tmax-cloud/gists{/gist_id}\",\n      \"starred_url\": \"https://api.github.com/users/tmax-cloud/starred{/owner}{/repo}\",\n      \"subscriptions_url\": \"https://api.github.com/users/tmax-cloud/subscriptions\",\n      \"organizations_url\": \"https://api.github.com/users/tmax-cloud/orgs\",\n      \"repos_url\": \"https://api.github.com/users/tmax-cloud/repos\",\n      \"events_url\": \"https://api.github.com/users/tmax-cloud/events{/privacy}\",\n      \"received_events_url\": \"https://api.github.com/users/tmax-cloud/received_events\",\n      \"type\": \"Organization\",\n      \"site_admin\": false\n    },\n    \"html_url\": \"https://github.com/tmax-cloud/cicd-operator\",\n    \"description\": \"K8s-native CI/CD operator\",\n    \"fork\": false,\n    \"url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator\",\n    \"forks_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/forks\",\n    \"keys_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/keys{/key_id}\",\n    \"collaborators_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/collaborators{/collaborator}\",\n    \"teams_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/teams\",\n    \"hooks_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/hooks\",\n    \"issue_events_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/issues/events{/number}\",\n    \"events_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/events\",\n    \"assignees_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/assignees{/user}\",\n    \"branches_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/branches{/branch}\",\n    \"tags_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/tags\",\n    \"blobs_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/git/blobs{/sha}\",\n    \"git_tags_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/git/tags{/sha}\",\n    \"git_refs_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/git/refs{/sha}\",\n    \"trees_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/git/trees{/sha}\",\n    \"statuses_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/statuses/{sha}\",\n    \"languages_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/languages\",\n    \"stargazers_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/stargazers\",\n    \"contributors_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/contributors\",\n    \"subscribers_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/subscribers\",\n    \"subscription_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/subscription\",\n    \"commits_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/commits{/sha}\",\n    \"git_commits_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/git/commits{/sha}\",\n    \"comments_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/comments{/number}\",\n    \"issue_comment_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/issues/comments{/number}\",\n    \"contents_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/contents/{+path}\",\n    \"compare_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/compare/{base}...{head}\",\n    \"merges_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/merges\",\n    \"archive_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/{archive_format}{/ref}\",\n    \"downloads_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/downloads\",\n    \"issues_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/issues{/number}\",\n    \"pulls_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls{/number}\",\n    \"milestones_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/milestones{/number}\",\n    \"notifications_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/notifications{?since,all,participating}\",\n    \"labels_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/labels{/name}\",\n    \"releases_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/releases{/id}\",\n    \"deployments_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/deployments\",\n    \"created_at\": \"2020-11-02T02:27:33Z\",\n    \"updated_at\": \"2022-03-16T14:10:56Z\",\n    \"pushed_at\": \"2022-03-23T08:54:18Z\",\n    \"git_url\": \"git://github.com/tmax-cloud/cicd-operator.git\",\n    \"ssh_url\": \"git@github.com:tmax-cloud/cicd-operator.git\",\n    \"clone_url\": \"https://github.com/tmax-cloud/cicd-operator.git\",\n    \"svn_url\": \"https://github.com/tmax-cloud/cicd-operator\",\n    \"homepage\": \"\",\n    \"size\": 1870,\n    \"stargazers_count\": 13,\n    \"watchers_count\": 13,\n    \"language\": \"Go\",\n    \"has_issues\": true,\n    \"has_projects\": true,\n    \"has_downloads\": true,\n    \"has_wiki\": true,\n    \"has_pages\": false,\n    \"forks_count\": 4,\n    \"mirror_url\": null,\n    \"archived\": false,\n    \"disabled\": false,\n    \"open_issues_count\": 7,\n    \"license\": {\n      \"key\": \"apache-2.0\",\n      \"name\": \"Apache License 2.0\",\n      \"spdx_id\": \"Apache-2.0\",\n      \"url\": \"https://api.github.com/licenses/apache-2.0\",\n      \"node_id\": \"MDc6TGljZW5zZTI=\"\n    },\n    \"allow_forking\": true,\n    \"is_template\": false,\n    \"topics\": [\n\n    ],\n    \"visibility\": \"public\",\n    \"forks\": 4,\n    \"open_issues\": 7,\n    \"watchers\": 13,\n    \"default_branch\": \"master\"\n  },\n  \"organization\": {\n    \"login\": \"tmax-cloud\",\n    \"id\": 60682780,\n    \"node_id\": \"MDEyOk9yZ2FuaXphdGlvbjYwNjgyNzgw\",\n    \"url\": \"https://api.github.com/orgs/tmax-cloud\",\n    \"repos_url\": \"https://api.github.com/orgs/tmax-cloud/repos\",\n    \"events_url\": \"https://api.github.com/orgs/tmax-cloud/events\",\n    \"hooks_url\": \"https://api.github.com/orgs/tmax-cloud/hooks\",\n    \"issues_url\": \"https://api.github.com/orgs/tmax-cloud/issues\",\n    \"members_url\": \"https://api.github.com/orgs/tmax-cloud/members{/member}\",\n    \"public_members_url\": \"https://api.github.com/orgs/tmax-cloud/public_members{/member}\",\n    \"avatar_url\": \"https://avatars.githubusercontent.com/u/60682780?v=4\",\n    \"description\": \"\"\n  },\n  \"sender\": {\n    \"login\": \"changjjjjjjj\",\n    \"id\": 56624551,\n    \"node_id\": \"MDQ6VXNlcjU2NjI0NTUx\",\n    \"avatar_url\": \"https://avatars.githubusercontent.com/u/56624551?v=4\",\n    \"gravatar_id\": \"\",\n    \"url\": \"https://api.github.com/users/changjjjjjjj\",\n    \"html_url\": \"https://github.com/changjjjjjjj\",\n    \"followers_url\": \"https://api.github.com/users/changjjjjjjj/followers\",\n    \"following_url\": \"https://api.github.com/users/changjjjjjjj/following{/other_user}\",\n    \"gists_url\": \"https://api.github.com/users/changjjjjjjj/gists{/gist_id}\",\n    \"starred_url\": \"https://api.github.com/users/changjjjjjjj/starred{/owner}{/repo}\",\n    \"subscriptions_url\": \"https://api.github.com/users/changjjjjjjj/subscriptions\",\n    \"organizations_url\": \"https://api.github.com/users/changjjjjjjj/orgs\",\n    \"repos_url\": \"https://api.github.com/users/changjjjjjjj/repos\",\n    \"events_url\": \"https://api.github.com/users/changjjjjjjj/events{/privacy}\",\n    \"received_events_url\": \"https://api.github.com/users/changjjjjjjj/received_events\",\n    \"type\": \"User\",\n    \"site_admin\": false\n  }\n}"
 	sampleCommitCommentWebhookMarshalErr = "{\n  \"action\": 123,\n  \"comment\": {\n    \"url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/comments/69331665\",\n    \"html_url\": \"https://github.com/tmax-cloud/cicd-operator/commit/a5cf330e39fdfdfcbb8a0341edd4bae38d49c47f#commitcomment-69331665\",\n    \"id\": \"69331665\",\n    \"node_id\": \"CC_kwDOEm6Tx84EIerR\",\n    \"user\": {\n      \"login\": \"changjjjjjjj\",\n      \"id\": 56624551,\n      \"node_id\": \"MDQ6VXNlcjU2NjI0NTUx\",\n      \"avatar_url\": \"https://avatars.githubusercontent.com/u/56624551?v=4\",\n      \"gravatar_id\": \"\",\n      \"url\": \"https://api.github.com/users/changjjjjjjj\",\n      \"html_url\": \"https://github.com/changjjjjjjj\",\n      \"followers_url\": \"https://api.github.com/users/changjjjjjjj/followers\",\n      \"following_url\": \"https://api.github.com/users/changjjjjjjj/following{/other_user}\",\n      \"gists_url\": \"https://api.github.com/users/changjjjjjjj/gists{/gist_id}\",\n      \"starred_url\": \"https://api.github.com/users/changjjjjjjj/starred{/owner}{/repo}\",\n      \"subscriptions_url\": \"https://api.github.com/users/changjjjjjjj/subscriptions\",\n      \"organizations_url\": \"https://api.github.com/users/changjjjjjjj/orgs\",\n      \"repos_url\": \"https://api.github.com/users/changjjjjjjj/repos\",\n      \"events_url\": \"https://api.github.com/users/changjjjjjjj/events{/privacy}\",\n      \"received_events_url\": \"https://api.github.com/users/changjjjjjjj/received_events\",\n      \"type\": \"User\",\n      \"site_admin\": false\n    },\n    \"position\": null,\n    \"line\": null,\n    \"path\": null,\n    \"commit_id\": \"a5cf330e39fdfdfcbb8a0341edd4bae38d49c47f\",\n    \"created_at\": \"2022-03-23T08:58:43Z\",\n    \"updated_at\": \"2022-03-23T08:58:43Z\",\n    \"author_association\": \"COLLABORATOR\",\n    \"body\": \"test\",\n    \"reactions\": {\n      \"url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/comments/69331665/reactions\",\n      \"total_count\": 0,\n      \"+1\": 0,\n      \"-1\": 0,\n      \"laugh\": 0,\n      \"hooray\": 0,\n      \"confused\": 0,\n      \"heart\": 0,\n      \"rocket\": 0,\n      \"eyes\": 0\n    }\n  },\n  \"repository\": {\n    \"id\": 309236679,\n    \"node_id\": \"MDEwOlJlcG9zaXRvcnkzMDkyMzY2Nzk=\",\n    \"name\": \"cicd-operator\",\n    \"full_name\": \"tmax-cloud/cicd-operator\",\n    \"private\": false,\n    \"owner\": {\n      \"login\": \"tmax-cloud\",\n      \"id\": 60682780,\n      \"node_id\": \"MDEyOk9yZ2FuaXphdGlvbjYwNjgyNzgw\",\n      \"avatar_url\": \"https://avatars.githubusercontent.com/u/60682780?v=4\",\n      \"gravatar_id\": \"\",\n      \"url\": \"https://api.github.com/users/tmax-cloud\",\n      \"html_url\": \"https://github.com/tmax-cloud\",\n      \"followers_url\": \"https://api.github.com/users/tmax-cloud/followers\",\n      \"following_url\": \"https://api.github.com/users/tmax-cloud/following{/other_user}\",\n      \"gists_url\": \"https://api.github.com/users/tmax-cloud/gists{/gist_id}\",\n      \"starred_url\": \"https://api.github.com/users/tmax-cloud/starred{/owner}{/repo}\",\n      \"subscriptions_url\": \"https://api.github.com/users/tmax-cloud/subscriptions\",\n      \"organizations_url\": \"https://api.github.com/users/tmax-cloud/orgs\",\n      \"repos_url\": \"https://api.github.com/users/tmax-cloud/repos\",\n      \"events_url\": \"https://api.github.com/users/tmax-cloud/events{/privacy}\",\n      \"received_events_url\": \"https://api.github.com/users/tmax-cloud/received_events\",\n      \"type\": \"Organization\",\n      \"site_admin\": false\n    },\n    \"html_url\": \"https://github.com/tmax-cloud/cicd-operator\",\n    \"description\": \"K8s-native CI/CD operator\",\n    \"fork\": false,\n    \"url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator\",\n    \"forks_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/forks\",\n    \"keys_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/keys{/key_id}\",\n    \"collaborators_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/collaborators{/collaborator}\",\n    \"teams_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/teams\",\n    \"hooks_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/hooks\",\n    \"issue_events_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/issues/events{/number}\",\n    \"events_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/events\",\n    \"assignees_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/assignees{/user}\",\n    \"branches_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/branches{/branch}\",\n    \"tags_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/tags\",\n    \"blobs_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/git/blobs{/sha}\",\n    \"git_tags_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/git/tags{/sha}\",\n    \"git_refs_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/git/refs{/sha}\",\n    \"trees_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/git/trees{/sha}\",\n    \"statuses_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/statuses/{sha}\",\n    \"languages_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/languages\",\n    \"stargazers_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/stargazers\",\n    \"contributors_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/contributors\",\n    \"subscribers_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/subscribers\",\n    \"subscription_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/subscription\",\n    \"commits_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/commits{/sha}\",\n    \"git_commits_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/git/commits{/sha}\",\n    \"comments_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/comments{/number}\",\n    \"issue_comment_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/issues/comments{/number}\",\n    \"contents_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/contents/{+path}\",\n    \"compare_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/compare/{base}...{head}\",\n    \"merges_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/merges\",\n    \"archive_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/{archive_format}{/ref}\",\n    \"downloads_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/downloads\",\n    \"issues_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/issues{/number}\",\n    \"pulls_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/pulls{/number}\",\n    \"milestones_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/milestones{/number}\",\n    \"notifications_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/notifications{?since,all,participating}\",\n    \"labels_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/labels{/name}\",\n    \"releases_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/releases{/id}\",\n    \"deployments_url\": \"https://api.github.com/repos/tmax-cloud/cicd-operator/deployments\",\n    \"created_at\": \"2020-11-02T02:27:33Z\",\n    \"updated_at\": \"2022-03-16T14:10:56Z\",\n    \"pushed_at\": \"2022-03-23T08:54:18Z\",\n    \"git_url\": \"git://github.com/tmax-cloud/cicd-operator.git\",\n    \"ssh_url\": \"git@github.com:tmax-cloud/cicd-operator.git\",\n    \"clone_url\": \"https://github.com/tmax-cloud/cicd-operator.git\",\n    \"svn_url\": \"https://github.com/tmax-cloud/cicd-operator\",\n    \"homepage\": \"\",\n    \"size\": 1870,\n    \"stargazers_count\": 13,\n    \"watchers_count\": 13,\n    \"language\": \"Go\",\n    \"has_issues\": true,\n    \"has_projects\": true,\n    \"has_downloads\": true,\n    \"has_wiki\": true,\n    \"has_pages\": false,\n    \"forks_count\": 4,\n    \"mirror_url\": null,\n    \"archived\": false,\n    \"disabled\": false,\n    \"open_issues_count\": 7,\n    \"license\": {\n      \"key\": \"apache-2.0\",\n      \"name\": \"Apache License 2.0\",\n      \"spdx_id\": \"Apache-2.0\",\n      \"url\": \"https://api.github.com/licenses/apache-2.0\",\n      \"node_id\": \"MDc6TGljZW5zZTI=\"\n    },\n    \"allow_forking\": true,\n    \"is_template\": false,\n    \"topics\": [\n\n    ],\n    \"visibility\": \"public\",\n    \"forks\": 4,\n    \"open_issues\": 7,\n    \"watchers\": 13,\n    \"default_branch\": \"master\"\n  },\n  \"organization\": {\n    \"login\": \"tmax-cloud\",\n    \"id\": 60682780,\n    \"node_id\": \"MDEyOk9yZ2FuaXphdGlvbjYwNjgyNzgw\",\n    \"url\": \"https://api.github.com/orgs/tmax-cloud\",\n    \"repos_url\": \"https://api.github.com/orgs/tmax-cloud/repos\",\n    \"events_url\": \"https://api.github.com/orgs/tmax-cloud/events\",\n    \"hooks_url\": \"https://api.github.com/orgs/tmax-cloud/hooks\",\n    \"issues_url\": \"https://api.github.com/orgs/tmax-cloud/issues\",\n    \"members_url\": \"https://api.github.com/orgs/tmax-cloud/members{/member}\",\n    \"public_members_url\": \"https://api.github.com/orgs/tmax-cloud/public_members{/member}\",\n    \"avatar_url\": \"https://avatars.githubusercontent.com/u/60682780?v=4\",\n    \"description\": \"\"\n  },\n  \"sender\": {\n    \"login\": \"changjjjjjjj\",\n    \"id\": 56624551,\n    \"node_id\": \"MDQ6VXNlcjU2NjI0NTUx\",\n    \"avatar_url\": \"https://avatars.githubusercontent.com/u/56624551?v=4\",\n    \"gravatar_id\": \"\",\n    \"url\": \"https://api.github.com/users/changjjjjjjj\",\n    \"html_url\": \"https://github.com/changjjjjjjj\",\n    \"followers_url\": \"https://api.github.com/users/changjjjjjjj/followers\",\n    \"following_url\": \"https://api.github.com/users/changjjjjjjj/following{/other_user}\",\n    \"gists_url\": \"https://api.github.com/users/changjjjjjjj/gists{/gist_id}\",\n    \"starred_url\": \"https://api.github.com/users/changjjjjjjj/starred{/owner}{/repo}\",\n    \"subscriptions_url\": \"https://api.github.com/users/changjjjjjjj/subscriptions\",\n    \"organizations_url\": \"https://api.github.com/users/changjjjjjjj/orgs\",\n    \"repos_url\": \"https://api.github.com/users/changjjjjjjj/repos\",\n    \"events_url\": \"https://api.github.com/users/changjjjjjjj/events{/privacy}\",\n    \"received_events_url\": \"https://api.github.com/users/changjjjjjjj/received_events\",\n    \"type\": \"User\",\n    \"site_admin\": false\n  }\n}"
+	sampleStatusWebhook                  = "{\"sha\":\"bfa929712952e60d5ad5d3b73376f6ba392f8b50\",\"context\":\"test-1\",\"state\":\"success\",\"description\":\"Job is successful\",\"target_url\":\"https://test\",\"repository\":{\"full_name\":\"name\",\"html_url\":\"https://test\",\"owner\":{\"login\":\"changjjjjjjj\"},\"private\":false},\"sender\":{\"login\":\"changjjjjjjj\",\"id\":111111}}"
+	sampleStatusWebhookMarshalErr        = "{\"sha\":123,\"context\":\"test-1\",\"state\":\"success\",\"description\":\"Job is successful\",\"target_url\":\"https://test\",\"repository\":{\"full_name\":\"name\",\"html_url\":\"https://test\",\"owner\":{\"login\":\"changjjjjjjj\"},\"private\":false},\"sender\":{\"login\":\"changjjjjjjj\",\"id\":111111}}"
 )
 
 // "sender":{"login":"changjjjjjjj","id":111111},
@@ -244,6 +246,19 @@ func TestClient_ParseWebhook(t *testing.T) {
 			event:         git.EventTypeCommitComment,
 			jsonString:    []byte(sampleCommitCommentWebhookMarshalErr),
 
+			expectedErr:    true,
+			expectedErrMsg: "json: cannot unmarshal",
+		},
+		"status": {
+			xHubSignature: "sha1=c82b77c5d64a0ca27f5fc89d36934e5469b12b3e",
+			event:         git.EventTypeStatus,
+			jsonString:    []byte(sampleStatusWebhook),
+		},
+		"statusMarshalErr": {
+			xHubSignature: "sha1=8a8525fb0cd64eb8fc6e6d4e2e7b300e23dc8585",
+			event:         git.EventTypeStatus,
+			jsonString:    []byte(sampleStatusWebhookMarshalErr),
+
 			expectedErr:    true,
 			expectedErrMsg: "json: cannot unmarshal",
 		},
@@ -422,6 +437,56 @@ func TestClient_CanUserWriteToRepo(t *testing.T) {
 	}
 }
 
+func TestClient_IsUserRepoAdmin(t *testing.T) {
+	tc := map[string]struct {
+		user git.User
+
+		expectedAdmin  bool
+		expectErr      bool
+		expectedErrMsg string
+	}{
+		"adminUser": {user: git.User{Name: "changjjjjjjj"}, expectedAdmin: true},
+		"devUser":   {user: git.User{Name: "developer"}, expectedAdmin: false},
+		"noUser":    {user: git.User{Name: "whoru"}, expectErr: true, expectedErrMsg: "doesn't exists"},
+	}
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			cli, _ := testEnv()
+			admin, err := cli.IsUserRepoAdmin(c.user)
+			if c.expectErr {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), c.expectedErrMsg)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, c.expectedAdmin, admin)
+			}
+		})
+	}
+}
+
+func TestClient_IsUserInGroup(t *testing.T) {
+	tc := map[string]struct {
+		user  git.User
+		group string
+
+		expectedMember bool
+	}{
+		"orgMember":     {user: git.User{Name: "changjjjjjjj"}, group: "tmax-cloud", expectedMember: true},
+		"orgNotMember":  {user: git.User{Name: "whoru"}, group: "tmax-cloud", expectedMember: false},
+		"teamMember":    {user: git.User{Name: "changjjjjjjj"}, group: "tmax-cloud/cicd", expectedMember: true},
+		"teamPending":   {user: git.User{Name: "developer"}, group: "tmax-cloud/cicd", expectedMember: false},
+		"teamNotMember": {user: git.User{Name: "whoru"}, group: "tmax-cloud/cicd", expectedMember: false},
+	}
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			cli, _ := testEnv()
+			member, err := cli.IsUserInGroup(c.user, c.group)
+			require.NoError(t, err)
+			require.Equal(t, c.expectedMember, member)
+		})
+	}
+}
+
 func TestClient_RegisterComment(t *testing.T) {
 	tc := map[string]struct {
 		issueType git.IssueType
@@ -454,6 +519,43 @@ func TestClient_RegisterComment(t *testing.T) {
 	}
 }
 
+func TestClient_EditComment(t *testing.T) {
+	tc := map[string]struct {
+		issueType git.IssueType
+		commentID int
+
+		expectErr      bool
+		expectedErrMsg string
+	}{
+		"prComment": {
+			issueType: git.IssueTypePullRequest,
+			commentID: 1,
+		},
+		"commitComment": {
+			issueType: git.IssueTypeCommit,
+			commentID: 1,
+		},
+		"notFound": {
+			issueType:      git.IssueTypePullRequest,
+			commentID:      2,
+			expectErr:      true,
+			expectedErrMsg: "404",
+		},
+	}
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			cli, _ := testEnv()
+			err := cli.EditComment(c.issueType, 1, c.commentID, "test body")
+			if c.expectErr {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), c.expectedErrMsg)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestClient_ListCommitStatuses(t *testing.T) {
 	c, err := testEnv()
 	if err != nil {
@@ -480,6 +582,10 @@ func TestClient_ListComments(t *testing.T) {
 	comments, err := c.ListComments(5)
 	require.NoError(t, err)
 	require.Len(t, comments, 9)
+
+	// Review states are upper-case in GitHub
+	require.Equal(t, git.PullRequestReviewStateCommented, comments[5].ReviewState)
+	require.Equal(t, git.PullRequestReviewStateApproved, comments[8].ReviewState)
 }
 
 func TestClient_ListPullRequests(t *testing.T) {
@@ -531,6 +637,36 @@ func TestClient_MergePullRequest(t *testing.T) {
 	}
 }
 
+func TestClient_MergePullRequestFastForward(t *testing.T) {
+	cli, err := testEnv()
+	require.NoError(t, err)
+
+	require.NoError(t, cli.MergePullRequest(771113606, "sha1=11111111111111", git.MergeMethodFastForward, ""))
+
+	err = cli.MergePullRequest(771113606, git.FakeSha, git.MergeMethodFastForward, "")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not "+git.FakeSha)
+}
+
+func TestClient_UpdatePullRequestBranch(t *testing.T) {
+	cli, err := testEnv()
+	require.NoError(t, err)
+
+	require.NoError(t, cli.UpdatePullRequestBranch(1, git.FakeSha))
+
+	err = cli.UpdatePullRequestBranch(2, git.FakeSha)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "expected head sha")
+}
+
+func TestClient_UpdatePullRequestBase(t *testing.T) {
+	cli, err := testEnv()
+	require.NoError(t, err)
+
+	require.NoError(t, cli.UpdatePullRequestBase(1, "master"))
+	require.Error(t, cli.UpdatePullRequestBase(2, "master"))
+}
+
 func TestClient_GetPullRequestDiff(t *testing.T) {
 	c, err := testEnv()
 	if err != nil {
@@ -647,6 +783,52 @@ func TestClient_DeleteLabel(t *testing.T) {
 	}
 }
 
+func TestClient_Assignees(t *testing.T) {
+	tc := map[string]struct {
+		users []string
+
+		expectErr bool
+	}{
+		"success": {users: []string{"cqbqdd11519"}},
+		"noUser":  {expectErr: true},
+	}
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			cli, _ := testEnv()
+			for _, err := range []error{cli.AddAssignees(1, c.users), cli.RemoveAssignees(1, c.users)} {
+				if c.expectErr {
+					require.Error(t, err)
+				} else {
+					require.NoError(t, err)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_ReviewRequests(t *testing.T) {
+	tc := map[string]struct {
+		users []string
+
+		expectErr bool
+	}{
+		"success": {users: []string{"cqbqdd11519"}},
+		"noUser":  {expectErr: true},
+	}
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			cli, _ := testEnv()
+			for _, err := range []error{cli.RequestReviews(1, c.users), cli.CancelReviewRequests(1, c.users)} {
+				if c.expectErr {
+					require.Error(t, err)
+				} else {
+					require.NoError(t, err)
+				}
+			}
+		})
+	}
+}
+
 func TestClient_GetBranch(t *testing.T) {
 	tc := map[string]struct {
 		branch string
@@ -682,6 +864,59 @@ func TestClient_GetBranch(t *testing.T) {
 	}
 }
 
+func TestClient_ListPullRequestReviews(t *testing.T) {
+	c, err := testEnv()
+	require.NoError(t, err)
+
+	reviews, err := c.ListPullRequestReviews(324)
+	require.NoError(t, err)
+	require.Len(t, reviews, 4)
+	require.Equal(t, 834849190, reviews[0].ID)
+	require.Equal(t, "eddy-kor-92", reviews[0].Author.Name)
+	require.Equal(t, 33279734, reviews[0].Author.ID)
+	require.Equal(t, git.PullRequestReviewStateCommented, reviews[0].State)
+	require.Equal(t, "654761e79f45e62ef8ca4d94c47cf7adc1756122", reviews[0].Sha)
+}
+
+func TestClient_GetFileContent(t *testing.T) {
+	tc := map[string]struct {
+		ref  string
+		path string
+
+		expectedContent string
+		expectedErr     bool
+		notFound        bool
+	}{
+		"normal": {
+			ref:             "master",
+			path:            ".github/CODEOWNERS",
+			expectedContent: "* @cqbqdd11519\n",
+		},
+		"notFound": {
+			ref:         "master",
+			path:        "CODEOWNERS",
+			expectedErr: true,
+			notFound:    true,
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			cli, err := testEnv()
+			require.NoError(t, err)
+
+			content, err := cli.GetFileContent(c.ref, c.path)
+			if c.expectedErr {
+				require.Error(t, err)
+				require.Equal(t, c.notFound, git.IsNotFound(err))
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, c.expectedContent, string(content))
+			}
+		})
+	}
+}
+
 func testEnv() (*Client, error) {
 	r := mux.NewRouter()
 
@@ -820,6 +1055,23 @@ func setRouter2(r *mux.Router) {
 			_, _ = w.Write(j)
 		}
 	})
+	r.HandleFunc("/orgs/{org}/members/{username}", func(w http.ResponseWriter, req *http.Request) {
+		if mux.Vars(req)["username"] != "changjjjjjjj" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	r.HandleFunc("/orgs/{org}/teams/{team}/memberships/{username}", func(w http.ResponseWriter, req *http.Request) {
+		switch mux.Vars(req)["username"] {
+		case "changjjjjjjj":
+			_, _ = w.Write([]byte(`{"state":"active","role":"member"}`))
+		case "developer":
+			_, _ = w.Write([]byte(`{"state":"pending","role":"member"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
 	r.HandleFunc("/repos/{org}/{repo}/pulls", func(w http.ResponseWriter, req *http.Request) {
 		page := req.URL.Query().Get("page")
 		if page == "" || page == "1" {
@@ -853,6 +1105,18 @@ func setRouter2(r *mux.Router) {
 			_, _ = w.Write(j)
 		}
 	}).Methods(http.MethodDelete)
+	r.HandleFunc("/repos/{org}/{repo}/issues/{id}/assignees", func(w http.ResponseWriter, req *http.Request) {
+		body := &AssigneesBody{}
+		if err := json.NewDecoder(req.Body).Decode(body); err != nil || len(body.Assignees) == 0 {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		}
+	}).Methods(http.MethodPost, http.MethodDelete)
+	r.HandleFunc("/repos/{org}/{repo}/pulls/{id}/requested_reviewers", func(w http.ResponseWriter, req *http.Request) {
+		body := &ReviewersBody{}
+		if err := json.NewDecoder(req.Body).Decode(body); err != nil || len(body.Reviewers) == 0 {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		}
+	}).Methods(http.MethodPost, http.MethodDelete)
 	r.HandleFunc("/repos/{org}/{repo}/pulls/{id}/comments", func(w http.ResponseWriter, req *http.Request) {
 		_, _ = w.Write([]byte(samplePRComments))
 	})
@@ -868,6 +1132,32 @@ func setRouter2(r *mux.Router) {
 			_, _ = w.Write(j)
 		}
 	})
+	r.HandleFunc("/repos/{org}/{repo}/pulls/{id}", func(w http.ResponseWriter, req *http.Request) {
+		body := &UpdatePullRequest{}
+		_ = json.NewDecoder(req.Body).Decode(body)
+		if mux.Vars(req)["id"] != "1" || body.Base == "" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte("{\"message\":\"Validation Failed\"}"))
+		}
+	}).MatcherFunc(func(req *http.Request, _ *mux.RouteMatch) bool {
+		// Other methods should not be matched, so that GET requests for unknown PRs get 404
+		return req.Method == http.MethodPatch
+	})
+	r.HandleFunc("/repos/{org}/{repo}/pulls/{id}/update-branch", func(w http.ResponseWriter, req *http.Request) {
+		if mux.Vars(req)["id"] != "1" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte("{\"message\":\"expected head sha didn't match current head ref.\"}"))
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}).Methods(http.MethodPut)
+	r.HandleFunc("/repos/{org}/{repo}/git/refs/heads/{branch}", func(w http.ResponseWriter, req *http.Request) {
+		body := &UpdateRefRequest{}
+		_ = json.NewDecoder(req.Body).Decode(body)
+		if mux.Vars(req)["branch"] != "master" || body.Force {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		}
+	}).Methods(http.MethodPatch)
 	r.HandleFunc("/repos/{org}/{repo}/pulls/{id}/reviews", func(w http.ResponseWriter, req *http.Request) {
 		_, _ = w.Write([]byte(samplePRReviews))
 	})
@@ -877,6 +1167,16 @@ func setRouter2(r *mux.Router) {
 	r.HandleFunc("/repos/{org}/{repo}/commits/{id}/comments", func(w http.ResponseWriter, req *http.Request) {
 		_, _ = w.Write([]byte(sampleIssueComments))
 	})
+	r.HandleFunc("/repos/{org}/{repo}/issues/comments/{id}", func(w http.ResponseWriter, req *http.Request) {
+		if mux.Vars(req)["id"] != "1" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}).Methods(http.MethodPatch)
+	r.HandleFunc("/repos/{org}/{repo}/comments/{id}", func(w http.ResponseWriter, req *http.Request) {
+		if mux.Vars(req)["id"] != "1" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}).Methods(http.MethodPatch)
 	r.HandleFunc("/repos/{org}/{repo}/branches/{branch}", func(w http.ResponseWriter, req *http.Request) {
 		vars := mux.Vars(req)
 		branch := vars["branch"]
@@ -890,6 +1190,13 @@ func setRouter2(r *mux.Router) {
 			_, _ = w.Write(j)
 		}
 	})
+	r.HandleFunc("/repos/{org}/{repo}/contents/{path:.*}", func(w http.ResponseWriter, req *http.Request) {
+		if mux.Vars(req)["path"] != ".github/CODEOWNERS" || req.URL.Query().Get("ref") != "master" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"type": "file", "encoding": "base64", "content": "KiBAY3FicWRkMTE1MTkK\n"}`))
+	})
 }
 
 func wrongTestEnv() (*Client, error) {
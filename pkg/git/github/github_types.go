@@ -33,6 +33,11 @@ type UserPermission struct {
 	Permission string `json:"permission"`
 }
 
+// TeamMembership is a membership of a user in a team
+type TeamMembership struct {
+	State string `json:"state"`
+}
+
 // CommitStatusRequest is an API body for setting commits' status
 type CommitStatusRequest struct {
 	State       string `json:"state"`
@@ -59,6 +64,16 @@ type LabelBody struct {
 	Name string `json:"name"`
 }
 
+// AssigneesBody is a body structure for adding/removing assignees to/from issues/prs
+type AssigneesBody struct {
+	Assignees []string `json:"assignees"`
+}
+
+// ReviewersBody is a body structure for requesting/removing reviews to/from prs
+type ReviewersBody struct {
+	Reviewers []string `json:"reviewers"`
+}
+
 // BranchResponse is a respond struct for branch request
 type BranchResponse struct {
 	Name   string `json:"name"`
@@ -75,6 +90,22 @@ type MergeRequest struct {
 	Sha           string `json:"sha"`
 }
 
+// UpdatePullRequest is a request body to update a pull request
+type UpdatePullRequest struct {
+	Base string `json:"base,omitempty"`
+}
+
+// UpdateBranchRequest is a request body to update a pull request's branch
+type UpdateBranchRequest struct {
+	ExpectedHeadSha string `json:"expected_head_sha,omitempty"`
+}
+
+// UpdateRefRequest is a request body to update a git reference
+type UpdateRefRequest struct {
+	Sha   string `json:"sha"`
+	Force bool   `json:"force"`
+}
+
 // DiffFiles is a list of DiffFile
 type DiffFiles []DiffFile
 
@@ -105,13 +136,24 @@ type CommitResponse struct {
 
 // CommentResponse is a comment list response
 type CommentResponse struct {
+	ID        int      `json:"id"`
+	User      User     `json:"user"`
 	Body      string   `json:"body"`
 	CreatedAt *v1.Time `json:"created_at"`
 }
 
 // ReviewResponse is a review list response
 type ReviewResponse struct {
+	ID          int                        `json:"id"`
+	User        User                       `json:"user"`
+	CommitID    string                     `json:"commit_id"`
 	Body        string                     `json:"body"`
 	SubmittedAt *v1.Time                   `json:"submitted_at"`
 	State       git.PullRequestReviewState `json:"state"`
 }
+
+// ContentResponse is a response of the repository contents api
+type ContentResponse struct {
+	Content  string `json:"content"`
+	Encoding string `json:"encoding"`
+}
@@ -62,7 +62,7 @@ func (c *Client) parsePushWebhook(jsonString []byte) (*git.Webhook, error) {
 		return nil, nil
 	}
 	sender := git.User{Name: data.Sender.Name, ID: data.Sender.ID}
-	push := git.Push{Ref: data.Ref, Sha: data.Sha}
+	push := git.Push{Ref: data.Ref, Sha: data.Sha, Author: git.User{Name: data.HeadCommit.Author.UserName, Email: data.HeadCommit.Author.Email}}
 
 	// Get sender email
 	userInfo, err := c.GetUserInfo(data.Sender.Name)
@@ -73,6 +73,27 @@ func (c *Client) parsePushWebhook(jsonString []byte) (*git.Webhook, error) {
 	return &git.Webhook{EventType: git.EventTypePush, Repo: repo, Sender: sender, Push: &push, RequestBody: string(jsonString)}, nil
 }
 
+func (c *Client) parseStatusWebhook(jsonString []byte) (*git.Webhook, error) {
+	var data StatusWebhook
+
+	if err := json.Unmarshal(jsonString, &data); err != nil {
+		return nil, err
+	}
+	repo := git.Repository{Name: data.Repo.Name, URL: data.Repo.URL}
+	sender := git.User{Name: data.Sender.Name, ID: data.Sender.ID}
+	status := git.Status{
+		Sha: data.Sha,
+		CommitStatus: git.CommitStatus{
+			Context:     data.Context,
+			State:       git.CommitStatusState(data.State),
+			Description: data.Description,
+			TargetURL:   data.TargetURL,
+		},
+	}
+
+	return &git.Webhook{EventType: git.EventTypeStatus, Repo: repo, Sender: sender, Status: &status, RequestBody: string(jsonString)}, nil
+}
+
 func (c *Client) parseIssueCommentWebhook(jsonString []byte) (*git.Webhook, error) {
 	issueComment := &IssueCommentWebhook{}
 	if err := json.Unmarshal(jsonString, issueComment); err != nil {
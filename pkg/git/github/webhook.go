@@ -34,10 +34,31 @@ type PullRequestWebhook struct {
 
 // PushWebhook is a github-specific push event webhook body
 type PushWebhook struct {
-	Ref    string `json:"ref"`
-	Repo   Repo   `json:"repository"`
-	Sender User   `json:"sender"`
-	Sha    string `json:"after"`
+	Ref        string `json:"ref"`
+	Repo       Repo   `json:"repository"`
+	Sender     User   `json:"sender"`
+	Sha        string `json:"after"`
+	HeadCommit struct {
+		Author CommitAuthor `json:"author"`
+	} `json:"head_commit"`
+}
+
+// CommitAuthor is an author of a commit in the push event webhook body
+type CommitAuthor struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	UserName string `json:"username"`
+}
+
+// StatusWebhook is a github-specific status event webhook body
+type StatusWebhook struct {
+	Sha         string `json:"sha"`
+	Context     string `json:"context"`
+	State       string `json:"state"`
+	Description string `json:"description"`
+	TargetURL   string `json:"target_url"`
+	Repo        Repo   `json:"repository"`
+	Sender      User   `json:"sender"`
 }
 
 // IssueCommentWebhook is a github-specific issue_comment webhook body
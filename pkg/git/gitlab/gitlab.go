@@ -67,6 +67,8 @@ func (c *Client) ParseWebhook(header http.Header, jsonString []byte) (*git.Webho
 		return c.parsePushWebhook(jsonString)
 	case "Note Hook":
 		return c.parseIssueComment(jsonString)
+	case "Pipeline Hook":
+		return c.parsePipelineWebhook(jsonString)
 	}
 
 	return nil, nil
@@ -236,20 +238,53 @@ func (c *Client) GetUserInfo(userID string) (*git.User, error) {
 
 // CanUserWriteToRepo decides if the user has write permission on the repo
 func (c *Client) CanUserWriteToRepo(user git.User) (bool, error) {
+	accessLevel, err := c.getUserAccessLevel(user)
+	if err != nil {
+		return false, err
+	}
+	// Developer or higher
+	return accessLevel >= 30, nil
+}
+
+// IsUserRepoAdmin decides if the user has admin permission on the repo
+func (c *Client) IsUserRepoAdmin(user git.User) (bool, error) {
+	accessLevel, err := c.getUserAccessLevel(user)
+	if err != nil {
+		return false, err
+	}
+	// Maintainer or Owner
+	return accessLevel >= 40, nil
+}
+
+func (c *Client) getUserAccessLevel(user git.User) (int, error) {
 	// userID is int!
 	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/members/all/%d", c.IntegrationConfig.Spec.Git.GetAPIUrl(), url.QueryEscape(c.IntegrationConfig.Spec.Git.Repository), user.ID)
 
 	result, _, err := c.requestHTTP(http.MethodGet, apiURL, nil)
 	if err != nil {
-		return false, err
+		return 0, err
 	}
 
 	var permission UserPermission
 	if err := json.Unmarshal(result, &permission); err != nil {
-		return false, err
+		return 0, err
 	}
 
-	return permission.AccessLevel >= 30, nil
+	return permission.AccessLevel, nil
+}
+
+// IsUserInGroup decides if the user is a member of the group (full path of the group, including its ancestors)
+func (c *Client) IsUserInGroup(user git.User, group string) (bool, error) {
+	// userID is int!
+	apiURL := fmt.Sprintf("%s/api/v4/groups/%s/members/all/%d", c.IntegrationConfig.Spec.Git.GetAPIUrl(), url.QueryEscape(group), user.ID)
+
+	if _, _, err := c.requestHTTP(http.MethodGet, apiURL, nil); err != nil {
+		if git.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
 }
 
 // RegisterComment registers comment to an issue
@@ -276,6 +311,24 @@ func (c *Client) RegisterComment(issueType git.IssueType, issueNo int, sha, body
 	return nil
 }
 
+// EditComment edits the note's body
+func (c *Client) EditComment(issueType git.IssueType, issueNo, commentID int, body string) error {
+	var apiUrl string
+	switch issueType {
+	case git.IssueTypeIssue:
+		apiUrl = fmt.Sprintf("%s/api/v4/projects/%s/issues/%d/notes/%d", c.IntegrationConfig.Spec.Git.GetAPIUrl(), url.QueryEscape(c.IntegrationConfig.Spec.Git.Repository), issueNo, commentID)
+	case git.IssueTypePullRequest:
+		apiUrl = fmt.Sprintf("%s/api/v4/projects/%s/merge_requests/%d/notes/%d", c.IntegrationConfig.Spec.Git.GetAPIUrl(), url.QueryEscape(c.IntegrationConfig.Spec.Git.Repository), issueNo, commentID)
+	default:
+		return fmt.Errorf("issue type %s is not supported", issueType)
+	}
+
+	if _, _, err := c.requestHTTP(http.MethodPut, apiUrl, &CommentBody{Body: body}); err != nil {
+		return err
+	}
+	return nil
+}
+
 // ListComments lists comments of the issue id
 // TODO: Consider Gitlab approve
 func (c *Client) ListComments(issueNo int) ([]git.IssueComment, error) {
@@ -293,9 +346,11 @@ func (c *Client) ListComments(issueNo int) ([]git.IssueComment, error) {
 	for _, noteResponse := range noteResponses {
 		comments = append(comments, git.IssueComment{
 			Comment: git.Comment{
+				ID:        noteResponse.ID,
 				Body:      noteResponse.Body,
 				CreatedAt: noteResponse.CreatedAt,
 			},
+			Author: git.User{ID: noteResponse.Author.ID, Name: noteResponse.Author.UserName},
 		})
 	}
 	return comments, nil
@@ -377,6 +432,8 @@ func (c *Client) GetPullRequest(id int) (*git.PullRequest, error) {
 }
 
 // MergePullRequest merges a pull request
+// GitLab doesn't allow choosing rebase or fast-forward merge for each merge request. Those methods follow the project's
+// merge method, and the merge fails if the project requires fast-forward merge and the source branch is behind the target
 func (c *Client) MergePullRequest(id int, sha string, method git.MergeMethod, msg string) error {
 	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/merge_requests/%d/merge", c.IntegrationConfig.Spec.Git.GetAPIUrl(), url.QueryEscape(c.IntegrationConfig.Spec.Git.Repository), id)
 
@@ -400,6 +457,31 @@ func (c *Client) MergePullRequest(id int, sha string, method git.MergeMethod, ms
 	return nil
 }
 
+// UpdatePullRequestBranch rebases the source branch of the merge request onto the target branch.
+// The rebase is done asynchronously by GitLab
+func (c *Client) UpdatePullRequestBranch(id int, _ string) error {
+	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/merge_requests/%d/rebase", c.IntegrationConfig.Spec.Git.GetAPIUrl(), url.QueryEscape(c.IntegrationConfig.Spec.Git.Repository), id)
+
+	_, _, err := c.requestHTTP(http.MethodPut, apiURL, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdatePullRequestBase changes the target branch of the merge request
+func (c *Client) UpdatePullRequestBase(id int, base string) error {
+	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/merge_requests/%d", c.IntegrationConfig.Spec.Git.GetAPIUrl(), url.QueryEscape(c.IntegrationConfig.Spec.Git.Repository), id)
+
+	_, _, err := c.requestHTTP(http.MethodPut, apiURL, UpdateMergeRequest{TargetBranch: base})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // GetPullRequestDiff gets diff of the pull request
 func (c *Client) GetPullRequestDiff(id int) (*git.Diff, error) {
 	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/merge_requests/%d/changes", c.IntegrationConfig.Spec.Git.GetAPIUrl(), url.QueryEscape(c.IntegrationConfig.Spec.Git.Repository), id)
@@ -530,6 +612,140 @@ func (c *Client) DeleteLabel(issueType git.IssueType, id int, label string) erro
 	return nil
 }
 
+// AddAssignees adds assignees to the merge request id
+func (c *Client) AddAssignees(id int, users []string) error {
+	mr, err := c.getMergeRequest(id)
+	if err != nil {
+		return err
+	}
+
+	ids, err := c.getUserIDs(users)
+	if err != nil {
+		return err
+	}
+
+	return c.updateMergeRequest(id, &UpdateMergeRequestAssignees{AssigneeIDs: mergeUserIDs(mr.Assignees, ids, true)})
+}
+
+// RemoveAssignees removes assignees from the merge request id
+func (c *Client) RemoveAssignees(id int, users []string) error {
+	mr, err := c.getMergeRequest(id)
+	if err != nil {
+		return err
+	}
+
+	ids, err := c.getUserIDs(users)
+	if err != nil {
+		return err
+	}
+
+	return c.updateMergeRequest(id, &UpdateMergeRequestAssignees{AssigneeIDs: mergeUserIDs(mr.Assignees, ids, false)})
+}
+
+// RequestReviews sets the users as reviewers of the merge request
+func (c *Client) RequestReviews(id int, users []string) error {
+	mr, err := c.getMergeRequest(id)
+	if err != nil {
+		return err
+	}
+
+	ids, err := c.getUserIDs(users)
+	if err != nil {
+		return err
+	}
+
+	return c.updateMergeRequest(id, &UpdateMergeRequestReviewers{ReviewerIDs: mergeUserIDs(mr.Reviewers, ids, true)})
+}
+
+// CancelReviewRequests removes the users from reviewers of the merge request
+func (c *Client) CancelReviewRequests(id int, users []string) error {
+	mr, err := c.getMergeRequest(id)
+	if err != nil {
+		return err
+	}
+
+	ids, err := c.getUserIDs(users)
+	if err != nil {
+		return err
+	}
+
+	return c.updateMergeRequest(id, &UpdateMergeRequestReviewers{ReviewerIDs: mergeUserIDs(mr.Reviewers, ids, false)})
+}
+
+func (c *Client) getMergeRequest(id int) (*MergeRequest, error) {
+	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/merge_requests/%d", c.IntegrationConfig.Spec.Git.GetAPIUrl(), url.QueryEscape(c.IntegrationConfig.Spec.Git.Repository), id)
+
+	raw, _, err := c.requestHTTP(http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	mr := &MergeRequest{}
+	if err := json.Unmarshal(raw, mr); err != nil {
+		return nil, err
+	}
+	return mr, nil
+}
+
+func (c *Client) updateMergeRequest(id int, body interface{}) error {
+	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/merge_requests/%d", c.IntegrationConfig.Spec.Git.GetAPIUrl(), url.QueryEscape(c.IntegrationConfig.Spec.Git.Repository), id)
+
+	if _, _, err := c.requestHTTP(http.MethodPut, apiURL, body); err != nil {
+		return err
+	}
+	return nil
+}
+
+// getUserIDs gets IDs of the users. GitLab's API identifies users only by their IDs
+func (c *Client) getUserIDs(users []string) ([]int, error) {
+	var ids []int
+	for _, u := range users {
+		apiURL := fmt.Sprintf("%s/api/v4/users?username=%s", c.IntegrationConfig.Spec.Git.GetAPIUrl(), url.QueryEscape(u))
+
+		raw, _, err := c.requestHTTP(http.MethodGet, apiURL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp []UserInfo
+		if err := json.Unmarshal(raw, &resp); err != nil {
+			return nil, err
+		}
+		if len(resp) == 0 {
+			return nil, fmt.Errorf("user %s is not found", u)
+		}
+		ids = append(ids, resp[0].ID)
+	}
+	return ids, nil
+}
+
+// mergeUserIDs adds ids to (or removes ids from) the current users' ids
+func mergeUserIDs(current []UserInfo, ids []int, add bool) []int {
+	result := []int{}
+	exists := map[int]struct{}{}
+	target := map[int]struct{}{}
+	for _, id := range ids {
+		target[id] = struct{}{}
+	}
+	for _, u := range current {
+		if _, ok := target[u.ID]; ok && !add {
+			continue
+		}
+		exists[u.ID] = struct{}{}
+		result = append(result, u.ID)
+	}
+	if add {
+		for _, id := range ids {
+			if _, ok := exists[id]; ok {
+				continue
+			}
+			exists[id] = struct{}{}
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
 // GetBranch gets branch info
 func (c *Client) GetBranch(branch string) (*git.Branch, error) {
 	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/repository/branches/%s", c.IntegrationConfig.Spec.Git.GetAPIUrl(), url.QueryEscape(c.IntegrationConfig.Spec.Git.Repository), branch)
@@ -547,6 +763,43 @@ func (c *Client) GetBranch(branch string) (*git.Branch, error) {
 	return &git.Branch{Name: resp.Name, CommitID: resp.Commit.ID}, nil
 }
 
+// ListPullRequestReviews lists approvals of a merge request. GitLab doesn't have review states, so only approvals are listed
+func (c *Client) ListPullRequestReviews(id int) ([]git.PullRequestReview, error) {
+	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/merge_requests/%d/approvals", c.IntegrationConfig.Spec.Git.GetAPIUrl(), url.QueryEscape(c.IntegrationConfig.Spec.Git.Repository), id)
+
+	raw, _, err := c.requestHTTP(http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ApprovalsResponse{}
+	if err := json.Unmarshal(raw, resp); err != nil {
+		return nil, err
+	}
+
+	var reviews []git.PullRequestReview
+	for _, a := range resp.ApprovedBy {
+		reviews = append(reviews, git.PullRequestReview{
+			Author: git.User{ID: a.User.ID, Name: a.User.UserName},
+			State:  git.PullRequestReviewStateApproved,
+		})
+	}
+
+	return reviews, nil
+}
+
+// GetFileContent gets the content of a file in the ref
+func (c *Client) GetFileContent(ref, path string) ([]byte, error) {
+	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/repository/files/%s/raw?ref=%s", c.IntegrationConfig.Spec.Git.GetAPIUrl(), url.QueryEscape(c.IntegrationConfig.Spec.Git.Repository), url.PathEscape(strings.TrimPrefix(path, "/")), url.QueryEscape(ref))
+
+	raw, _, err := c.requestHTTP(http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return raw, nil
+}
+
 func (c *Client) requestHTTP(method, apiURL string, data interface{}) ([]byte, http.Header, error) {
 	tlsConfig := c.IntegrationConfig.GetTLSConfig()
 
@@ -23,6 +23,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/require"
 	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/pkg/git"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -36,6 +37,7 @@ const (
 	sampleWebhooksList = "[{\"id\":7194623,\"url\":\"http://asdasd/webhook/default/chatops-test-gitlab\",\"created_at\":\"2021-04-12T04:35:27.210Z\",\"push_events\":true,\"tag_push_events\":true,\"merge_requests_events\":true,\"repository_update_events\":false,\"enable_ssl_verification\":false,\"project_id\":25815215,\"issues_events\":true,\"confidential_issues_events\":true,\"note_events\":true,\"confidential_note_events\":true,\"pipeline_events\":true,\"wiki_page_events\":true,\"deployment_events\":true,\"job_events\":true,\"releases_events\":false,\"push_events_branch_filter\":null}]"
 	sampleStatusesList = "[{\"id\":1170837740,\"sha\":\"5f065c6de7dacb91aa5929a5c0ab71ecba5456b0\",\"ref\":\"newnew\",\"status\":\"running\",\"name\":\"blocker\",\"target_url\":\"http://a\",\"description\":\"PR does not meet all conditions. Label lgtm is required. Checks [blocker] are not met. \",\"created_at\":\"2021-04-12T05:40:07.995Z\",\"started_at\":\"2021-04-12T05:40:08.028Z\",\"finished_at\":null,\"allow_failure\":false,\"coverage\":null,\"author\":{\"id\":7169076,\"name\":\"Sunghyun Kim\",\"username\":\"cqbqdd11519\",\"state\":\"active\",\"avatar_url\":\"https://secure.gravatar.com/avatar/4021c3aaa995c31bd117cb7800005e85?s=80\\u0026d=identicon\",\"web_url\":\"https://gitlab.com/cqbqdd11519\"}},{\"id\":1171264736,\"sha\":\"5f065c6de7dacb91aa5929a5c0ab71ecba5456b0\",\"ref\":\"newnew\",\"status\":\"success\",\"name\":\"test-1\",\"target_url\":\"http://cicd-local.vingsu.com:8080/report/default/chatops-test-gitlab-5f065-cmiyw/test-1\",\"description\":\"All Steps have completed executing\",\"created_at\":\"2021-04-12T08:38:29.773Z\",\"started_at\":\"2021-04-12T08:38:29.819Z\",\"finished_at\":\"2021-04-12T08:38:51.996Z\",\"allow_failure\":false,\"coverage\":null,\"author\":{\"id\":7169076,\"name\":\"Sunghyun Kim\",\"username\":\"cqbqdd11519\",\"state\":\"active\",\"avatar_url\":\"https://secure.gravatar.com/avatar/4021c3aaa995c31bd117cb7800005e85?s=80\\u0026d=identicon\",\"web_url\":\"https://gitlab.com/cqbqdd11519\"}}]"
 	sampleMRList       = "[{\"id\":95464030,\"iid\":3,\"project_id\":25815215,\"title\":\"Newnew\",\"description\":\"\",\"state\":\"opened\",\"created_at\":\"2021-04-12T05:07:00.660Z\",\"updated_at\":\"2021-04-13T04:53:14.489Z\",\"merged_by\":null,\"merged_at\":null,\"closed_by\":null,\"closed_at\":null,\"target_branch\":\"master\",\"source_branch\":\"newnew\",\"user_notes_count\":2,\"upvotes\":0,\"downvotes\":0,\"author\":{\"id\":7169076,\"name\":\"Sunghyun Kim\",\"username\":\"cqbqdd11519\",\"state\":\"active\",\"avatar_url\":\"https://secure.gravatar.com/avatar/4021c3aaa995c31bd117cb7800005e85?s=80\\u0026d=identicon\",\"web_url\":\"https://gitlab.com/cqbqdd11519\"},\"assignees\":[],\"assignee\":null,\"reviewers\":[],\"source_project_id\":25815215,\"target_project_id\":25815215,\"labels\":[],\"work_in_progress\":false,\"milestone\":null,\"merge_when_pipeline_succeeds\":false,\"merge_status\":\"can_be_merged\",\"sha\":\"5f065c6de7dacb91aa5929a5c0ab71ecba5456b0\",\"merge_commit_sha\":null,\"squash_commit_sha\":null,\"discussion_locked\":null,\"should_remove_source_branch\":null,\"force_remove_source_branch\":false,\"reference\":\"!3\",\"references\":{\"short\":\"!3\",\"relative\":\"!3\",\"full\":\"cqbqdd11519/cicd-test!3\"},\"web_url\":\"https://gitlab.com/cqbqdd11519/cicd-test/-/merge_requests/3\",\"time_stats\":{\"time_estimate\":0,\"total_time_spent\":0,\"human_time_estimate\":null,\"human_total_time_spent\":null},\"squash\":false,\"task_completion_status\":{\"count\":0,\"completed_count\":0},\"has_conflicts\":false,\"blocking_discussions_resolved\":true,\"approvals_before_merge\":null},{\"id\":95463922,\"iid\":2,\"project_id\":25815215,\"title\":\"Newnew\",\"description\":\"\",\"state\":\"closed\",\"created_at\":\"2021-04-12T05:05:06.339Z\",\"updated_at\":\"2021-04-12T05:05:42.049Z\",\"merged_by\":null,\"merged_at\":null,\"closed_by\":{\"id\":7169076,\"name\":\"Sunghyun Kim\",\"username\":\"cqbqdd11519\",\"state\":\"active\",\"avatar_url\":\"https://secure.gravatar.com/avatar/4021c3aaa995c31bd117cb7800005e85?s=80\\u0026d=identicon\",\"web_url\":\"https://gitlab.com/cqbqdd11519\"},\"closed_at\":\"2021-04-12T05:05:42.070Z\",\"target_branch\":\"master\",\"source_branch\":\"newnew\",\"user_notes_count\":0,\"upvotes\":0,\"downvotes\":0,\"author\":{\"id\":7169076,\"name\":\"Sunghyun Kim\",\"username\":\"cqbqdd11519\",\"state\":\"active\",\"avatar_url\":\"https://secure.gravatar.com/avatar/4021c3aaa995c31bd117cb7800005e85?s=80\\u0026d=identicon\",\"web_url\":\"https://gitlab.com/cqbqdd11519\"},\"assignees\":[],\"assignee\":null,\"reviewers\":[],\"source_project_id\":25815215,\"target_project_id\":25815215,\"labels\":[],\"work_in_progress\":false,\"milestone\":null,\"merge_when_pipeline_succeeds\":false,\"merge_status\":\"can_be_merged\",\"sha\":\"dace98c2d0437f6ccacd8b9c8094f4dde9162214\",\"merge_commit_sha\":null,\"squash_commit_sha\":null,\"discussion_locked\":null,\"should_remove_source_branch\":null,\"force_remove_source_branch\":false,\"reference\":\"!2\",\"references\":{\"short\":\"!2\",\"relative\":\"!2\",\"full\":\"cqbqdd11519/cicd-test!2\"},\"web_url\":\"https://gitlab.com/cqbqdd11519/cicd-test/-/merge_requests/2\",\"time_stats\":{\"time_estimate\":0,\"total_time_spent\":0,\"human_time_estimate\":null,\"human_total_time_spent\":null},\"squash\":false,\"task_completion_status\":{\"count\":0,\"completed_count\":0},\"has_conflicts\":false,\"blocking_discussions_resolved\":true,\"approvals_before_merge\":null},{\"id\":95462727,\"iid\":1,\"project_id\":25815215,\"title\":\"newnew\",\"description\":\"\",\"state\":\"closed\",\"created_at\":\"2021-04-12T04:42:18.407Z\",\"updated_at\":\"2021-04-12T04:58:53.632Z\",\"merged_by\":null,\"merged_at\":null,\"closed_by\":{\"id\":7169076,\"name\":\"Sunghyun Kim\",\"username\":\"cqbqdd11519\",\"state\":\"active\",\"avatar_url\":\"https://secure.gravatar.com/avatar/4021c3aaa995c31bd117cb7800005e85?s=80\\u0026d=identicon\",\"web_url\":\"https://gitlab.com/cqbqdd11519\"},\"closed_at\":\"2021-04-12T04:58:53.649Z\",\"target_branch\":\"master\",\"source_branch\":\"newnew\",\"user_notes_count\":0,\"upvotes\":0,\"downvotes\":0,\"author\":{\"id\":7169076,\"name\":\"Sunghyun Kim\",\"username\":\"cqbqdd11519\",\"state\":\"active\",\"avatar_url\":\"https://secure.gravatar.com/avatar/4021c3aaa995c31bd117cb7800005e85?s=80\\u0026d=identicon\",\"web_url\":\"https://gitlab.com/cqbqdd11519\"},\"assignees\":[{\"id\":7169076,\"name\":\"Sunghyun Kim\",\"username\":\"cqbqdd11519\",\"state\":\"active\",\"avatar_url\":\"https://secure.gravatar.com/avatar/4021c3aaa995c31bd117cb7800005e85?s=80\\u0026d=identicon\",\"web_url\":\"https://gitlab.com/cqbqdd11519\"}],\"assignee\":{\"id\":7169076,\"name\":\"Sunghyun Kim\",\"username\":\"cqbqdd11519\",\"state\":\"active\",\"avatar_url\":\"https://secure.gravatar.com/avatar/4021c3aaa995c31bd117cb7800005e85?s=80\\u0026d=identicon\",\"web_url\":\"https://gitlab.com/cqbqdd11519\"},\"reviewers\":[],\"source_project_id\":25815215,\"target_project_id\":25815215,\"labels\":[\"kind/test\"],\"work_in_progress\":false,\"milestone\":null,\"merge_when_pipeline_succeeds\":false,\"merge_status\":\"unchecked\",\"sha\":\"e703f64f722f33c4fbb1f326aed08edc81053b0b\",\"merge_commit_sha\":null,\"squash_commit_sha\":null,\"discussion_locked\":null,\"should_remove_source_branch\":null,\"force_remove_source_branch\":false,\"reference\":\"!1\",\"references\":{\"short\":\"!1\",\"relative\":\"!1\",\"full\":\"cqbqdd11519/cicd-test!1\"},\"web_url\":\"https://gitlab.com/cqbqdd11519/cicd-test/-/merge_requests/1\",\"time_stats\":{\"time_estimate\":0,\"total_time_spent\":0,\"human_time_estimate\":null,\"human_total_time_spent\":null},\"squash\":false,\"task_completion_status\":{\"count\":0,\"completed_count\":0},\"has_conflicts\":false,\"blocking_discussions_resolved\":true,\"approvals_before_merge\":null}]"
+	sampleMRApprovals  = `{"id":104830956,"iid":5,"project_id":25815215,"title":"Newnew","state":"opened","approved":true,"approvals_required":0,"approvals_left":0,"approved_by":[{"user":{"id":7169076,"name":"Sunghyun Kim","username":"cqbqdd11519","state":"active"}}]}`
 	sampleMRChange     = `{"id":104830956,"iid":5,"project_id":25815215,"title":"Newnew","state":"opened","created_at":"2021-06-18T07:11:01.715Z","updated_at":"2021-07-13T01:05:33.877Z","target_branch":"master","source_branch":"newnew","source_project_id":25815215,"target_project_id":25815215,"sha":"5f065c6de7dacb91aa5929a5c0ab71ecba5456b0","changes":[{"old_path":"src/main/webapp/index.html","new_path":"src/main/webapp/index.html","a_mode":"100644","b_mode":"100644","new_file":false,"renamed_file":false,"deleted_file":false,"diff":"@@ -1,7 +1,7 @@\n \u003c!DOCTYPE html\u003e\n \u003chtml\u003e\n     \u003chead\u003e\n-        \u003ctitle\u003eTomcatMavenApp\u003c/title\u003e\n+        \u003ctitle\u003eTomcatMavenAppaaaa - add commit3\u003c/title\u003e\n         \u003cmeta http-equiv=\"Content-Type\" content=\"text/html; charset=UTF-8\"\u003e\n     \u003c/head\u003e\n     \u003cbody\u003e\n"}]}`
 	sampleMRCommits    = "[\n    {\n        \"id\":\"5f065c6de7dacb91aa5929a5c0ab71ecba5456b0\",\n        \"created_at\":\"2021-04-12T05:07:48.000Z\",\n        \"title\":\"Update index.html\",\n        \"message\":\"Update index.html\",\n        \"author_name\":\"Sunghyun Kim\",\n        \"author_email\":\"cqbqdd11519@gmail.com\",\n        \"authored_date\":\"2021-04-12T05:07:48.000Z\",\n        \"committer_name\":\"Sunghyun Kim\",\n        \"committer_email\":\"cqbqdd11519@gmail.com\",\n        \"committed_date\":\"2021-04-12T05:07:48.000Z\"\n    },\n    {\n        \"id\":\"dace98c2d0437f6ccacd8b9c8094f4dde9162214\",\n        \"created_at\":\"2021-04-12T05:04:54.000Z\",\n        \"title\":\"Update index.html\",\n        \"message\":\"Update index.html\",\n        \"author_name\":\"Sunghyun Kim\",\n        \"author_email\":\"cqbqdd11519@gmail.com\",\n        \"authored_date\":\"2021-04-12T05:04:54.000Z\",\n        \"committer_name\":\"Sunghyun Kim\",\n        \"committer_email\":\"cqbqdd11519@gmail.com\",\n        \"committed_date\":\"2021-04-12T05:04:54.000Z\"\n    },\n    {\n        \"id\":\"e703f64f722f33c4fbb1f326aed08edc81053b0b\",\n        \"created_at\":\"2021-04-12T04:50:34.000Z\",\n        \"title\":\"Update index.html\",\n        \"message\":\"Update index.html\",\n        \"author_name\":\"Sunghyun Kim\",\n        \"author_email\":\"cqbqdd11519@gmail.com\",\n        \"authored_date\":\"2021-04-12T04:50:34.000Z\",\n        \"committer_name\":\"Sunghyun Kim\",\n        \"committer_email\":\"cqbqdd11519@gmail.com\",\n        \"committed_date\":\"2021-04-12T04:50:34.000Z\"\n    },\n    {\n        \"id\":\"3196ccc37bcae94852079b04fcbfaf928341d6e9\",\n        \"created_at\":\"2021-01-22T03:25:50.000Z\",\n        \"title\":\"newnew\",\n        \"message\":\"newnew\\n\",\n        \"author_name\":\"Sunghyun Kim\",\n        \"author_email\":\"cqbqdd11519@gmail.com\",\n        \"authored_date\":\"2021-01-22T03:25:50.000Z\",\n        \"committer_name\":\"Sunghyun Kim\",\n        \"committer_email\":\"cqbqdd11519@gmail.com\",\n        \"committed_date\":\"2021-01-22T03:25:50.000Z\"\n    }\n]"
 	sampleMR           = "{\"id\":133148669,\"iid\":1,\"project_id\":31228574,\"title\":\"Child directory test\",\"description\":\"\",\"state\":\"opened\",\"created_at\":\"2021-12-30T06:58:09.077Z\",\"updated_at\":\"2021-12-30T07:18:33.391Z\",\"merged_by\":null,\"merged_at\":null,\"closed_by\":null,\"closed_at\":null,\"target_branch\":\"main\",\"source_branch\":\"child-directory-test\",\"user_notes_count\":1,\"upvotes\":0,\"downvotes\":0,\"author\":{\"id\":10192010,\"username\":\"changjjjjjjj\",\"name\":\"Changju Kim\",\"state\":\"active\",\"avatar_url\":\"https://secure.gravatar.com/avatar/c9995fef2d5a47e133b9461fea8cf3d3?s=80\\u0026d=identicon\",\"web_url\":\"https://gitlab.com/changjjjjjjj\"},\"assignees\":[],\"assignee\":null,\"reviewers\":[],\"source_project_id\":31228574,\"target_project_id\":31228574,\"labels\":[\"approved\"],\"draft\":false,\"work_in_progress\":false,\"milestone\":null,\"merge_when_pipeline_succeeds\":false,\"merge_status\":\"can_be_merged\",\"sha\":\"d84e251bf2d84b74e2e5161bcf693cdbb7130f23\",\"merge_commit_sha\":null,\"squash_commit_sha\":null,\"discussion_locked\":null,\"should_remove_source_branch\":null,\"force_remove_source_branch\":true,\"reference\":\"!1\",\"references\":{\"short\":\"!1\",\"relative\":\"!1\",\"full\":\"changjjjjjjj/cd-example-apps!1\"},\"web_url\":\"https://gitlab.com/changjjjjjjj/cd-example-apps/-/merge_requests/1\",\"time_stats\":{\"time_estimate\":0,\"total_time_spent\":0,\"human_time_estimate\":null,\"human_total_time_spent\":null},\"squash\":false,\"task_completion_status\":{\"count\":0,\"completed_count\":0},\"has_conflicts\":false,\"blocking_discussions_resolved\":true,\"approvals_before_merge\":null,\"subscribed\":true,\"changes_count\":\"2\",\"latest_build_started_at\":null,\"latest_build_finished_at\":null,\"first_deployed_to_production_at\":null,\"pipeline\":null,\"head_pipeline\":null,\"diff_refs\":{\"base_sha\":\"e1eb6f3829eee63f55e77fdf6cf2b332d3a91ae0\",\"head_sha\":\"d84e251bf2d84b74e2e5161bcf693cdbb7130f23\",\"start_sha\":\"c37271972e2bb9fe7ada89e2e7ae7045da4fffcb\"},\"merge_error\":null,\"first_contribution\":false,\"user\":{\"can_merge\":true}}"
@@ -148,6 +150,15 @@ func TestClient_ListComments(t *testing.T) {
 	require.Equal(t, "test", comments[0].Comment.Body)
 }
 
+func TestClient_EditComment(t *testing.T) {
+	c, err := testEnv()
+	require.NoError(t, err)
+
+	require.NoError(t, c.EditComment(git.IssueTypePullRequest, 5, 1, "test body"))
+	require.Error(t, c.EditComment(git.IssueTypePullRequest, 5, 2, "test body"))
+	require.Error(t, c.EditComment(git.IssueTypeCommit, 5, 1, "test body"))
+}
+
 func TestClient_ListPullRequestCommits(t *testing.T) {
 	c, err := testEnv()
 	if err != nil {
@@ -165,6 +176,46 @@ func TestClient_ListPullRequestCommits(t *testing.T) {
 	require.Equal(t, "cqbqdd11519@gmail.com", commits[0].Committer.Email)
 }
 
+func TestClient_ListPullRequestReviews(t *testing.T) {
+	c, err := testEnv()
+	require.NoError(t, err)
+
+	reviews, err := c.ListPullRequestReviews(5)
+	require.NoError(t, err)
+	require.Len(t, reviews, 1)
+	require.Equal(t, 7169076, reviews[0].Author.ID)
+	require.Equal(t, "cqbqdd11519", reviews[0].Author.Name)
+	require.Equal(t, git.PullRequestReviewStateApproved, reviews[0].State)
+}
+
+func TestClient_GetFileContent(t *testing.T) {
+	c, err := testEnv()
+	require.NoError(t, err)
+
+	content, err := c.GetFileContent("master", ".gitlab/CODEOWNERS")
+	require.NoError(t, err)
+	require.Equal(t, "* @cqbqdd11519\n", string(content))
+
+	_, err = c.GetFileContent("master", "CODEOWNERS")
+	require.Error(t, err)
+	require.True(t, git.IsNotFound(err))
+}
+
+func TestClient_UpdatePullRequestBranch(t *testing.T) {
+	c, err := testEnv()
+	require.NoError(t, err)
+
+	require.NoError(t, c.UpdatePullRequestBranch(5, ""))
+	require.Error(t, c.UpdatePullRequestBranch(6, ""))
+}
+
+func TestClient_UpdatePullRequestBase(t *testing.T) {
+	c, err := testEnv()
+	require.NoError(t, err)
+
+	require.NoError(t, c.UpdatePullRequestBase(5, "master"))
+}
+
 func testEnv() (*Client, error) {
 	r := mux.NewRouter()
 	r.HandleFunc("/", func(w http.ResponseWriter, req *http.Request) {
@@ -204,6 +255,29 @@ func testEnv() (*Client, error) {
 	r.HandleFunc("/api/v4/projects/{org}/{repo}/merge_requests/{iid}/notes", func(w http.ResponseWriter, req *http.Request) {
 		_, _ = w.Write([]byte(sampleMRNotes))
 	})
+	r.HandleFunc("/api/v4/projects/{org}/{repo}/merge_requests/{iid}/notes/{id}", func(w http.ResponseWriter, req *http.Request) {
+		if mux.Vars(req)["id"] != "1" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}).Methods(http.MethodPut)
+	r.HandleFunc("/api/v4/projects/{org}/{repo}/merge_requests/{iid}/rebase", func(w http.ResponseWriter, req *http.Request) {
+		if mux.Vars(req)["iid"] != "5" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte("{\"rebase_in_progress\":true}"))
+	}).Methods(http.MethodPut)
+	r.HandleFunc("/api/v4/projects/{org}/{repo}/merge_requests/{iid}/approvals", func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte(sampleMRApprovals))
+	})
+	r.HandleFunc("/api/v4/projects/{org}/{repo}/repository/files/{path:.*}/raw", func(w http.ResponseWriter, req *http.Request) {
+		if mux.Vars(req)["path"] != ".gitlab/CODEOWNERS" || req.URL.Query().Get("ref") != "master" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("* @cqbqdd11519\n"))
+	})
 
 	testSrv := httptest.NewServer(r)
 	serverURL = testSrv.URL
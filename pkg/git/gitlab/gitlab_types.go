@@ -61,6 +61,7 @@ type CommitCommentBody struct {
 type UpdateMergeRequest struct {
 	AddLabels    string `json:"add_labels"`
 	RemoveLabels string `json:"remove_labels"`
+	TargetBranch string `json:"target_branch,omitempty"`
 }
 
 // MergeRequest is a body struct of a merge request
@@ -72,12 +73,24 @@ type MergeRequest struct {
 		ID       int    `json:"id"`
 		UserName string `json:"username"`
 	} `json:"author"`
-	WebURL       string   `json:"web_url"`
-	TargetBranch string   `json:"target_branch"`
-	SourceBranch string   `json:"source_branch"`
-	SHA          string   `json:"sha"`
-	Labels       []string `json:"labels"`
-	HasConflicts bool     `json:"has_conflicts"`
+	WebURL       string     `json:"web_url"`
+	TargetBranch string     `json:"target_branch"`
+	SourceBranch string     `json:"source_branch"`
+	SHA          string     `json:"sha"`
+	Labels       []string   `json:"labels"`
+	HasConflicts bool       `json:"has_conflicts"`
+	Assignees    []UserInfo `json:"assignees"`
+	Reviewers    []UserInfo `json:"reviewers"`
+}
+
+// UpdateMergeRequestAssignees is a struct to update assignees of a merge request
+type UpdateMergeRequestAssignees struct {
+	AssigneeIDs []int `json:"assignee_ids"`
+}
+
+// UpdateMergeRequestReviewers is a struct to update reviewers of a merge request
+type UpdateMergeRequestReviewers struct {
+	ReviewerIDs []int `json:"reviewer_ids"`
 }
 
 // BranchResponse is a respond struct for branch request
@@ -119,6 +132,18 @@ type CommitResponse struct {
 
 // NoteResponse is a note list response
 type NoteResponse struct {
+	ID        int      `json:"id"`
+	Author    UserInfo `json:"author"`
 	Body      string   `json:"body"`
 	CreatedAt *v1.Time `json:"created_at"`
 }
+
+// ApprovalsResponse is an approval state response of a merge request
+type ApprovalsResponse struct {
+	ApprovedBy []struct {
+		User struct {
+			ID       int    `json:"id"`
+			UserName string `json:"username"`
+		} `json:"user"`
+	} `json:"approved_by"`
+}
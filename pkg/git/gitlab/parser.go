@@ -98,6 +98,11 @@ func (c *Client) parsePushWebhook(jsonString []byte) (*git.Webhook, error) {
 	}
 	sender := git.User{Name: data.UserName, ID: data.UserID}
 	push := git.Push{Ref: data.Ref, Sha: data.Sha}
+	for _, commit := range data.Commits {
+		if commit.ID == data.Sha {
+			push.Author = git.User{Name: commit.Author.Name, Email: commit.Author.Email}
+		}
+	}
 
 	// Get sender email
 	userInfo, err := c.GetUserInfo(strconv.Itoa(data.UserID))
@@ -108,6 +113,27 @@ func (c *Client) parsePushWebhook(jsonString []byte) (*git.Webhook, error) {
 	return &git.Webhook{EventType: git.EventTypePush, Repo: repo, Sender: sender, Push: &push, RequestBody: string(jsonString)}, nil
 }
 
+func (c *Client) parsePipelineWebhook(jsonString []byte) (*git.Webhook, error) {
+	var data PipelineWebhook
+
+	if err := json.Unmarshal(jsonString, &data); err != nil {
+		return nil, err
+	}
+	repo := git.Repository{Name: data.Project.Name, URL: data.Project.WebURL}
+	sender := git.User{Name: data.User.Name, ID: data.User.ID, Email: data.User.Email}
+
+	state := git.CommitStatusState(data.ObjectAttributes.Status)
+	switch data.ObjectAttributes.Status {
+	case "created", "waiting_for_resource", "preparing", "running", "manual", "scheduled":
+		state = git.CommitStatusStatePending
+	case "failed", "canceled":
+		state = git.CommitStatusStateFailure
+	}
+	status := git.Status{Sha: data.ObjectAttributes.Sha, CommitStatus: git.CommitStatus{State: state}}
+
+	return &git.Webhook{EventType: git.EventTypeStatus, Repo: repo, Sender: sender, Status: &status, RequestBody: string(jsonString)}, nil
+}
+
 func (c *Client) parseIssueComment(jsonString []byte) (*git.Webhook, error) {
 	data := &NoteHook{}
 
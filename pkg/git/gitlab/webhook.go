@@ -45,12 +45,33 @@ type MergeRequestWebhook struct {
 
 // PushWebhook is a gitlab-specific push event webhook body
 type PushWebhook struct {
-	Kind     string  `json:"object_kind"`
-	Ref      string  `json:"ref"`
-	Project  Project `json:"project"`
-	UserName string  `json:"user_name"`
-	UserID   int     `json:"user_id"`
-	Sha      string  `json:"after"`
+	Kind     string       `json:"object_kind"`
+	Ref      string       `json:"ref"`
+	Project  Project      `json:"project"`
+	UserName string       `json:"user_name"`
+	UserID   int          `json:"user_id"`
+	Sha      string       `json:"after"`
+	Commits  []PushCommit `json:"commits"`
+}
+
+// PushCommit is a commit in the push event webhook body
+type PushCommit struct {
+	ID     string `json:"id"`
+	Author struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	} `json:"author"`
+}
+
+// PipelineWebhook is a gitlab-specific pipeline event webhook body
+type PipelineWebhook struct {
+	User             User    `json:"user"`
+	Project          Project `json:"project"`
+	ObjectAttributes struct {
+		Ref    string `json:"ref"`
+		Sha    string `json:"sha"`
+		Status string `json:"status"`
+	} `json:"object_attributes"`
 }
 
 // NoteHook is a gitlab-specific issue comment webhook body
@@ -20,7 +20,6 @@ import (
 	"bytes"
 	"crypto/tls"
 	"encoding/json"
-	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -117,7 +116,7 @@ func RequestHTTP(method string, uri string, header map[string]string, data inter
 	// Check additional response header
 	var newErr error
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		newErr = fmt.Errorf("error requesting api [%s] %s, code %d, msg %s", method, uri, resp.StatusCode, string(body))
+		newErr = &HTTPError{Method: method, URI: uri, Code: resp.StatusCode, Message: string(body)}
 	}
 	return body, resp.Header, newErr
 }
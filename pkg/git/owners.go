@@ -0,0 +1,160 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package git
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// OwnersFileName is a name of the OWNERS file
+const OwnersFileName = "OWNERS"
+
+// OwnersFile is a parsed OWNERS file. Approvers and reviewers of a directory are inherited by its subdirectories
+type OwnersFile struct {
+	Approvers []string     `yaml:"approvers,omitempty"`
+	Reviewers []string     `yaml:"reviewers,omitempty"`
+	Options   OwnersOption `yaml:"options,omitempty"`
+}
+
+// OwnersOption is an option of the OWNERS file
+type OwnersOption struct {
+	// NoParentOwners stops inheriting the approvers and reviewers of the parent directories
+	NoParentOwners bool `yaml:"no_parent_owners,omitempty"`
+}
+
+// ParseOwnersFile parses an OWNERS file
+func ParseOwnersFile(content []byte) (*OwnersFile, error) {
+	file := &OwnersFile{}
+	if err := yaml.Unmarshal(content, file); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// Owners is a set of OWNERS files of a repository. Key of the files is a directory ("" for the root directory)
+// The value is nil if there is no OWNERS file in the directory
+type Owners struct {
+	files map[string]*OwnersFile
+}
+
+// LoadOwners reads the OWNERS files of the ref, from the root directory to the directories of the paths
+func LoadOwners(gitCli Client, ref string, paths []string) (*Owners, error) {
+	owners := &Owners{files: map[string]*OwnersFile{}}
+	dirs := []string{""}
+	for _, p := range paths {
+		dirs = append(dirs, parentDirs(p)...)
+	}
+	for _, dir := range dirs {
+		if _, exist := owners.files[dir]; exist {
+			continue
+		}
+		content, err := gitCli.GetFileContent(ref, path.Join(dir, OwnersFileName))
+		if err != nil {
+			if IsNotFound(err) {
+				owners.files[dir] = nil
+				continue
+			}
+			return nil, err
+		}
+		file, err := ParseOwnersFile(content)
+		if err != nil {
+			return nil, err
+		}
+		owners.files[dir] = file
+	}
+	return owners, nil
+}
+
+// Exists returns true if there is any OWNERS file
+func (o *Owners) Exists() bool {
+	for _, f := range o.files {
+		if f != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Approvers returns the approvers of a file, including the ones inherited from the parent directories
+func (o *Owners) Approvers(filePath string) []string {
+	return o.collect(filePath, func(f *OwnersFile) []string { return f.Approvers })
+}
+
+// Reviewers returns the reviewers of a file, including the ones inherited from the parent directories
+func (o *Owners) Reviewers(filePath string) []string {
+	return o.collect(filePath, func(f *OwnersFile) []string { return f.Reviewers })
+}
+
+// ApproverDir returns the nearest directory of the file whose OWNERS file has approvers.
+// Returns "" (the root directory) if there is no such directory
+func (o *Owners) ApproverDir(filePath string) string {
+	dirs := parentDirs(filePath)
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if f := o.files[dirs[i]]; f != nil && len(f.Approvers) > 0 {
+			return dirs[i]
+		}
+	}
+	return ""
+}
+
+func (o *Owners) collect(filePath string, getter func(f *OwnersFile) []string) []string {
+	var users []string
+	dirs := append([]string{""}, parentDirs(filePath)...)
+	for i := len(dirs) - 1; i >= 0; i-- {
+		f := o.files[dirs[i]]
+		if f == nil {
+			continue
+		}
+		for _, u := range getter(f) {
+			if !ContainsUser(users, u) {
+				users = append(users, u)
+			}
+		}
+		if f.Options.NoParentOwners {
+			break
+		}
+	}
+	sort.Strings(users)
+	return users
+}
+
+// ContainsUser checks if the user name is in the list. User names are case-insensitive
+func ContainsUser(users []string, user string) bool {
+	for _, u := range users {
+		if strings.EqualFold(u, user) {
+			return true
+		}
+	}
+	return false
+}
+
+// parentDirs returns the parent directories of a file, except the root directory, from the top-most one
+// e.g., a/b/c.go -> [a, a/b]
+func parentDirs(filePath string) []string {
+	filePath = strings.Trim(filePath, "/")
+	var dirs []string
+	for i, r := range filePath {
+		if r == '/' {
+			dirs = append(dirs, filePath[:i])
+		}
+	}
+	return dirs
+}
@@ -0,0 +1,86 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOwnersFile(t *testing.T) {
+	file, err := ParseOwnersFile([]byte(`approvers:
+- alice
+- bob
+reviewers:
+- carol
+options:
+  no_parent_owners: true
+`))
+	require.NoError(t, err)
+	require.Equal(t, []string{"alice", "bob"}, file.Approvers)
+	require.Equal(t, []string{"carol"}, file.Reviewers)
+	require.True(t, file.Options.NoParentOwners)
+
+	_, err = ParseOwnersFile([]byte("approvers: {"))
+	require.Error(t, err)
+}
+
+func TestOwners(t *testing.T) {
+	owners := &Owners{files: map[string]*OwnersFile{
+		"":               {Approvers: []string{"root-approver"}, Reviewers: []string{"root-reviewer"}},
+		"pkg":            nil,
+		"pkg/git":        {Approvers: []string{"git-approver", "Root-Approver"}},
+		"pkg/git/fake":   {Reviewers: []string{"fake-reviewer"}},
+		"docs":           {Approvers: []string{"docs-approver"}, Options: OwnersOption{NoParentOwners: true}},
+		"docs/modules":   nil,
+		"config":         nil,
+		"config/samples": nil,
+	}}
+	require.True(t, owners.Exists())
+
+	tc := map[string]struct {
+		path string
+
+		expectedApprovers   []string
+		expectedReviewers   []string
+		expectedApproverDir string
+	}{
+		"root":           {path: "main.go", expectedApprovers: []string{"root-approver"}, expectedReviewers: []string{"root-reviewer"}, expectedApproverDir: ""},
+		"inherited":      {path: "pkg/server/server.go", expectedApprovers: []string{"root-approver"}, expectedReviewers: []string{"root-reviewer"}, expectedApproverDir: ""},
+		"nested":         {path: "pkg/git/git.go", expectedApprovers: []string{"Root-Approver", "git-approver"}, expectedReviewers: []string{"root-reviewer"}, expectedApproverDir: "pkg/git"},
+		"nestedNoApprov": {path: "pkg/git/fake/fake.go", expectedApprovers: []string{"Root-Approver", "git-approver"}, expectedReviewers: []string{"fake-reviewer", "root-reviewer"}, expectedApproverDir: "pkg/git"},
+		"noParentOwners": {path: "docs/modules/a.md", expectedApprovers: []string{"docs-approver"}, expectedReviewers: nil, expectedApproverDir: "docs"},
+		"leadingSlash":   {path: "/pkg/git/git.go", expectedApprovers: []string{"Root-Approver", "git-approver"}, expectedReviewers: []string{"root-reviewer"}, expectedApproverDir: "pkg/git"},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, c.expectedApprovers, owners.Approvers(c.path))
+			require.Equal(t, c.expectedReviewers, owners.Reviewers(c.path))
+			require.Equal(t, c.expectedApproverDir, owners.ApproverDir(c.path))
+		})
+	}
+
+	require.False(t, (&Owners{files: map[string]*OwnersFile{"": nil}}).Exists())
+}
+
+func TestContainsUser(t *testing.T) {
+	require.True(t, ContainsUser([]string{"alice", "Bob"}, "bob"))
+	require.False(t, ContainsUser([]string{"alice", "Bob"}, "carol"))
+	require.False(t, ContainsUser(nil, "carol"))
+}
@@ -0,0 +1,168 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package slack
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tmax-cloud/cicd-operator/internal/configs"
+	"github.com/tmax-cloud/cicd-operator/internal/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Keys of the slack app secret
+const (
+	SecretKeyBotToken      = "botToken"
+	SecretKeySigningSecret = "signingSecret"
+)
+
+// UserGroupsKeySuffix is a suffix of the slack user configmap's keys, whose values are comma-separated groups of the
+// kubernetes users mapped from the slack users, e.g., U01ABCDEFGH.groups: approvers,developers
+const UserGroupsKeySuffix = ".groups"
+
+// Action IDs of the approval request message's buttons. Values of the buttons are <namespace>/<approval name>
+const (
+	ActionIDApprove = "approve"
+	ActionIDReject  = "reject"
+)
+
+const (
+	headerSignature = "X-Slack-Signature"
+	headerTimestamp = "X-Slack-Request-Timestamp"
+
+	signatureVersion = "v0"
+	maxRequestAge    = 5 * time.Minute
+)
+
+// timeNow is a function returning current time. It's a variable for testing
+var timeNow = time.Now
+
+// AppCredentials is a credential of the slack app
+type AppCredentials struct {
+	BotToken      string
+	SigningSecret string
+}
+
+// GetAppCredentials reads the slack app secret from the operator's namespace
+func GetAppCredentials(cli client.Client) (*AppCredentials, error) {
+	secret := &corev1.Secret{}
+	if err := cli.Get(context.Background(), types.NamespacedName{Name: configs.SlackAppSecret, Namespace: utils.Namespace()}, secret); err != nil {
+		return nil, err
+	}
+
+	token, tokenExist := secret.Data[SecretKeyBotToken]
+	signingSecret, signingExist := secret.Data[SecretKeySigningSecret]
+	if !tokenExist || !signingExist {
+		return nil, fmt.Errorf("secret %s should have both keys %s, %s", configs.SlackAppSecret, SecretKeyBotToken, SecretKeySigningSecret)
+	}
+
+	return &AppCredentials{BotToken: string(token), SigningSecret: string(signingSecret)}, nil
+}
+
+// GetKubernetesUser maps a slack user ID to a kubernetes user name and its groups, using the slack user configmap.
+// Groups are read from the <slack user ID>.groups key, if it exists
+func GetKubernetesUser(cli client.Client, slackUserID string) (string, []string, error) {
+	cm := &corev1.ConfigMap{}
+	if err := cli.Get(context.Background(), types.NamespacedName{Name: configs.SlackUserConfigMap, Namespace: utils.Namespace()}, cm); err != nil {
+		return "", nil, err
+	}
+
+	user, exist := cm.Data[slackUserID]
+	if !exist || user == "" {
+		return "", nil, fmt.Errorf("slack user %s is not mapped to any kubernetes user", slackUserID)
+	}
+
+	var groups []string
+	for _, g := range strings.Split(cm.Data[slackUserID+UserGroupsKeySuffix], ",") {
+		if g = strings.TrimSpace(g); g != "" {
+			groups = append(groups, g)
+		}
+	}
+	return user, groups, nil
+}
+
+// VerifyRequest verifies the signature of a request sent by slack.
+// See https://api.slack.com/authentication/verifying-requests-from-slack
+func VerifyRequest(signingSecret string, header http.Header, body []byte) error {
+	signature := header.Get(headerSignature)
+	timestamp := header.Get(headerTimestamp)
+	if signature == "" || timestamp == "" {
+		return fmt.Errorf("no header %s or %s", headerSignature, headerTimestamp)
+	}
+
+	sec, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("timestamp %s is malformed", timestamp)
+	}
+	age := timeNow().Sub(time.Unix(sec, 0))
+	if age > maxRequestAge || age < -maxRequestAge {
+		return fmt.Errorf("timestamp %s is too old", timestamp)
+	}
+
+	mac := hmac.New(sha256.New, []byte(signingSecret))
+	_, _ = mac.Write([]byte(fmt.Sprintf("%s:%s:", signatureVersion, timestamp)))
+	_, _ = mac.Write(body)
+	expected := signatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return fmt.Errorf("signature is not valid")
+	}
+	return nil
+}
+
+// ParseSlashCommand parses a form-encoded slash command request body
+func ParseSlashCommand(body []byte) (*SlashCommand, error) {
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, err
+	}
+
+	return &SlashCommand{
+		Command:     form.Get("command"),
+		Text:        form.Get("text"),
+		UserID:      form.Get("user_id"),
+		UserName:    form.Get("user_name"),
+		ChannelID:   form.Get("channel_id"),
+		ResponseURL: form.Get("response_url"),
+	}, nil
+}
+
+// ParseInteractionPayload parses a form-encoded interaction request body
+func ParseInteractionPayload(body []byte) (*InteractionPayload, error) {
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, err
+	}
+
+	payload := &InteractionPayload{}
+	if err := json.Unmarshal([]byte(form.Get("payload")), payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
@@ -0,0 +1,221 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package slack
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmax-cloud/cicd-operator/internal/configs"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetAppCredentials(t *testing.T) {
+	tc := map[string]struct {
+		secret *corev1.Secret
+
+		errorOccurs  bool
+		errorMessage string
+		expected     *AppCredentials
+	}{
+		"normal": {
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "slack-app", Namespace: "cicd-system"},
+				Data: map[string][]byte{
+					SecretKeyBotToken:      []byte("xoxb-token"),
+					SecretKeySigningSecret: []byte("signing-secret"),
+				},
+			},
+			expected: &AppCredentials{BotToken: "xoxb-token", SigningSecret: "signing-secret"},
+		},
+		"noKey": {
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "slack-app", Namespace: "cicd-system"},
+				Data: map[string][]byte{
+					SecretKeyBotToken: []byte("xoxb-token"),
+				},
+			},
+			errorOccurs:  true,
+			errorMessage: "secret slack-app should have both keys botToken, signingSecret",
+		},
+		"noSecret": {
+			errorOccurs:  true,
+			errorMessage: "secrets \"slack-app\" not found",
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			configs.SlackAppSecret = "slack-app"
+			s := runtime.NewScheme()
+			require.NoError(t, corev1.AddToScheme(s))
+			builder := fake.NewClientBuilder().WithScheme(s)
+			if c.secret != nil {
+				builder = builder.WithObjects(c.secret)
+			}
+			cli := builder.Build()
+
+			cred, err := GetAppCredentials(cli)
+			if c.errorOccurs {
+				require.Error(t, err)
+				require.Equal(t, c.errorMessage, err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, c.expected, cred)
+			}
+		})
+	}
+}
+
+func TestGetKubernetesUser(t *testing.T) {
+	s := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(s))
+
+	configs.SlackUserConfigMap = "slack-users"
+	cli := fake.NewClientBuilder().WithScheme(s).WithObjects(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "slack-users", Namespace: "cicd-system"},
+		Data: map[string]string{
+			"U0001":        "admin@tmax.co.kr",
+			"U0001.groups": "approvers, developers",
+			"U0003":        "developer@tmax.co.kr",
+		},
+	}).Build()
+
+	user, groups, err := GetKubernetesUser(cli, "U0001")
+	require.NoError(t, err)
+	require.Equal(t, "admin@tmax.co.kr", user)
+	require.Equal(t, []string{"approvers", "developers"}, groups)
+
+	user, groups, err = GetKubernetesUser(cli, "U0003")
+	require.NoError(t, err)
+	require.Equal(t, "developer@tmax.co.kr", user)
+	require.Empty(t, groups)
+
+	_, _, err = GetKubernetesUser(cli, "U0002")
+	require.Error(t, err)
+	require.Equal(t, "slack user U0002 is not mapped to any kubernetes user", err.Error())
+}
+
+func TestVerifyRequest(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	body := []byte("token=abc&command=%2Fcicd")
+
+	tc := map[string]struct {
+		secret    string
+		timestamp time.Time
+		noHeader  bool
+
+		errorOccurs  bool
+		errorMessage string
+	}{
+		"normal": {
+			secret:    "signing-secret",
+			timestamp: now.Add(-time.Minute),
+		},
+		"noHeader": {
+			noHeader:     true,
+			errorOccurs:  true,
+			errorMessage: "no header X-Slack-Signature or X-Slack-Request-Timestamp",
+		},
+		"tooOld": {
+			secret:       "signing-secret",
+			timestamp:    now.Add(-10 * time.Minute),
+			errorOccurs:  true,
+			errorMessage: "timestamp 1599999400 is too old",
+		},
+		"invalidSignature": {
+			secret:       "wrong-secret",
+			timestamp:    now,
+			errorOccurs:  true,
+			errorMessage: "signature is not valid",
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			header := http.Header{}
+			if !c.noHeader {
+				ts := strconv.FormatInt(c.timestamp.Unix(), 10)
+				mac := hmac.New(sha256.New, []byte(c.secret))
+				_, _ = mac.Write([]byte("v0:" + ts + ":"))
+				_, _ = mac.Write(body)
+				header.Set("X-Slack-Request-Timestamp", ts)
+				header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+			}
+
+			err := VerifyRequest("signing-secret", header, body)
+			if c.errorOccurs {
+				require.Error(t, err)
+				require.Equal(t, c.errorMessage, err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseSlashCommand(t *testing.T) {
+	form := url.Values{}
+	form.Set("command", "/cicd")
+	form.Set("text", "run pre test-ns/test-ic feat/test")
+	form.Set("user_id", "U0001")
+	form.Set("user_name", "test-user")
+	form.Set("channel_id", "C0001")
+	form.Set("response_url", "https://hooks.slack.com/commands/1234")
+
+	cmd, err := ParseSlashCommand([]byte(form.Encode()))
+	require.NoError(t, err)
+	require.Equal(t, &SlashCommand{
+		Command:     "/cicd",
+		Text:        "run pre test-ns/test-ic feat/test",
+		UserID:      "U0001",
+		UserName:    "test-user",
+		ChannelID:   "C0001",
+		ResponseURL: "https://hooks.slack.com/commands/1234",
+	}, cmd)
+}
+
+func TestParseInteractionPayload(t *testing.T) {
+	form := url.Values{}
+	form.Set("payload", `{"type":"block_actions","user":{"id":"U0001","username":"test-user"},"channel":{"id":"C0001"},"actions":[{"action_id":"approve","value":"test-ns/test-approval"}],"response_url":"https://hooks.slack.com/actions/1234"}`)
+
+	payload, err := ParseInteractionPayload([]byte(form.Encode()))
+	require.NoError(t, err)
+	require.Equal(t, &InteractionPayload{
+		Type:        "block_actions",
+		User:        InteractionUser{ID: "U0001", Username: "test-user"},
+		Channel:     InteractionChannel{ID: "C0001"},
+		Actions:     []InteractionAction{{ActionID: ActionIDApprove, Value: "test-ns/test-approval"}},
+		ResponseURL: "https://hooks.slack.com/actions/1234",
+	}, payload)
+
+	_, err = ParseInteractionPayload([]byte("payload=%7B"))
+	require.Error(t, err)
+}
@@ -28,6 +28,9 @@ const (
 	messageTitle = "IntegrationJobNotification"
 )
 
+// apiURL is a base url of the slack web api. It's a variable for testing
+var apiURL = "https://slack.com/api"
+
 // SendMessage sends webhook payload
 func SendMessage(url, message string) error {
 	// Generate message payload
@@ -35,13 +38,34 @@ func SendMessage(url, message string) error {
 		Text: messageTitle,
 		Blocks: []MessageBlock{{
 			Type: "section",
-			Text: BlockText{
+			Text: &BlockText{
 				Type: "mrkdwn",
 				Text: message,
 			},
 		}},
 	}
 
+	return postJSON(url, "", data, nil)
+}
+
+// RespondMessage responds to an interaction/slash command using its response url
+func RespondMessage(responseURL string, msg *Message) error {
+	return postJSON(responseURL, "", msg, nil)
+}
+
+// PostMessage posts a message to a channel as the slack app's bot user
+func PostMessage(token string, msg *Message) error {
+	resp := &APIResponse{}
+	if err := postJSON(apiURL+"/chat.postMessage", token, msg, resp); err != nil {
+		return err
+	}
+	if !resp.OK {
+		return fmt.Errorf("cannot post message, error: %s", resp.Error)
+	}
+	return nil
+}
+
+func postJSON(url, token string, data interface{}, result interface{}) error {
 	jsonBytes, err := json.Marshal(data)
 	if err != nil {
 		return err
@@ -52,6 +76,9 @@ func SendMessage(url, message string) error {
 		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
@@ -71,5 +98,9 @@ func SendMessage(url, message string) error {
 		return fmt.Errorf("status: %d, error: %s", resp.StatusCode, string(respBody))
 	}
 
+	if result != nil {
+		return json.Unmarshal(respBody, result)
+	}
+
 	return nil
 }
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
 	"github.com/tmax-cloud/cicd-operator/internal/utils"
 	"net/http"
 	"net/http/httptest"
@@ -69,7 +70,7 @@ func newTestServer() *httptest.Server {
 			Text: messageTitle,
 			Blocks: []MessageBlock{{
 				Type: "section",
-				Text: BlockText{
+				Text: &BlockText{
 					Type: "mrkdwn",
 					Text: testMessage,
 				},
@@ -84,3 +85,34 @@ func newTestServer() *httptest.Server {
 
 	return httptest.NewServer(router)
 }
+
+func TestPostMessage(t *testing.T) {
+	router := mux.NewRouter()
+	router.HandleFunc("/chat.postMessage", func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer xoxb-token" {
+			_ = utils.RespondJSON(w, &APIResponse{OK: false, Error: "invalid_auth"})
+			return
+		}
+		msg := &Message{}
+		if err := json.NewDecoder(req.Body).Decode(msg); err != nil || msg.Channel == "" {
+			_ = utils.RespondJSON(w, &APIResponse{OK: false, Error: "channel_not_found"})
+			return
+		}
+		_ = utils.RespondJSON(w, &APIResponse{OK: true})
+	})
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	apiURL = srv.URL
+	defer func() { apiURL = "https://slack.com/api" }()
+
+	require.NoError(t, PostMessage("xoxb-token", &Message{Channel: "C0001", Text: testMessage}))
+
+	err := PostMessage("wrong-token", &Message{Channel: "C0001", Text: testMessage})
+	require.Error(t, err)
+	require.Equal(t, "cannot post message, error: invalid_auth", err.Error())
+
+	err = PostMessage("xoxb-token", &Message{Text: testMessage})
+	require.Error(t, err)
+	require.Equal(t, "cannot post message, error: channel_not_found", err.Error())
+}
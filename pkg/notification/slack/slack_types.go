@@ -18,14 +18,18 @@ package slack
 
 // Message is a slack message
 type Message struct {
-	Text   string         `json:"text"`
-	Blocks []MessageBlock `json:"blocks"`
+	Channel         string         `json:"channel,omitempty"`
+	ResponseType    string         `json:"response_type,omitempty"`
+	ReplaceOriginal bool           `json:"replace_original,omitempty"`
+	Text            string         `json:"text"`
+	Blocks          []MessageBlock `json:"blocks,omitempty"`
 }
 
 // MessageBlock is a slack message block
 type MessageBlock struct {
-	Type string    `json:"type"`
-	Text BlockText `json:"text"`
+	Type     string         `json:"type"`
+	Text     *BlockText     `json:"text,omitempty"`
+	Elements []BlockElement `json:"elements,omitempty"`
 }
 
 // BlockText is an actual text
@@ -33,3 +37,54 @@ type BlockText struct {
 	Type string `json:"type"`
 	Text string `json:"text"`
 }
+
+// BlockElement is an interactive element (e.g., button) of an actions block
+type BlockElement struct {
+	Type     string     `json:"type"`
+	Text     *BlockText `json:"text,omitempty"`
+	ActionID string     `json:"action_id,omitempty"`
+	Value    string     `json:"value,omitempty"`
+	Style    string     `json:"style,omitempty"`
+}
+
+// APIResponse is a common response of the slack web api
+type APIResponse struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// InteractionPayload is a payload sent by slack when a user interacts with a message (e.g., clicks a button)
+type InteractionPayload struct {
+	Type        string              `json:"type"`
+	User        InteractionUser     `json:"user"`
+	Channel     InteractionChannel  `json:"channel"`
+	Actions     []InteractionAction `json:"actions"`
+	ResponseURL string              `json:"response_url"`
+}
+
+// InteractionUser is a user who interacted with the message
+type InteractionUser struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+// InteractionChannel is a channel where the interaction occurred
+type InteractionChannel struct {
+	ID string `json:"id"`
+}
+
+// InteractionAction is an action taken by the user
+type InteractionAction struct {
+	ActionID string `json:"action_id"`
+	Value    string `json:"value"`
+}
+
+// SlashCommand is a slash command invoked by a user. It's sent as a form, not a json
+type SlashCommand struct {
+	Command     string
+	Text        string
+	UserID      string
+	UserName    string
+	ChannelID   string
+	ResponseURL string
+}
@@ -132,9 +132,10 @@ func generatePeriodic(config *cicdv1.IntegrationConfig, job cicdv1.Job) *cicdv1.
 					Email: "",
 				},
 			},
-			PodTemplate: config.Spec.PodTemplate,
-			Timeout:     config.GetDuration(),
-			ParamConfig: config.Spec.ParamConfig,
+			PodTemplate:     config.Spec.PodTemplate,
+			Timeout:         config.GetDuration(),
+			PipelineTimeout: config.Spec.IJManageSpec.PipelineTimeout,
+			ParamConfig:     config.Spec.ParamConfig,
 		},
 	}
 }
@@ -0,0 +1,177 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pipelinemanager
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/utils"
+	"github.com/tmax-cloud/cicd-operator/pkg/git"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// coverageStatusContext is a commit status context of a job's coverage, so that the jobs' statuses do not overwrite each other
+	coverageStatusContext = "coverage/%s"
+
+	// coverageCommentMarker identifies the coverage comment of a job, so that the comment is updated, not duplicated
+	coverageCommentMarker = "<!-- cicd-operator:coverage:%s -->"
+
+	// coverageCommentMaxFiles limits the number of the files listed in the coverage comment
+	coverageCommentMaxFiles = 20
+)
+
+// reportCoverage posts (or updates) the coverage comment to the pull request and sets the coverage commit status,
+// for the jobs whose coverage is collected
+func (p *pipelineManager) reportCoverage(cfg *cicdv1.IntegrationConfig, job *cicdv1.IntegrationJob, stateChanged []bool) error {
+	// Skip if token is nil or it's not a single pull request
+	if cfg.Spec.Git.Token == nil || len(job.Spec.Refs.Pulls) != 1 {
+		return nil
+	}
+
+	var gitCli git.Client
+	for i, j := range job.Status.Jobs {
+		if !stateChanged[i] || j.Coverage == nil || i >= len(job.Spec.Jobs) || job.Spec.Jobs[i].Coverage == nil {
+			continue
+		}
+
+		if gitCli == nil {
+			cli, err := utils.GetGitCli(cfg, p.Client)
+			if err != nil {
+				return err
+			}
+			gitCli = cli
+		}
+
+		base, err := p.getBaseCoverage(job, j.Name)
+		if err != nil {
+			log.Error(err, "")
+		}
+
+		pull := job.Spec.Refs.Pulls[0]
+		body := generateCoverageComment(j.Name, job.Spec.Refs.Base.Ref.GetBranch(), j.Coverage, base)
+		if err := upsertCoverageComment(gitCli, pull.ID, j.Name, body); err != nil {
+			log.Error(err, "")
+		}
+
+		threshold := job.Spec.Jobs[i].Coverage.Threshold
+		if threshold == nil {
+			continue
+		}
+		state := git.CommitStatusStateSuccess
+		if j.Coverage.Percent() < float64(*threshold) {
+			state = git.CommitStatusStateFailure
+		}
+		desc := fmt.Sprintf("Coverage %.2f%% (threshold %d%%)", j.Coverage.Percent(), *threshold)
+		if err := gitCli.SetCommitStatus(pull.Sha, git.CommitStatus{Context: fmt.Sprintf(coverageStatusContext, j.Name), State: state, Description: desc, TargetURL: job.GetReportServerAddress(j.Name)}); err != nil {
+			log.Error(err, "")
+		}
+	}
+
+	return nil
+}
+
+// getBaseCoverage returns the coverage of the job in the latest successful post-submit IntegrationJob
+// of the pull request's base branch. It returns nil if there is no such IntegrationJob
+func (p *pipelineManager) getBaseCoverage(job *cicdv1.IntegrationJob, jobName string) (*cicdv1.JobCoverageStatus, error) {
+	ijList := &cicdv1.IntegrationJobList{}
+	if err := p.Client.List(context.Background(), ijList, client.InNamespace(job.Namespace), client.MatchingLabels{cicdv1.JobLabelConfig: job.Spec.ConfigRef.Name}); err != nil {
+		return nil, err
+	}
+
+	var latest *cicdv1.IntegrationJob
+	var coverage *cicdv1.JobCoverageStatus
+	for i := range ijList.Items {
+		ij := &ijList.Items[i]
+		if ij.Spec.ConfigRef.Type != cicdv1.JobTypePostSubmit || ij.Status.State != cicdv1.IntegrationJobStateCompleted ||
+			ij.Spec.Refs.Base.Ref.GetBranch() != job.Spec.Refs.Base.Ref.GetBranch() {
+			continue
+		}
+		if latest != nil && !latest.Status.CompletionTime.Before(ij.Status.CompletionTime) {
+			continue
+		}
+		for _, j := range ij.Status.Jobs {
+			if j.Name == jobName && j.Coverage != nil {
+				latest = ij
+				coverage = j.Coverage
+				break
+			}
+		}
+	}
+	return coverage, nil
+}
+
+// upsertCoverageComment updates the job's coverage comment if it exists, or registers a new one
+func upsertCoverageComment(gitCli git.Client, prID int, jobName, body string) error {
+	comments, err := gitCli.ListComments(prID)
+	if err != nil {
+		return err
+	}
+
+	marker := fmt.Sprintf(coverageCommentMarker, jobName)
+	for _, c := range comments {
+		if c.Comment.ID > 0 && strings.HasPrefix(c.Comment.Body, marker) {
+			return gitCli.EditComment(git.IssueTypePullRequest, prID, c.Comment.ID, body)
+		}
+	}
+	return gitCli.RegisterComment(git.IssueTypePullRequest, prID, "", body)
+}
+
+// generateCoverageComment generates a markdown comment of the coverage and its delta from the base coverage
+func generateCoverageComment(jobName, baseBranch string, coverage, base *cicdv1.JobCoverageStatus) string {
+	b := &strings.Builder{}
+	b.WriteString(fmt.Sprintf(coverageCommentMarker, jobName) + "\n")
+	b.WriteString(fmt.Sprintf("### Coverage of `%s`\n\n", jobName))
+
+	total := fmt.Sprintf("Total coverage: **%.2f%%** (%d/%d)", coverage.Percent(), coverage.Covered, coverage.Total)
+	if base != nil {
+		total += fmt.Sprintf(", %s compared to `%s`", coverageDelta(coverage.Percent(), base.Percent()), baseBranch)
+	}
+	b.WriteString(total + "\n")
+
+	if len(coverage.Files) == 0 {
+		return b.String()
+	}
+
+	baseFiles := map[string]float64{}
+	if base != nil {
+		for _, f := range base.Files {
+			baseFiles[f.Name] = f.Percent()
+		}
+	}
+
+	b.WriteString("\n| File | Coverage | Delta |\n| --- | ---: | ---: |\n")
+	for i, f := range coverage.Files {
+		if i >= coverageCommentMaxFiles {
+			b.WriteString(fmt.Sprintf("\n_%d more files are not shown_\n", len(coverage.Files)-coverageCommentMaxFiles))
+			break
+		}
+		delta := "-"
+		if basePercent, exist := baseFiles[f.Name]; exist {
+			delta = coverageDelta(f.Percent(), basePercent)
+		}
+		b.WriteString(fmt.Sprintf("| `%s` | %.2f%% | %s |\n", f.Name, f.Percent(), delta))
+	}
+	return b.String()
+}
+
+func coverageDelta(cur, base float64) string {
+	return fmt.Sprintf("%+.2f%%", cur-base)
+}
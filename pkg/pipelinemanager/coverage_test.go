@@ -0,0 +1,130 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pipelinemanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/pkg/git"
+	gitfake "github.com/tmax-cloud/cicd-operator/pkg/git/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPipelineManager_reportCoverage(t *testing.T) {
+	const testRepo = "tmax-cloud/cicd-operator"
+
+	s := runtime.NewScheme()
+	utilruntime.Must(cicdv1.AddToScheme(s))
+
+	threshold := 50
+	cfg := &cicdv1.IntegrationConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cfg", Namespace: "default"},
+		Spec: cicdv1.IntegrationConfigSpec{
+			Git: cicdv1.GitConfig{Type: cicdv1.GitTypeFake, Repository: testRepo, Token: &cicdv1.GitToken{Value: "token"}},
+		},
+	}
+
+	baseIJ := func(name string, completed time.Time, covered int) *cicdv1.IntegrationJob {
+		return &cicdv1.IntegrationJob{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{cicdv1.JobLabelConfig: cfg.Name}},
+			Spec: cicdv1.IntegrationJobSpec{
+				ConfigRef: cicdv1.IntegrationJobConfigRef{Name: cfg.Name, Type: cicdv1.JobTypePostSubmit},
+				Refs:      cicdv1.IntegrationJobRefs{Base: cicdv1.IntegrationJobRefsBase{Ref: "refs/heads/master"}},
+			},
+			Status: cicdv1.IntegrationJobStatus{
+				State:          cicdv1.IntegrationJobStateCompleted,
+				CompletionTime: &metav1.Time{Time: completed},
+				Jobs: []cicdv1.JobStatus{{Name: "test", Coverage: &cicdv1.JobCoverageStatus{Covered: covered, Total: 10, Files: []cicdv1.FileCoverage{
+					{Name: "a.go", Covered: covered, Total: 10},
+				}}}},
+			},
+		}
+	}
+	now := time.Now()
+	cli := fake.NewClientBuilder().WithScheme(s).WithObjects(cfg, baseIJ("old", now.Add(-time.Hour), 2), baseIJ("latest", now, 5)).Build()
+	p := &pipelineManager{Client: cli, Scheme: s}
+
+	gitfake.Repos = map[string]*gitfake.Repo{
+		testRepo: {
+			CommitStatuses: map[string][]git.CommitStatus{},
+			Comments:       map[int][]git.IssueComment{},
+		},
+	}
+
+	ij := &cicdv1.IntegrationJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "default"},
+		Spec: cicdv1.IntegrationJobSpec{
+			ConfigRef: cicdv1.IntegrationJobConfigRef{Name: cfg.Name, Type: cicdv1.JobTypePreSubmit},
+			Refs: cicdv1.IntegrationJobRefs{
+				Base:  cicdv1.IntegrationJobRefsBase{Ref: "master"},
+				Pulls: []cicdv1.IntegrationJobRefsPull{{ID: 1, Sha: git.FakeSha}},
+			},
+			Jobs: []cicdv1.Job{{Coverage: &cicdv1.JobCoverage{Format: cicdv1.CoverageFormatGo, Paths: []string{"cover.out"}, Threshold: &threshold}}},
+		},
+		Status: cicdv1.IntegrationJobStatus{
+			Jobs: []cicdv1.JobStatus{{Name: "test", State: cicdv1.CommitStatusStateSuccess, Coverage: &cicdv1.JobCoverageStatus{Covered: 4, Total: 10, Files: []cicdv1.FileCoverage{
+				{Name: "a.go", Covered: 4, Total: 10},
+			}}}},
+		},
+	}
+
+	// First report registers a comment
+	require.NoError(t, p.reportCoverage(cfg, ij, []bool{true}))
+	comments := gitfake.Repos[testRepo].Comments[1]
+	require.Len(t, comments, 1)
+	require.Contains(t, comments[0].Comment.Body, "<!-- cicd-operator:coverage:test -->")
+	require.Contains(t, comments[0].Comment.Body, "Total coverage: **40.00%** (4/10), -10.00% compared to `master`")
+	require.Contains(t, comments[0].Comment.Body, "| `a.go` | 40.00% | -10.00% |")
+
+	statuses := gitfake.Repos[testRepo].CommitStatuses[git.FakeSha]
+	require.Len(t, statuses, 1)
+	require.Equal(t, "coverage/test", statuses[0].Context)
+	require.Equal(t, git.CommitStatusStateFailure, statuses[0].State)
+	require.Equal(t, "Coverage 40.00% (threshold 50%)", statuses[0].Description)
+
+	// Second report updates the comment
+	ij.Status.Jobs[0].Coverage.Covered = 6
+	require.NoError(t, p.reportCoverage(cfg, ij, []bool{true}))
+	comments = gitfake.Repos[testRepo].Comments[1]
+	require.Len(t, comments, 1)
+	require.Contains(t, comments[0].Comment.Body, "**60.00%**")
+	require.Equal(t, git.CommitStatusStateSuccess, gitfake.Repos[testRepo].CommitStatuses[git.FakeSha][1].State)
+
+	// Not changed
+	ij.Status.Jobs[0].Coverage.Covered = 7
+	require.NoError(t, p.reportCoverage(cfg, ij, []bool{false}))
+	require.Contains(t, gitfake.Repos[testRepo].Comments[1][0].Comment.Body, "**60.00%**")
+}
+
+func Test_generateCoverageComment(t *testing.T) {
+	coverage := &cicdv1.JobCoverageStatus{Covered: 1, Total: 4}
+	for i := 0; i < coverageCommentMaxFiles+2; i++ {
+		coverage.Files = append(coverage.Files, cicdv1.FileCoverage{Name: "f.go", Covered: 1, Total: 2})
+	}
+
+	comment := generateCoverageComment("test", "master", coverage, nil)
+	require.Contains(t, comment, "Total coverage: **25.00%** (1/4)\n")
+	require.NotContains(t, comment, "compared to")
+	require.Contains(t, comment, "| `f.go` | 50.00% | - |")
+	require.Contains(t, comment, "_2 more files are not shown_")
+}
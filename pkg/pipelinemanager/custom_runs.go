@@ -28,14 +28,7 @@ func generateApprovalRunTask(job *cicdv1.IntegrationJob, j *cicdv1.Job, task *te
 	task.TaskRef = generateCustomTaskRef(cicdv1.CustomTaskKindApproval)
 
 	// Get approvers
-	var approvers []string
-	for _, approver := range j.Approval.Approvers {
-		param := approver.Name
-		if approver.Email != "" {
-			param += "=" + approver.Email
-		}
-		approvers = append(approvers, param)
-	}
+	approvers := approverParams(j.Approval.Approvers)
 
 	// Get message
 	msg := j.Approval.RequestMessage
@@ -65,6 +58,63 @@ func generateApprovalRunTask(job *cicdv1.IntegrationJob, j *cicdv1.Job, task *te
 		approverCm = j.Approval.ApproversConfigMap.Name
 	}
 	task.Params = append(task.Params, tektonv1beta1.Param{Name: cicdv1.CustomTaskApprovalParamKeyApproversCM, Value: tektonv1beta1.ArrayOrString{Type: tektonv1beta1.ParamTypeString, StringVal: approverCm}})
+
+	// Get policy
+	if policy := j.Approval.Policy; policy != nil {
+		// Authors are the pull requests' authors (all of them for a batch), or the head commit's author for the push events.
+		// Author is the first one, for the Runs handled by the older versions
+		authors := getAuthors(job)
+		var author cicdv1.ApprovalUser
+		if len(authors) > 0 {
+			author = authors[0]
+		}
+		task.Params = append(task.Params, []tektonv1beta1.Param{
+			{Name: cicdv1.CustomTaskApprovalParamKeyAuthor, Value: tektonv1beta1.ArrayOrString{Type: tektonv1beta1.ParamTypeString, StringVal: author.Name}},
+			{Name: cicdv1.CustomTaskApprovalParamKeyAuthorEmail, Value: tektonv1beta1.ArrayOrString{Type: tektonv1beta1.ParamTypeString, StringVal: author.Email}},
+			{Name: cicdv1.CustomTaskApprovalParamKeyAuthors, Value: tektonv1beta1.ArrayOrString{Type: tektonv1beta1.ParamTypeArray, ArrayVal: approverParams(authors)}},
+			{Name: cicdv1.CustomTaskApprovalParamKeyMinApprovals, Value: tektonv1beta1.ArrayOrString{Type: tektonv1beta1.ParamTypeString, StringVal: strconv.Itoa(policy.GetMinApprovals())}},
+			{Name: cicdv1.CustomTaskApprovalParamKeyGroups, Value: tektonv1beta1.ArrayOrString{Type: tektonv1beta1.ParamTypeArray, ArrayVal: policy.Groups}},
+			{Name: cicdv1.CustomTaskApprovalParamKeySeparationOfDuties, Value: tektonv1beta1.ArrayOrString{Type: tektonv1beta1.ParamTypeString, StringVal: strconv.FormatBool(policy.SeparationOfDuties)}},
+		}...)
+	}
+
+	// Get timeout/reminder
+	if timeout := j.Approval.Timeout; timeout != nil {
+		task.Params = append(task.Params, []tektonv1beta1.Param{
+			{Name: cicdv1.CustomTaskApprovalParamKeyTimeout, Value: tektonv1beta1.ArrayOrString{Type: tektonv1beta1.ParamTypeString, StringVal: timeout.Duration.String()}},
+			{Name: cicdv1.CustomTaskApprovalParamKeyOnTimeout, Value: tektonv1beta1.ArrayOrString{Type: tektonv1beta1.ParamTypeString, StringVal: string(j.Approval.OnTimeout)}},
+			{Name: cicdv1.CustomTaskApprovalParamKeyEscalationApprovers, Value: tektonv1beta1.ArrayOrString{Type: tektonv1beta1.ParamTypeArray, ArrayVal: approverParams(j.Approval.EscalationApprovers)}},
+		}...)
+	}
+	if interval := j.Approval.ReminderInterval; interval != nil {
+		task.Params = append(task.Params, tektonv1beta1.Param{Name: cicdv1.CustomTaskApprovalParamKeyReminderInterval, Value: tektonv1beta1.ArrayOrString{Type: tektonv1beta1.ParamTypeString, StringVal: interval.Duration.String()}})
+	}
+}
+
+// getAuthors returns the authors of the pull requests, or the author of the pushed head commit
+func getAuthors(job *cicdv1.IntegrationJob) []cicdv1.ApprovalUser {
+	var authors []cicdv1.ApprovalUser
+	if job.Spec.Refs.Pulls != nil {
+		for _, pull := range job.Spec.Refs.Pulls {
+			authors = append(authors, cicdv1.ApprovalUser{Name: pull.Author.Name, Email: pull.Author.Email})
+		}
+	} else if job.Spec.Refs.Base.Author != nil {
+		authors = append(authors, cicdv1.ApprovalUser{Name: job.Spec.Refs.Base.Author.Name, Email: job.Spec.Refs.Base.Author.Email})
+	}
+	return authors
+}
+
+// approverParams converts the approvers into the <Name>=<Email> form
+func approverParams(users []cicdv1.ApprovalUser) []string {
+	var approvers []string
+	for _, approver := range users {
+		param := approver.Name
+		if approver.Email != "" {
+			param += "=" + approver.Email
+		}
+		approvers = append(approvers, param)
+	}
+	return approvers
 }
 
 // Email custom tasks
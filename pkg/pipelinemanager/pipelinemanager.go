@@ -27,6 +27,7 @@ import (
 	tektonv1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/configs"
 	"github.com/tmax-cloud/cicd-operator/internal/utils"
 	"github.com/tmax-cloud/cicd-operator/pkg/events"
 	"github.com/tmax-cloud/cicd-operator/pkg/git"
@@ -51,6 +52,7 @@ const (
 	JobMessagePending    = "Job is running"
 	JobMessageSuccessful = "Job succeeded"
 	JobMessageFailure    = "Job failed"
+	JobMessageTimeout    = "Job timed out"
 )
 
 const (
@@ -91,7 +93,20 @@ func (p *pipelineManager) Generate(job *cicdv1.IntegrationJob) (*tektonv1beta1.P
 			}
 		}
 	}
-	
+
+	// Cache workspace
+	workspaces := job.Spec.Workspaces
+	if job.Spec.Jobs.HasCache() {
+		workspaceDefs = append(workspaceDefs, tektonv1beta1.PipelineWorkspaceDeclaration{Name: cacheWorkspaceName})
+		workspaces = append(append([]tektonv1beta1.WorkspaceBinding{}, workspaces...), cacheWorkspaceBinding(job))
+	}
+
+	// Artifact workspace
+	if configs.ArtifactStorage == configs.ArtifactStoragePVC && job.Spec.Jobs.HasArtifacts() {
+		workspaceDefs = append(workspaceDefs, tektonv1beta1.PipelineWorkspaceDeclaration{Name: artifactWorkspaceName})
+		workspaces = append(append([]tektonv1beta1.WorkspaceBinding{}, workspaces...), artifactWorkspaceBinding(job))
+	}
+
 	// Params
 	paramDefine, paramValue := getParams(job)
 
@@ -123,6 +138,10 @@ func (p *pipelineManager) Generate(job *cicdv1.IntegrationJob) (*tektonv1beta1.P
 	if err := fillDefaultEnvs(tasks, job); err != nil {
 		return nil, nil, err
 	}
+	// Fill artifact upload policies
+	if err := p.fillArtifactUploadEnvs(tasks, job); err != nil {
+		return nil, nil, err
+	}
 	pl := &tektonv1beta1.Pipeline{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      Name(job),
@@ -149,17 +168,27 @@ func (p *pipelineManager) Generate(job *cicdv1.IntegrationJob) (*tektonv1beta1.P
 				Name: pl.Name,
 			},
 			PodTemplate: job.Spec.PodTemplate,
-			Workspaces:  job.Spec.Workspaces,
-			Timeout: &metav1.Duration{
-				Duration: job.Spec.Timeout.Duration,
-			},
-			Params: paramValue,
+			Workspaces:  workspaces,
+			Timeout:     getPipelineRunTimeout(job),
+			Params:      paramValue,
 		},
 	}
 	return pl, pr, nil
 
 }
 
+// getPipelineRunTimeout returns the PipelineRun's timeout.
+// PipelineTimeout is used if it's set. If not, the pending timeout is used, for backward compatibility.
+func getPipelineRunTimeout(job *cicdv1.IntegrationJob) *metav1.Duration {
+	if job.Spec.PipelineTimeout != nil {
+		return job.Spec.PipelineTimeout.DeepCopy()
+	}
+	if job.Spec.Timeout != nil {
+		return job.Spec.Timeout.DeepCopy()
+	}
+	return nil
+}
+
 func getParams(job *cicdv1.IntegrationJob) ([]tektonv1beta1.ParamSpec, []tektonv1beta1.Param) {
 	var paramSpec []tektonv1beta1.ParamSpec
 	var param []tektonv1beta1.Param
@@ -226,7 +255,7 @@ func generateTask(job *cicdv1.IntegrationJob, j *cicdv1.Job, token string) (*tek
 		generateWebhookRunTask(job, j, task)
 	} else {
 		// Steps
-		steps, err := generateSteps(j)
+		steps, err := generateSteps(job, j)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -245,6 +274,31 @@ func generateTask(job *cicdv1.IntegrationJob, j *cicdv1.Job, token string) (*tek
 			wsBindings = append(wsBindings, tektonv1beta1.WorkspacePipelineTaskBinding{Name: w.Name, Workspace: w.Name})
 		}
 
+		// Cache workspace
+		if j.Cache != nil {
+			wsDefs = append(wsDefs, tektonv1beta1.WorkspaceDeclaration{Name: cacheWorkspaceName})
+			wsBindings = append(wsBindings, tektonv1beta1.WorkspacePipelineTaskBinding{Name: cacheWorkspaceName, Workspace: cacheWorkspaceName})
+		}
+
+		// Artifact workspace/result
+		if artifactsEnabled(j) {
+			if configs.ArtifactStorage == configs.ArtifactStoragePVC {
+				wsDefs = append(wsDefs, tektonv1beta1.WorkspaceDeclaration{Name: artifactWorkspaceName})
+				wsBindings = append(wsBindings, tektonv1beta1.WorkspacePipelineTaskBinding{Name: artifactWorkspaceName, Workspace: artifactWorkspaceName})
+			}
+			task.TaskSpec.Results = append(task.TaskSpec.Results, tektonv1beta1.TaskResult{Name: artifactResultName, Description: "Uploaded artifacts"})
+		}
+
+		// Test report result
+		if len(j.TestReports) > 0 {
+			task.TaskSpec.Results = append(task.TaskSpec.Results, tektonv1beta1.TaskResult{Name: testReportResultName, Description: "Test report summary"})
+		}
+
+		// Coverage result
+		if j.Coverage != nil {
+			task.TaskSpec.Results = append(task.TaskSpec.Results, tektonv1beta1.TaskResult{Name: coverageResultName, Description: "Coverage summary"})
+		}
+
 		task.TaskSpec.Workspaces = wsDefs
 		task.Workspaces = wsBindings
 	}
@@ -260,10 +314,15 @@ func generateTask(job *cicdv1.IntegrationJob, j *cicdv1.Job, token string) (*tek
 		task.TaskSpec.Results = append(task.TaskSpec.Results, j.Results...)
 	}
 
+	// Timeout
+	if j.Timeout != nil {
+		task.Timeout = j.Timeout.DeepCopy()
+	}
+
 	return task, resources, nil
 }
 
-func generateSteps(j *cicdv1.Job) ([]tektonv1beta1.Step, error) {
+func generateSteps(job *cicdv1.IntegrationJob, j *cicdv1.Job) ([]tektonv1beta1.Step, error) {
 	var steps []tektonv1beta1.Step
 
 	if !j.SkipCheckout {
@@ -280,7 +339,42 @@ func generateSteps(j *cicdv1.Job) ([]tektonv1beta1.Step, error) {
 		step.WorkingDir = DefaultWorkingDir
 	}
 	step.Script = j.Script
+
+	// Steps running before/after the job's step
+	var preSteps, postSteps []tektonv1beta1.Step
+	if j.Cache != nil {
+		restore, save, err := cacheSteps(j, step.WorkingDir)
+		if err != nil {
+			return nil, err
+		}
+		preSteps = append(preSteps, restore)
+		postSteps = append(postSteps, save)
+	}
+
+	// Final steps (e.g., uploading artifacts, parsing test reports) run even if the job fails,
+	// so the step is wrapped not to stop the task
+	var finalSteps []tektonv1beta1.Step
+	if artifactsEnabled(j) {
+		upload, err := artifactUploadStep(job, j, step.WorkingDir)
+		if err != nil {
+			return nil, err
+		}
+		finalSteps = append(finalSteps, upload)
+	}
+	if len(j.TestReports) > 0 {
+		finalSteps = append(finalSteps, testReportStep(j, step.WorkingDir))
+	}
+	if j.Coverage != nil {
+		finalSteps = append(finalSteps, coverageStep(j, step.WorkingDir))
+	}
+	if len(finalSteps) > 0 && wrapStep(&step) {
+		postSteps = append(postSteps, finalSteps...)
+		postSteps = append(postSteps, exitStep(step.Image))
+	}
+
+	steps = append(steps, preSteps...)
 	steps = append(steps, step)
+	steps = append(steps, postSteps...)
 	return steps, nil
 }
 
@@ -359,6 +453,11 @@ func (p *pipelineManager) ReflectStatus(pr *tektonv1beta1.PipelineRun, job *cicd
 		if err := p.updateGitCommitStatus(cfg, job, stateChanged); err != nil {
 			return err
 		}
+
+		// Report coverage to the pull request
+		if err := p.reportCoverage(cfg, job, stateChanged); err != nil {
+			log.Error(err, "")
+		}
 	}
 
 	// Emit events
@@ -415,10 +514,41 @@ func getJobRunStatus(prStatus tektonv1beta1.PipelineRunStatus, j *cicdv1.Job) *c
 	reflectFromTaskRuns(prStatus, j, jobStatus)
 	// Now find in Run
 	reflectFromRuns(prStatus, j, jobStatus)
+	// If the PipelineRun timed out, Tekton cancels the running tasks and never starts the others.
+	// Mark them as timed out, rather than failed or pending
+	if isPipelineRunTimedOut(prStatus) && isJobUnfinished(prStatus, j, jobStatus) {
+		jobStatus.State = cicdv1.CommitStatusStateFailure
+		jobStatus.Reason = cicdv1.JobStatusReasonTimedOut
+		if jobStatus.CompletionTime == nil {
+			jobStatus.CompletionTime = prStatus.CompletionTime.DeepCopy()
+		}
+	}
 
 	return jobStatus
 }
 
+func isPipelineRunTimedOut(prStatus tektonv1beta1.PipelineRunStatus) bool {
+	cond := prStatus.GetCondition(apis.ConditionSucceeded)
+	return cond != nil && cond.Reason == string(tektonv1beta1.PipelineRunReasonTimedOut)
+}
+
+// isJobUnfinished returns if the job is still pending or its TaskRun is cancelled before it finishes
+func isJobUnfinished(prStatus tektonv1beta1.PipelineRunStatus, j *cicdv1.Job, jobStatus *cicdv1.JobStatus) bool {
+	if jobStatus.State == cicdv1.CommitStatusStatePending {
+		return true
+	}
+	if jobStatus.State != cicdv1.CommitStatusStateFailure || jobStatus.Reason == cicdv1.JobStatusReasonTimedOut {
+		return false
+	}
+	for _, runStatus := range prStatus.TaskRuns {
+		if runStatus.Status != nil && runStatus.PipelineTaskName == j.Name {
+			cond := runStatus.Status.GetCondition(apis.ConditionSucceeded)
+			return cond != nil && cond.Reason == string(tektonv1beta1.TaskRunReasonCancelled)
+		}
+	}
+	return false
+}
+
 func reflectFromTaskRuns(prStatus tektonv1beta1.PipelineRunStatus, j *cicdv1.Job, jobStatus *cicdv1.JobStatus) {
 	for _, runStatus := range prStatus.TaskRuns {
 		if runStatus.Status != nil && runStatus.PipelineTaskName == j.Name {
@@ -434,7 +564,13 @@ func reflectFromTaskRuns(prStatus tektonv1beta1.PipelineRunStatus, j *cicdv1.Job
 				case corev1.ConditionFalse:
 					jobStatus.State = cicdv1.CommitStatusStateFailure
 				}
+				if rStatus.Conditions[0].Reason == string(tektonv1beta1.TaskRunReasonTimedOut) {
+					jobStatus.Reason = cicdv1.JobStatusReasonTimedOut
+				}
 			}
+			jobStatus.Artifacts = getArtifactsFromResults(rStatus.TaskRunResults)
+			jobStatus.TestReport = getTestReportFromResults(rStatus.TaskRunResults)
+			jobStatus.Coverage = getCoverageFromResults(rStatus.TaskRunResults)
 			break
 		}
 	}
@@ -481,13 +617,7 @@ func (p *pipelineManager) updateGitCommitStatus(cfg *cicdv1.IntegrationConfig, j
 	for i, j := range job.Status.Jobs {
 		if stateChanged[i] {
 			// Set simple message
-			msg := JobMessagePending
-			switch j.State {
-			case cicdv1.CommitStatusStateSuccess:
-				msg = JobMessageSuccessful
-			case cicdv1.CommitStatusStateFailure:
-				msg = JobMessageFailure
-			}
+			msg := getJobStatusDescription(&j)
 			if job.Spec.Refs.Pulls != nil {
 				msg = appendBaseShaToDescription(msg, job.Spec.Refs.Base.Sha)
 			}
@@ -509,6 +639,31 @@ func (p *pipelineManager) updateGitCommitStatus(cfg *cicdv1.IntegrationConfig, j
 	return nil
 }
 
+// getJobStatusDescription returns a simple description of the job's state
+func getJobStatusDescription(j *cicdv1.JobStatus) string {
+	switch j.State {
+	case cicdv1.CommitStatusStateSuccess:
+		if j.TestReport != nil {
+			return getTestReportDescription(j.TestReport)
+		}
+		return JobMessageSuccessful
+	case cicdv1.CommitStatusStateFailure:
+		if j.Reason == cicdv1.JobStatusReasonTimedOut {
+			return JobMessageTimeout
+		}
+		if j.TestReport != nil {
+			return getTestReportDescription(j.TestReport)
+		}
+		return JobMessageFailure
+	}
+	return JobMessagePending
+}
+
+// getTestReportDescription returns a summary of the test report, e.g., '12 failed / 340 passed'
+func getTestReportDescription(r *cicdv1.JobTestReport) string {
+	return fmt.Sprintf("%d failed / %d passed", r.Failed, r.Passed)
+}
+
 // appendBaseShaToDescription appends Base SHA to the commit statuses' description.
 // Merger can use this base SHA to check if the tests of the pull request is done against the most recent commit of the
 // target branch before merging it.
@@ -24,10 +24,15 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/run/v1alpha1"
 	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
 	"github.com/tmax-cloud/cicd-operator/pkg/git"
+	gitfake "github.com/tmax-cloud/cicd-operator/pkg/git/fake"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"knative.dev/pkg/apis"
 	"knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/apis/duck/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"testing"
 	"time"
 )
@@ -67,6 +72,7 @@ func TestGetJobRunStatus(t *testing.T) {
 		job      *cicdv1.Job
 
 		expectedJobStatus cicdv1.CommitStatusState
+		expectedReason    cicdv1.JobStatusReason
 	}{
 		"successTaskRun": {
 			prStatus: tektonv1beta1.PipelineRunStatus{
@@ -111,6 +117,40 @@ func TestGetJobRunStatus(t *testing.T) {
 			},
 			expectedJobStatus: cicdv1.CommitStatusStateSuccess,
 		},
+		"timedOutTaskRun": {
+			prStatus: tektonv1beta1.PipelineRunStatus{
+				PipelineRunStatusFields: tektonv1beta1.PipelineRunStatusFields{
+					TaskRuns: map[string]*tektonv1beta1.PipelineRunTaskRunStatus{
+						"matchName": {
+							PipelineTaskName: "matchTask",
+							Status: &tektonv1beta1.TaskRunStatus{
+								Status: v1beta1.Status{
+									Conditions: v1beta1.Conditions{
+										{
+											Status:  corev1.ConditionFalse,
+											Reason:  string(tektonv1beta1.TaskRunReasonTimedOut),
+											Message: "TaskRun \"matchName\" failed to finish within \"10m0s\"",
+										},
+									},
+								},
+								TaskRunStatusFields: tektonv1beta1.TaskRunStatusFields{
+									PodName:        "match",
+									StartTime:      &metav1.Time{Time: time.Now().Add(-1 * time.Hour)},
+									CompletionTime: &metav1.Time{Time: time.Now()},
+								},
+							},
+						},
+					},
+				},
+			},
+			job: &cicdv1.Job{
+				Container: corev1.Container{
+					Name: "matchTask",
+				},
+			},
+			expectedJobStatus: cicdv1.CommitStatusStateFailure,
+			expectedReason:    cicdv1.JobStatusReasonTimedOut,
+		},
 		"failureTaskRun": {
 			prStatus: tektonv1beta1.PipelineRunStatus{
 				PipelineRunStatusFields: tektonv1beta1.PipelineRunStatusFields{
@@ -270,10 +310,201 @@ func TestGetJobRunStatus(t *testing.T) {
 			jobStatus := getJobRunStatus(c.prStatus, c.job)
 
 			require.Equal(t, c.expectedJobStatus, jobStatus.State)
+			require.Equal(t, c.expectedReason, jobStatus.Reason)
 		})
 	}
 }
 
+func TestPipelineManager_ReflectStatus_timedOut(t *testing.T) {
+	const testRepo = "tmax-cloud/cicd-operator"
+
+	s := runtime.NewScheme()
+	utilruntime.Must(cicdv1.AddToScheme(s))
+	utilruntime.Must(corev1.AddToScheme(s))
+
+	cfg := &cicdv1.IntegrationConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cfg", Namespace: "default"},
+		Spec: cicdv1.IntegrationConfigSpec{
+			Git: cicdv1.GitConfig{Type: cicdv1.GitTypeFake, Repository: testRepo, Token: &cicdv1.GitToken{Value: "token"}},
+		},
+	}
+	p := &pipelineManager{Client: fake.NewClientBuilder().WithScheme(s).WithObjects(cfg).Build(), Scheme: s}
+
+	gitfake.Repos = map[string]*gitfake.Repo{
+		testRepo: {CommitStatuses: map[string][]git.CommitStatus{}},
+	}
+
+	job := &cicdv1.IntegrationJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-job", Namespace: "default"},
+		Spec: cicdv1.IntegrationJobSpec{
+			ConfigRef: cicdv1.IntegrationJobConfigRef{Name: cfg.Name, Type: cicdv1.JobTypePreSubmit},
+			Refs: cicdv1.IntegrationJobRefs{
+				Base:  cicdv1.IntegrationJobRefsBase{Ref: "master"},
+				Pulls: []cicdv1.IntegrationJobRefsPull{{ID: 1, Sha: git.FakeSha}},
+			},
+			Jobs: []cicdv1.Job{
+				{Container: corev1.Container{Name: "succeeded"}},
+				{Container: corev1.Container{Name: "failed"}},
+				{Container: corev1.Container{Name: "cancelled"}},
+				{Container: corev1.Container{Name: "notStarted"}},
+			},
+		},
+	}
+
+	completed := &metav1.Time{Time: time.Now()}
+	taskRun := func(name string, status corev1.ConditionStatus, reason string) *tektonv1beta1.PipelineRunTaskRunStatus {
+		return &tektonv1beta1.PipelineRunTaskRunStatus{
+			PipelineTaskName: name,
+			Status: &tektonv1beta1.TaskRunStatus{
+				Status: v1beta1.Status{Conditions: v1beta1.Conditions{{Type: apis.ConditionSucceeded, Status: status, Reason: reason}}},
+				TaskRunStatusFields: tektonv1beta1.TaskRunStatusFields{
+					PodName:        name,
+					StartTime:      &metav1.Time{Time: completed.Add(-time.Hour)},
+					CompletionTime: completed,
+				},
+			},
+		}
+	}
+	pr := &tektonv1beta1.PipelineRun{
+		Status: tektonv1beta1.PipelineRunStatus{
+			Status: v1beta1.Status{Conditions: v1beta1.Conditions{{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionFalse,
+				Reason: string(tektonv1beta1.PipelineRunReasonTimedOut),
+			}}},
+			PipelineRunStatusFields: tektonv1beta1.PipelineRunStatusFields{
+				CompletionTime: completed,
+				TaskRuns: map[string]*tektonv1beta1.PipelineRunTaskRunStatus{
+					"succeeded": taskRun("succeeded", corev1.ConditionTrue, string(tektonv1beta1.TaskRunReasonSuccessful)),
+					"failed":    taskRun("failed", corev1.ConditionFalse, string(tektonv1beta1.TaskRunReasonFailed)),
+					"cancelled": taskRun("cancelled", corev1.ConditionFalse, string(tektonv1beta1.TaskRunReasonCancelled)),
+				},
+			},
+		},
+	}
+
+	require.NoError(t, p.ReflectStatus(pr, job, cfg))
+	require.Equal(t, cicdv1.IntegrationJobStateFailed, job.Status.State)
+
+	expected := map[string]struct {
+		state  cicdv1.CommitStatusState
+		reason cicdv1.JobStatusReason
+	}{
+		"succeeded":  {state: cicdv1.CommitStatusStateSuccess},
+		"failed":     {state: cicdv1.CommitStatusStateFailure},
+		"cancelled":  {state: cicdv1.CommitStatusStateFailure, reason: cicdv1.JobStatusReasonTimedOut},
+		"notStarted": {state: cicdv1.CommitStatusStateFailure, reason: cicdv1.JobStatusReasonTimedOut},
+	}
+	for _, j := range job.Status.Jobs {
+		require.Equal(t, expected[j.Name].state, j.State, j.Name)
+		require.Equal(t, expected[j.Name].reason, j.Reason, j.Name)
+		require.NotNil(t, j.CompletionTime, j.Name)
+	}
+
+	statuses := map[string]git.CommitStatus{}
+	for _, st := range gitfake.Repos[testRepo].CommitStatuses[git.FakeSha] {
+		statuses[st.Context] = st
+	}
+	require.Len(t, statuses, 4)
+	require.Equal(t, git.CommitStatusStateFailure, statuses["notStarted"].State)
+	require.Contains(t, statuses["notStarted"].Description, JobMessageTimeout)
+	require.Contains(t, statuses["cancelled"].Description, JobMessageTimeout)
+	require.NotContains(t, statuses["failed"].Description, JobMessageTimeout)
+}
+
+func TestGetPipelineRunTimeout(t *testing.T) {
+	tc := map[string]struct {
+		spec cicdv1.IntegrationJobSpec
+
+		expectedTimeout *metav1.Duration
+	}{
+		"pipelineTimeout": {
+			spec: cicdv1.IntegrationJobSpec{
+				Timeout:         &metav1.Duration{Duration: 24 * time.Hour},
+				PipelineTimeout: &metav1.Duration{Duration: 30 * time.Minute},
+			},
+			expectedTimeout: &metav1.Duration{Duration: 30 * time.Minute},
+		},
+		"pendingTimeout": {
+			spec: cicdv1.IntegrationJobSpec{
+				Timeout: &metav1.Duration{Duration: 24 * time.Hour},
+			},
+			expectedTimeout: &metav1.Duration{Duration: 24 * time.Hour},
+		},
+		"noTimeout": {
+			spec:            cicdv1.IntegrationJobSpec{},
+			expectedTimeout: nil,
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, c.expectedTimeout, getPipelineRunTimeout(&cicdv1.IntegrationJob{Spec: c.spec}))
+		})
+	}
+}
+
+func TestGetJobStatusDescription(t *testing.T) {
+	tc := map[string]struct {
+		status cicdv1.JobStatus
+
+		expectedDesc string
+	}{
+		"pending": {
+			status:       cicdv1.JobStatus{State: cicdv1.CommitStatusStatePending},
+			expectedDesc: JobMessagePending,
+		},
+		"success": {
+			status:       cicdv1.JobStatus{State: cicdv1.CommitStatusStateSuccess},
+			expectedDesc: JobMessageSuccessful,
+		},
+		"failure": {
+			status:       cicdv1.JobStatus{State: cicdv1.CommitStatusStateFailure},
+			expectedDesc: JobMessageFailure,
+		},
+		"timedOut": {
+			status:       cicdv1.JobStatus{State: cicdv1.CommitStatusStateFailure, Reason: cicdv1.JobStatusReasonTimedOut},
+			expectedDesc: JobMessageTimeout,
+		},
+		"testReportSuccess": {
+			status:       cicdv1.JobStatus{State: cicdv1.CommitStatusStateSuccess, TestReport: &cicdv1.JobTestReport{Passed: 340}},
+			expectedDesc: "0 failed / 340 passed",
+		},
+		"testReportFailure": {
+			status:       cicdv1.JobStatus{State: cicdv1.CommitStatusStateFailure, TestReport: &cicdv1.JobTestReport{Passed: 340, Failed: 12}},
+			expectedDesc: "12 failed / 340 passed",
+		},
+		"testReportPending": {
+			status:       cicdv1.JobStatus{State: cicdv1.CommitStatusStatePending, TestReport: &cicdv1.JobTestReport{Passed: 340}},
+			expectedDesc: JobMessagePending,
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, c.expectedDesc, getJobStatusDescription(&c.status))
+		})
+	}
+}
+
+func TestGenerateTaskTimeout(t *testing.T) {
+	ij := &cicdv1.IntegrationJob{}
+	j := &cicdv1.Job{
+		Container: corev1.Container{Name: "test", Image: "alpine"},
+		Script:    "sleep 1000",
+		Timeout:   &metav1.Duration{Duration: 10 * time.Minute},
+	}
+
+	task, _, err := generateTask(ij, j, "")
+	require.NoError(t, err)
+	require.Equal(t, &metav1.Duration{Duration: 10 * time.Minute}, task.Timeout)
+
+	j.Timeout = nil
+	task, _, err = generateTask(ij, j, "")
+	require.NoError(t, err)
+	require.Nil(t, task.Timeout)
+}
+
 func TestGetParams(t *testing.T) {
 	tc := map[string]struct {
 		job *cicdv1.IntegrationJob
@@ -0,0 +1,205 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pipelinemanager
+
+import (
+	"strings"
+	"time"
+
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/configs"
+	"github.com/tmax-cloud/cicd-operator/pkg/artifacts"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	artifactStepName      = "artifacts-upload"
+	artifactWorkspaceName = "cicd-artifacts"
+	artifactResultName    = "cicd-artifacts"
+
+	// artifactResultMaxLength limits the length of the artifact list, as the termination message is limited to 4KiB
+	artifactResultMaxLength = 1500
+
+	// defaultArtifactUploadExpiration is used for the upload policy, if the pipeline has no timeout
+	defaultArtifactUploadExpiration = 24 * time.Hour
+)
+
+// Environment variables of the artifact upload step, containing the presigned POST policy
+const (
+	artifactEnvPrefix     = "ARTIFACT_PREFIX"
+	artifactEnvURL        = "ARTIFACT_UPLOAD_URL"
+	artifactEnvAlgorithm  = "ARTIFACT_UPLOAD_ALGORITHM"
+	artifactEnvCredential = "ARTIFACT_UPLOAD_CREDENTIAL"
+	artifactEnvDate       = "ARTIFACT_UPLOAD_DATE"
+	artifactEnvPolicy     = "ARTIFACT_UPLOAD_POLICY"
+	artifactEnvSignature  = "ARTIFACT_UPLOAD_SIGNATURE"
+)
+
+const artifactUploadScript = `#!/bin/sh
+FOUND_FILE=/tekton/home/.cicd-artifacts-found
+UPLOADED_FILE=/tekton/home/.cicd-artifacts-uploaded
+: > "$FOUND_FILE"
+: > "$UPLOADED_FILE"
+
+for PATTERN in {{.Patterns}}; do
+  find . -type f -path "./$PATTERN" | sed 's|^\./||' >> "$FOUND_FILE"
+done
+sort -u "$FOUND_FILE" -o "$FOUND_FILE"
+
+while read -r FILE; do
+  echo "Uploading $FILE"
+{{- if eq .Storage "s3"}}
+  if curl -sSf -o /dev/null "$ARTIFACT_UPLOAD_URL" \
+    --form-string "key=$ARTIFACT_PREFIX/$FILE" \
+    --form-string "X-Amz-Algorithm=$ARTIFACT_UPLOAD_ALGORITHM" \
+    --form-string "X-Amz-Credential=$ARTIFACT_UPLOAD_CREDENTIAL" \
+    --form-string "X-Amz-Date=$ARTIFACT_UPLOAD_DATE" \
+    --form-string "Policy=$ARTIFACT_UPLOAD_POLICY" \
+    --form-string "X-Amz-Signature=$ARTIFACT_UPLOAD_SIGNATURE" \
+    -F "file=@\"$FILE\""; then
+{{- else}}
+  TARGET="$(workspaces.{{.Workspace}}.path)/$ARTIFACT_PREFIX/$FILE"
+  if mkdir -p "$(dirname "$TARGET")" && cp "$FILE" "$TARGET"; then
+{{- end}}
+    echo "$FILE" >> "$UPLOADED_FILE"
+  else
+    echo "Cannot upload $FILE"
+  fi
+done < "$FOUND_FILE"
+
+SIZE=0
+while read -r FILE; do
+  SIZE=$((SIZE + ${#FILE} + 1))
+  [ "$SIZE" -gt {{.MaxLength}} ] && break
+  echo "$FILE"
+done < "$UPLOADED_FILE" > $(results.{{.Result}}.path)
+`
+
+type artifactScriptParam struct {
+	Patterns  string
+	Storage   string
+	Workspace string
+	Result    string
+	MaxLength int
+}
+
+// artifactsEnabled returns if the job's artifacts should be uploaded
+func artifactsEnabled(j *cicdv1.Job) bool {
+	return len(j.Artifacts) > 0 && configs.ArtifactStorage != ""
+}
+
+// artifactUploadStep generates a step uploading the artifacts to the storage and reporting the uploaded paths as a result.
+// The upload policy is filled later by fillArtifactUploadEnvs
+func artifactUploadStep(job *cicdv1.IntegrationJob, j *cicdv1.Job, workingDir string) (tektonv1beta1.Step, error) {
+	var patterns []string
+	for _, p := range j.Artifacts {
+		patterns = append(patterns, shellQuote(strings.TrimPrefix(p, "./")))
+	}
+
+	script, err := executeScript(artifactUploadScript, artifactScriptParam{
+		Patterns:  strings.Join(patterns, " "),
+		Storage:   configs.ArtifactStorage,
+		Workspace: artifactWorkspaceName,
+		Result:    artifactResultName,
+		MaxLength: artifactResultMaxLength,
+	})
+	if err != nil {
+		return tektonv1beta1.Step{}, err
+	}
+
+	return tektonv1beta1.Step{
+		Container: corev1.Container{
+			Name:       artifactStepName,
+			Image:      configs.ArtifactImage,
+			WorkingDir: workingDir,
+			Env:        []corev1.EnvVar{{Name: artifactEnvPrefix, Value: artifacts.Prefix(job, j.Name)}},
+		},
+		Script: script,
+	}, nil
+}
+
+// fillArtifactUploadEnvs issues a presigned POST policy for each artifact upload step, which only allows uploading
+// objects under the job's artifact prefix. The storage credential itself is never handed out to the jobs
+func (p *pipelineManager) fillArtifactUploadEnvs(tasks []tektonv1beta1.PipelineTask, job *cicdv1.IntegrationJob) error {
+	if configs.ArtifactStorage != configs.ArtifactStorageS3 || !job.Spec.Jobs.HasArtifacts() {
+		return nil
+	}
+
+	s3, err := artifacts.NewS3(p.Client)
+	if err != nil {
+		return err
+	}
+
+	expires := defaultArtifactUploadExpiration
+	if timeout := getPipelineRunTimeout(job); timeout != nil && timeout.Duration > 0 {
+		expires = timeout.Duration
+	}
+
+	// Index-based loop, because Go creates a copy when iterating
+	for i := range tasks {
+		if tasks[i].TaskSpec == nil {
+			continue
+		}
+		steps := tasks[i].TaskSpec.Steps
+		for j := range steps {
+			if steps[j].Name != artifactStepName {
+				continue
+			}
+			policy, err := s3.PresignPost(artifacts.Prefix(job, tasks[i].Name)+"/", expires)
+			if err != nil {
+				return err
+			}
+			steps[j].Env = append(steps[j].Env,
+				corev1.EnvVar{Name: artifactEnvURL, Value: policy.URL},
+				corev1.EnvVar{Name: artifactEnvAlgorithm, Value: policy.Fields[artifacts.PostFieldAlgorithm]},
+				corev1.EnvVar{Name: artifactEnvCredential, Value: policy.Fields[artifacts.PostFieldCredential]},
+				corev1.EnvVar{Name: artifactEnvDate, Value: policy.Fields[artifacts.PostFieldDate]},
+				corev1.EnvVar{Name: artifactEnvPolicy, Value: policy.Fields[artifacts.PostFieldPolicy]},
+				corev1.EnvVar{Name: artifactEnvSignature, Value: policy.Fields[artifacts.PostFieldSignature]},
+			)
+		}
+	}
+	return nil
+}
+
+// artifactWorkspaceBinding binds the artifact workspace to the config's artifact PVC
+func artifactWorkspaceBinding(job *cicdv1.IntegrationJob) tektonv1beta1.WorkspaceBinding {
+	return tektonv1beta1.WorkspaceBinding{
+		Name: artifactWorkspaceName,
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+			ClaimName: cicdv1.GetArtifactPVCName(job.Spec.ConfigRef.Name),
+		},
+	}
+}
+
+// getArtifactsFromResults parses the uploaded artifact list from the TaskRun's results
+func getArtifactsFromResults(results []tektonv1beta1.TaskRunResult) []string {
+	for _, r := range results {
+		if r.Name != artifactResultName {
+			continue
+		}
+		var paths []string
+		for _, p := range strings.Split(r.Value, "\n") {
+			if p = strings.TrimSpace(p); p != "" {
+				paths = append(paths, p)
+			}
+		}
+		return paths
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pipelinemanager
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/configs"
+	"github.com/tmax-cloud/cicd-operator/internal/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_artifactUploadStep(t *testing.T) {
+	ij := &cicdv1.IntegrationJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-ij", Namespace: "default"},
+		Spec:       cicdv1.IntegrationJobSpec{ConfigRef: cicdv1.IntegrationJobConfigRef{Name: "test-ic"}},
+	}
+	j := &cicdv1.Job{Container: corev1.Container{Name: "build"}, Artifacts: []string{"./bin/*", "report.xml"}}
+
+	tc := map[string]struct {
+		storage string
+
+		expectedCommand string
+	}{
+		"pvc": {
+			storage:         configs.ArtifactStoragePVC,
+			expectedCommand: `TARGET="$(workspaces.cicd-artifacts.path)/$ARTIFACT_PREFIX/$FILE"`,
+		},
+		"s3": {
+			storage:         configs.ArtifactStorageS3,
+			expectedCommand: `--form-string "key=$ARTIFACT_PREFIX/$FILE"`,
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			configs.ArtifactStorage = c.storage
+			configs.ArtifactImage = "curlimages/curl"
+			defer func() { configs.ArtifactStorage = "" }()
+
+			step, err := artifactUploadStep(ij, j, DefaultWorkingDir)
+			require.NoError(t, err)
+			require.Equal(t, "artifacts-upload", step.Name)
+			require.Equal(t, "curlimages/curl", step.Image)
+			require.Contains(t, step.Script, `for PATTERN in 'bin/*' 'report.xml'; do`)
+			require.Contains(t, step.Script, c.expectedCommand)
+			require.Contains(t, step.Script, "$(results.cicd-artifacts.path)")
+			require.Equal(t, []corev1.EnvVar{{Name: "ARTIFACT_PREFIX", Value: "default/test-ij/build"}}, step.Env)
+		})
+	}
+}
+
+func TestPipelineManager_fillArtifactUploadEnvs(t *testing.T) {
+	s := runtime.NewScheme()
+	utilruntime.Must(corev1.AddToScheme(s))
+
+	configs.ArtifactStorage = configs.ArtifactStorageS3
+	configs.ArtifactS3Endpoint = "http://minio.minio:9000"
+	configs.ArtifactS3Bucket = "artifacts"
+	configs.ArtifactS3Secret = "artifact-secret"
+	defer func() { configs.ArtifactStorage = "" }()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "artifact-secret", Namespace: utils.Namespace()},
+		Data:       map[string][]byte{"accessKey": []byte("access"), "secretKey": []byte("secret")},
+	}
+	ij := &cicdv1.IntegrationJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-ij", Namespace: "default"},
+		Spec: cicdv1.IntegrationJobSpec{
+			Jobs: cicdv1.Jobs{
+				{Container: corev1.Container{Name: "build"}, Artifacts: []string{"bin/*"}},
+				{Container: corev1.Container{Name: "test"}},
+			},
+		},
+	}
+	tasks := []tektonv1beta1.PipelineTask{
+		{Name: "build", TaskSpec: &tektonv1beta1.EmbeddedTask{TaskSpec: tektonv1beta1.TaskSpec{Steps: []tektonv1beta1.Step{
+			{Container: corev1.Container{Name: "step-0"}},
+			{Container: corev1.Container{Name: "artifacts-upload", Env: []corev1.EnvVar{{Name: "ARTIFACT_PREFIX", Value: "default/test-ij/build"}}}},
+		}}}},
+		{Name: "test", TaskSpec: &tektonv1beta1.EmbeddedTask{TaskSpec: tektonv1beta1.TaskSpec{Steps: []tektonv1beta1.Step{
+			{Container: corev1.Container{Name: "step-0"}},
+		}}}},
+	}
+
+	// No credential
+	p := &pipelineManager{Client: fake.NewClientBuilder().WithScheme(s).Build(), Scheme: s}
+	require.Error(t, p.fillArtifactUploadEnvs(tasks, ij))
+
+	p = &pipelineManager{Client: fake.NewClientBuilder().WithScheme(s).WithObjects(secret).Build(), Scheme: s}
+	require.NoError(t, p.fillArtifactUploadEnvs(tasks, ij))
+
+	envs := map[string]string{}
+	for _, e := range tasks[0].TaskSpec.Steps[1].Env {
+		require.Nil(t, e.ValueFrom)
+		envs[e.Name] = e.Value
+	}
+	require.Equal(t, "http://minio.minio:9000/artifacts", envs["ARTIFACT_UPLOAD_URL"])
+	require.Equal(t, "AWS4-HMAC-SHA256", envs["ARTIFACT_UPLOAD_ALGORITHM"])
+	require.True(t, strings.HasPrefix(envs["ARTIFACT_UPLOAD_CREDENTIAL"], "access/"))
+	require.NotEmpty(t, envs["ARTIFACT_UPLOAD_SIGNATURE"])
+	for _, e := range envs {
+		require.NotContains(t, e, "secret")
+	}
+
+	policy, err := base64.StdEncoding.DecodeString(envs["ARTIFACT_UPLOAD_POLICY"])
+	require.NoError(t, err)
+	require.Contains(t, string(policy), `["starts-with","$key","default/test-ij/build/"]`)
+
+	require.Len(t, tasks[0].TaskSpec.Steps[0].Env, 0)
+	require.Len(t, tasks[1].TaskSpec.Steps[0].Env, 0)
+}
+
+func Test_generateSteps_artifacts(t *testing.T) {
+	configs.ArtifactStorage = configs.ArtifactStoragePVC
+	defer func() { configs.ArtifactStorage = "" }()
+
+	j := &cicdv1.Job{
+		Container: corev1.Container{Name: "test", Image: "golang:1.17"},
+		Script:    "go build -o bin/app ./...",
+		Cache:     &cicdv1.JobCache{Key: "go-mod", Paths: []string{".cache/go-mod"}},
+		Artifacts: []string{"bin/*"},
+	}
+
+	steps, err := generateSteps(&cicdv1.IntegrationJob{}, j)
+	require.NoError(t, err)
+	require.Len(t, steps, 6)
+	require.Equal(t, "git-clone", steps[0].Name)
+	require.Equal(t, "cache-restore", steps[1].Name)
+	require.Contains(t, steps[2].Script, wrappedScriptFile)
+	require.Equal(t, "cache-save", steps[3].Name)
+	require.Equal(t, "artifacts-upload", steps[4].Name)
+	require.Equal(t, "exit", steps[5].Name)
+	require.Equal(t, "golang:1.17", steps[5].Image)
+
+	task, _, err := generateTask(&cicdv1.IntegrationJob{}, j, "")
+	require.NoError(t, err)
+	require.Equal(t, artifactWorkspaceName, task.TaskSpec.Workspaces[1].Name)
+	require.Equal(t, artifactResultName, task.TaskSpec.Results[0].Name)
+
+	configs.ArtifactStorage = configs.ArtifactStorageS3
+	task, _, err = generateTask(&cicdv1.IntegrationJob{}, j, "")
+	require.NoError(t, err)
+	require.Len(t, task.TaskSpec.Workspaces, 1)
+	require.Equal(t, artifactResultName, task.TaskSpec.Results[0].Name)
+
+	configs.ArtifactStorage = ""
+	steps, err = generateSteps(&cicdv1.IntegrationJob{}, j)
+	require.NoError(t, err)
+	require.Len(t, steps, 4)
+	require.Equal(t, j.Script, steps[2].Script)
+}
+
+func Test_getArtifactsFromResults(t *testing.T) {
+	results := []tektonv1beta1.TaskRunResult{
+		{Name: "other", Value: "value"},
+		{Name: artifactResultName, Value: "bin/app\nreport.xml\n\n"},
+	}
+	require.Equal(t, []string{"bin/app", "report.xml"}, getArtifactsFromResults(results))
+	require.Nil(t, getArtifactsFromResults(nil))
+}
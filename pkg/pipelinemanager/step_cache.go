@@ -0,0 +1,172 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pipelinemanager
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/configs"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	cacheWorkspaceName = "cicd-cache"
+	cacheKeyFile       = "/tekton/home/.cicd-cache-key"
+)
+
+const cacheRestoreScript = `#!/bin/sh
+CACHE_KEY="{{.Key}}"
+CACHE_KEY="$(printf '%s' "$CACHE_KEY" | tr -c 'a-zA-Z0-9._-' '_')"
+CACHE_FILE="$(workspaces.{{.Workspace}}.path)/$CACHE_KEY.tar"
+printf '%s' "$CACHE_KEY" > {{.KeyFile}}
+
+if [ -f "$CACHE_FILE" ]; then
+  echo "Restoring cache $CACHE_KEY"
+  tar -xf "$CACHE_FILE" || echo "Cannot restore cache $CACHE_KEY"
+  touch "$CACHE_FILE"
+else
+  echo "Cache $CACHE_KEY does not exist"
+fi
+`
+
+const cacheSaveScript = `#!/bin/sh
+if [ "$(cat {{.ExitCodeFile}} 2>/dev/null || echo 0)" != "0" ]; then
+  echo "Job failed. Skipping saving cache"
+  exit 0
+fi
+
+CACHE_KEY="$(cat {{.KeyFile}})"
+CACHE_FILE="$(workspaces.{{.Workspace}}.path)/$CACHE_KEY.tar"
+# Jobs sharing the key may save the cache at the same time, so each job writes its own temporary file
+TMP_FILE="$CACHE_FILE.$(hostname).tmp"
+
+if [ -f "$CACHE_FILE" ]; then
+  echo "Cache $CACHE_KEY already exists"
+  exit 0
+fi
+
+if tar -cf "$TMP_FILE" {{.Paths}}; then
+  mv "$TMP_FILE" "$CACHE_FILE"
+  echo "Saved cache $CACHE_KEY"
+else
+  rm -f "$TMP_FILE"
+  echo "Cannot save cache $CACHE_KEY"
+fi
+`
+
+type cacheScriptParam struct {
+	Key          string
+	KeyFile      string
+	ExitCodeFile string
+	Workspace    string
+	Paths        string
+}
+
+// cacheSteps generates steps restoring the cache before the job and saving it after the job
+func cacheSteps(j *cicdv1.Job, workingDir string) (tektonv1beta1.Step, tektonv1beta1.Step, error) {
+	key, err := renderCacheKey(j.Cache.Key)
+	if err != nil {
+		return tektonv1beta1.Step{}, tektonv1beta1.Step{}, err
+	}
+
+	var paths []string
+	for _, p := range j.Cache.Paths {
+		paths = append(paths, shellQuote(p))
+	}
+
+	param := cacheScriptParam{
+		Key:          key,
+		KeyFile:      cacheKeyFile,
+		ExitCodeFile: exitCodeFile,
+		Workspace:    cacheWorkspaceName,
+		Paths:        strings.Join(paths, " "),
+	}
+
+	restoreScript, err := executeScript(cacheRestoreScript, param)
+	if err != nil {
+		return tektonv1beta1.Step{}, tektonv1beta1.Step{}, err
+	}
+	saveScript, err := executeScript(cacheSaveScript, param)
+	if err != nil {
+		return tektonv1beta1.Step{}, tektonv1beta1.Step{}, err
+	}
+
+	restore := tektonv1beta1.Step{Container: corev1.Container{Name: "cache-restore", Image: configs.CacheImage, WorkingDir: workingDir}, Script: restoreScript}
+	save := tektonv1beta1.Step{Container: corev1.Container{Name: "cache-save", Image: configs.CacheImage, WorkingDir: workingDir}, Script: saveScript}
+	return restore, save, nil
+}
+
+// renderCacheKey renders the key template into a shell expression
+// hashFiles function is rendered as a command substitution, which calculates hash of the files in the job's pod
+func renderCacheKey(keyTemplate string) (string, error) {
+	tmpl, err := template.New("").Funcs(template.FuncMap{
+		"hashFiles": func(files ...string) string {
+			var quoted []string
+			for _, f := range files {
+				quoted = append(quoted, shellQuote(f))
+			}
+			return fmt.Sprintf("$(cat %s 2>/dev/null | sha256sum | cut -c1-16)", strings.Join(quoted, " "))
+		},
+	}).Parse(keyTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, nil); err != nil {
+		return "", err
+	}
+	key := buf.String()
+	if key == "" {
+		return "", fmt.Errorf("cache key is empty")
+	}
+	if strings.ContainsAny(key, "\"`\\\n") {
+		return "", fmt.Errorf("cache key should not contain double quotes, backquotes, backslashes or new lines")
+	}
+	return key, nil
+}
+
+func executeScript(script string, param interface{}) (string, error) {
+	tmpl, err := template.New("").Parse(script)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, param); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// cacheWorkspaceBinding binds the cache workspace to the config's cache PVC
+func cacheWorkspaceBinding(job *cicdv1.IntegrationJob) tektonv1beta1.WorkspaceBinding {
+	return tektonv1beta1.WorkspaceBinding{
+		Name: cacheWorkspaceName,
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+			ClaimName: cicdv1.GetCachePVCName(job.Spec.ConfigRef.Name),
+		},
+	}
+}
@@ -0,0 +1,128 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pipelinemanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/configs"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func Test_renderCacheKey(t *testing.T) {
+	tc := map[string]struct {
+		key string
+
+		errorOccurs  bool
+		errorMessage string
+		expectedKey  string
+	}{
+		"static": {
+			key:         "go-mod",
+			expectedKey: "go-mod",
+		},
+		"hashFiles": {
+			key:         `go-{{ hashFiles "go.sum" "go.mod" }}`,
+			expectedKey: "go-$(cat 'go.sum' 'go.mod' 2>/dev/null | sha256sum | cut -c1-16)",
+		},
+		"env": {
+			key:         "$CI_CONFIG_NAME-{{ hashFiles \"package-lock.json\" }}",
+			expectedKey: "$CI_CONFIG_NAME-$(cat 'package-lock.json' 2>/dev/null | sha256sum | cut -c1-16)",
+		},
+		"parseErr": {
+			key:          "go-{{ hashFiles ",
+			errorOccurs:  true,
+			errorMessage: "template: :1: unclosed action",
+		},
+		"empty": {
+			key:          "",
+			errorOccurs:  true,
+			errorMessage: "cache key is empty",
+		},
+		"quote": {
+			key:          `go-"mod`,
+			errorOccurs:  true,
+			errorMessage: "cache key should not contain double quotes, backquotes, backslashes or new lines",
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			key, err := renderCacheKey(c.key)
+			if c.errorOccurs {
+				require.Error(t, err)
+				require.Equal(t, c.errorMessage, err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, c.expectedKey, key)
+			}
+		})
+	}
+}
+
+func Test_cacheSteps(t *testing.T) {
+	configs.CacheImage = "alpine:3.14"
+	j := &cicdv1.Job{
+		Container: corev1.Container{Name: "test"},
+		Cache: &cicdv1.JobCache{
+			Key:   `go-{{ hashFiles "go.sum" }}`,
+			Paths: []string{".cache/go-mod", "vendor dir"},
+		},
+	}
+
+	restore, save, err := cacheSteps(j, DefaultWorkingDir)
+	require.NoError(t, err)
+
+	require.Equal(t, "cache-restore", restore.Name)
+	require.Equal(t, "alpine:3.14", restore.Image)
+	require.Equal(t, DefaultWorkingDir, restore.WorkingDir)
+	require.Contains(t, restore.Script, `CACHE_KEY="go-$(cat 'go.sum' 2>/dev/null | sha256sum | cut -c1-16)"`)
+	require.Contains(t, restore.Script, `CACHE_FILE="$(workspaces.cicd-cache.path)/$CACHE_KEY.tar"`)
+
+	require.Equal(t, "cache-save", save.Name)
+	require.Equal(t, DefaultWorkingDir, save.WorkingDir)
+	require.Contains(t, save.Script, `tar -cf "$TMP_FILE" '.cache/go-mod' 'vendor dir'`)
+}
+
+func Test_generateSteps_cache(t *testing.T) {
+	j := &cicdv1.Job{
+		Container: corev1.Container{Name: "test", Image: "golang:1.17"},
+		Script:    "go build ./...",
+		Cache: &cicdv1.JobCache{
+			Key:   "go-mod",
+			Paths: []string{".cache/go-mod"},
+		},
+	}
+
+	steps, err := generateSteps(&cicdv1.IntegrationJob{}, j)
+	require.NoError(t, err)
+	require.Len(t, steps, 4)
+	require.Equal(t, "git-clone", steps[0].Name)
+	require.Equal(t, "cache-restore", steps[1].Name)
+	require.Equal(t, "golang:1.17", steps[2].Image)
+	require.Equal(t, "cache-save", steps[3].Name)
+
+	task, _, err := generateTask(&cicdv1.IntegrationJob{}, j, "")
+	require.NoError(t, err)
+	require.Equal(t, cacheWorkspaceName, task.TaskSpec.Workspaces[0].Name)
+	require.Equal(t, cacheWorkspaceName, task.Workspaces[0].Workspace)
+
+	binding := cacheWorkspaceBinding(&cicdv1.IntegrationJob{Spec: cicdv1.IntegrationJobSpec{ConfigRef: cicdv1.IntegrationJobConfigRef{Name: "test-ic"}}})
+	require.Equal(t, "test-ic-cache", binding.PersistentVolumeClaim.ClaimName)
+}
@@ -0,0 +1,72 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pipelinemanager
+
+import (
+	"encoding/json"
+	"fmt"
+
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/configs"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	coverageResultName = "cicd-coverage"
+
+	// coverageResultMaxLength limits the length of the coverage summary, as the termination message is limited to 4KiB
+	coverageResultMaxLength = 800
+)
+
+// coverageStep generates a step collecting the code coverage and reporting the summary as a result.
+// The coverage files are parsed by the helper image, and the summary is written in JSON
+func coverageStep(j *cicdv1.Job, workingDir string) tektonv1beta1.Step {
+	args := []string{
+		"coverage",
+		fmt.Sprintf("--format=%s", j.Coverage.Format),
+		fmt.Sprintf("--result=$(results.%s.path)", coverageResultName),
+		fmt.Sprintf("--max-length=%d", coverageResultMaxLength),
+	}
+	args = append(args, j.Coverage.Paths...)
+
+	return tektonv1beta1.Step{
+		Container: corev1.Container{
+			Name:       "coverage",
+			Image:      configs.HelperImage,
+			Command:    []string{"/helper"},
+			Args:       args,
+			WorkingDir: workingDir,
+		},
+	}
+}
+
+// getCoverageFromResults parses the coverage summary from the TaskRun's results
+func getCoverageFromResults(results []tektonv1beta1.TaskRunResult) *cicdv1.JobCoverageStatus {
+	for _, r := range results {
+		if r.Name != coverageResultName {
+			continue
+		}
+		coverage := &cicdv1.JobCoverageStatus{}
+		if err := json.Unmarshal([]byte(r.Value), coverage); err != nil {
+			log.Error(err, "cannot parse the coverage summary")
+			return nil
+		}
+		return coverage
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pipelinemanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/configs"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func Test_coverageStep(t *testing.T) {
+	configs.HelperImage = "tmaxcloudck/cicd-helper:latest"
+	j := &cicdv1.Job{Container: corev1.Container{Name: "test"}, Coverage: &cicdv1.JobCoverage{Format: cicdv1.CoverageFormatLCOV, Paths: []string{"./coverage/*.info"}}}
+
+	step := coverageStep(j, DefaultWorkingDir)
+	require.Equal(t, "coverage", step.Name)
+	require.Equal(t, "tmaxcloudck/cicd-helper:latest", step.Image)
+	require.Equal(t, DefaultWorkingDir, step.WorkingDir)
+	require.Equal(t, []string{"/helper"}, step.Command)
+	require.Equal(t, []string{"coverage", "--format=lcov", "--result=$(results.cicd-coverage.path)", "--max-length=800", "./coverage/*.info"}, step.Args)
+}
+
+func Test_generateSteps_coverage(t *testing.T) {
+	j := &cicdv1.Job{
+		Container: corev1.Container{Name: "test", Image: "golang:1.17"},
+		Script:    "go test -coverprofile=cover.out ./...",
+		Coverage:  &cicdv1.JobCoverage{Format: cicdv1.CoverageFormatGo, Paths: []string{"cover.out"}},
+	}
+
+	steps, err := generateSteps(&cicdv1.IntegrationJob{}, j)
+	require.NoError(t, err)
+	require.Len(t, steps, 4)
+	require.Contains(t, steps[1].Script, wrappedScriptFile)
+	require.Equal(t, "coverage", steps[2].Name)
+	require.Equal(t, "exit", steps[3].Name)
+
+	task, _, err := generateTask(&cicdv1.IntegrationJob{}, j, "")
+	require.NoError(t, err)
+	require.Equal(t, coverageResultName, task.TaskSpec.Results[0].Name)
+}
+
+func Test_getCoverageFromResults(t *testing.T) {
+	tc := map[string]struct {
+		results []tektonv1beta1.TaskRunResult
+
+		expectedCoverage *cicdv1.JobCoverageStatus
+	}{
+		"normal": {
+			results: []tektonv1beta1.TaskRunResult{
+				{Name: testReportResultName, Value: `{"passed":1,"failed":0,"skipped":0}`},
+				{Name: coverageResultName, Value: `{"covered":2,"total":6,"files":[{"name":"pkg/a b.go","covered":2,"total":5},{"name":"pkg/c.go","covered":0,"total":1}]}`},
+			},
+			expectedCoverage: &cicdv1.JobCoverageStatus{Covered: 2, Total: 6, Files: []cicdv1.FileCoverage{
+				{Name: "pkg/a b.go", Covered: 2, Total: 5},
+				{Name: "pkg/c.go", Covered: 0, Total: 1},
+			}},
+		},
+		"malformed": {
+			results:          []tektonv1beta1.TaskRunResult{{Name: coverageResultName, Value: "total 0 0\nx 1 a.go\n"}},
+			expectedCoverage: nil,
+		},
+		"noResult": {
+			results:          []tektonv1beta1.TaskRunResult{{Name: artifactResultName, Value: "cover.out"}},
+			expectedCoverage: nil,
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, c.expectedCoverage, getCoverageFromResults(c.results))
+		})
+	}
+}
@@ -0,0 +1,83 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pipelinemanager
+
+import (
+	"fmt"
+	"strings"
+
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	exitCodeFile      = "/tekton/home/.cicd-exit-code"
+	wrappedScriptFile = "/tekton/home/.cicd-script"
+
+	wrappedScriptDelimiter = "CICD_SCRIPT_EOF"
+)
+
+const wrappedScript = `#!/bin/sh
+cat > %[1]s <<'%[4]s'
+%[2]s
+%[4]s
+chmod +x %[1]s
+%[1]s "$@"
+echo $? > %[3]s
+`
+
+const exitScript = `#!/bin/sh
+exit "$(cat %s 2>/dev/null || echo 0)"
+`
+
+// wrapStep makes the step always succeed, storing its exit code in a file, so that the following post steps
+// (e.g., uploading artifacts) run even if the step fails. exitStep should be placed after the post steps.
+// Step's onError field cannot be used instead, as it's not supported by the Tekton versions below v0.27.
+// It returns false if the step cannot be wrapped (i.e., neither script nor command is given)
+func wrapStep(step *tektonv1beta1.Step) bool {
+	switch {
+	case step.Script != "":
+		script := strings.TrimSuffix(step.Script, "\n")
+		if !strings.HasPrefix(script, "#!") {
+			script = "#!/bin/sh\nset -xe\n" + script
+		}
+		step.Script = fmt.Sprintf(wrappedScript, wrappedScriptFile, script, exitCodeFile, scriptDelimiter(script))
+	case len(step.Command) > 0:
+		step.Args = append(append([]string{}, step.Command...), step.Args...)
+		step.Command = []string{"/bin/sh", "-c", `"$0" "$@"; echo $? > ` + exitCodeFile}
+	default:
+		return false
+	}
+	return true
+}
+
+// scriptDelimiter returns a heredoc delimiter which does not appear in the script, so that the script is not cut
+func scriptDelimiter(script string) string {
+	delimiter := wrappedScriptDelimiter
+	for strings.Contains(script, delimiter) {
+		delimiter += "_"
+	}
+	return delimiter
+}
+
+// exitStep exits with the exit code of the wrapped step
+func exitStep(image string) tektonv1beta1.Step {
+	return tektonv1beta1.Step{
+		Container: corev1.Container{Name: "exit", Image: image},
+		Script:    fmt.Sprintf(exitScript, exitCodeFile),
+	}
+}
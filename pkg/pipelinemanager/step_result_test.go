@@ -0,0 +1,73 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pipelinemanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func Test_wrapStep(t *testing.T) {
+	tc := map[string]struct {
+		step tektonv1beta1.Step
+
+		expectedWrapped bool
+		expectedScript  string
+		expectedCommand []string
+		expectedArgs    []string
+	}{
+		"script": {
+			step:            tektonv1beta1.Step{Script: "make test\n"},
+			expectedWrapped: true,
+			expectedScript:  "#!/bin/sh\ncat > /tekton/home/.cicd-script <<'CICD_SCRIPT_EOF'\n#!/bin/sh\nset -xe\nmake test\nCICD_SCRIPT_EOF\nchmod +x /tekton/home/.cicd-script\n/tekton/home/.cicd-script \"$@\"\necho $? > /tekton/home/.cicd-exit-code\n",
+		},
+		"scriptShebang": {
+			step:            tektonv1beta1.Step{Script: "#!/usr/bin/env python\nprint(1)"},
+			expectedWrapped: true,
+			expectedScript:  "#!/bin/sh\ncat > /tekton/home/.cicd-script <<'CICD_SCRIPT_EOF'\n#!/usr/bin/env python\nprint(1)\nCICD_SCRIPT_EOF\nchmod +x /tekton/home/.cicd-script\n/tekton/home/.cicd-script \"$@\"\necho $? > /tekton/home/.cicd-exit-code\n",
+		},
+		"scriptDelimiter": {
+			step:            tektonv1beta1.Step{Script: "cat <<CICD_SCRIPT_EOF\ntest\nCICD_SCRIPT_EOF"},
+			expectedWrapped: true,
+			expectedScript:  "#!/bin/sh\ncat > /tekton/home/.cicd-script <<'CICD_SCRIPT_EOF_'\n#!/bin/sh\nset -xe\ncat <<CICD_SCRIPT_EOF\ntest\nCICD_SCRIPT_EOF\nCICD_SCRIPT_EOF_\nchmod +x /tekton/home/.cicd-script\n/tekton/home/.cicd-script \"$@\"\necho $? > /tekton/home/.cicd-exit-code\n",
+		},
+		"command": {
+			step:            tektonv1beta1.Step{Container: corev1.Container{Command: []string{"make"}, Args: []string{"test"}}},
+			expectedWrapped: true,
+			expectedCommand: []string{"/bin/sh", "-c", `"$0" "$@"; echo $? > /tekton/home/.cicd-exit-code`},
+			expectedArgs:    []string{"make", "test"},
+		},
+		"none": {
+			step:            tektonv1beta1.Step{Container: corev1.Container{Args: []string{"test"}}},
+			expectedWrapped: false,
+			expectedArgs:    []string{"test"},
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			step := c.step
+			require.Equal(t, c.expectedWrapped, wrapStep(&step))
+			require.Equal(t, c.expectedScript, step.Script)
+			require.Equal(t, c.expectedCommand, step.Command)
+			require.Equal(t, c.expectedArgs, step.Args)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pipelinemanager
+
+import (
+	"encoding/json"
+	"fmt"
+
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/configs"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	testReportResultName = "cicd-test-reports"
+
+	// testReportResultMaxLength limits the length of the test report summary, as the termination message is limited to 4KiB
+	testReportResultMaxLength = 800
+)
+
+// testReportStep generates a step parsing the JUnit XML test reports and reporting the summary as a result.
+// The reports are parsed by the helper image, and the summary is written in JSON
+func testReportStep(j *cicdv1.Job, workingDir string) tektonv1beta1.Step {
+	args := []string{
+		"test-report",
+		fmt.Sprintf("--result=$(results.%s.path)", testReportResultName),
+		fmt.Sprintf("--max-length=%d", testReportResultMaxLength),
+	}
+	args = append(args, j.TestReports...)
+
+	return tektonv1beta1.Step{
+		Container: corev1.Container{
+			Name:       "test-reports",
+			Image:      configs.HelperImage,
+			Command:    []string{"/helper"},
+			Args:       args,
+			WorkingDir: workingDir,
+		},
+	}
+}
+
+// getTestReportFromResults parses the test report summary from the TaskRun's results
+func getTestReportFromResults(results []tektonv1beta1.TaskRunResult) *cicdv1.JobTestReport {
+	for _, r := range results {
+		if r.Name != testReportResultName {
+			continue
+		}
+		report := &cicdv1.JobTestReport{}
+		if err := json.Unmarshal([]byte(r.Value), report); err != nil {
+			log.Error(err, "cannot parse the test report summary")
+			return nil
+		}
+		return report
+	}
+	return nil
+}
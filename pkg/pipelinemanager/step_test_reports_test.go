@@ -0,0 +1,97 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pipelinemanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/configs"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func Test_testReportStep(t *testing.T) {
+	configs.HelperImage = "tmaxcloudck/cicd-helper:latest"
+	j := &cicdv1.Job{Container: corev1.Container{Name: "test"}, TestReports: []string{"./reports/*.xml", "junit.xml"}}
+
+	step := testReportStep(j, DefaultWorkingDir)
+	require.Equal(t, "test-reports", step.Name)
+	require.Equal(t, "tmaxcloudck/cicd-helper:latest", step.Image)
+	require.Equal(t, DefaultWorkingDir, step.WorkingDir)
+	require.Equal(t, []string{"/helper"}, step.Command)
+	require.Equal(t, []string{"test-report", "--result=$(results.cicd-test-reports.path)", "--max-length=800", "./reports/*.xml", "junit.xml"}, step.Args)
+}
+
+func Test_generateSteps_testReports(t *testing.T) {
+	j := &cicdv1.Job{
+		Container:   corev1.Container{Name: "test", Image: "golang:1.17"},
+		Script:      "go test ./... 2>&1 | go-junit-report > junit.xml",
+		TestReports: []string{"junit.xml"},
+	}
+
+	steps, err := generateSteps(&cicdv1.IntegrationJob{}, j)
+	require.NoError(t, err)
+	require.Len(t, steps, 4)
+	require.Equal(t, "git-clone", steps[0].Name)
+	require.Contains(t, steps[1].Script, wrappedScriptFile)
+	require.Equal(t, "test-reports", steps[2].Name)
+	require.Equal(t, "exit", steps[3].Name)
+
+	task, _, err := generateTask(&cicdv1.IntegrationJob{}, j, "")
+	require.NoError(t, err)
+	require.Equal(t, testReportResultName, task.TaskSpec.Results[0].Name)
+}
+
+func Test_getTestReportFromResults(t *testing.T) {
+	tc := map[string]struct {
+		results []tektonv1beta1.TaskRunResult
+
+		expectedReport *cicdv1.JobTestReport
+	}{
+		"normal": {
+			results: []tektonv1beta1.TaskRunResult{
+				{Name: artifactResultName, Value: "junit.xml"},
+				{Name: testReportResultName, Value: `{"passed":340,"failed":2,"skipped":3,"failedTests":["pkg/a.TestFail","pkg/b.TestErr"]}`},
+			},
+			expectedReport: &cicdv1.JobTestReport{Passed: 340, Failed: 2, Skipped: 3, FailedTests: []string{"pkg/a.TestFail", "pkg/b.TestErr"}},
+		},
+		"failedTestLikeCount": {
+			results: []tektonv1beta1.TaskRunResult{
+				{Name: testReportResultName, Value: `{"passed":0,"failed":1,"skipped":0,"failedTests":["passed=3"]}`},
+			},
+			expectedReport: &cicdv1.JobTestReport{Failed: 1, FailedTests: []string{"passed=3"}},
+		},
+		"malformed": {
+			results: []tektonv1beta1.TaskRunResult{
+				{Name: testReportResultName, Value: "passed=3"},
+			},
+			expectedReport: nil,
+		},
+		"noResult": {
+			results:        []tektonv1beta1.TaskRunResult{{Name: artifactResultName, Value: "junit.xml"}},
+			expectedReport: nil,
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, c.expectedReport, getTestReportFromResults(c.results))
+		})
+	}
+}
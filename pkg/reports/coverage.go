@@ -0,0 +1,221 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package reports
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+)
+
+// Coverage collects the coverage units (i.e., statement blocks or lines) of the files.
+// A unit reported in several files is counted once, and it's covered if it's covered in any of the files
+type Coverage struct {
+	prefix string
+	files  map[string]map[string]*coverageUnit
+}
+
+type coverageUnit struct {
+	weight  int
+	covered bool
+}
+
+// NewCoverage creates a Coverage. The prefix (e.g., the working directory) is trimmed from the files' names
+func NewCoverage(prefix string) *Coverage {
+	return &Coverage{prefix: prefix, files: map[string]map[string]*coverageUnit{}}
+}
+
+func (c *Coverage) add(file, key string, weight, count int) {
+	file = strings.TrimPrefix(strings.TrimPrefix(file, c.prefix), "./")
+	units, exist := c.files[file]
+	if !exist {
+		units = map[string]*coverageUnit{}
+		c.files[file] = units
+	}
+	unit, exist := units[key]
+	if !exist {
+		unit = &coverageUnit{}
+		units[key] = unit
+	}
+	unit.weight = weight
+	unit.covered = unit.covered || count > 0
+}
+
+// Parse parses the coverage file of the format
+func (c *Coverage) Parse(format cicdv1.CoverageFormat, r io.Reader) error {
+	switch format {
+	case cicdv1.CoverageFormatGo:
+		return c.parseGo(r)
+	case cicdv1.CoverageFormatLCOV:
+		return c.parseLCOV(r)
+	case cicdv1.CoverageFormatCobertura:
+		return c.parseCobertura(r)
+	default:
+		return fmt.Errorf("coverage format %s is not supported", format)
+	}
+}
+
+// parseGo parses the go cover profile, whose lines are in the form of 'file:startLine.startCol,endLine.endCol numStmts count'
+func (c *Coverage) parseGo(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "mode:") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			return fmt.Errorf("malformed line %q", line)
+		}
+		block := strings.Join(fields[:len(fields)-2], " ")
+		stmts, stmtsErr := strconv.Atoi(fields[len(fields)-2])
+		count, countErr := strconv.Atoi(fields[len(fields)-1])
+		colon := strings.LastIndex(block, ":")
+		if stmtsErr != nil || countErr != nil || colon < 0 {
+			return fmt.Errorf("malformed line %q", line)
+		}
+		c.add(block[:colon], block[colon+1:], stmts, count)
+	}
+	return scanner.Err()
+}
+
+// parseLCOV parses the lcov tracefile, using SF (source file) and DA (line, hit count) records
+func (c *Coverage) parseLCOV(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	var file string
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "SF:"):
+			file = strings.TrimPrefix(line, "SF:")
+		case strings.HasPrefix(line, "DA:"):
+			tokens := strings.Split(strings.TrimPrefix(line, "DA:"), ",")
+			if len(tokens) < 2 || file == "" {
+				return fmt.Errorf("malformed line %q", line)
+			}
+			count, err := strconv.Atoi(tokens[1])
+			if err != nil {
+				return fmt.Errorf("malformed line %q", line)
+			}
+			c.add(file, tokens[0], 1, count)
+		case line == "end_of_record":
+			file = ""
+		}
+	}
+	return scanner.Err()
+}
+
+// parseCobertura parses the cobertura XML, using the classes' filenames and their lines' hits
+func (c *Coverage) parseCobertura(r io.Reader) error {
+	decoder := xml.NewDecoder(r)
+	decoder.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) { return input, nil }
+
+	var file string
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case "class":
+			file = xmlAttr(start, "filename")
+		case "line":
+			if file == "" {
+				continue
+			}
+			hits, err := strconv.Atoi(xmlAttr(start, "hits"))
+			if err != nil {
+				return fmt.Errorf("malformed hits of line %s in %s", xmlAttr(start, "number"), file)
+			}
+			c.add(file, xmlAttr(start, "number"), 1, hits)
+		}
+	}
+}
+
+// Status returns the total and per-file coverage, sorted by the number of the uncovered units
+func (c *Coverage) Status() *cicdv1.JobCoverageStatus {
+	status := &cicdv1.JobCoverageStatus{}
+	for name, units := range c.files {
+		file := cicdv1.FileCoverage{Name: name}
+		for _, u := range units {
+			file.Total += u.weight
+			if u.covered {
+				file.Covered += u.weight
+			}
+		}
+		status.Covered += file.Covered
+		status.Total += file.Total
+		status.Files = append(status.Files, file)
+	}
+
+	sort.Slice(status.Files, func(i, j int) bool {
+		ui := status.Files[i].Total - status.Files[i].Covered
+		uj := status.Files[j].Total - status.Files[j].Covered
+		if ui != uj {
+			return ui > uj
+		}
+		return status.Files[i].Name < status.Files[j].Name
+	})
+	return status
+}
+
+// SummarizeCoverage parses the coverage files and returns the coverage as a JSON result.
+// Per-file coverages are truncated so that the result is not longer than maxLength
+func SummarizeCoverage(format cicdv1.CoverageFormat, files []string, prefix string, maxLength int, logWriter io.Writer) (string, error) {
+	coverage := NewCoverage(prefix)
+	for _, file := range files {
+		_, _ = fmt.Fprintf(logWriter, "Parsing %s\n", file)
+		if err := parseCoverageFile(coverage, format, file); err != nil {
+			_, _ = fmt.Fprintf(logWriter, "Cannot parse %s: %s\n", file, err.Error())
+		}
+	}
+
+	status := coverage.Status()
+	return encodeResult(status, maxLength, func() bool {
+		if len(status.Files) == 0 {
+			return false
+		}
+		status.Files = status.Files[:len(status.Files)-1]
+		return true
+	})
+}
+
+func parseCoverageFile(coverage *Coverage, format cicdv1.CoverageFormat, file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	return coverage.Parse(format, f)
+}
@@ -0,0 +1,128 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package reports
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+)
+
+func TestCoverage_Parse(t *testing.T) {
+	tc := map[string]struct {
+		format  cicdv1.CoverageFormat
+		reports []string
+
+		errorOccurs      bool
+		expectedCoverage *cicdv1.JobCoverageStatus
+	}{
+		"go": {
+			format: cicdv1.CoverageFormatGo,
+			reports: []string{
+				"mode: set\n/work/pkg/a b.go:1.1,2.2 3 1\n/work/pkg/a b.go:3.1,4.2 2 0\n./pkg/c.go:1.1,2.2 1 0\n",
+				"mode: set\n/work/pkg/a b.go:3.1,4.2 2 1\n",
+			},
+			expectedCoverage: &cicdv1.JobCoverageStatus{Covered: 5, Total: 6, Files: []cicdv1.FileCoverage{
+				{Name: "pkg/c.go", Covered: 0, Total: 1},
+				{Name: "pkg/a b.go", Covered: 5, Total: 5},
+			}},
+		},
+		"goMalformed": {
+			format:      cicdv1.CoverageFormatGo,
+			reports:     []string{"mode: set\npkg/a.go:1.1,2.2 x 1\n"},
+			errorOccurs: true,
+		},
+		"lcov": {
+			format: cicdv1.CoverageFormatLCOV,
+			reports: []string{
+				"TN:\nSF:/work/src/a.js\nDA:1,1\nDA:2,0\nDA:3,0,abcd\nend_of_record\nSF:src/b.js\nDA:1,0\nend_of_record\n",
+			},
+			expectedCoverage: &cicdv1.JobCoverageStatus{Covered: 1, Total: 4, Files: []cicdv1.FileCoverage{
+				{Name: "src/a.js", Covered: 1, Total: 3},
+				{Name: "src/b.js", Covered: 0, Total: 1},
+			}},
+		},
+		"cobertura": {
+			format: cicdv1.CoverageFormatCobertura,
+			reports: []string{`<?xml version='1.0'?>
+<coverage>
+  <packages><package name='app'><classes>
+    <class name='a' filename='app/a.py'>
+      <methods><method name='f'><lines><line number='1' hits='1'/></lines></method></methods>
+      <lines>
+        <line number='1' hits='1'/>
+        <line number='2' hits='0'/>
+      </lines>
+    </class>
+  </classes></package></packages>
+</coverage>`},
+			expectedCoverage: &cicdv1.JobCoverageStatus{Covered: 1, Total: 2, Files: []cicdv1.FileCoverage{
+				{Name: "app/a.py", Covered: 1, Total: 2},
+			}},
+		},
+		"unknownFormat": {
+			format:      cicdv1.CoverageFormat("jacoco"),
+			reports:     []string{""},
+			errorOccurs: true,
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			coverage := NewCoverage("/work/")
+			var err error
+			for _, r := range c.reports {
+				if err = coverage.Parse(c.format, strings.NewReader(r)); err != nil {
+					break
+				}
+			}
+			if c.errorOccurs {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, c.expectedCoverage, coverage.Status())
+		})
+	}
+}
+
+func TestSummarizeCoverage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reports")
+	require.NoError(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	file := filepath.Join(dir, "cover.out")
+	require.NoError(t, ioutil.WriteFile(file, []byte("mode: set\na.go:1.1,2.2 1 0\nb.go:1.1,2.2 1 1\n"), 0644))
+
+	logs := &bytes.Buffer{}
+	result, err := SummarizeCoverage(cicdv1.CoverageFormatGo, []string{file}, dir+"/", 800, logs)
+	require.NoError(t, err)
+	require.Equal(t, `{"covered":1,"total":2,"files":[{"name":"a.go","covered":0,"total":1},{"name":"b.go","covered":1,"total":1}]}`, result)
+	require.Contains(t, logs.String(), "Parsing "+file)
+
+	result, err = SummarizeCoverage(cicdv1.CoverageFormatGo, []string{file}, dir+"/", 80, logs)
+	require.NoError(t, err)
+	require.Equal(t, `{"covered":1,"total":2,"files":[{"name":"a.go","covered":0,"total":1}]}`, result)
+}
@@ -0,0 +1,124 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package reports
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+)
+
+// ParseJUnit parses the JUnit XML test report and adds the test cases to the report
+func ParseJUnit(r io.Reader, report *cicdv1.JobTestReport) error {
+	decoder := xml.NewDecoder(r)
+	// Reports declaring non-UTF-8 encodings are parsed as they are, as only the test cases' names are used
+	decoder.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) { return input, nil }
+
+	inCase := false
+	var name, state string
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "testcase":
+				inCase = true
+				name, state = testCaseName(t), ""
+			case "failure", "error":
+				if inCase {
+					state = "failed"
+				}
+			case "skipped":
+				if inCase && state == "" {
+					state = "skipped"
+				}
+			}
+		case xml.EndElement:
+			if t.Name.Local != "testcase" || !inCase {
+				continue
+			}
+			inCase = false
+			switch state {
+			case "failed":
+				report.Failed++
+				report.FailedTests = append(report.FailedTests, name)
+			case "skipped":
+				report.Skipped++
+			default:
+				report.Passed++
+			}
+		}
+	}
+}
+
+func testCaseName(t xml.StartElement) string {
+	name, class := xmlAttr(t, "name"), xmlAttr(t, "classname")
+	if class != "" {
+		return class + "." + name
+	}
+	return name
+}
+
+func xmlAttr(t xml.StartElement, key string) string {
+	for _, a := range t.Attr {
+		if a.Name.Local == key {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// SummarizeTestReports parses the JUnit XML files and returns the summary as a JSON result.
+// Failed tests' names are truncated so that the result is not longer than maxLength
+func SummarizeTestReports(files []string, maxLength int, logWriter io.Writer) (string, error) {
+	report := &cicdv1.JobTestReport{}
+	for _, file := range files {
+		_, _ = fmt.Fprintf(logWriter, "Parsing %s\n", file)
+		if err := parseJUnitFile(file, report); err != nil {
+			_, _ = fmt.Fprintf(logWriter, "Cannot parse %s: %s\n", file, err.Error())
+		}
+	}
+
+	return encodeResult(report, maxLength, func() bool {
+		if len(report.FailedTests) == 0 {
+			return false
+		}
+		report.FailedTests = report.FailedTests[:len(report.FailedTests)-1]
+		return true
+	})
+}
+
+func parseJUnitFile(file string, report *cicdv1.JobTestReport) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	return ParseJUnit(f, report)
+}
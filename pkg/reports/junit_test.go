@@ -0,0 +1,98 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package reports
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+)
+
+const testJUnitReport = `<?xml version='1.0' encoding='ISO-8859-1'?>
+<testsuites>
+  <testsuite name="pkg/a" tests="5">
+    <testcase classname='pkg/a' name='TestPass'/>
+    <testcase classname="pkg/a" name="TestFail">
+      <failure message="assertion failed"><![CDATA[expected <a> but got </testcase>]]></failure>
+    </testcase>
+    <testcase classname="pkg/a" name="TestErr"><error/></testcase>
+    <testcase classname="pkg/a" name="TestSkip"><skipped/></testcase>
+    <testcase name="passed=3">
+      <system-out>ok</system-out>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+
+func TestParseJUnit(t *testing.T) {
+	tc := map[string]struct {
+		report string
+
+		errorOccurs    bool
+		expectedReport *cicdv1.JobTestReport
+	}{
+		"normal": {
+			report:         testJUnitReport,
+			expectedReport: &cicdv1.JobTestReport{Passed: 2, Failed: 2, Skipped: 1, FailedTests: []string{"pkg/a.TestFail", "pkg/a.TestErr"}},
+		},
+		"malformed": {
+			report:         `<testsuite><testcase name="a"/><testcase name="b">`,
+			errorOccurs:    true,
+			expectedReport: &cicdv1.JobTestReport{Passed: 1},
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			report := &cicdv1.JobTestReport{}
+			err := ParseJUnit(strings.NewReader(c.report), report)
+			if c.errorOccurs {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, c.expectedReport, report)
+		})
+	}
+}
+
+func TestSummarizeTestReports(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reports")
+	require.NoError(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	file := filepath.Join(dir, "junit.xml")
+	require.NoError(t, ioutil.WriteFile(file, []byte(testJUnitReport), 0644))
+
+	logs := &bytes.Buffer{}
+	result, err := SummarizeTestReports([]string{file, filepath.Join(dir, "not-exist.xml")}, 800, logs)
+	require.NoError(t, err)
+	require.Equal(t, `{"passed":2,"failed":2,"skipped":1,"failedTests":["pkg/a.TestFail","pkg/a.TestErr"]}`, result)
+	require.Contains(t, logs.String(), "Cannot parse "+filepath.Join(dir, "not-exist.xml"))
+
+	result, err = SummarizeTestReports([]string{file}, 70, logs)
+	require.NoError(t, err)
+	require.Equal(t, `{"passed":2,"failed":2,"skipped":1,"failedTests":["pkg/a.TestFail"]}`, result)
+}
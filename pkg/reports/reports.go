@@ -0,0 +1,107 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+// Package reports implements parsers of the jobs' test reports and coverage files, which are run by the helper image
+// in the jobs' pods
+package reports
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// FindFiles returns the files under the root directory, matching any of the glob patterns.
+// Patterns are relative to the root directory and '*' also matches '/'
+func FindFiles(root string, patterns []string) ([]string, error) {
+	var regs []*regexp.Regexp
+	for _, p := range patterns {
+		reg, err := globToRegexp(strings.TrimPrefix(p, "./"))
+		if err != nil {
+			return nil, err
+		}
+		regs = append(regs, reg)
+	}
+
+	var files []string
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		for _, reg := range regs {
+			if reg.MatchString(rel) {
+				files = append(files, path)
+				break
+			}
+		}
+		return nil
+	})
+	return files, err
+}
+
+// globToRegexp converts the glob pattern (*, ?, [...]) into a regular expression matching the whole path
+func globToRegexp(pattern string) (*regexp.Regexp, error) {
+	b := &strings.Builder{}
+	b.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				b.WriteString(regexp.QuoteMeta(string(c)))
+				continue
+			}
+			class := pattern[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+// encodeResult encodes the value as a JSON result, calling truncate until the result gets shorter than maxLength.
+// truncate returns false if it cannot truncate the value anymore
+func encodeResult(v interface{}, maxLength int, truncate func() bool) (string, error) {
+	for {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		if len(b) <= maxLength || !truncate() {
+			return string(b), nil
+		}
+	}
+}
@@ -0,0 +1,93 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package reports
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reports")
+	require.NoError(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	for _, f := range []string{"junit.xml", "reports/a.xml", "reports/sub/b.xml", "reports/c.txt", "other/d.xml"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(f)), 0755))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, f), []byte{}, 0644))
+	}
+
+	tc := map[string]struct {
+		patterns []string
+
+		expectedFiles []string
+	}{
+		"exact": {
+			patterns:      []string{"./junit.xml"},
+			expectedFiles: []string{"junit.xml"},
+		},
+		"starMatchesSlash": {
+			patterns:      []string{"reports/*.xml"},
+			expectedFiles: []string{"reports/a.xml", "reports/sub/b.xml"},
+		},
+		"questionAndClass": {
+			patterns:      []string{"reports/?.[tx][xm][tl]", "[!r]*/d.xml"},
+			expectedFiles: []string{"other/d.xml", "reports/a.xml", "reports/c.txt"},
+		},
+		"noMatch": {
+			patterns: []string{"*.json"},
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			files, err := FindFiles(dir, c.patterns)
+			require.NoError(t, err)
+
+			var rel []string
+			for _, f := range files {
+				r, err := filepath.Rel(dir, f)
+				require.NoError(t, err)
+				rel = append(rel, filepath.ToSlash(r))
+			}
+			require.Equal(t, c.expectedFiles, rel)
+		})
+	}
+}
+
+func Test_encodeResult(t *testing.T) {
+	v := []string{"a", "b", "c"}
+	result, err := encodeResult(&v, 10, func() bool {
+		if len(v) == 0 {
+			return false
+		}
+		v = v[:len(v)-1]
+		return true
+	})
+	require.NoError(t, err)
+	require.Equal(t, `["a","b"]`, result)
+
+	result, err = encodeResult(&v, 1, func() bool { return false })
+	require.NoError(t, err)
+	require.Equal(t, `["a","b"]`, result)
+}
@@ -0,0 +1,146 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/configs"
+	"github.com/tmax-cloud/cicd-operator/internal/utils"
+	"github.com/tmax-cloud/cicd-operator/pkg/artifacts"
+	"k8s.io/apimachinery/pkg/types"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	paramKeyArtifactPath = "artifactPath"
+
+	artifactURLExpiration = 10 * time.Minute
+)
+
+var artifactPath = fmt.Sprintf("/artifact/{%s}/{%s}/{%s}/{%s:.*}", paramKeyNamespace, paramKeyIJName, paramKeyJobName, paramKeyArtifactPath)
+
+// artifactHandler redirects to the artifact's presigned url (S3) or streams the artifact from the artifact server (PVC)
+type artifactHandler struct {
+	k8sClient      client.Client
+	servicesGetter typedcorev1.ServicesGetter
+}
+
+func (h *artifactHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqID := utils.RandomString(10)
+	log := logger.WithValues("request", reqID)
+
+	vars := mux.Vars(r)
+
+	ns := vars[paramKeyNamespace]
+	ijName := vars[paramKeyIJName]
+	job := vars[paramKeyJobName]
+	artifact := vars[paramKeyArtifactPath]
+
+	if ns == "" || ijName == "" || job == "" || artifact == "" {
+		logAndRespond(w, log, http.StatusBadRequest, fmt.Sprintf("req: %s, path is not in form of '%s'", reqID, artifactPath),
+			fmt.Sprintf("Bad request for path, path: %s", r.RequestURI))
+		return
+	}
+
+	if configs.ArtifactStorage == "" {
+		logAndRespond(w, log, http.StatusNotFound, fmt.Sprintf("req: %s, artifact storage is not configured", reqID),
+			"Artifact storage is not configured")
+		return
+	}
+
+	iJob := &cicdv1.IntegrationJob{}
+	if err := h.k8sClient.Get(context.Background(), types.NamespacedName{Name: ijName, Namespace: ns}, iJob); err != nil {
+		logAndRespond(w, log, http.StatusBadRequest, fmt.Sprintf("req: %s, cannot get IntegrationJob %s/%s", reqID, ns, ijName),
+			fmt.Sprintf("Bad request for path, path: %s", r.RequestURI))
+		return
+	}
+
+	// Only the uploaded artifacts are served
+	if !hasArtifact(iJob, job, artifact) {
+		logAndRespond(w, log, http.StatusNotFound, fmt.Sprintf("req: %s, there is no artifact %s in job %s", reqID, artifact, job),
+			fmt.Sprintf("Artifact not found, ns: %s, job: %s, jobJob: %s, artifact: %s", ns, ijName, job, artifact))
+		return
+	}
+
+	if configs.ArtifactStorage == configs.ArtifactStoragePVC {
+		h.serveFromPVC(w, log, reqID, iJob, job, artifact)
+		return
+	}
+
+	s3, err := artifacts.NewS3(h.k8sClient)
+	if err != nil {
+		logAndRespond(w, log, http.StatusInternalServerError, fmt.Sprintf("req: %s, cannot initialize s3 client", reqID),
+			"Cannot initialize s3 client, err: "+err.Error())
+		return
+	}
+
+	u, err := s3.PresignGet(artifacts.ObjectKey(iJob, job, artifact), artifactURLExpiration)
+	if err != nil {
+		logAndRespond(w, log, http.StatusInternalServerError, fmt.Sprintf("req: %s, cannot presign artifact url", reqID),
+			"Cannot presign artifact url, err: "+err.Error())
+		return
+	}
+
+	http.Redirect(w, r, u, http.StatusFound)
+}
+
+// +kubebuilder:rbac:groups="",resources=services/proxy,verbs=get
+
+// serveFromPVC streams the artifact from the IntegrationConfig's artifact server, through the API server's service proxy
+func (h *artifactHandler) serveFromPVC(w http.ResponseWriter, log logr.Logger, reqID string, ij *cicdv1.IntegrationJob, job, artifact string) {
+	svcName := cicdv1.GetArtifactServerName(ij.Spec.ConfigRef.Name)
+	stream, err := h.servicesGetter.Services(ij.Namespace).ProxyGet("http", svcName, strconv.Itoa(artifacts.ServerPort), "/"+artifacts.ObjectKey(ij, job, artifact), nil).Stream(context.Background())
+	if err != nil {
+		logAndRespond(w, log, http.StatusInternalServerError, fmt.Sprintf("req: %s, cannot get artifact %s from the artifact server", reqID, artifact),
+			"Cannot get artifact from the artifact server, err: "+err.Error())
+		return
+	}
+	defer func() {
+		_ = stream.Close()
+	}()
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", path.Base(artifact)))
+	if _, err := io.Copy(w, stream); err != nil {
+		log.Error(err, "")
+	}
+}
+
+func hasArtifact(ij *cicdv1.IntegrationJob, job, artifact string) bool {
+	for _, j := range ij.Status.Jobs {
+		if j.Name != job {
+			continue
+		}
+		for _, a := range j.Artifacts {
+			if a == artifact {
+				return true
+			}
+		}
+	}
+	return false
+}
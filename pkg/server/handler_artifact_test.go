@@ -0,0 +1,205 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/configs"
+	"github.com/tmax-cloud/cicd-operator/internal/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	restclient "k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_artifactHandler_ServeHTTP(t *testing.T) {
+	require.NoError(t, cicdv1.AddToScheme(scheme.Scheme))
+
+	configs.ArtifactS3Endpoint = "http://minio:9000"
+	configs.ArtifactS3Region = "us-east-1"
+	configs.ArtifactS3Bucket = "artifacts"
+	configs.ArtifactS3Secret = "artifact-secret"
+
+	ij := &cicdv1.IntegrationJob{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec:       cicdv1.IntegrationJobSpec{ConfigRef: cicdv1.IntegrationJobConfigRef{Name: "test-ic"}},
+		Status: cicdv1.IntegrationJobStatus{
+			Jobs: []cicdv1.JobStatus{
+				{Name: "job1", Artifacts: []string{"bin/app"}},
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: utils.Namespace(), Name: "artifact-secret"},
+		Data:       map[string][]byte{"accessKey": []byte("access"), "secretKey": []byte("secret")},
+	}
+
+	tc := map[string]struct {
+		storage  string
+		name     string
+		artifact string
+		secret   bool
+		proxyErr bool
+
+		expectedCode    int
+		expectedMessage string
+	}{
+		"normal": {
+			storage:         configs.ArtifactStorageS3,
+			name:            "test",
+			artifact:        "bin/app",
+			secret:          true,
+			expectedCode:    http.StatusFound,
+			expectedMessage: "http://minio:9000/artifacts/default/test/job1/bin/app?X-Amz-Algorithm=AWS4-HMAC-SHA256",
+		},
+		"pvc": {
+			storage:         configs.ArtifactStoragePVC,
+			name:            "test",
+			artifact:        "bin/app",
+			expectedCode:    http.StatusOK,
+			expectedMessage: "content of default/test/job1/bin/app",
+		},
+		"pvcServerErr": {
+			storage:         configs.ArtifactStoragePVC,
+			name:            "test",
+			artifact:        "bin/app",
+			proxyErr:        true,
+			expectedCode:    http.StatusInternalServerError,
+			expectedMessage: "cannot get artifact bin/app from the artifact server",
+		},
+		"pathErr": {
+			storage:         configs.ArtifactStorageS3,
+			name:            "test",
+			expectedCode:    http.StatusBadRequest,
+			expectedMessage: "path is not in form of '/artifact/{namespace}/{jobName}/{jobJobName}/{artifactPath:.*}'",
+		},
+		"noStorageErr": {
+			name:            "test",
+			artifact:        "bin/app",
+			expectedCode:    http.StatusNotFound,
+			expectedMessage: "artifact storage is not configured",
+		},
+		"noIJErr": {
+			storage:         configs.ArtifactStorageS3,
+			name:            "test2",
+			artifact:        "bin/app",
+			expectedCode:    http.StatusBadRequest,
+			expectedMessage: "cannot get IntegrationJob default/test2",
+		},
+		"noArtifactErr": {
+			storage:         configs.ArtifactStorageS3,
+			name:            "test",
+			artifact:        "bin/app2",
+			expectedCode:    http.StatusNotFound,
+			expectedMessage: "there is no artifact bin/app2 in job job1",
+		},
+		"noSecretErr": {
+			storage:         configs.ArtifactStorageS3,
+			name:            "test",
+			artifact:        "bin/app",
+			expectedCode:    http.StatusInternalServerError,
+			expectedMessage: "cannot initialize s3 client",
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			configs.ArtifactStorage = c.storage
+			defer func() { configs.ArtifactStorage = "" }()
+
+			builder := ctrlfake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(ij.DeepCopy())
+			if c.secret {
+				builder = builder.WithObjects(secret.DeepCopy())
+			}
+			clientSet := fake.NewSimpleClientset()
+			clientSet.PrependProxyReactor("services", func(action k8stesting.Action) (bool, restclient.ResponseWrapper, error) {
+				proxy := action.(k8stesting.ProxyGetAction)
+				require.Equal(t, "default", proxy.GetNamespace())
+				require.Equal(t, "test-ic-artifact-server", proxy.GetName())
+				require.Equal(t, "8080", proxy.GetPort())
+				return true, &fakeResponseWrapper{path: proxy.GetPath(), err: c.proxyErr}, nil
+			})
+			handler := &artifactHandler{k8sClient: builder.Build(), servicesGetter: clientSet.CoreV1()}
+
+			req := httptest.NewRequest(http.MethodGet, "https://test", nil)
+			req = mux.SetURLVars(req, map[string]string{
+				paramKeyNamespace:    "default",
+				paramKeyIJName:       c.name,
+				paramKeyJobName:      "job1",
+				paramKeyArtifactPath: c.artifact,
+			})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, c.expectedCode, w.Code)
+			if c.expectedCode == http.StatusFound {
+				require.True(t, strings.HasPrefix(w.Header().Get("Location"), c.expectedMessage), w.Header().Get("Location"))
+				return
+			}
+			b, err := ioutil.ReadAll(w.Body)
+			require.NoError(t, err)
+			require.Contains(t, string(b), c.expectedMessage)
+		})
+	}
+}
+
+type fakeResponseWrapper struct {
+	path string
+	err  bool
+}
+
+func (f *fakeResponseWrapper) DoRaw(_ context.Context) ([]byte, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (f *fakeResponseWrapper) Stream(_ context.Context) (io.ReadCloser, error) {
+	if f.err {
+		return nil, fmt.Errorf("the server could not find the requested resource")
+	}
+	return ioutil.NopCloser(strings.NewReader("content of " + strings.TrimPrefix(f.path, "/"))), nil
+}
+
+func Test_getReportArtifacts(t *testing.T) {
+	configs.CurrentExternalHostName = "cicd.example.com"
+	ij := &cicdv1.IntegrationJob{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}}
+	jobStatus := &cicdv1.JobStatus{Name: "job1", Artifacts: []string{"bin/app"}}
+
+	configs.ArtifactStorage = ""
+	require.Equal(t, []reportArtifact{{Path: "bin/app"}}, getReportArtifacts(ij, jobStatus))
+
+	configs.ArtifactStorage = configs.ArtifactStorageS3
+	defer func() { configs.ArtifactStorage = "" }()
+	require.Equal(t, []reportArtifact{{Path: "bin/app", URL: "http://cicd.example.com/artifact/default/test/job1/bin/app"}}, getReportArtifacts(ij, jobStatus))
+
+	configs.ArtifactStorage = configs.ArtifactStoragePVC
+	require.Equal(t, []reportArtifact{{Path: "bin/app", URL: "http://cicd.example.com/artifact/default/test/job1/bin/app"}}, getReportArtifacts(ij, jobStatus))
+}
@@ -52,9 +52,15 @@ type report struct {
 	JobName    string
 	JobJobName string
 	JobStatus  *cicdv1.JobStatus
+	Artifacts  []reportArtifact
 	Log        string
 }
 
+type reportArtifact struct {
+	Path string
+	URL  string
+}
+
 type reportHandler struct {
 	k8sClient  client.Client
 	podsGetter typedcorev1.PodsGetter
@@ -130,7 +136,7 @@ func (h *reportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Publish report
 	// Maybe template.Execute writes header before returning 400...? so we do NOT call tmpl.Execute(w, ...) directly
 	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, report{JobName: ijName, JobJobName: job, JobStatus: jobStatus, Log: podLog}); err != nil {
+	if err := tmpl.Execute(&buf, report{JobName: ijName, JobJobName: job, JobStatus: jobStatus, Artifacts: getReportArtifacts(iJob, jobStatus), Log: podLog}); err != nil {
 		logAndRespond(w, log, http.StatusBadRequest, fmt.Sprintf("req: %s, cannot execute report template", reqID),
 			"Cannot execute report template, err: "+err.Error())
 		return
@@ -151,6 +157,19 @@ func (h *reportHandler) getJobStatus(ij *cicdv1.IntegrationJob, job string) *cic
 	return nil
 }
 
+// getReportArtifacts returns download links of the artifacts. Links are not given if the artifact storage is not configured
+func getReportArtifacts(ij *cicdv1.IntegrationJob, jobStatus *cicdv1.JobStatus) []reportArtifact {
+	var result []reportArtifact
+	for _, a := range jobStatus.Artifacts {
+		artifact := reportArtifact{Path: a}
+		if configs.ArtifactStorage != "" {
+			artifact.URL = ij.GetArtifactServerAddress(jobStatus.Name, a)
+		}
+		result = append(result, artifact)
+	}
+	return result
+}
+
 func (h *reportHandler) getTemplate() (*template.Template, error) {
 	templatePath := os.Getenv(templateConfigMapPathEnv)
 	if templatePath == "" {
@@ -71,12 +71,20 @@ func New(c client.Client, cfg *rest.Config) *server {
 	// Add report handler
 	r.Methods(http.MethodGet).Subrouter().Handle(reportPath, &reportHandler{k8sClient: c, podsGetter: clientSet.CoreV1()})
 
+	// Add artifact handler
+	r.Methods(http.MethodGet).Subrouter().Handle(artifactPath, &artifactHandler{k8sClient: c, servicesGetter: clientSet.CoreV1()})
+
 	return &server{
 		k8sClient: c,
 		router:    r,
 	}
 }
 
+// AddHandler adds an extra handler (e.g., slack app) to the server. It should be called before Start
+func (s *server) AddHandler(method, path string, handler http.Handler) {
+	s.router.Methods(method).Subrouter().Handle(path, handler)
+}
+
 // Start starts the server
 func (s *server) Start() {
 	httpAddr := fmt.Sprintf("0.0.0.0:%d", port)
@@ -0,0 +1,110 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package slackapp
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/utils"
+	"github.com/tmax-cloud/cicd-operator/pkg/apiserver/apis/v1/integrationconfigs"
+	"github.com/tmax-cloud/cicd-operator/pkg/notification/slack"
+)
+
+const (
+	commandRun = "run"
+
+	runTypePre  = "pre"
+	runTypePost = "post"
+)
+
+func (a *App) handleCommand(w http.ResponseWriter, req *http.Request) {
+	reqID := utils.RandomString(10)
+	log := a.log.WithValues("request", reqID)
+
+	body, ok := a.readAndVerify(w, req, log)
+	if !ok {
+		return
+	}
+
+	cmd, err := slack.ParseSlashCommand(body)
+	if err != nil {
+		log.Info(err.Error())
+		_ = utils.RespondError(w, http.StatusBadRequest, fmt.Sprintf("req: %s, command is malformed", reqID))
+		return
+	}
+
+	args := strings.Fields(cmd.Text)
+	if len(args) < 3 || args[0] != commandRun {
+		respondMessage(w, responseTypeEphemeral, generateHelpMessage(cmd.Command))
+		return
+	}
+
+	nsName := strings.Split(args[2], "/")
+	if len(nsName) != 2 {
+		respondMessage(w, responseTypeEphemeral, generateHelpMessage(cmd.Command))
+		return
+	}
+	ns, name := nsName[0], nsName[1]
+
+	switch args[1] {
+	case runTypePre:
+		if len(args) < 4 {
+			respondMessage(w, responseTypeEphemeral, generateHelpMessage(cmd.Command))
+			return
+		}
+		user, _, err := a.authorize(cmd.UserID, ns, cicdv1.IntegrationConfigKind, name, cicdv1.IntegrationConfigAPIRunPre, "create")
+		if err != nil {
+			respondMessage(w, responseTypeEphemeral, err.Error())
+			return
+		}
+		runReq := &cicdv1.IntegrationConfigAPIReqRunPreBody{HeadBranch: args[3]}
+		if len(args) > 4 {
+			runReq.BaseBranch = args[4]
+		}
+		if err := integrationconfigs.RunPre(a.k8sClient, ns, name, user, runReq); err != nil {
+			respondMessage(w, responseTypeEphemeral, fmt.Sprintf("Cannot run %s/%s, err : %s", ns, name, err.Error()))
+			return
+		}
+		respondMessage(w, responseTypeInChannel, fmt.Sprintf("Pre-submit jobs of %s/%s are triggered for branch %s by %s", ns, name, runReq.HeadBranch, user))
+	case runTypePost:
+		user, _, err := a.authorize(cmd.UserID, ns, cicdv1.IntegrationConfigKind, name, cicdv1.IntegrationConfigAPIRunPost, "create")
+		if err != nil {
+			respondMessage(w, responseTypeEphemeral, err.Error())
+			return
+		}
+		runReq := &cicdv1.IntegrationConfigAPIReqRunPostBody{}
+		if len(args) > 3 {
+			runReq.Branch = args[3]
+		}
+		if err := integrationconfigs.RunPost(a.k8sClient, ns, name, user, runReq); err != nil {
+			respondMessage(w, responseTypeEphemeral, fmt.Sprintf("Cannot run %s/%s, err : %s", ns, name, err.Error()))
+			return
+		}
+		respondMessage(w, responseTypeInChannel, fmt.Sprintf("Post-submit jobs of %s/%s are triggered by %s", ns, name, user))
+	default:
+		respondMessage(w, responseTypeEphemeral, generateHelpMessage(cmd.Command))
+	}
+}
+
+func generateHelpMessage(command string) string {
+	return fmt.Sprintf("Usage:\n"+
+		"`%[1]s run pre <namespace>/<integrationconfig> <head branch> [<base branch>]` : run pre-submit jobs\n"+
+		"`%[1]s run post <namespace>/<integrationconfig> [<branch>]` : run post-submit jobs", command)
+}
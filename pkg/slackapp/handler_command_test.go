@@ -0,0 +1,98 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package slackapp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/pkg/notification/slack"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestApp_handleCommand(t *testing.T) {
+	tc := map[string]struct {
+		slackUser string
+		text      string
+
+		expectedMessage slack.Message
+	}{
+		"runPre": {
+			slackUser:       "U0001",
+			text:            "run pre test-ns/test-ic feat/test",
+			expectedMessage: slack.Message{ResponseType: "in_channel", Text: "Pre-submit jobs of test-ns/test-ic are triggered for branch feat/test by admin"},
+		},
+		"runPost": {
+			slackUser:       "U0001",
+			text:            "run post test-ns/test-ic",
+			expectedMessage: slack.Message{ResponseType: "in_channel", Text: "Post-submit jobs of test-ns/test-ic are triggered by admin"},
+		},
+		"runPreNoBranch": {
+			slackUser:       "U0001",
+			text:            "run pre test-ns/test-ic",
+			expectedMessage: slack.Message{ResponseType: "ephemeral", Text: generateHelpMessage("/cicd")},
+		},
+		"unknownCommand": {
+			slackUser:       "U0001",
+			text:            "deploy test-ns/test-ic",
+			expectedMessage: slack.Message{ResponseType: "ephemeral", Text: generateHelpMessage("/cicd")},
+		},
+		"notAllowed": {
+			slackUser:       "U0002",
+			text:            "run post test-ns/test-ic",
+			expectedMessage: slack.Message{ResponseType: "ephemeral", Text: "user developer cannot create integrationconfigs/runpost of test-ns/test-ic, err : not allowed"},
+		},
+		"noIntegrationConfig": {
+			slackUser:       "U0001",
+			text:            "run post test-ns/no-ic",
+			expectedMessage: slack.Message{ResponseType: "ephemeral", Text: "Cannot run test-ns/no-ic, err : cannot get IntegrationConfig test-ns/no-ic"},
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			app := newTestApp(t, &cicdv1.IntegrationConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-ic", Namespace: "test-ns"},
+				Spec: cicdv1.IntegrationConfigSpec{
+					Git: cicdv1.GitConfig{
+						Type:       "fake",
+						APIUrl:     "https://test.git.com",
+						Repository: "test/test",
+					},
+				},
+			})
+
+			form := url.Values{}
+			form.Set("command", "/cicd")
+			form.Set("text", c.text)
+			form.Set("user_id", c.slackUser)
+
+			w := httptest.NewRecorder()
+			app.handleCommand(w, newSignedRequest(form.Encode(), testSigningSecret))
+			require.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+			msg := slack.Message{}
+			require.NoError(t, json.NewDecoder(w.Result().Body).Decode(&msg))
+			require.Equal(t, c.expectedMessage, msg)
+		})
+	}
+}
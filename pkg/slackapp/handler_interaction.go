@@ -0,0 +1,103 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package slackapp
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/utils"
+	"github.com/tmax-cloud/cicd-operator/pkg/apiserver/apis/v1/approvals"
+	"github.com/tmax-cloud/cicd-operator/pkg/notification/slack"
+)
+
+const (
+	interactionTypeBlockActions = "block_actions"
+)
+
+func (a *App) handleInteraction(w http.ResponseWriter, req *http.Request) {
+	reqID := utils.RandomString(10)
+	log := a.log.WithValues("request", reqID)
+
+	body, ok := a.readAndVerify(w, req, log)
+	if !ok {
+		return
+	}
+
+	payload, err := slack.ParseInteractionPayload(body)
+	if err != nil {
+		log.Info(err.Error())
+		_ = utils.RespondError(w, http.StatusBadRequest, fmt.Sprintf("req: %s, payload is malformed", reqID))
+		return
+	}
+
+	if payload.Type != interactionTypeBlockActions {
+		return
+	}
+
+	for _, action := range payload.Actions {
+		var decision cicdv1.ApprovalResult
+		var subResource string
+		switch action.ActionID {
+		case slack.ActionIDApprove:
+			decision, subResource = cicdv1.ApprovalResultApproved, cicdv1.ApprovalAPIApprove
+		case slack.ActionIDReject:
+			decision, subResource = cicdv1.ApprovalResultRejected, cicdv1.ApprovalAPIReject
+		default:
+			continue
+		}
+
+		msg := a.decideApproval(payload.User.ID, action.Value, decision, subResource)
+		if err := slack.RespondMessage(payload.ResponseURL, msg); err != nil {
+			log.Error(err, "")
+		}
+	}
+}
+
+// decideApproval decides the approval (value: <namespace>/<name>) and returns a message to be responded
+func (a *App) decideApproval(slackUserID, value string, decision cicdv1.ApprovalResult, subResource string) *slack.Message {
+	nsName := strings.Split(value, "/")
+	if len(nsName) != 2 {
+		return &slack.Message{ResponseType: responseTypeEphemeral, Text: fmt.Sprintf("Approval %s is malformed", value)}
+	}
+	ns, name := nsName[0], nsName[1]
+
+	user, groups, err := a.authorize(slackUserID, ns, cicdv1.ApprovalKind, name, subResource, "update")
+	if err != nil {
+		return &slack.Message{ResponseType: responseTypeEphemeral, Text: err.Error()}
+	}
+
+	approval, err := approvals.Decide(a.k8sClient, ns, name, user, groups, decision, fmt.Sprintf("%s on Slack", decision))
+	if err != nil {
+		return &slack.Message{ResponseType: responseTypeEphemeral, Text: fmt.Sprintf("Cannot decide approval %s, err : %s", value, err.Error())}
+	}
+
+	// Keep the buttons for the other approvers, if more approvals are required
+	if approval.Status.DecisionTime == nil {
+		return &slack.Message{
+			ResponseType: responseTypeEphemeral,
+			Text:         fmt.Sprintf("Your approval is recorded. Approval %s needs %d approvals", value, approval.Spec.Policy.GetMinApprovals()),
+		}
+	}
+
+	return &slack.Message{
+		ReplaceOriginal: true,
+		Text:            fmt.Sprintf("Approval %s is %s by %s", value, strings.ToLower(string(decision)), approval.Status.Approver),
+	}
+}
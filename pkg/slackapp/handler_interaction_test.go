@@ -0,0 +1,232 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package slackapp
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	cicdv1 "github.com/tmax-cloud/cicd-operator/api/v1"
+	"github.com/tmax-cloud/cicd-operator/internal/configs"
+	"github.com/tmax-cloud/cicd-operator/internal/test"
+	"github.com/tmax-cloud/cicd-operator/pkg/notification/slack"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	testing2 "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testSigningSecret = "signing-secret"
+)
+
+func TestApp_handleInteraction(t *testing.T) {
+	tc := map[string]struct {
+		slackUser string
+		actionID  string
+		value     string
+		decided   bool
+		groups    []string
+
+		expectedResult  cicdv1.ApprovalResult
+		expectedMessage slack.Message
+	}{
+		"approve": {
+			slackUser:       "U0001",
+			actionID:        slack.ActionIDApprove,
+			value:           "test-ns/test-approval",
+			expectedResult:  cicdv1.ApprovalResultApproved,
+			expectedMessage: slack.Message{ReplaceOriginal: true, Text: "Approval test-ns/test-approval is approved by admin"},
+		},
+		"reject": {
+			slackUser:       "U0001",
+			actionID:        slack.ActionIDReject,
+			value:           "test-ns/test-approval",
+			expectedResult:  cicdv1.ApprovalResultRejected,
+			expectedMessage: slack.Message{ReplaceOriginal: true, Text: "Approval test-ns/test-approval is rejected by admin"},
+		},
+		"approveAsGroupMember": {
+			slackUser:       "U0004",
+			actionID:        slack.ActionIDApprove,
+			value:           "test-ns/test-approval",
+			groups:          []string{"release-managers"},
+			expectedResult:  cicdv1.ApprovalResultApproved,
+			expectedMessage: slack.Message{ReplaceOriginal: true, Text: "Approval test-ns/test-approval is approved by release-manager"},
+		},
+		"notMapped": {
+			slackUser:       "U0003",
+			actionID:        slack.ActionIDApprove,
+			value:           "test-ns/test-approval",
+			expectedResult:  cicdv1.ApprovalResultAwaiting,
+			expectedMessage: slack.Message{ResponseType: "ephemeral", Text: "slack user U0003 is not mapped to any kubernetes user"},
+		},
+		"notAllowed": {
+			slackUser:       "U0002",
+			actionID:        slack.ActionIDApprove,
+			value:           "test-ns/test-approval",
+			expectedResult:  cicdv1.ApprovalResultAwaiting,
+			expectedMessage: slack.Message{ResponseType: "ephemeral", Text: "user developer cannot update approvals/approve of test-ns/test-approval, err : not allowed"},
+		},
+		"alreadyDecided": {
+			slackUser:       "U0001",
+			actionID:        slack.ActionIDApprove,
+			value:           "test-ns/test-approval",
+			decided:         true,
+			expectedResult:  cicdv1.ApprovalResultRejected,
+			expectedMessage: slack.Message{ResponseType: "ephemeral", Text: "Cannot decide approval test-ns/test-approval, err : approval test-ns/test-approval is already in Rejected status"},
+		},
+		"malformedValue": {
+			slackUser:       "U0001",
+			actionID:        slack.ActionIDApprove,
+			value:           "test-approval",
+			expectedResult:  cicdv1.ApprovalResultAwaiting,
+			expectedMessage: slack.Message{ResponseType: "ephemeral", Text: "Approval test-approval is malformed"},
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			approval := &cicdv1.Approval{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-approval", Namespace: "test-ns"},
+				Spec: cicdv1.ApprovalSpec{
+					Users: []cicdv1.ApprovalUser{{Name: "admin"}},
+				},
+				Status: cicdv1.ApprovalStatus{Result: cicdv1.ApprovalResultAwaiting},
+			}
+			if c.decided {
+				approval.Status.Result = cicdv1.ApprovalResultRejected
+			}
+			if c.groups != nil {
+				approval.Spec.Users = nil
+				approval.Spec.Policy = &cicdv1.ApprovalPolicy{Groups: c.groups}
+			}
+			app := newTestApp(t, approval)
+
+			// Response url server
+			var responded slack.Message
+			responseSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				require.NoError(t, json.NewDecoder(req.Body).Decode(&responded))
+			}))
+			defer responseSrv.Close()
+
+			payload, err := json.Marshal(&slack.InteractionPayload{
+				Type:        "block_actions",
+				User:        slack.InteractionUser{ID: c.slackUser},
+				Actions:     []slack.InteractionAction{{ActionID: c.actionID, Value: c.value}},
+				ResponseURL: responseSrv.URL,
+			})
+			require.NoError(t, err)
+			form := url.Values{}
+			form.Set("payload", string(payload))
+
+			w := httptest.NewRecorder()
+			app.handleInteraction(w, newSignedRequest(form.Encode(), testSigningSecret))
+
+			require.Equal(t, http.StatusOK, w.Result().StatusCode)
+			require.Equal(t, c.expectedMessage, responded)
+
+			result := &cicdv1.Approval{}
+			require.NoError(t, app.k8sClient.Get(context.Background(), client.ObjectKeyFromObject(approval), result))
+			require.Equal(t, c.expectedResult, result.Status.Result)
+		})
+	}
+}
+
+func TestApp_readAndVerify(t *testing.T) {
+	app := newTestApp(t)
+
+	w := httptest.NewRecorder()
+	app.handleInteraction(w, newSignedRequest("payload=%7B%7D", "wrong-secret"))
+	require.Equal(t, http.StatusUnauthorized, w.Result().StatusCode)
+
+	configs.EnableSlackApp = false
+	w = httptest.NewRecorder()
+	app.handleInteraction(w, newSignedRequest("payload=%7B%7D", testSigningSecret))
+	require.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+}
+
+func newTestApp(t *testing.T, objs ...client.Object) *App {
+	configs.EnableSlackApp = true
+	configs.SlackAppSecret = "slack-app"
+	configs.SlackUserConfigMap = "slack-users"
+
+	s := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(s))
+	require.NoError(t, cicdv1.AddToScheme(s))
+
+	objs = append(objs,
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "slack-app", Namespace: "cicd-system"},
+			Data: map[string][]byte{
+				slack.SecretKeyBotToken:      []byte("xoxb-token"),
+				slack.SecretKeySigningSecret: []byte(testSigningSecret),
+			},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "slack-users", Namespace: "cicd-system"},
+			Data: map[string]string{
+				"U0001":        "admin",
+				"U0002":        "developer",
+				"U0004":        "release-manager",
+				"U0004.groups": "release-managers",
+			},
+		},
+	)
+
+	fakeSet := fake.NewSimpleClientset()
+	fakeSet.PrependReactor("create", "subjectaccessreviews", func(action testing2.Action) (bool, runtime.Object, error) {
+		sar := action.(testing2.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		sar.Status.Allowed = sar.Spec.User == "admin" || (len(sar.Spec.Groups) > 1 && sar.Spec.Groups[1] == "release-managers")
+		if !sar.Status.Allowed {
+			sar.Status.Reason = "not allowed"
+		}
+		return true, sar, nil
+	})
+
+	return &App{
+		k8sClient: fakeclient.NewClientBuilder().WithScheme(s).WithObjects(objs...).Build(),
+		authCli:   fakeSet.AuthorizationV1(),
+		log:       &test.FakeLogger{},
+	}
+}
+
+func newSignedRequest(body, signingSecret string) *http.Request {
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(signingSecret))
+	_, _ = mac.Write([]byte("v0:" + ts + ":" + body))
+
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Slack-Request-Timestamp", ts)
+	req.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
@@ -0,0 +1,136 @@
+/*
+ Copyright 2021 The CI/CD Operator Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+// Package slackapp implements an interactive slack app, which lets users decide approvals using the buttons in the
+// approval request messages and trigger IntegrationConfigs using slash commands.
+// Slack users are mapped to kubernetes users, and the same SubjectAccessReview as the api server is done for them.
+package slackapp
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/tmax-cloud/cicd-operator/internal/apiserver"
+	"github.com/tmax-cloud/cicd-operator/internal/configs"
+	"github.com/tmax-cloud/cicd-operator/internal/utils"
+	"github.com/tmax-cloud/cicd-operator/pkg/notification/slack"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	authorization "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Paths of the slack app's request urls
+const (
+	InteractionPath = "/slack/interactions"
+	CommandPath     = "/slack/commands"
+)
+
+const (
+	apiVersion = "v1"
+
+	responseTypeEphemeral = "ephemeral"
+	responseTypeInChannel = "in_channel"
+)
+
+// slackUserGroups are groups of all the users mapped from slack users.
+// Groups mapped in the slack user configmap are added to them
+var slackUserGroups = []string{"system:authenticated"}
+
+// App is a slack app, handling interactions and slash commands
+type App struct {
+	k8sClient client.Client
+	authCli   authorization.AuthorizationV1Interface
+	log       logr.Logger
+}
+
+// New is a constructor of App
+func New(cli client.Client, authCli authorization.AuthorizationV1Interface) *App {
+	return &App{
+		k8sClient: cli,
+		authCli:   authCli,
+		log:       logf.Log.WithName("slack-app"),
+	}
+}
+
+// InteractionHandler returns a handler for the interaction requests
+func (a *App) InteractionHandler() http.Handler {
+	return http.HandlerFunc(a.handleInteraction)
+}
+
+// CommandHandler returns a handler for the slash command requests
+func (a *App) CommandHandler() http.Handler {
+	return http.HandlerFunc(a.handleCommand)
+}
+
+// readAndVerify reads the request body and verifies its signature
+func (a *App) readAndVerify(w http.ResponseWriter, req *http.Request, log logr.Logger) ([]byte, bool) {
+	if !configs.EnableSlackApp {
+		_ = utils.RespondError(w, http.StatusNotFound, "slack app is not enabled")
+		return nil, false
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		log.Info(err.Error())
+		_ = utils.RespondError(w, http.StatusBadRequest, "cannot read body")
+		return nil, false
+	}
+
+	cred, err := slack.GetAppCredentials(a.k8sClient)
+	if err != nil {
+		log.Error(err, "")
+		_ = utils.RespondError(w, http.StatusInternalServerError, "cannot get slack app credentials")
+		return nil, false
+	}
+
+	if err := slack.VerifyRequest(cred.SigningSecret, req.Header, body); err != nil {
+		log.Info(err.Error())
+		_ = utils.RespondError(w, http.StatusUnauthorized, err.Error())
+		return nil, false
+	}
+
+	return body, true
+}
+
+// authorize maps the slack user to a kubernetes user and its groups, and checks if the user can access the subresource
+func (a *App) authorize(slackUserID, ns, resource, name, subResource, verb string) (string, []string, error) {
+	user, mappedGroups, err := slack.GetKubernetesUser(a.k8sClient, slackUserID)
+	if err != nil {
+		return "", nil, err
+	}
+	groups := append(append([]string{}, slackUserGroups...), mappedGroups...)
+
+	if err := apiserver.ReviewAccess(a.authCli, user, groups, nil, &authorizationv1.ResourceAttributes{
+		Name:        name,
+		Namespace:   ns,
+		Group:       apiserver.APIGroup,
+		Version:     apiVersion,
+		Resource:    resource,
+		Subresource: subResource,
+		Verb:        verb,
+	}); err != nil {
+		return "", nil, fmt.Errorf("user %s cannot %s %s/%s of %s/%s, err : %s", user, verb, resource, subResource, ns, name, err.Error())
+	}
+
+	return user, groups, nil
+}
+
+func respondMessage(w http.ResponseWriter, responseType, text string) {
+	_ = utils.RespondJSON(w, &slack.Message{ResponseType: responseType, Text: text})
+}
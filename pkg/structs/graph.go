@@ -21,6 +21,7 @@ type Graph interface {
 	AddEdge(from, to string)
 	IsCyclic() bool
 	GetPres(target string) []string
+	GetPosts(target string) []string
 }
 
 // graph is a graph struct
@@ -114,6 +115,26 @@ func (g *graph) GetPres(target string) []string {
 	return pres
 }
 
+// GetPosts get the list of children (post-s)
+func (g *graph) GetPosts(target string) []string {
+	var posts []string
+
+	tos, ok := g.edgesTo[target]
+	if !ok {
+		return posts
+	}
+
+	for _, to := range tos {
+		posts = appendUnique(posts, to)
+		chPosts := g.GetPosts(to)
+		for _, p := range chPosts {
+			posts = appendUnique(posts, p)
+		}
+	}
+
+	return posts
+}
+
 func appendUnique(arr []string, val string) []string {
 	for _, a := range arr {
 		if a == val {
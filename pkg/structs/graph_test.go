@@ -106,6 +106,43 @@ func TestGraph_GetPres(t *testing.T) {
 	assert.Equal(t, "b-1", pres[0])
 }
 
+func TestGraph_GetPosts(t *testing.T) {
+	/*
+		a-1  -->  a-2  --> a-4
+		     \->  a-3  -/
+
+		b-1  -->  b-2
+	*/
+
+	graph := NewGraph()
+	graph.AddEdge("a-1", "a-2")
+	graph.AddEdge("a-1", "a-3")
+	graph.AddEdge("a-2", "a-4")
+	graph.AddEdge("a-3", "a-4")
+	graph.AddEdge("b-1", "b-2")
+
+	target := "a-1"
+	posts := graph.GetPosts(target)
+	assert.Equal(t, 3, len(posts))
+	assert.Equal(t, "a-2", posts[0])
+	assert.Equal(t, "a-4", posts[1])
+	assert.Equal(t, "a-3", posts[2])
+
+	target = "a-2"
+	posts = graph.GetPosts(target)
+	assert.Equal(t, 1, len(posts))
+	assert.Equal(t, "a-4", posts[0])
+
+	target = "a-4"
+	posts = graph.GetPosts(target)
+	assert.Equal(t, 0, len(posts))
+
+	target = "b-1"
+	posts = graph.GetPosts(target)
+	assert.Equal(t, 1, len(posts))
+	assert.Equal(t, "b-2", posts[0])
+}
+
 func TestGraph_IsCyclic(t *testing.T) {
 	/*
 		a-1  -->  a-2  --> a-4